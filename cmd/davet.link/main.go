@@ -1,14 +1,17 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"davet.link/configs/configscsrf"
 	"davet.link/configs/configsdatabase"
+	"davet.link/configs/configsenv"
 	"davet.link/configs/configslog"
 	"davet.link/configs/configssession"
+	"davet.link/jobs"
 	"davet.link/pkg/flashmessages"
 	"davet.link/pkg/templatehelpers"
 	"davet.link/routes"
@@ -40,6 +43,8 @@ func main() {
 
 	app := fiber.New(fiber.Config{
 		Views: engine,
+		// Form dosya yüklemeleri için varsayılan 4 MB gövde sınırı yükseltilir.
+		BodyLimit: configsenv.GetEnvAsInt("HTTP_BODY_LIMIT_MB", 50) * 1024 * 1024,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 			message := "Internal Server Error"
@@ -65,10 +70,13 @@ func main() {
 	app.Use(configscsrf.SetupCSRF())
 	routes.SetupRoutes(app, configsdatabase.GetDB())
 
-	startServer(app)
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	jobs.Start(jobsCtx)
+
+	startServer(app, stopJobs)
 }
 
-func startServer(app *fiber.App) {
+func startServer(app *fiber.App, stopJobs context.CancelFunc) {
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
 
@@ -95,6 +103,7 @@ func startServer(app *fiber.App) {
 
 	<-shutdown
 	configslog.Log.Info("Kapatma sinyali alındı, uygulama kapatılıyor...")
+	stopJobs()
 
 	if err := app.Shutdown(); err != nil {
 		configslog.Log.Error("Sunucu kapatılırken hata oluştu", zap.Error(err))
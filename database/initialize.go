@@ -113,6 +113,48 @@ func RunMigrationsInOrder(db *gorm.DB) error {
 	}
 	configslog.SLog.Info(" -> Appointment migrasyonları tamamlandı.")
 
+	configslog.SLog.Info(" -> Availability migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateAvailabilityTables(db); err != nil {
+		configslog.Log.Error("Availability tabloları migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info(" -> Availability migrasyonları tamamlandı.")
+
+	configslog.SLog.Info(" -> Appointment booking migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateAppointmentBookingsTable(db); err != nil {
+		configslog.Log.Error("Appointment_bookings tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info(" -> Appointment booking migrasyonları tamamlandı.")
+
+	configslog.SLog.Info(" -> Appointment question migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateAppointmentQuestionTables(db); err != nil {
+		configslog.Log.Error("Appointment question tabloları migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info(" -> Appointment question migrasyonları tamamlandı.")
+
+	configslog.SLog.Info(" -> Customer record migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateCustomerRecordsTable(db); err != nil {
+		configslog.Log.Error("Customer_records tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info(" -> Customer record migrasyonları tamamlandı.")
+
+	configslog.SLog.Info(" -> Booking waitlist migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateBookingWaitlistTable(db); err != nil {
+		configslog.Log.Error("Booking_waitlist_entries tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info(" -> Booking waitlist migrasyonları tamamlandı.")
+
+	configslog.SLog.Info(" -> External calendar migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateExternalCalendarTables(db); err != nil {
+		configslog.Log.Error("External calendar tabloları migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info(" -> External calendar migrasyonları tamamlandı.")
+
 	configslog.SLog.Info(" -> Form migrasyonları çalıştırılıyor...")
 	if err := migrations.MigrateFormsTables(db); err != nil {
 		configslog.Log.Error("Forms tabloları migrasyonu başarısız oldu", zap.Error(err))
@@ -120,6 +162,20 @@ func RunMigrationsInOrder(db *gorm.DB) error {
 	}
 	configslog.SLog.Info(" -> Form migrasyonları tamamlandı.")
 
+	configslog.SLog.Info(" -> Mail outbox migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateMailOutboxTable(db); err != nil {
+		configslog.Log.Error("Mail_outboxes tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info(" -> Mail outbox migrasyonları tamamlandı.")
+
+	configslog.SLog.Info(" -> Webhook migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateWebhookTables(db); err != nil {
+		configslog.Log.Error("Webhook tabloları migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info(" -> Webhook migrasyonları tamamlandı.")
+
 	configslog.SLog.Info(" -> Card migrasyonları çalıştırılıyor...")
 	if err := migrations.MigrateCardsTables(db); err != nil {
 		configslog.Log.Error("Cards tabloları migrasyonu başarısız oldu", zap.Error(err))
@@ -15,6 +15,21 @@ func MigrateAppointmentsTables(db *gorm.DB) error {
 		configslog.Log.Error("Failed to migrate appointments & appointment_details tables", zap.Error(err))
 		return err
 	}
+
+	// Eski float fiyat sütunu (numeric price) kuruş cinsinden price_minor'a taşınır
+	if db.Migrator().HasColumn(&models.AppointmentDetail{}, "price") {
+		configslog.SLog.Info("Converting appointment_details.price to price_minor...")
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("UPDATE appointment_details SET price_minor = ROUND(price * 100) WHERE price IS NOT NULL").Error; err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&models.AppointmentDetail{}, "price")
+		})
+		if err != nil {
+			configslog.Log.Error("Failed to convert appointment_details.price", zap.Error(err))
+			return err
+		}
+	}
 	configslog.SLog.Info("Appointments & appointment_details tables migrated successfully")
 	return nil
 }
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"davet.link/configs/configslog"
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateAppointmentBookingsTable(db *gorm.DB) error {
+	configslog.SLog.Info("Migrating booking_series, appointment_bookings & booking_payments tables...")
+	err := db.AutoMigrate(&models.BookingSeries{}, &models.AppointmentBooking{}, &models.BookingPayment{})
+	if err != nil {
+		configslog.Log.Error("Failed to migrate booking_series, appointment_bookings & booking_payments tables", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info("Booking_series, appointment_bookings & booking_payments tables migrated successfully")
+	return nil
+}
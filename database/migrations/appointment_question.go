@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"davet.link/configs/configslog"
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateAppointmentQuestionTables(db *gorm.DB) error {
+	configslog.SLog.Info("Migrating appointment_questions and appointment_booking_answers tables...")
+	err := db.AutoMigrate(&models.AppointmentQuestion{}, &models.AppointmentBookingAnswer{})
+	if err != nil {
+		configslog.Log.Error("Failed to migrate appointment question tables", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info("Appointment question tables migrated successfully")
+	return nil
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"davet.link/configs/configslog"
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateAvailabilityTables(db *gorm.DB) error {
+	configslog.SLog.Info("Migrating appointment_availability_rules, appointment_availability_overrides & provider_settings tables...")
+	err := db.AutoMigrate(&models.AppointmentAvailabilityRule{}, &models.AppointmentAvailabilityOverride{}, &models.ProviderSetting{})
+	if err != nil {
+		configslog.Log.Error("Failed to migrate availability tables", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info("Availability tables migrated successfully")
+	return nil
+}
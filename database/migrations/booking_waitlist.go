@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"davet.link/configs/configslog"
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateBookingWaitlistTable(db *gorm.DB) error {
+	configslog.SLog.Info("Migrating booking_waitlist_entries & waitlist_freed_slots tables...")
+	err := db.AutoMigrate(&models.BookingWaitlistEntry{}, &models.WaitlistFreedSlot{})
+	if err != nil {
+		configslog.Log.Error("Failed to migrate booking_waitlist_entries & waitlist_freed_slots tables", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info("Booking_waitlist_entries & waitlist_freed_slots tables migrated successfully")
+	return nil
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"davet.link/configs/configslog"
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateCustomerRecordsTable(db *gorm.DB) error {
+	configslog.SLog.Info("Migrating customer_records table...")
+	err := db.AutoMigrate(&models.CustomerRecord{})
+	if err != nil {
+		configslog.Log.Error("Failed to migrate customer_records table", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info("Customer_records table migrated successfully")
+	return nil
+}
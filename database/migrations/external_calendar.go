@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"davet.link/configs/configslog"
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateExternalCalendarTables(db *gorm.DB) error {
+	configslog.SLog.Info("Migrating external_calendars & external_busy_blocks tables...")
+	err := db.AutoMigrate(&models.ExternalCalendar{}, &models.ExternalBusyBlock{})
+	if err != nil {
+		configslog.Log.Error("Failed to migrate external calendar tables", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info("External calendar tables migrated successfully")
+	return nil
+}
@@ -9,12 +9,14 @@ import (
 )
 
 func MigrateFormsTables(db *gorm.DB) error {
-	configslog.SLog.Info("Migrating forms & form_details tables...")
-	err := db.AutoMigrate(&models.Form{}, &models.FormDetail{})
+	configslog.SLog.Info("Migrating forms, form_details, form_field_definitions, form_versions, form_submissions, form_export_schedules & form statistics tables...")
+	err := db.AutoMigrate(&models.Form{}, &models.FormDetail{}, &models.FormFieldDefinition{}, &models.FormVersion{},
+		&models.FormSubmission{}, &models.FormSubmissionAnswer{}, &models.FormSubmissionFile{},
+		&models.FormDraft{}, &models.FormQuizAttempt{}, &models.FormExportSchedule{}, &models.FormViewStat{}, &models.FormPageStat{}, &models.FormSpamStat{})
 	if err != nil {
-		configslog.Log.Error("Failed to migrate forms & form_details tables", zap.Error(err))
+		configslog.Log.Error("Failed to migrate form tables", zap.Error(err))
 		return err
 	}
-	configslog.SLog.Info("Forms & form_details tables migrated successfully")
+	configslog.SLog.Info("Forms, form_details, form_field_definitions, form_versions, form_submissions, form_export_schedules & form statistics tables migrated successfully")
 	return nil
 }
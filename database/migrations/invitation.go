@@ -9,12 +9,12 @@ import (
 )
 
 func MigrateInvitationsTables(db *gorm.DB) error {
-	configslog.SLog.Info("Migrating invitations & invitation_details tables...")
-	err := db.AutoMigrate(&models.Invitation{}, &models.InvitationDetail{})
+	configslog.SLog.Info("Migrating invitations, invitation_details & invitation_guests tables...")
+	err := db.AutoMigrate(&models.Invitation{}, &models.InvitationDetail{}, &models.InvitationGuest{})
 	if err != nil {
-		configslog.Log.Error("Failed to migrate invitations & invitation_details tables", zap.Error(err))
+		configslog.Log.Error("Failed to migrate invitations, invitation_details & invitation_guests tables", zap.Error(err))
 		return err
 	}
-	configslog.SLog.Info("Invitations & invitation_details tables migrated successfully")
+	configslog.SLog.Info("Invitations, invitation_details & invitation_guests tables migrated successfully")
 	return nil
 }
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"davet.link/configs/configslog"
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateMailOutboxTable(db *gorm.DB) error {
+	configslog.SLog.Info("Migrating mail_outboxes & mail_outbox_attachments tables...")
+	err := db.AutoMigrate(&models.MailOutbox{}, &models.MailOutboxAttachment{})
+	if err != nil {
+		configslog.Log.Error("Failed to migrate mail outbox tables", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info("Mail_outboxes & mail_outbox_attachments tables migrated successfully")
+	return nil
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"davet.link/configs/configslog"
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateWebhookTables(db *gorm.DB) error {
+	configslog.SLog.Info("Migrating webhook_endpoints & webhook_deliveries tables...")
+	err := db.AutoMigrate(&models.WebhookEndpoint{}, &models.WebhookDelivery{})
+	if err != nil {
+		configslog.Log.Error("Failed to migrate webhook tables", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info("Webhook_endpoints & webhook_deliveries tables migrated successfully")
+	return nil
+}
@@ -111,6 +111,13 @@ func (h *AppointmentHandler) UpdateAppointment(c *fiber.Ctx) error {
 	isEnabledStr := c.FormValue("is_enabled", "false")
 	isEnabled := isEnabledStr == "true" || isEnabledStr == "on"
 
+	// Ücret ve kapora "150,00" biçiminde gelir, kuruşa çevrilir
+	if err := services.ParseAppointmentPrices(&detailUpdates, c.FormValue("price"), c.FormValue("deposit"), c.FormValue("currency")); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		_ = flashmessages.SetFlashFormData(c, detailUpdates)
+		return c.Redirect(redirectPathOnError, fiber.StatusSeeOther)
+	}
+
 	// Validasyon
 	if err := services.ValidateAppointmentDetail(detailUpdates); err != nil {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
@@ -164,4 +171,4 @@ func (h *AppointmentHandler) DeleteAppointment(c *fiber.Ctx) error {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Randevu hizmeti başarıyla silindi.")
 	}
 	return c.Redirect("/dashboard/appointments", fiber.StatusSeeOther) // Listeye dön
-}
\ No newline at end of file
+}
\ No newline at end of file
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PublicAppointmentHandler public randevu sayfası işlemleri için handler.
+type PublicAppointmentHandler struct {
+	appointmentService  services.IAppointmentService
+	availabilityService services.IAvailabilityService
+	bookingService      services.IBookingService
+	seriesService       services.IBookingSeriesService
+	waitlistService     services.IWaitlistService
+}
+
+// NewPublicAppointmentHandler yeni bir PublicAppointmentHandler örneği oluşturur.
+func NewPublicAppointmentHandler() *PublicAppointmentHandler {
+	return &PublicAppointmentHandler{
+		appointmentService:  services.NewAppointmentService(),
+		availabilityService: services.NewAvailabilityService(),
+		bookingService:      services.NewBookingService(),
+		seriesService:       services.NewBookingSeriesService(),
+		waitlistService:     services.NewWaitlistService(),
+	}
+}
+
+// GetSlots (GET /{key}/slots?date=2006-01-02)
+// Verilen gün için rezervasyona açık slotları JSON olarak döndürür. Çalışma günü tamamen
+// doluysa "waitlist" true döner ve sayfa bekleme listesi formunu gösterir.
+func (h *PublicAppointmentHandler) GetSlots(c *fiber.Ctx) error {
+	key := c.Params("key")
+
+	appointment, err := h.appointmentService.GetAppointmentByKey(c.UserContext(), key)
+	if err != nil {
+		if errors.Is(err, services.ErrAppointmentNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Randevu hizmeti bulunamadı."})
+		}
+		configslog.Log.Error("GetSlots: GetAppointmentByKey error", zap.String("key", key), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Randevu hizmeti yüklenemedi."})
+	}
+
+	loc := services.AppointmentLocation(appointment.Detail)
+	day, err := time.ParseInLocation("2006-01-02", c.Query("date"), loc)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": services.ErrAvailInvalidDate.Error()})
+	}
+
+	available, err := h.availabilityService.GetAvailableSlots(c.UserContext(), appointment, day)
+	if err != nil {
+		configslog.Log.Error("GetSlots: GetAvailableSlots error", zap.String("key", key), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Uygun saatler hesaplanamadı."})
+	}
+
+	waitlist := false
+	if len(available) == 0 {
+		if waitlist, err = h.waitlistService.IsWaitlistOpen(c.UserContext(), appointment, day); err != nil {
+			configslog.Log.Error("GetSlots: IsWaitlistOpen error", zap.String("key", key), zap.Error(err))
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"date":     day.Format("2006-01-02"),
+		"timezone": loc.String(),
+		"slots":    available,
+		"waitlist": waitlist,
+	})
+}
+
+// bookingRequest POST /{key}/book gövdesi (form veya JSON).
+type bookingRequest struct {
+	Start string `json:"start" form:"start"` // RFC3339, /slots yanıtındaki "start" değeri
+	Name  string `json:"name" form:"name"`
+	Email string `json:"email" form:"email"`
+	Phone string `json:"phone" form:"phone"`
+	Notes string `json:"notes" form:"notes"`
+	// Ödeme sağlayıcısının formundan dönen anahtar; verilirse onaylı rezervasyonun kaporası (yoksa ücretin tamamı) çekilir.
+	PaymentToken string `json:"payment_token" form:"payment_token"`
+	// JSON gövdede soru ID -> cevap; form gönderiminde "question_{id}" alanları kullanılır.
+	Answers map[string]string `json:"answers" form:"-"`
+}
+
+// bookingQuestionFieldPrefix public formdaki soru alanlarının ad öneki.
+const bookingQuestionFieldPrefix = "question_"
+
+// bookingAnswers istekteki soru cevaplarını soru ID'sine göre toplar.
+func bookingAnswers(c *fiber.Ctx, req bookingRequest) map[uint]string {
+	answers := make(map[uint]string)
+	for k, v := range req.Answers {
+		if id, err := strconv.ParseUint(k, 10, 64); err == nil {
+			answers[uint(id)] = v
+		}
+	}
+	c.Request().PostArgs().VisitAll(func(key, value []byte) {
+		name := string(key)
+		if !strings.HasPrefix(name, bookingQuestionFieldPrefix) {
+			return
+		}
+		if id, err := strconv.ParseUint(strings.TrimPrefix(name, bookingQuestionFieldPrefix), 10, 64); err == nil {
+			answers[uint(id)] = string(value)
+		}
+	})
+	return answers
+}
+
+// CreateBooking (POST /{key}/book)
+// Seçilen slot için rezervasyon oluşturur. Slot, sağlayıcının tüm hizmetlerindeki
+// rezervasyonlara karşı yeniden kontrol edilir. payment_token verilmişse ödeme rezervasyonla
+// birlikte alınır; ödeme alınamazsa rezervasyon oluşturulmaz. Onay bekleyen rezervasyonlardan ödeme alınmaz.
+func (h *PublicAppointmentHandler) CreateBooking(c *fiber.Ctx) error {
+	key := c.Params("key")
+
+	var req bookingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri."})
+	}
+	startsAt, err := time.Parse(time.RFC3339, req.Start)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz randevu saati."})
+	}
+
+	booking, err := h.bookingService.CreateBooking(c.UserContext(), key, services.BookingInput{
+		StartsAt:      startsAt,
+		CustomerName:  req.Name,
+		CustomerEmail: req.Email,
+		CustomerPhone: req.Phone,
+		Notes:         req.Notes,
+		Answers:       bookingAnswers(c, req),
+		PaymentToken:  req.PaymentToken,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAppointmentNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Randevu hizmeti bulunamadı."})
+		case errors.Is(err, services.ErrBookingInvalidInput):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, services.ErrBookingSlotUnavailable):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, services.ErrCustomerBlocked):
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, services.ErrPaymentDeclined):
+			return c.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, services.ErrPaymentProviderDisabled):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, services.ErrPaymentSaveFailed):
+			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "Ödeme alınamadı, rezervasyon oluşturulmadı."})
+		}
+		configslog.Log.Error("CreateBooking Error", zap.String("key", key), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Rezervasyon oluşturulamadı."})
+	}
+
+	response := fiber.Map{
+		"id":        booking.ID,
+		"status":    booking.Status,
+		"starts_at": booking.StartsAt,
+		"ends_at":   booking.EndsAt,
+		"paid":      booking.PaidMinor > 0,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// seriesRequest POST /{key}/book/series gövdesi: rezervasyon alanlarına ek olarak tekrarlama bilgisi.
+type seriesRequest struct {
+	bookingRequest
+	IntervalWeeks int    `json:"interval_weeks" form:"interval_weeks"` // 1 veya 2
+	Count         int    `json:"count" form:"count"`
+	Until         string `json:"until" form:"until"`                   // 2006-01-02, isteğe bağlı
+	SkipConflicts bool   `json:"skip_conflicts" form:"skip_conflicts"` // Çakışan tekrarlar atlanarak oluşturulsun
+}
+
+// parseSeriesRequest isteği okuyup servis girdisine çevirir.
+func parseSeriesRequest(c *fiber.Ctx, loc *time.Location) (*seriesRequest, services.SeriesInput, error) {
+	var req seriesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return nil, services.SeriesInput{}, errors.New("Geçersiz veri.")
+	}
+	startsAt, err := time.Parse(time.RFC3339, req.Start)
+	if err != nil {
+		return nil, services.SeriesInput{}, errors.New("Geçersiz randevu saati.")
+	}
+	input := services.SeriesInput{
+		Booking: services.BookingInput{
+			StartsAt:      startsAt,
+			CustomerName:  req.Name,
+			CustomerEmail: req.Email,
+			CustomerPhone: req.Phone,
+			Notes:         req.Notes,
+			Answers:       bookingAnswers(c, req.bookingRequest),
+		},
+		IntervalWeeks: req.IntervalWeeks,
+		Count:         req.Count,
+	}
+	if req.Until != "" {
+		until, err := time.ParseInLocation("2006-01-02", req.Until, loc)
+		if err != nil {
+			return nil, services.SeriesInput{}, errors.New("Geçersiz bitiş tarihi.")
+		}
+		input.Until = &until
+	}
+	return &req, input, nil
+}
+
+// seriesErrorResponse seri işlemlerindeki hataları uygun HTTP durumuna çevirir.
+func seriesErrorResponse(c *fiber.Ctx, key string, err error, occurrences []services.SeriesOccurrence) error {
+	switch {
+	case errors.Is(err, services.ErrAppointmentNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Randevu hizmeti bulunamadı."})
+	case errors.Is(err, services.ErrSeriesNotAllowed):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrSeriesInvalidInput), errors.Is(err, services.ErrBookingInvalidInput):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrCustomerBlocked):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrSeriesConflict):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error(), "occurrences": occurrences})
+	}
+	configslog.Log.Error("Series Error", zap.String("key", key), zap.Error(err))
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Tekrarlayan rezervasyon oluşturulamadı."})
+}
+
+// PreviewSeries (POST /{key}/book/series/preview)
+// Tekrarlayan rezervasyonun tekrarlarını ve hangilerinin müsait olmadığını onaydan önce döndürür.
+func (h *PublicAppointmentHandler) PreviewSeries(c *fiber.Ctx) error {
+	key := c.Params("key")
+	appointment, err := h.appointmentService.GetAppointmentByKey(c.UserContext(), key)
+	if err != nil {
+		return seriesErrorResponse(c, key, err, nil)
+	}
+	_, input, err := parseSeriesRequest(c, services.AppointmentLocation(appointment.Detail))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	occurrences, err := h.seriesService.PreviewCustomerSeries(c.UserContext(), key, input)
+	if err != nil {
+		return seriesErrorResponse(c, key, err, nil)
+	}
+	return c.JSON(fiber.Map{"occurrences": occurrences})
+}
+
+// CreateSeries (POST /{key}/book/series)
+// Haftalık veya iki haftalık tekrarlayan rezervasyon oluşturur. skip_conflicts gönderilmezse
+// tek bir tekrar bile müsait değilse hiçbir rezervasyon oluşturulmaz.
+func (h *PublicAppointmentHandler) CreateSeries(c *fiber.Ctx) error {
+	key := c.Params("key")
+	appointment, err := h.appointmentService.GetAppointmentByKey(c.UserContext(), key)
+	if err != nil {
+		return seriesErrorResponse(c, key, err, nil)
+	}
+	req, input, err := parseSeriesRequest(c, services.AppointmentLocation(appointment.Detail))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	series, occurrences, err := h.seriesService.CreateCustomerSeries(c.UserContext(), key, input, req.SkipConflicts)
+	if err != nil {
+		return seriesErrorResponse(c, key, err, occurrences)
+	}
+
+	status := models.BookingStatusConfirmed
+	if appointment.Detail.RequiresApproval {
+		status = models.BookingStatusPending
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"series_id":   series.ID,
+		"status":      status,
+		"created":     len(series.Bookings),
+		"occurrences": occurrences,
+	})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"davet.link/configs/configslog"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PublicCalendarHandler sağlayıcıların gizli ICS abonelik adreslerini sunar.
+type PublicCalendarHandler struct {
+	bookingService services.IBookingService
+}
+
+// NewPublicCalendarHandler yeni bir PublicCalendarHandler örneği oluşturur.
+func NewPublicCalendarHandler() *PublicCalendarHandler {
+	return &PublicCalendarHandler{
+		bookingService: services.NewBookingService(),
+	}
+}
+
+// GetFeed (GET /calendar/{token}.ics)
+// Sağlayıcının onaylı rezervasyonlarını iCalendar olarak döndürür.
+func (h *PublicCalendarHandler) GetFeed(c *fiber.Ctx) error {
+	token := strings.TrimSuffix(c.Params("token"), ".ics")
+
+	feed, err := h.bookingService.GetCalendarFeed(c.UserContext(), token)
+	if err != nil {
+		if errors.Is(err, services.ErrAvailFeedNotFound) {
+			return c.Status(fiber.StatusNotFound).SendString("Takvim bulunamadı.")
+		}
+		configslog.Log.Error("GetFeed: GetCalendarFeed error", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).SendString("Takvim oluşturulamadı.")
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="davet-link.ics"`)
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return c.Send(feed)
+}
@@ -0,0 +1,363 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/configs/configssession"
+	"davet.link/models"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// formFieldPrefix public formdaki alan inputlarının ad öneki (field_{alanID}).
+const formFieldPrefix = "field_"
+
+// respondentCookie anonim gönderenleri kişi başı sınır için ayırt eden çerez.
+const (
+	respondentCookie    = "dl_respondent"
+	respondentCookieAge = 365 * 24 * time.Hour
+)
+
+// PublicFormHandler public form gönderimleri için handler.
+type PublicFormHandler struct {
+	submissionService services.IFormSubmissionService
+	draftService      services.IFormDraftService
+	quizService       services.IFormQuizService
+}
+
+// NewPublicFormHandler yeni bir PublicFormHandler örneği oluşturur.
+func NewPublicFormHandler() *PublicFormHandler {
+	return &PublicFormHandler{
+		submissionService: services.NewFormSubmissionService(),
+		draftService:      services.NewFormDraftService(),
+		quizService:       services.NewFormQuizService(),
+	}
+}
+
+// formFieldID "field_12" biçimindeki input adından alan ID'sini çıkarır.
+func formFieldID(name string) (uint, bool) {
+	if !strings.HasPrefix(name, formFieldPrefix) {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(name, formFieldPrefix), 10, 64)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// respondentHash anonim tanımlayıcıyı saklamadan önce özetler.
+func respondentHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:16])
+}
+
+// formRespondent isteği yapan kişiyi belirler. Oturum varsa kullanıcı ID'si, yoksa anonim
+// çerez kullanılır; çerez de yoksa IP ve tarayıcı bilgisinden üretilen parmak izi anahtar olur.
+// Anonim gönderende parmak izi her durumda ayrıca verilir ve kişi başı sınır çerez ile
+// parmak izine birlikte uygulanır. issueCookie true ise (form görüntülenirken) eksik çerez oluşturulur.
+func formRespondent(c *fiber.Ctx, issueCookie bool) services.FormRespondent {
+	if sess, err := configssession.SessionStart(c); err == nil {
+		if userID, err := configssession.GetUserIDFromSession(sess); err == nil && userID != 0 {
+			return services.FormRespondent{UserID: userID, Key: "u:" + strconv.FormatUint(uint64(userID), 10)}
+		}
+	}
+	fingerprint := "f:" + respondentHash(c.IP()+"|"+c.Get(fiber.HeaderUserAgent))
+	if token := c.Cookies(respondentCookie); len(token) == 32 {
+		return services.FormRespondent{Key: "c:" + respondentHash(token), Fingerprint: fingerprint}
+	}
+	if issueCookie {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err == nil {
+			token := hex.EncodeToString(buf)
+			c.Cookie(&fiber.Cookie{
+				Name:     respondentCookie,
+				Value:    token,
+				Path:     "/",
+				Expires:  time.Now().Add(respondentCookieAge),
+				HTTPOnly: true,
+				Secure:   c.Protocol() == "https",
+				SameSite: fiber.CookieSameSiteLaxMode,
+			})
+			return services.FormRespondent{Key: "c:" + respondentHash(token), Fingerprint: fingerprint}
+		}
+	}
+	return services.FormRespondent{Key: fingerprint, Fingerprint: fingerprint}
+}
+
+// formValues gönderilen alan değerlerini (çoklu seçimler dahil) alan ID'sine göre toplar.
+// multipart gövdede dosya alanları için yüklenen dosyaların adları değer olarak alınır.
+func formValues(c *fiber.Ctx) map[uint][]string {
+	values := make(map[uint][]string)
+	if multipartForm, err := c.MultipartForm(); err == nil {
+		for name, vs := range multipartForm.Value {
+			if id, ok := formFieldID(name); ok {
+				values[id] = append(values[id], vs...)
+			}
+		}
+		for name, files := range multipartForm.File {
+			if id, ok := formFieldID(name); ok {
+				for _, file := range files {
+					values[id] = append(values[id], file.Filename)
+				}
+			}
+		}
+		return values
+	}
+	c.Request().PostArgs().VisitAll(func(key, value []byte) {
+		if id, ok := formFieldID(string(key)); ok {
+			values[id] = append(values[id], string(value))
+		}
+	})
+	return values
+}
+
+// requestQuery isteğin sorgu parametrelerini döndürür; form bağlantısındaki ön doldurma
+// değerleri buradan okunur.
+func requestQuery(c *fiber.Ctx) url.Values {
+	query, _ := url.ParseQuery(string(c.Request().URI().QueryString()))
+	return query
+}
+
+// formPrefill sayfayla taşınan ön doldurma değerlerini (_prefill) okur.
+func formPrefill(c *fiber.Ctx) url.Values {
+	prefill, _ := url.ParseQuery(c.FormValue("_prefill"))
+	return prefill
+}
+
+// formUploads multipart gövdedeki dosya alanlarının dosyalarını alan ID'sine göre toplar.
+func formUploads(c *fiber.Ctx) map[uint][]services.FormUpload {
+	uploads := make(map[uint][]services.FormUpload)
+	multipartForm, err := c.MultipartForm()
+	if err != nil {
+		return uploads
+	}
+	for name, files := range multipartForm.File {
+		id, ok := formFieldID(name)
+		if !ok {
+			continue
+		}
+		for _, file := range files {
+			file := file
+			uploads[id] = append(uploads[id], services.FormUpload{
+				FileName: file.Filename,
+				Size:     file.Size,
+				Open:     func() (io.ReadCloser, error) { return file.Open() },
+			})
+		}
+	}
+	return uploads
+}
+
+// formFillData public/form_fill şablonunun verisini hazırlar. Çok sayfalı formda yalnızca
+// gösterilen sayfanın alanları basılır; diğer sayfaların cevapları sunucudaki taslaktadır.
+func formFillData(c *fiber.Ctx, view *services.FormPageView, state services.FormState, message string) fiber.Map {
+	contextValues, err := json.Marshal(view.ContextValues)
+	if err != nil {
+		contextValues = []byte("{}")
+	}
+	return fiber.Map{
+		"Form":          view.Form,
+		"Detail":        view.Form.Detail,
+		"Fields":        view.CurrentPage().Fields,
+		"View":          view,
+		"Values":        view.Values,
+		"ContextValues": string(contextValues),
+		"State":         string(state),
+		"Error":         message,
+		"CsrfToken":     c.Locals("csrf"), // Form gönderimi için CSRF
+		"HoneypotField": services.FormHoneypotField,
+	}
+}
+
+// renderFormView formu verilen sayfa durumuyla gösterir; açık değilse şablon form yerine
+// durum mesajını basar.
+func (h *PublicFormHandler) renderFormView(c *fiber.Ctx, view *services.FormPageView, state services.FormState, status int, message string) error {
+	// View: public/form_fill.html
+	return c.Status(status).Render("public/form_fill", formFillData(c, view, state, message))
+}
+
+// renderFormState tek sayfalık gönderimde formu gönderilen cevaplarla yeniden gösterir.
+func (h *PublicFormHandler) renderFormState(c *fiber.Ctx, form *models.Form, values map[uint][]string, state services.FormState, status int, message string) error {
+	prefill := formPrefill(c)
+	view, err := h.draftService.GetFormPage(c.UserContext(), form, formRespondent(c, false), "", prefill, prefill.Get(models.FormPrefillReferrer))
+	if err != nil {
+		configslog.Log.Error("SubmitForm: GetFormPage error", zap.Uint("formID", form.ID), zap.Error(err))
+		view = &services.FormPageView{Form: form, Pages: []services.FormPage{{}}}
+	}
+	if values != nil {
+		view.Values = values
+	}
+	return h.renderFormView(c, view, state, status, message)
+}
+
+// submitErrorState gönderim hatasını gösterilecek form durumuna ve HTTP durumuna eşler.
+// Tanınmayan hatalarda ok false döner.
+func submitErrorState(err error) (state services.FormState, status int, ok bool) {
+	switch {
+	case errors.Is(err, services.ErrSubmissionInvalid), errors.Is(err, services.ErrSubmissionFormNotReady), errors.Is(err, services.ErrQuizStartInvalid):
+		return services.FormStateOpen, fiber.StatusUnprocessableEntity, true
+	case errors.Is(err, services.ErrSpamBlocked), errors.Is(err, services.ErrSpamTooFast),
+		errors.Is(err, services.ErrSpamPageExpired), errors.Is(err, services.ErrSpamCaptcha):
+		return services.FormStateOpen, fiber.StatusUnprocessableEntity, true
+	case errors.Is(err, services.ErrSpamRateLimited):
+		return services.FormStateOpen, fiber.StatusTooManyRequests, true
+	case errors.Is(err, services.ErrSubmissionFormChanged):
+		return services.FormStateOpen, fiber.StatusConflict, true
+	case errors.Is(err, services.ErrQuizTimeUp):
+		return services.FormStateQuizTimeUp, fiber.StatusForbidden, true
+	case errors.Is(err, services.ErrDraftNotFound):
+		return services.FormStateOpen, fiber.StatusNotFound, true
+	case errors.Is(err, services.ErrSubmissionClosed):
+		return services.FormStateClosed, fiber.StatusGone, true
+	case errors.Is(err, services.ErrSubmissionFull):
+		return services.FormStateFull, fiber.StatusConflict, true
+	case errors.Is(err, services.ErrSubmissionUserLimit):
+		return services.FormStateUserLimit, fiber.StatusConflict, true
+	case errors.Is(err, services.ErrSubmissionLoginNeeded):
+		return services.FormStateLoginRequired, fiber.StatusUnauthorized, true
+	}
+	return services.FormStateOpen, fiber.StatusInternalServerError, false
+}
+
+// renderFormLoadError form bulunamadığında veya yüklenemediğinde hata sayfasını gösterir.
+func renderFormLoadError(c *fiber.Ctx, key string, err error) error {
+	if errors.Is(err, services.ErrFormNotFound) {
+		return c.Status(fiber.StatusNotFound).Render("errors/404", fiber.Map{"Title": "Bulunamadı", "Message": "Form Bulunamadı"}, "layouts/error_layout")
+	}
+	configslog.Log.Error("SubmitForm: GetFormByKey error", zap.String("key", key), zap.Error(err))
+	return c.Status(fiber.StatusInternalServerError).Render("errors/500", fiber.Map{"Title": "Sunucu Hatası", "Message": "Form yüklenirken bir sorun oluştu."}, "layouts/error_layout")
+}
+
+// renderSubmitted gönderim sonrası teşekkür sayfasını gösterir veya tanımlı adrese yönlendirir.
+// Sınav formlarında sonuç her zaman gösterilir; tanımlı adres sayfada devam bağlantısı olur.
+func (h *PublicFormHandler) renderSubmitted(c *fiber.Ctx, form *models.Form, submission *models.FormSubmission) error {
+	redirectURL := form.Detail.RedirectURLOnSubmit
+	if !strings.HasPrefix(redirectURL, "https://") && !strings.HasPrefix(redirectURL, "http://") {
+		redirectURL = ""
+	}
+	result := h.quizService.GetResult(c.UserContext(), form, submission)
+	// Sahibi tanımladıysa teşekkür sayfası yerine verilen adrese yönlendir.
+	if redirectURL != "" && result == nil {
+		return c.Redirect(redirectURL, fiber.StatusSeeOther)
+	}
+	// View: public/form_submitted.html
+	return c.Status(http.StatusOK).Render("public/form_submitted", fiber.Map{
+		"Form":        form,
+		"Detail":      form.Detail,
+		"Result":      result,
+		"RedirectURL": redirectURL,
+	})
+}
+
+// SubmitForm (POST /{key})
+// Form cevaplarını sunucuda alan tanımlarına göre doğrular ve kaydeder. Çok sayfalı
+// formlarda sayfa düğmeleri _action alanını gönderir; bu istekler taslak akışına gider.
+func (h *PublicFormHandler) SubmitForm(c *fiber.Ctx) error {
+	key := c.Params("key")
+
+	input := services.FormSubmissionInput{
+		Values:     formValues(c),
+		Files:      formUploads(c),
+		Respondent: formRespondent(c, false),
+		IPAddress:  c.IP(),
+		UserAgent:  c.Get(fiber.HeaderUserAgent),
+		Prefill:    formPrefill(c),
+		Spam: services.FormSpamInput{
+			Honeypot:      c.FormValue(services.FormHoneypotField),
+			RenderToken:   c.FormValue("_rendered"),
+			CaptchaToken:  c.FormValue("_captcha"),
+			CaptchaAnswer: c.FormValue("_captcha_answer"),
+		},
+	}
+	if action := c.FormValue("_action"); action != "" {
+		return h.submitFormPage(c, key, action, input)
+	}
+
+	form, submission, err := h.submissionService.SubmitForm(c.UserContext(), key, input)
+	if err != nil {
+		if form == nil {
+			return renderFormLoadError(c, key, err)
+		}
+		state, status, ok := submitErrorState(err)
+		if !ok {
+			configslog.Log.Error("SubmitForm Error", zap.String("key", key), zap.Error(err))
+			return h.renderFormState(c, form, input.Values, services.FormStateOpen, status, "Form gönderilirken bir sorun oluştu. Lütfen tekrar deneyin.")
+		}
+		return h.renderFormState(c, form, input.Values, state, status, err.Error())
+	}
+	return h.renderSubmitted(c, form, submission)
+}
+
+// submitFormPage çok sayfalı formda bir sayfanın ileri, geri, kaydet veya gönder işlemini uygular.
+func (h *PublicFormHandler) submitFormPage(c *fiber.Ctx, key string, action string, input services.FormSubmissionInput) error {
+	page, _ := strconv.Atoi(c.FormValue("_page"))
+	view, err := h.draftService.SubmitFormPage(c.UserContext(), key, services.FormPageInput{
+		FormSubmissionInput: input,
+		DraftToken:          c.FormValue("_draft"),
+		Page:                page,
+		Action:              services.FormPageAction(action),
+	})
+	if err != nil {
+		if view == nil {
+			return renderFormLoadError(c, key, err)
+		}
+		state, status, ok := submitErrorState(err)
+		if !ok {
+			configslog.Log.Error("SubmitFormPage Error", zap.String("key", key), zap.Error(err))
+			return h.renderFormView(c, view, services.FormStateOpen, status, "Cevaplar kaydedilirken bir sorun oluştu. Lütfen tekrar deneyin.")
+		}
+		return h.renderFormView(c, view, state, status, err.Error())
+	}
+	if view.Submission != nil {
+		return h.renderSubmitted(c, view.Form, view.Submission)
+	}
+	return h.renderFormView(c, view, services.FormStateOpen, fiber.StatusOK, "")
+}
+
+// DownloadFile (GET /files/forms/:id?expires=...&signature=...)
+// Gönderime yüklenen dosyayı imzalı ve süreli bağlantıyla indirir. Bağlantılar panelde
+// gönderim görüntülenirken üretilir; oturum gerektirmez.
+func (h *PublicFormHandler) DownloadFile(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusNotFound).Render("errors/404", fiber.Map{"Title": "Bulunamadı", "Message": "Dosya Bulunamadı"}, "layouts/error_layout")
+	}
+	file, rc, err := h.submissionService.OpenFile(c.UserContext(), uint(id), c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSubmissionFileLink):
+			return c.Status(fiber.StatusForbidden).Render("errors/404", fiber.Map{"Title": "Bağlantı Geçersiz", "Message": err.Error()}, "layouts/error_layout")
+		case errors.Is(err, services.ErrSubmissionFileNotFound):
+			return c.Status(fiber.StatusNotFound).Render("errors/404", fiber.Map{"Title": "Bulunamadı", "Message": "Dosya Bulunamadı"}, "layouts/error_layout")
+		}
+		configslog.Log.Error("DownloadFile Error", zap.Int("fileID", id), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).Render("errors/500", fiber.Map{"Title": "Sunucu Hatası", "Message": "Dosya okunurken bir sorun oluştu."}, "layouts/error_layout")
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName})
+	if disposition == "" {
+		disposition = fmt.Sprintf("attachment; filename=\"dosya-%d\"", file.ID)
+	}
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	c.Set(fiber.HeaderContentDisposition, disposition)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	// Akış gönderildikten sonra fasthttp okuyucuyu kapatır.
+	return c.SendStream(rc, int(file.Size))
+}
@@ -14,22 +14,30 @@ import (
 
 // LinkHandler public link isteklerini yönetir.
 type LinkHandler struct {
-	linkService        services.ILinkService // Linki bulmak ve doğrulamak için
-	invitationService  services.IInvitationService
-	appointmentService services.IAppointmentService
-	formService        services.IFormService
-	cardService        services.ICardService
+	linkService           services.ILinkService // Linki bulmak ve doğrulamak için
+	invitationService     services.IInvitationService
+	appointmentService    services.IAppointmentService
+	questionService       services.IBookingQuestionService
+	formService           services.IFormService
+	formDraftService      services.IFormDraftService
+	formSubmissionService services.IFormSubmissionService
+	formAnalyticsService  services.IFormAnalyticsService
+	cardService           services.ICardService
 	// TODO: Gerekirse IAuthService (örn. şifreli linkler için)
 }
 
 // NewLinkHandler yeni bir LinkHandler örneği oluşturur.
 func NewLinkHandler() *LinkHandler {
 	return &LinkHandler{
-		linkService:        services.NewLinkService(),
-		invitationService:  services.NewInvitationService(),
-		appointmentService: services.NewAppointmentService(),
-		formService:        services.NewFormService(),
-		cardService:        services.NewCardService(),
+		linkService:           services.NewLinkService(),
+		invitationService:     services.NewInvitationService(),
+		appointmentService:    services.NewAppointmentService(),
+		questionService:       services.NewBookingQuestionService(),
+		formService:           services.NewFormService(),
+		formDraftService:      services.NewFormDraftService(),
+		formSubmissionService: services.NewFormSubmissionService(),
+		formAnalyticsService:  services.NewFormAnalyticsService(),
+		cardService:           services.NewCardService(),
 	}
 }
 
@@ -82,11 +90,16 @@ func (h *LinkHandler) HandleLink(c *fiber.Ctx) error {
 			return h.renderError(c, "Randevu hizmeti yüklenirken bir sorun oluştu.")
 		}
 		// TODO: Şifre kontrolü
-		// TODO: View "public/appointment_booking.html"
-		return c.Render("public/appointment_booking", fiber.Map{"Appointment": appointment, "Detail": appointment.Detail})
+		questions, qErr := h.questionService.GetPublicQuestions(ctx, appointment.ID)
+		if qErr != nil {
+			configslog.Log.Error("HandleLink: GetPublicQuestions error", zap.String("key", key), zap.Error(qErr))
+			return h.renderError(c, "Randevu hizmeti yüklenirken bir sorun oluştu.")
+		}
+		// View: public/appointment_booking.html (slotlar /{key}/slots, rezervasyon POST /{key}/book)
+		return c.Render("public/appointment_booking", fiber.Map{"Appointment": appointment, "Detail": appointment.Detail, "Questions": questions, "CsrfToken": c.Locals("csrf")})
 
 	case models.TypeNameForm:
-		form, formErr := h.formService.GetFormByKey(key)
+		form, formErr := h.formService.GetFormByKey(ctx, key)
 		if formErr != nil {
 			if errors.Is(formErr, services.ErrFormNotFound) {
 				return h.renderNotFound(c, "Form Bulunamadı")
@@ -95,9 +108,30 @@ func (h *LinkHandler) HandleLink(c *fiber.Ctx) error {
 			return h.renderError(c, "Form yüklenirken bir sorun oluştu.")
 		}
 		// TODO: Şifre kontrolü
-		// TODO: Form alanları (FieldDefinitions) servisten gelmeli
-		// TODO: View "public/form_fill.html"
-		return c.Render("public/form_fill", fiber.Map{"Form": form, "Detail": form.Detail, "CsrfToken": c.Locals("csrf")}) // Form gönderimi için CSRF
+		// Çok sayfalı formda ?resume= ile kayıtlı cevaplara ve kaldığı sayfaya dönülür. Alanlar
+		// bağlantıdaki parametrelerle (örn. ?email=...&utm_source=...) önceden doldurulur.
+		respondent := formRespondent(c, true)
+		view, vErr := h.formDraftService.GetFormPage(ctx, form, respondent, c.Query("resume"), requestQuery(c), c.Get(fiber.HeaderReferer))
+		message := ""
+		if vErr != nil {
+			if !errors.Is(vErr, services.ErrDraftNotFound) {
+				configslog.Log.Error("HandleLink: GetFormPage error", zap.String("key", key), zap.Error(vErr))
+				return h.renderError(c, "Form yüklenirken bir sorun oluştu.")
+			}
+			message = vErr.Error()
+		}
+		// Kapalı, dolu veya giriş gerektiren formlarda şablon form yerine durum mesajını gösterir.
+		state, sErr := h.formSubmissionService.GetFormState(ctx, form, respondent)
+		if sErr != nil {
+			configslog.Log.Error("HandleLink: GetFormState error", zap.String("key", key), zap.Error(sErr))
+			return h.renderError(c, "Form yüklenirken bir sorun oluştu.")
+		}
+		// Dönüşüm oranı için yalnızca doldurulabilir formun ilk açılışı sayılır (devam bağlantısı hariç).
+		if state == services.FormStateOpen && c.Query("resume") == "" {
+			h.formAnalyticsService.RecordView(ctx, form)
+		}
+		// View: public/form_fill.html
+		return c.Render("public/form_fill", formFillData(c, view, state, message))
 
 	case models.TypeNameCard:
 		card, cardErr := h.cardService.GetCardByKey(key)
@@ -1,21 +1,19 @@
-// handlers/public/public_rsvp_handler.go (YENİ DOSYA)
-package handlers // veya handlers/public
+package handlers
 
 import (
 	"errors"
 
 	"davet.link/configs/configslog"
-	"davet.link/models" // Belki gerekmez, JSON dönülebilir
+	"davet.link/models"
 	"davet.link/services"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
 
-// PublicRSVPHandler public RSVP işlemleri için handler.
+// PublicRSVPHandler davetlilerin LCV yanıtları için handler.
 type PublicRSVPHandler struct {
 	invitationService services.IInvitationService
-	// Belki Captcha servisi?
 }
 
 // NewPublicRSVPHandler yeni bir PublicRSVPHandler örneği oluşturur.
@@ -25,70 +23,41 @@ func NewPublicRSVPHandler() *PublicRSVPHandler {
 	}
 }
 
-// ShowRSVPForm (GET /{key}/rsvp veya GET /rsvp/{key})
-// Davetiyeyi bulur ve RSVP formunu gösterir. Misafir ID/Kod da parametre olarak gelebilir.
-func (h *PublicRSVPHandler) ShowRSVPForm(c *fiber.Ctx) error {
-	key := c.Params("key")
-	guestIdentifier := c.Query("guest") // Query param ?guest=CODE veya ?guest=ID
-
-	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), key)
-	if err != nil {
-		// Handle NotFound etc. -> 404 sayfası göster
-		return c.Status(fiber.StatusNotFound).Render("errors/404", fiber.Map{"Title": "Davetiye Bulunamadı"})
-	}
-
-	// TODO: Misafir bilgilerini (guestIdentifier ile) alıp forma gönderebiliriz.
-	// guest, _ := invitationService.GetGuestByIdentifier(invitation.ID, guestIdentifier)
-
-	// TODO: View "public/rsvp_form.html"
-	return c.Render("public/rsvp_form", fiber.Map{
-		"Title":           "LCV: " + invitation.Detail.Title,
-		"Invitation":      invitation,
-		"Detail":          invitation.Detail,
-		"GuestIdentifier": guestIdentifier,  // Formun hangi misafir için olduğunu bilmesi için
-		"CsrfToken":       c.Locals("csrf"), // CSRF token (public formda gerekli mi? Captcha daha iyi olabilir)
-	}) // Layout?
+// rsvpRequest LCV isteğinin gövdesi (JSON veya form).
+type rsvpRequest struct {
+	Status   string `json:"status" form:"status"`
+	PlusOnes int    `json:"plus_ones" form:"plus_ones"`
+	Notes    string `json:"notes" form:"notes"`
 }
 
-// SubmitRSVP (POST /{key}/rsvp veya POST /rsvp/{key})
-// Formdan gelen RSVP verisini işler.
+// SubmitRSVP (POST /{key}/rsvp/{token})
+// Davetlinin LCV yanıtını kaydeder. token davetliye özel bağlantıdaki gizli anahtardır;
+// yanıt kaydedilince rsvp.updated webhook olayı kuyruğa eklenir.
 func (h *PublicRSVPHandler) SubmitRSVP(c *fiber.Ctx) error {
 	key := c.Params("key")
-	guestIdentifier := c.FormValue("guest_identifier") // Formdan hidden input ile alınır
 
-	var rsvpData models.InvitationRSVP
-	// Formdan sadece Status, PlusOnes, Notes alınır.
-	if err := c.BodyParser(&rsvpData); err != nil {
-		configslog.Log.Warn("SubmitRSVP: Form verisi parse edilemedi", zap.Error(err))
-		// Genellikle JSON yanıt dönmek daha iyi olabilir
+	var req rsvpRequest
+	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri."})
-		// Veya flash mesajla forma geri yönlendir?
-		// _ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen formu kontrol edin.")
-		// return c.Redirect(fmt.Sprintf("/%s/rsvp?guest=%s", key, guestIdentifier), fiber.StatusSeeOther)
 	}
 
-	// Servisi çağır
-	err := h.invitationService.SubmitRSVP(c.UserContext(), key, guestIdentifier, rsvpData)
+	err := h.invitationService.SubmitRSVP(c.UserContext(), key, c.Params("token"), models.InvitationRSVP{
+		Status:   models.RSVPStatus(req.Status),
+		PlusOnes: req.PlusOnes,
+		Notes:    req.Notes,
+	})
 	if err != nil {
-		errMsg := "LCV gönderilirken bir hata oluştu: " + err.Error()
-		statusCode := fiber.StatusInternalServerError
-		if errors.Is(err, services.ErrInvitationNotFound) || errors.Is(err, services.ErrGuestNotFoundForRSVP) {
-			statusCode = fiber.StatusNotFound
-		} else if errors.Is(err, services.ErrRSVPDeadlinePassed) || errors.Is(err, services.ErrInvalidRSVPStatus) ||
-			errors.Is(err, services.ErrPlusOnesNotAllowed) || errors.Is(err, services.ErrMaxPlusOnesExceeded) {
-			statusCode = fiber.StatusBadRequest
+		switch {
+		case errors.Is(err, services.ErrInvitationNotFound), errors.Is(err, services.ErrGuestNotFoundForRSVP):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "LCV bağlantısı geçersiz."})
+		case errors.Is(err, services.ErrRSVPDeadlinePassed):
+			return c.Status(fiber.StatusGone).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidRSVPStatus), errors.Is(err, services.ErrPlusOnesNotAllowed),
+			errors.Is(err, services.ErrMaxPlusOnesExceeded), errors.Is(err, services.ErrInvInvalidInput):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 		}
-		configslog.Log.Error("SubmitRSVP Error", zap.String("key", key), zap.String("guest", guestIdentifier), zap.Error(err))
-		return c.Status(statusCode).JSON(fiber.Map{"error": errMsg})
-		// Veya flash mesajla forma geri yönlendir?
-		// _ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
-		// return c.Redirect(fmt.Sprintf("/%s/rsvp?guest=%s", key, guestIdentifier), fiber.StatusSeeOther)
+		configslog.Log.Error("SubmitRSVP Error", zap.String("key", key), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "LCV yanıtı kaydedilemedi."})
 	}
-
-	// Başarılı yanıt
-	// TODO: Teşekkürler sayfası veya mesajı göster
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "LCV yanıtınız başarıyla alındı."})
-	// Veya
-	// _ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "LCV yanıtınız başarıyla alındı.")
-	// return c.Redirect(fmt.Sprintf("/%s/rsvp/thankyou", key)) // Teşekkürler sayfasına yönlendir
+	return c.JSON(fiber.Map{"message": "LCV yanıtınız alındı."})
 }
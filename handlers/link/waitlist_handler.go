@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PublicWaitlistHandler dolu günler için bekleme listesi ve teklif kabul sayfası için handler.
+type PublicWaitlistHandler struct {
+	appointmentService services.IAppointmentService
+	waitlistService    services.IWaitlistService
+	questionService    services.IBookingQuestionService
+}
+
+// NewPublicWaitlistHandler yeni bir PublicWaitlistHandler örneği oluşturur.
+func NewPublicWaitlistHandler() *PublicWaitlistHandler {
+	return &PublicWaitlistHandler{
+		appointmentService: services.NewAppointmentService(),
+		waitlistService:    services.NewWaitlistService(),
+		questionService:    services.NewBookingQuestionService(),
+	}
+}
+
+// waitlistRequest POST /{key}/waitlist gövdesi (form veya JSON).
+type waitlistRequest struct {
+	Date  string `json:"date" form:"date"` // 2006-01-02
+	Name  string `json:"name" form:"name"`
+	Email string `json:"email" form:"email"`
+	Phone string `json:"phone" form:"phone"`
+	Notes string `json:"notes" form:"notes"`
+}
+
+// JoinWaitlist (POST /{key}/waitlist)
+// Müsait slotu kalmayan gün için müşteriyi bekleme listesine ekler.
+func (h *PublicWaitlistHandler) JoinWaitlist(c *fiber.Ctx) error {
+	key := c.Params("key")
+
+	appointment, err := h.appointmentService.GetAppointmentByKey(c.UserContext(), key)
+	if err != nil {
+		if errors.Is(err, services.ErrAppointmentNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Randevu hizmeti bulunamadı."})
+		}
+		configslog.Log.Error("JoinWaitlist: GetAppointmentByKey error", zap.String("key", key), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Randevu hizmeti yüklenemedi."})
+	}
+
+	var req waitlistRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri."})
+	}
+	day, err := time.ParseInLocation("2006-01-02", req.Date, services.AppointmentLocation(appointment.Detail))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": services.ErrAvailInvalidDate.Error()})
+	}
+
+	entry, position, err := h.waitlistService.JoinWaitlist(c.UserContext(), key, services.WaitlistInput{
+		Date:          day,
+		CustomerName:  req.Name,
+		CustomerEmail: req.Email,
+		CustomerPhone: req.Phone,
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWaitlistInvalidInput):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, services.ErrWaitlistNotOpen), errors.Is(err, services.ErrWaitlistDuplicate):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, services.ErrCustomerBlocked):
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+		}
+		configslog.Log.Error("JoinWaitlist Error", zap.String("key", key), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Bekleme listesine eklenemediniz."})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":       entry.ID,
+		"date":     req.Date,
+		"position": position,
+	})
+}
+
+// renderClaim teklif sayfasını verilen sonuç mesajlarıyla gösterir.
+func (h *PublicWaitlistHandler) renderClaim(c *fiber.Ctx, status int, entry *models.BookingWaitlistEntry, data fiber.Map) error {
+	appointment := entry.Appointment
+	loc := services.AppointmentLocation(appointment.Detail)
+	questions, err := h.questionService.GetPublicQuestions(c.UserContext(), appointment.ID)
+	if err != nil {
+		configslog.Log.Error("Waitlist claim: GetPublicQuestions error", zap.Uint("appointmentID", appointment.ID), zap.Error(err))
+	}
+
+	view := fiber.Map{
+		"Entry":     entry,
+		"Detail":    appointment.Detail,
+		"Questions": questions,
+		"Usable":    entry.Status == models.WaitlistStatusOffered && entry.OfferExpiresAt != nil && entry.OfferExpiresAt.After(time.Now()),
+		"CsrfToken": c.Locals("csrf"),
+	}
+	if entry.OfferStartsAt != nil {
+		view["StartsAt"] = entry.OfferStartsAt.In(loc)
+		view["EndsAt"] = entry.OfferEndsAt.In(loc)
+	}
+	if entry.OfferExpiresAt != nil {
+		view["ExpiresAt"] = entry.OfferExpiresAt.In(loc)
+	}
+	for k, v := range data {
+		view[k] = v
+	}
+	// View: public/waitlist_claim.html
+	return c.Status(status).Render("public/waitlist_claim", view)
+}
+
+// ShowClaim (GET /waitlist/claim/{token})
+// Boşalan slot teklifini ve kabul/ret butonlarını gösterir.
+func (h *PublicWaitlistHandler) ShowClaim(c *fiber.Ctx) error {
+	entry, err := h.waitlistService.GetOffer(c.UserContext(), c.Params("token"))
+	if err != nil {
+		if !errors.Is(err, services.ErrWaitlistOfferNotFound) {
+			configslog.Log.Error("ShowClaim Error", zap.Error(err))
+		}
+		return c.Status(fiber.StatusNotFound).Render("errors/404", fiber.Map{"Title": "Teklif Bulunamadı"})
+	}
+	return h.renderClaim(c, fiber.StatusOK, entry, nil)
+}
+
+// Claim (POST /waitlist/claim/{token})
+// Teklifi kabul edip rezervasyonu oluşturur.
+func (h *PublicWaitlistHandler) Claim(c *fiber.Ctx) error {
+	token := c.Params("token")
+	entry, err := h.waitlistService.GetOffer(c.UserContext(), token)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).Render("errors/404", fiber.Map{"Title": "Teklif Bulunamadı"})
+	}
+
+	booking, err := h.waitlistService.ClaimOffer(c.UserContext(), token, bookingAnswers(c, bookingRequest{}))
+	if err != nil {
+		// Güncel durumu göstermek için teklif yeniden okunur
+		if fresh, getErr := h.waitlistService.GetOffer(c.UserContext(), token); getErr == nil {
+			entry = fresh
+		}
+		status := fiber.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrBookingInvalidInput):
+			status = fiber.StatusBadRequest
+		case errors.Is(err, services.ErrWaitlistOfferExpired), errors.Is(err, services.ErrBookingSlotUnavailable):
+			status = fiber.StatusConflict
+		default:
+			configslog.Log.Error("Claim Error", zap.Uint("waitlistID", entry.ID), zap.Error(err))
+		}
+		return h.renderClaim(c, status, entry, fiber.Map{"Error": err.Error()})
+	}
+
+	entry.Status = models.WaitlistStatusClaimed
+	return h.renderClaim(c, fiber.StatusOK, entry, fiber.Map{"Booking": booking})
+}
+
+// Decline (POST /waitlist/decline/{token})
+// Teklifi reddeder; slot sıradaki müşteriye teklif edilir.
+func (h *PublicWaitlistHandler) Decline(c *fiber.Ctx) error {
+	token := c.Params("token")
+	if err := h.waitlistService.DeclineOffer(c.UserContext(), token); err != nil && !errors.Is(err, services.ErrWaitlistOfferExpired) {
+		if errors.Is(err, services.ErrWaitlistOfferNotFound) {
+			return c.Status(fiber.StatusNotFound).Render("errors/404", fiber.Map{"Title": "Teklif Bulunamadı"})
+		}
+		configslog.Log.Error("Decline Error", zap.Error(err))
+	}
+	return c.Redirect("/waitlist/claim/"+token, fiber.StatusSeeOther)
+}
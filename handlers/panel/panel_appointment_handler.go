@@ -78,6 +78,13 @@ func (h *PanelAppointmentHandler) CreateAppointment(c *fiber.Ctx) error {
 		return c.Redirect("/panel/appointments/create", fiber.StatusSeeOther)
 	}
 
+	// Ücret ve kapora "150,00" biçiminde gelir, kuruşa çevrilir
+	if err := services.ParseAppointmentPrices(&detail, c.FormValue("price"), c.FormValue("deposit"), c.FormValue("currency")); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		_ = flashmessages.SetFlashFormData(c, detail)
+		return c.Redirect("/panel/appointments/create", fiber.StatusSeeOther)
+	}
+
 	if err := services.ValidateAppointmentDetail(detail); err != nil {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
 		_ = flashmessages.SetFlashFormData(c, detail)
@@ -147,6 +154,12 @@ func (h *PanelAppointmentHandler) UpdateAppointment(c *fiber.Ctx) error {
 	isEnabledStr := c.FormValue("is_enabled", "false")
 	isEnabled := isEnabledStr == "true" || isEnabledStr == "on"
 
+	if err := services.ParseAppointmentPrices(&detailUpdates, c.FormValue("price"), c.FormValue("deposit"), c.FormValue("currency")); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		_ = flashmessages.SetFlashFormData(c, detailUpdates)
+		return c.Redirect(redirectPathOnError, fiber.StatusSeeOther)
+	}
+
 	if err := services.ValidateAppointmentDetail(detailUpdates); err != nil {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
 		_ = flashmessages.SetFlashFormData(c, detailUpdates)
@@ -192,4 +205,4 @@ func (h *PanelAppointmentHandler) DeleteAppointment(c *fiber.Ctx) error {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Randevu hizmeti başarıyla silindi.")
 	}
 	return c.Redirect("/panel/appointments", fiber.StatusSeeOther) // Her durumda listeye dön
-}
\ No newline at end of file
+}
\ No newline at end of file
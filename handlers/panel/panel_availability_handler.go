@@ -0,0 +1,312 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// weekdayNames panelde gösterilen gün adları (time.Weekday sırasıyla).
+var weekdayNames = []string{"Pazar", "Pazartesi", "Salı", "Çarşamba", "Perşembe", "Cuma", "Cumartesi"}
+
+// overrideListDays istisna listesinde bugünden itibaren gösterilen gün sayısı.
+const overrideListDays = 180
+
+// PanelAvailabilityHandler çalışma saatleri, tarih istisnaları ve tatil ayarları için handler.
+type PanelAvailabilityHandler struct {
+	service                 services.IAvailabilityService
+	appointmentService      services.IAppointmentService
+	externalCalendarService services.IExternalCalendarService
+}
+
+// NewPanelAvailabilityHandler yeni bir PanelAvailabilityHandler örneği oluşturur.
+func NewPanelAvailabilityHandler() *PanelAvailabilityHandler {
+	return &PanelAvailabilityHandler{
+		service:                 services.NewAvailabilityService(),
+		appointmentService:      services.NewAppointmentService(),
+		externalCalendarService: services.NewExternalCalendarService(),
+	}
+}
+
+// weekdayRanges bir günün kurallarını formda gösterilecek "09:00-12:00, 13:00-17:00" metnine çevirir.
+func weekdayRanges(rules []models.AppointmentAvailabilityRule) []string {
+	ranges := make([]string, 7)
+	for _, rule := range rules {
+		text := fmt.Sprintf("%02d:%02d-%02d:%02d", rule.StartMinute/60, rule.StartMinute%60, rule.EndMinute/60, rule.EndMinute%60)
+		if ranges[rule.Weekday] != "" {
+			ranges[rule.Weekday] += ", "
+		}
+		ranges[rule.Weekday] += text
+	}
+	return ranges
+}
+
+// parseWeekdayRanges "09:00-12:00, 13:00-17:00" metnini kurallara çevirir.
+func parseWeekdayRanges(weekday int, text string) ([]models.AppointmentAvailabilityRule, error) {
+	var rules []models.AppointmentAvailabilityRule
+	for _, part := range strings.Split(text, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		bounds := strings.Split(part, "-")
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("%s: aralık SS:DD-SS:DD biçiminde olmalı (%s)", weekdayNames[weekday], part)
+		}
+		start, err := services.ParseClockMinutes(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		end, err := services.ParseClockMinutes(bounds[1])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, models.AppointmentAvailabilityRule{Weekday: weekday, StartMinute: start, EndMinute: end})
+	}
+	return rules, nil
+}
+
+// ShowAppointmentAvailability randevu hizmetinin haftalık saatlerini ve hizmete özel istisnalarını gösterir.
+func (h *PanelAvailabilityHandler) ShowAppointmentAvailability(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/appointments")
+	}
+	appointmentID := uint(id)
+
+	appointment, err := h.appointmentService.GetAppointmentByID(c.UserContext(), appointmentID, userID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Randevu hizmeti bulunamadı veya bu hizmeti düzenleme yetkiniz yok.")
+		return c.Redirect("/panel/appointments")
+	}
+
+	rules, err := h.service.GetWeeklyRules(c.UserContext(), appointmentID, userID)
+	if err != nil {
+		configslog.Log.Error("Panel - ShowAppointmentAvailability Error", zap.Uint("id", appointmentID), zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Çalışma saatleri alınırken bir hata oluştu.")
+		return c.Redirect("/panel/appointments")
+	}
+
+	today := time.Now().UTC()
+	overrides, err := h.service.GetOverrides(c.UserContext(), userID, today, today.AddDate(0, 0, overrideListDays))
+	if err != nil {
+		configslog.Log.Error("Panel - ShowAppointmentAvailability overrides Error", zap.Uint("userID", userID), zap.Error(err))
+	}
+	var ownOverrides []models.AppointmentAvailabilityOverride
+	for _, o := range overrides {
+		if o.AppointmentID != nil && *o.AppointmentID == appointmentID {
+			ownOverrides = append(ownOverrides, o)
+		}
+	}
+
+	// View: panel/appointments/availability.html
+	return renderer.Render(c, "panel/appointments/availability", "layouts/panel", fiber.Map{
+		"Title":        "Çalışma Saatleri: " + appointment.Detail.Name,
+		"Appointment":  appointment,
+		"WeekdayNames": weekdayNames,
+		"Ranges":       weekdayRanges(rules),
+		"Overrides":    ownOverrides,
+	}, http.StatusOK)
+}
+
+// UpdateAppointmentAvailability haftalık çalışma saatlerini kaydeder.
+// Her gün için "ranges_<0-6>" alanında virgülle ayrılmış aralıklar beklenir.
+func (h *PanelAvailabilityHandler) UpdateAppointmentAvailability(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/appointments")
+	}
+	appointmentID := uint(id)
+	redirectPath := fmt.Sprintf("/panel/appointments/availability/%d", appointmentID)
+
+	var rules []models.AppointmentAvailabilityRule
+	for weekday := 0; weekday < 7; weekday++ {
+		dayRules, parseErr := parseWeekdayRanges(weekday, c.FormValue("ranges_"+strconv.Itoa(weekday)))
+		if parseErr != nil {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, parseErr.Error())
+			return c.Redirect(redirectPath, fiber.StatusSeeOther)
+		}
+		rules = append(rules, dayRules...)
+	}
+
+	if err := h.service.UpdateWeeklyRules(c.UserContext(), appointmentID, userID, rules); err != nil {
+		if !errors.Is(err, services.ErrAvailInvalidRule) && !errors.Is(err, services.ErrAppointmentForbidden) {
+			configslog.Log.Error("Panel - UpdateAppointmentAvailability Error", zap.Uint("id", appointmentID), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Güncelleme hatası: "+err.Error())
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Çalışma saatleri güncellendi.")
+	return c.Redirect(redirectPath, fiber.StatusFound)
+}
+
+// ShowProviderAvailability sağlayıcı geneli istisnaları ve resmi tatil tercihini gösterir.
+func (h *PanelAvailabilityHandler) ShowProviderAvailability(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	renderData := fiber.Map{"Title": "Tatiller ve İzinler"}
+
+	setting, err := h.service.GetProviderSetting(c.UserContext(), userID)
+	if err != nil {
+		configslog.Log.Error("Panel - ShowProviderAvailability setting Error", zap.Uint("userID", userID), zap.Error(err))
+		setting = &models.ProviderSetting{UserID: userID}
+	}
+	renderData["Setting"] = setting
+	if setting.CalendarFeedToken != nil {
+		renderData["CalendarFeedURL"] = c.BaseURL() + "/calendar/" + *setting.CalendarFeedToken + ".ics"
+	}
+
+	today := time.Now().UTC()
+	overrides, err := h.service.GetOverrides(c.UserContext(), userID, today, today.AddDate(0, 0, overrideListDays))
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = "İstisnalar listelenirken bir hata oluştu."
+		configslog.Log.Error("Panel - ShowProviderAvailability Error", zap.Uint("userID", userID), zap.Error(err))
+	}
+	renderData["Overrides"] = overrides
+
+	externalCalendars, err := h.externalCalendarService.GetCalendarsForUser(c.UserContext(), userID)
+	if err != nil {
+		configslog.Log.Error("Panel - ShowProviderAvailability external calendars Error", zap.Uint("userID", userID), zap.Error(err))
+	}
+	renderData["ExternalCalendars"] = externalCalendars
+
+	// View: panel/availability/index.html
+	return renderer.Render(c, "panel/availability/index", "layouts/panel", renderData, http.StatusOK)
+}
+
+// UpdateProviderSettings resmi tatil tercihini kaydeder.
+func (h *PanelAvailabilityHandler) UpdateProviderSettings(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	observe := c.FormValue("observe_public_holidays", "false")
+	if err := h.service.UpdateProviderSetting(c.UserContext(), userID, observe == "true" || observe == "on"); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Ayarlar kaydedilemedi: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Tatil ayarları kaydedildi.")
+	}
+	return c.Redirect("/panel/availability", fiber.StatusSeeOther)
+}
+
+// RegenerateCalendarFeed gizli ICS abonelik adresini oluşturur veya yeniler.
+func (h *PanelAvailabilityHandler) RegenerateCalendarFeed(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	if _, err := h.service.RegenerateCalendarFeedToken(c.UserContext(), userID); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Takvim adresi oluşturulamadı: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Yeni takvim adresi oluşturuldu. Eski adres artık çalışmaz.")
+	}
+	return c.Redirect("/panel/availability", fiber.StatusSeeOther)
+}
+
+// CreateOverride sağlayıcı geneli veya hizmete özel bir tarih istisnası ekler.
+// appointment_id boşsa istisna tüm hizmetlere uygulanır.
+func (h *PanelAvailabilityHandler) CreateOverride(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	redirectPath := "/panel/availability"
+	var override models.AppointmentAvailabilityOverride
+	if appointmentIDStr := c.FormValue("appointment_id"); appointmentIDStr != "" {
+		appointmentID, err := strconv.ParseUint(appointmentIDStr, 10, 64)
+		if err != nil || appointmentID == 0 {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz randevu hizmeti.")
+			return c.Redirect(redirectPath, fiber.StatusSeeOther)
+		}
+		id := uint(appointmentID)
+		override.AppointmentID = &id
+		redirectPath = fmt.Sprintf("/panel/appointments/availability/%d", id)
+	}
+
+	date, err := time.Parse("2006-01-02", c.FormValue("date"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, services.ErrAvailInvalidDate.Error())
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+	override.Date = date
+	override.Note = c.FormValue("note")
+	isClosed := c.FormValue("is_closed", "false")
+	override.IsClosed = isClosed == "true" || isClosed == "on"
+	if !override.IsClosed {
+		if override.StartMinute, err = services.ParseClockMinutes(c.FormValue("start_time")); err == nil {
+			override.EndMinute, err = services.ParseClockMinutes(c.FormValue("end_time"))
+		}
+		if err != nil {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+			return c.Redirect(redirectPath, fiber.StatusSeeOther)
+		}
+	}
+
+	if err := h.service.CreateOverride(c.UserContext(), userID, override); err != nil {
+		if !errors.Is(err, services.ErrAvailInvalidOverride) {
+			configslog.Log.Error("Panel - CreateOverride Error", zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İstisna eklenemedi: "+err.Error())
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Tarih istisnası eklendi.")
+	return c.Redirect(redirectPath, fiber.StatusFound)
+}
+
+// DeleteOverride bir tarih istisnasını siler.
+func (h *PanelAvailabilityHandler) DeleteOverride(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	redirectPath := c.FormValue("redirect", "/panel/availability")
+	if !strings.HasPrefix(redirectPath, "/panel/") {
+		redirectPath = "/panel/availability"
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	if err := h.service.DeleteOverride(c.UserContext(), uint(id), userID); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Silme hatası: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Tarih istisnası silindi.")
+	}
+	return c.Redirect(redirectPath, fiber.StatusSeeOther)
+}
@@ -0,0 +1,247 @@
+package handlers // handlers/panel paketi
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/csvsafe"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// sessionListDays seans listesinde bugünden itibaren gösterilen gün sayısı.
+const sessionListDays = 60
+
+// PanelBookingHandler randevu rezervasyonları ve seans katılımcı listeleri için handler.
+type PanelBookingHandler struct {
+	service services.IBookingService
+}
+
+// NewPanelBookingHandler yeni bir PanelBookingHandler örneği oluşturur.
+func NewPanelBookingHandler() *PanelBookingHandler {
+	return &PanelBookingHandler{
+		service: services.NewBookingService(),
+	}
+}
+
+// ListSessions randevu hizmetinin yaklaşan seanslarını katılımcı listeleriyle gösterir.
+func (h *PanelBookingHandler) ListSessions(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/appointments")
+	}
+
+	appointment, sessions, err := h.service.GetUpcomingSessions(c.UserContext(), uint(id), userID, sessionListDays)
+	if err != nil {
+		configslog.Log.Error("Panel - ListSessions Error", zap.Int("id", id), zap.Uint("userID", userID), zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Randevu hizmeti bulunamadı veya bu hizmeti görüntüleme yetkiniz yok.")
+		return c.Redirect("/panel/appointments")
+	}
+
+	// Saatler hizmetin zaman diliminde gösterilir
+	loc := services.AppointmentLocation(appointment.Detail)
+	for i := range sessions {
+		sessions[i].StartsAt = sessions[i].StartsAt.In(loc)
+		sessions[i].EndsAt = sessions[i].EndsAt.In(loc)
+	}
+
+	// View: panel/appointments/sessions.html
+	return renderer.Render(c, "panel/appointments/sessions", "layouts/panel", fiber.Map{
+		"Title":       "Seanslar: " + appointment.Detail.Name,
+		"Appointment": appointment,
+		"Sessions":    sessions,
+	}, http.StatusOK)
+}
+
+// ExportRoster tek bir seansın katılımcı listesini CSV olarak indirir.
+// Seans, ?start= parametresinde RFC3339 biçimindeki başlangıç saatiyle belirtilir.
+func (h *PanelBookingHandler) ExportRoster(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID.")
+	}
+	startsAt, err := time.Parse(time.RFC3339, c.Query("start"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz seans saati.")
+	}
+
+	appointment, attendees, err := h.service.GetSessionRoster(c.UserContext(), uint(id), userID, startsAt)
+	if err != nil {
+		configslog.Log.Error("Panel - ExportRoster Error", zap.Int("id", id), zap.Uint("userID", userID), zap.Error(err))
+		return c.Status(fiber.StatusNotFound).SendString("Seans bulunamadı.")
+	}
+
+	local := startsAt.In(services.AppointmentLocation(appointment.Detail))
+	filename := fmt.Sprintf("katilimcilar-%d-%s.csv", appointment.ID, local.Format("20060102-1504"))
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+
+	// Excel'in UTF-8 Türkçe karakterleri doğru açması için BOM
+	if _, err := c.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return err
+	}
+	w := csv.NewWriter(c)
+	_ = w.Write([]string{"Sıra", "Ad Soyad", "E-posta", "Telefon", "Durum", "Not", "Kayıt Tarihi"})
+	for i, b := range attendees {
+		// Ad, iletişim ve not müşterinin yazdığı metinlerdir; formül olarak açılmamalı.
+		_ = w.Write(csvsafe.Row([]string{
+			fmt.Sprint(i + 1),
+			b.CustomerName,
+			b.CustomerEmail,
+			b.CustomerPhone,
+			string(b.Status),
+			b.Notes,
+			b.CreatedAt.In(local.Location()).Format("02.01.2006 15:04"),
+		}))
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// ShowBooking tek bir rezervasyonun müşteri bilgilerini, rezervasyon sorularına verilen cevapları
+// ve ödeme hareketlerini gösterir.
+func (h *PanelBookingHandler) ShowBooking(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/appointments")
+	}
+
+	booking, err := h.service.GetBookingDetail(c.UserContext(), uint(id), userID)
+	if err != nil {
+		if !errors.Is(err, services.ErrBookingNotFound) && !errors.Is(err, services.ErrBookingForbidden) {
+			configslog.Log.Error("Panel - ShowBooking Error", zap.Int("id", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Rezervasyon bulunamadı veya görüntüleme yetkiniz yok.")
+		return c.Redirect("/panel/appointments")
+	}
+
+	// Saatler hizmetin zaman diliminde gösterilir
+	loc := services.AppointmentLocation(booking.Appointment.Detail)
+	booking.StartsAt = booking.StartsAt.In(loc)
+	booking.EndsAt = booking.EndsAt.In(loc)
+
+	// View: panel/appointments/booking.html
+	return renderer.Render(c, "panel/appointments/booking", "layouts/panel", fiber.Map{
+		"Title":             "Rezervasyon: " + booking.CustomerName,
+		"Booking":           booking,
+		"RemainingMinor":    booking.AmountDueMinor - booking.PaidMinor,
+		"PaymentMethods":    paymentMethodLabels,
+		"Today":             time.Now().In(loc).Format("2006-01-02"),
+		"CanMarkAttendance": booking.Status != models.BookingStatusCancelled && !booking.EndsAt.After(time.Now()),
+	}, http.StatusOK)
+}
+
+// bookingRedirect formdan gelen dönüş adresini yalnızca panel içiyle sınırlar.
+func bookingRedirect(c *fiber.Ctx) string {
+	redirectPath := c.FormValue("redirect", "/panel/appointments")
+	if !strings.HasPrefix(redirectPath, "/panel/") {
+		redirectPath = "/panel/appointments"
+	}
+	return redirectPath
+}
+
+// ConfirmBooking onay bekleyen bir rezervasyonu onaylar.
+func (h *PanelBookingHandler) ConfirmBooking(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	redirectPath := bookingRedirect(c)
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	if err := h.service.ConfirmBooking(c.UserContext(), uint(id), userID); err != nil {
+		if !errors.Is(err, services.ErrBookingInvalidStatus) && !errors.Is(err, services.ErrBookingForbidden) {
+			configslog.Log.Error("Panel - ConfirmBooking Error", zap.Int("id", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Onaylama hatası: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Rezervasyon onaylandı.")
+	}
+	return c.Redirect(redirectPath, fiber.StatusSeeOther)
+}
+
+// CancelBooking rezervasyonu iptal eder.
+func (h *PanelBookingHandler) CancelBooking(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	redirectPath := bookingRedirect(c)
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	if err := h.service.CancelBooking(c.UserContext(), uint(id), userID); err != nil {
+		if !errors.Is(err, services.ErrBookingInvalidStatus) && !errors.Is(err, services.ErrBookingForbidden) {
+			configslog.Log.Error("Panel - CancelBooking Error", zap.Int("id", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İptal hatası: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Rezervasyon iptal edildi.")
+	}
+	return c.Redirect(redirectPath, fiber.StatusSeeOther)
+}
+
+// MarkAttendance bitmiş bir rezervasyonu gerçekleşti veya gelmedi olarak işaretler.
+func (h *PanelBookingHandler) MarkAttendance(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	redirectPath := bookingRedirect(c)
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	status := models.BookingStatus(c.FormValue("status"))
+	if err := h.service.MarkAttendance(c.UserContext(), uint(id), userID, status); err != nil {
+		if !errors.Is(err, services.ErrBookingInvalidStatus) && !errors.Is(err, services.ErrBookingInvalidInput) &&
+			!errors.Is(err, services.ErrBookingForbidden) && !errors.Is(err, services.ErrBookingNotFound) {
+			configslog.Log.Error("Panel - MarkAttendance Error", zap.Int("id", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İşaretleme hatası: "+err.Error())
+	} else if status == models.BookingStatusNoShow {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Rezervasyon gelmedi olarak işaretlendi.")
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Rezervasyon gerçekleşti olarak işaretlendi.")
+	}
+	return c.Redirect(redirectPath, fiber.StatusSeeOther)
+}
@@ -0,0 +1,119 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/money"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// paymentMethodLabels panelde gösterilen ödeme yöntemi adları.
+var paymentMethodLabels = map[models.PaymentMethod]string{
+	models.PaymentMethodCash:         "Nakit",
+	models.PaymentMethodBankTransfer: "Havale / EFT",
+	models.PaymentMethodCard:         "Kredi Kartı (POS)",
+	models.PaymentMethodOnline:       "Çevrim İçi",
+}
+
+// PanelBookingPaymentHandler rezervasyon ödemeleri ve iadeleri için handler.
+type PanelBookingPaymentHandler struct {
+	service services.IPaymentService
+}
+
+// NewPanelBookingPaymentHandler yeni bir PanelBookingPaymentHandler örneği oluşturur.
+func NewPanelBookingPaymentHandler() *PanelBookingPaymentHandler {
+	return &PanelBookingPaymentHandler{
+		service: services.NewPaymentService(),
+	}
+}
+
+// RecordPayment rezervasyon için elle ödeme veya iade hareketi kaydeder.
+func (h *PanelBookingPaymentHandler) RecordPayment(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/appointments", fiber.StatusSeeOther)
+	}
+	redirectPath := fmt.Sprintf("/panel/appointments/bookings/%d", id)
+
+	amount, err := money.Parse(c.FormValue("amount"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz tutar.")
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+	input := services.ManualPaymentInput{
+		Kind:        models.PaymentKind(c.FormValue("kind", string(models.PaymentKindPayment))),
+		Method:      models.PaymentMethod(c.FormValue("method")),
+		AmountMinor: amount,
+		Note:        c.FormValue("note"),
+	}
+	if paidAt := c.FormValue("paid_at"); paidAt != "" {
+		t, err := time.ParseInLocation("2006-01-02", paidAt, time.Local)
+		if err != nil {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ödeme tarihi.")
+			return c.Redirect(redirectPath, fiber.StatusSeeOther)
+		}
+		input.PaidAt = t
+	}
+
+	if err := h.service.RecordManualPayment(c.UserContext(), uint(id), userID, input); err != nil {
+		if !errors.Is(err, services.ErrPaymentInvalidInput) && !errors.Is(err, services.ErrPaymentRefundTooLarge) &&
+			!errors.Is(err, services.ErrPaymentForbidden) && !errors.Is(err, services.ErrBookingNotFound) {
+			configslog.Log.Error("Panel - RecordPayment Error", zap.Int("bookingID", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Ödeme kaydedilemedi: "+err.Error())
+	} else if input.Kind == models.PaymentKindRefund {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "İade kaydedildi.")
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Ödeme kaydedildi.")
+	}
+	return c.Redirect(redirectPath, fiber.StatusSeeOther)
+}
+
+// RefundPayment bir ödemenin tamamını veya girilen tutar kadarını iade eder.
+func (h *PanelBookingPaymentHandler) RefundPayment(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	redirectPath := bookingRedirect(c)
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	// Tutar boş bırakılırsa ödemenin iade edilmemiş kısmının tamamı iade edilir
+	var amount int64
+	if raw := c.FormValue("amount"); raw != "" {
+		if amount, err = money.Parse(raw); err != nil {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz tutar.")
+			return c.Redirect(redirectPath, fiber.StatusSeeOther)
+		}
+	}
+
+	if err := h.service.RefundPayment(c.UserContext(), uint(id), userID, amount, c.FormValue("note")); err != nil {
+		if !errors.Is(err, services.ErrPaymentNotFound) && !errors.Is(err, services.ErrPaymentForbidden) &&
+			!errors.Is(err, services.ErrPaymentRefundTooLarge) && !errors.Is(err, services.ErrPaymentInvalidInput) {
+			configslog.Log.Error("Panel - RefundPayment Error", zap.Int("paymentID", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İade hatası: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Ödeme iade edildi.")
+	}
+	return c.Redirect(redirectPath, fiber.StatusSeeOther)
+}
@@ -0,0 +1,135 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// questionTypeLabels panelde gösterilen soru tipi adları.
+var questionTypeLabels = map[models.QuestionType]string{
+	models.QuestionTypeText:     "Metin",
+	models.QuestionTypeChoice:   "Seçmeli",
+	models.QuestionTypeCheckbox: "Onay Kutusu",
+}
+
+// PanelBookingQuestionHandler randevu hizmetlerinin rezervasyon soruları için handler.
+type PanelBookingQuestionHandler struct {
+	service            services.IBookingQuestionService
+	appointmentService services.IAppointmentService
+}
+
+// NewPanelBookingQuestionHandler yeni bir PanelBookingQuestionHandler örneği oluşturur.
+func NewPanelBookingQuestionHandler() *PanelBookingQuestionHandler {
+	return &PanelBookingQuestionHandler{
+		service:            services.NewBookingQuestionService(),
+		appointmentService: services.NewAppointmentService(),
+	}
+}
+
+// ListQuestions randevu hizmetinin rezervasyon sorularını ve yeni soru formunu gösterir.
+func (h *PanelBookingQuestionHandler) ListQuestions(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/appointments")
+	}
+	appointmentID := uint(id)
+
+	appointment, err := h.appointmentService.GetAppointmentByID(c.UserContext(), appointmentID, userID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Randevu hizmeti bulunamadı veya bu hizmeti düzenleme yetkiniz yok.")
+		return c.Redirect("/panel/appointments")
+	}
+
+	questions, err := h.service.GetQuestions(c.UserContext(), appointmentID, userID)
+	if err != nil {
+		configslog.Log.Error("Panel - ListQuestions Error", zap.Uint("id", appointmentID), zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Sorular alınırken bir hata oluştu.")
+		return c.Redirect("/panel/appointments")
+	}
+
+	// View: panel/appointments/questions.html
+	return renderer.Render(c, "panel/appointments/questions", "layouts/panel", fiber.Map{
+		"Title":         "Rezervasyon Soruları: " + appointment.Detail.Name,
+		"Appointment":   appointment,
+		"Questions":     questions,
+		"QuestionTypes": questionTypeLabels,
+	}, http.StatusOK)
+}
+
+// CreateQuestion hizmete yeni bir rezervasyon sorusu ekler.
+// Seçmeli sorularda "options" alanında her satıra bir seçenek beklenir.
+func (h *PanelBookingQuestionHandler) CreateQuestion(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/appointments")
+	}
+	appointmentID := uint(id)
+	redirectPath := fmt.Sprintf("/panel/appointments/questions/%d", appointmentID)
+
+	required := c.FormValue("required", "false")
+	question := models.AppointmentQuestion{
+		Label:    c.FormValue("label"),
+		Type:     models.QuestionType(c.FormValue("type")),
+		Options:  c.FormValue("options"),
+		Required: required == "true" || required == "on",
+	}
+	if sortOrder, convErr := strconv.Atoi(c.FormValue("sort_order")); convErr == nil {
+		question.SortOrder = sortOrder
+	}
+
+	if err := h.service.CreateQuestion(c.UserContext(), appointmentID, userID, question); err != nil {
+		if !errors.Is(err, services.ErrQuestionInvalid) && !errors.Is(err, services.ErrAppointmentForbidden) {
+			configslog.Log.Error("Panel - CreateQuestion Error", zap.Uint("id", appointmentID), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Soru eklenemedi: "+err.Error())
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Soru eklendi.")
+	return c.Redirect(redirectPath, fiber.StatusFound)
+}
+
+// DeleteQuestion bir rezervasyon sorusunu siler. Önceki rezervasyonlardaki cevaplar korunur.
+func (h *PanelBookingQuestionHandler) DeleteQuestion(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	redirectPath := bookingRedirect(c)
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	if err := h.service.DeleteQuestion(c.UserContext(), uint(id), userID); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Silme hatası: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Soru silindi.")
+	}
+	return c.Redirect(redirectPath, fiber.StatusSeeOther)
+}
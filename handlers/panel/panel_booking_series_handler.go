@@ -0,0 +1,242 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PanelBookingSeriesHandler tekrarlayan rezervasyon serileri için handler.
+type PanelBookingSeriesHandler struct {
+	service            services.IBookingSeriesService
+	appointmentService services.IAppointmentService
+}
+
+// NewPanelBookingSeriesHandler yeni bir PanelBookingSeriesHandler örneği oluşturur.
+func NewPanelBookingSeriesHandler() *PanelBookingSeriesHandler {
+	return &PanelBookingSeriesHandler{
+		service:            services.NewBookingSeriesService(),
+		appointmentService: services.NewAppointmentService(),
+	}
+}
+
+// seriesForm panel seri formunun alanları; önizleme sonrası form aynı değerlerle yeniden gösterilir.
+type seriesForm struct {
+	Date          string
+	Time          string
+	IntervalWeeks int
+	Count         int
+	Until         string
+	Name          string
+	Email         string
+	Phone         string
+	Notes         string
+}
+
+// parseSeriesForm form alanlarını okuyup hizmetin zaman diliminde servis girdisine çevirir.
+func parseSeriesForm(c *fiber.Ctx, loc *time.Location) (seriesForm, services.SeriesInput, error) {
+	form := seriesForm{
+		Date:  c.FormValue("date"),
+		Time:  c.FormValue("time"),
+		Until: c.FormValue("until"),
+		Name:  c.FormValue("name"),
+		Email: c.FormValue("email"),
+		Phone: c.FormValue("phone"),
+		Notes: c.FormValue("notes"),
+	}
+	form.IntervalWeeks, _ = strconv.Atoi(c.FormValue("interval_weeks", "1"))
+	form.Count, _ = strconv.Atoi(c.FormValue("count", "0"))
+
+	startsAt, err := time.ParseInLocation("2006-01-02 15:04", form.Date+" "+form.Time, loc)
+	if err != nil {
+		return form, services.SeriesInput{}, errors.New("ilk randevu tarihi ve saati geçersiz")
+	}
+	input := services.SeriesInput{
+		Booking: services.BookingInput{
+			StartsAt:      startsAt,
+			CustomerName:  form.Name,
+			CustomerEmail: form.Email,
+			CustomerPhone: form.Phone,
+			Notes:         form.Notes,
+		},
+		IntervalWeeks: form.IntervalWeeks,
+		Count:         form.Count,
+	}
+	if form.Until != "" {
+		until, err := time.ParseInLocation("2006-01-02", form.Until, loc)
+		if err != nil {
+			return form, services.SeriesInput{}, errors.New("bitiş tarihi geçersiz")
+		}
+		input.Until = &until
+	}
+	return form, input, nil
+}
+
+// ShowCreateSeries tekrarlayan rezervasyon formunu gösterir.
+func (h *PanelBookingSeriesHandler) ShowCreateSeries(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/appointments")
+	}
+	appointment, err := h.appointmentService.GetAppointmentByID(c.UserContext(), uint(id), userID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Randevu hizmeti bulunamadı veya bu hizmeti görüntüleme yetkiniz yok.")
+		return c.Redirect("/panel/appointments")
+	}
+
+	// View: panel/appointments/series_create.html
+	return renderer.Render(c, "panel/appointments/series_create", "layouts/panel", fiber.Map{
+		"Title":       "Tekrarlayan Rezervasyon: " + appointment.Detail.Name,
+		"Appointment": appointment,
+		"Form":        seriesForm{IntervalWeeks: 1, Count: 4},
+	}, http.StatusOK)
+}
+
+// CreateSeries "preview" eyleminde tekrarları ve çakışmaları gösterir; "create" eyleminde seriyi oluşturur.
+func (h *PanelBookingSeriesHandler) CreateSeries(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/appointments", fiber.StatusSeeOther)
+	}
+	appointment, err := h.appointmentService.GetAppointmentByID(c.UserContext(), uint(id), userID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Randevu hizmeti bulunamadı veya bu hizmeti görüntüleme yetkiniz yok.")
+		return c.Redirect("/panel/appointments", fiber.StatusSeeOther)
+	}
+	loc := services.AppointmentLocation(appointment.Detail)
+
+	render := func(form seriesForm, occurrences []services.SeriesOccurrence, errMsg string) error {
+		conflicts := 0
+		for i := range occurrences {
+			occurrences[i].StartsAt = occurrences[i].StartsAt.In(loc)
+			occurrences[i].EndsAt = occurrences[i].EndsAt.In(loc)
+			if !occurrences[i].Available {
+				conflicts++
+			}
+		}
+		// View: panel/appointments/series_create.html
+		return renderer.Render(c, "panel/appointments/series_create", "layouts/panel", fiber.Map{
+			"Title":       "Tekrarlayan Rezervasyon: " + appointment.Detail.Name,
+			"Appointment": appointment,
+			"Form":        form,
+			"Occurrences": occurrences,
+			"Conflicts":   conflicts,
+			"Error":       errMsg,
+		}, http.StatusOK)
+	}
+
+	form, input, err := parseSeriesForm(c, loc)
+	if err != nil {
+		return render(form, nil, err.Error())
+	}
+
+	if c.FormValue("action") != "create" {
+		occurrences, err := h.service.PreviewProviderSeries(c.UserContext(), appointment.ID, userID, input)
+		if err != nil {
+			if !errors.Is(err, services.ErrSeriesInvalidInput) {
+				configslog.Log.Error("Panel - PreviewSeries Error", zap.Uint("appointmentID", appointment.ID), zap.Uint("userID", userID), zap.Error(err))
+			}
+			return render(form, nil, err.Error())
+		}
+		return render(form, occurrences, "")
+	}
+
+	skipConflicts := c.FormValue("skip_conflicts") == "true"
+	series, occurrences, err := h.service.CreateProviderSeries(c.UserContext(), appointment.ID, userID, input, skipConflicts)
+	if err != nil {
+		if !errors.Is(err, services.ErrSeriesInvalidInput) && !errors.Is(err, services.ErrSeriesConflict) && !errors.Is(err, services.ErrBookingInvalidInput) {
+			configslog.Log.Error("Panel - CreateSeries Error", zap.Uint("appointmentID", appointment.ID), zap.Uint("userID", userID), zap.Error(err))
+		}
+		return render(form, occurrences, err.Error())
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, fmt.Sprintf("%d tekrarlı rezervasyon oluşturuldu.", len(series.Bookings)))
+	return c.Redirect(fmt.Sprintf("/panel/appointments/series/%d", series.ID), fiber.StatusSeeOther)
+}
+
+// ShowSeries serinin tüm tekrarlarını durumlarıyla gösterir.
+func (h *PanelBookingSeriesHandler) ShowSeries(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/appointments")
+	}
+	series, err := h.service.GetSeries(c.UserContext(), uint(id), userID)
+	if err != nil {
+		if !errors.Is(err, services.ErrSeriesNotFound) && !errors.Is(err, services.ErrSeriesForbidden) {
+			configslog.Log.Error("Panel - ShowSeries Error", zap.Int("id", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Seri bulunamadı veya görüntüleme yetkiniz yok.")
+		return c.Redirect("/panel/appointments")
+	}
+
+	// Saatler hizmetin zaman diliminde gösterilir
+	loc := services.AppointmentLocation(series.Appointment.Detail)
+	for i := range series.Bookings {
+		series.Bookings[i].StartsAt = series.Bookings[i].StartsAt.In(loc)
+		series.Bookings[i].EndsAt = series.Bookings[i].EndsAt.In(loc)
+	}
+
+	// View: panel/appointments/series.html
+	return renderer.Render(c, "panel/appointments/series", "layouts/panel", fiber.Map{
+		"Title":  "Tekrarlayan Rezervasyon: " + series.CustomerName,
+		"Series": series,
+		"Now":    time.Now(),
+	}, http.StatusOK)
+}
+
+// CancelSeries seriye ait bir rezervasyondan başlayarak seçilen kapsamda iptal yapar
+// (yalnızca bu tekrar, bu ve sonrakiler veya tüm seri).
+func (h *PanelBookingSeriesHandler) CancelSeries(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	redirectPath := bookingRedirect(c)
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	scope := services.SeriesCancelScope(c.FormValue("scope", string(services.SeriesCancelOne)))
+	count, err := h.service.CancelSeries(c.UserContext(), uint(id), userID, scope)
+	if err != nil {
+		if !errors.Is(err, services.ErrBookingInvalidStatus) && !errors.Is(err, services.ErrBookingForbidden) &&
+			!errors.Is(err, services.ErrSeriesNotInSeries) && !errors.Is(err, services.ErrSeriesInvalidInput) {
+			configslog.Log.Error("Panel - CancelSeries Error", zap.Int("bookingID", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İptal hatası: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, fmt.Sprintf("%d rezervasyon iptal edildi.", count))
+	}
+	return c.Redirect(redirectPath, fiber.StatusSeeOther)
+}
@@ -0,0 +1,94 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"net/http"
+
+	"davet.link/configs/configslog"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PanelBookingWaitlistHandler hizmetlerin bekleme listeleri için handler.
+type PanelBookingWaitlistHandler struct {
+	service services.IWaitlistService
+}
+
+// NewPanelBookingWaitlistHandler yeni bir PanelBookingWaitlistHandler örneği oluşturur.
+func NewPanelBookingWaitlistHandler() *PanelBookingWaitlistHandler {
+	return &PanelBookingWaitlistHandler{
+		service: services.NewWaitlistService(),
+	}
+}
+
+// ListWaitlist hizmetin bugünden itibaren bekleme listesini, teklif durumlarıyla gösterir.
+func (h *PanelBookingWaitlistHandler) ListWaitlist(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/appointments")
+	}
+	appointment, entries, err := h.service.GetWaitlist(c.UserContext(), uint(id), userID)
+	if err != nil {
+		if !errors.Is(err, services.ErrAppointmentNotFound) && !errors.Is(err, services.ErrAppointmentForbidden) {
+			configslog.Log.Error("Panel - ListWaitlist Error", zap.Int("appointmentID", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Randevu hizmeti bulunamadı veya bu hizmeti görüntüleme yetkiniz yok.")
+		return c.Redirect("/panel/appointments")
+	}
+
+	// Teklif saatleri hizmetin zaman diliminde gösterilir
+	loc := services.AppointmentLocation(appointment.Detail)
+	for i := range entries {
+		if entries[i].OfferStartsAt != nil {
+			t := entries[i].OfferStartsAt.In(loc)
+			entries[i].OfferStartsAt = &t
+		}
+		if entries[i].OfferExpiresAt != nil {
+			t := entries[i].OfferExpiresAt.In(loc)
+			entries[i].OfferExpiresAt = &t
+		}
+	}
+
+	// View: panel/appointments/waitlist.html
+	return renderer.Render(c, "panel/appointments/waitlist", "layouts/panel", fiber.Map{
+		"Title":       "Bekleme Listesi: " + appointment.Detail.Name,
+		"Appointment": appointment,
+		"Entries":     entries,
+	}, http.StatusOK)
+}
+
+// RemoveEntry müşteriyi bekleme listesinden çıkarır.
+func (h *PanelBookingWaitlistHandler) RemoveEntry(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	redirectPath := bookingRedirect(c)
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	if err := h.service.RemoveEntry(c.UserContext(), uint(id), userID); err != nil {
+		if !errors.Is(err, services.ErrWaitlistNotFound) && !errors.Is(err, services.ErrWaitlistForbidden) &&
+			!errors.Is(err, services.ErrWaitlistInvalidInput) {
+			configslog.Log.Error("Panel - RemoveWaitlistEntry Error", zap.Int("id", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kayıt çıkarılamadı: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Müşteri bekleme listesinden çıkarıldı.")
+	}
+	return c.Redirect(redirectPath, fiber.StatusSeeOther)
+}
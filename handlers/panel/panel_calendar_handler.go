@@ -0,0 +1,127 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// defaultCalendarColor hizmette renk kodu tanımlı değilse kullanılan etkinlik rengi.
+const defaultCalendarColor = "#0d6efd"
+
+// calendarEvent panel takviminin (FullCalendar) beklediği etkinlik biçimi.
+type calendarEvent struct {
+	ID            uint                 `json:"id"`
+	Title         string               `json:"title"`
+	Start         time.Time            `json:"start"`
+	End           time.Time            `json:"end"`
+	Color         string               `json:"color"`
+	URL           string               `json:"url"`
+	Editable      bool                 `json:"editable"`
+	Status        models.BookingStatus `json:"status"`
+	AppointmentID uint                 `json:"appointmentId"`
+}
+
+// PanelCalendarHandler sağlayıcının tüm hizmetlerini kapsayan rezervasyon takvimi için handler.
+type PanelCalendarHandler struct {
+	service services.IBookingService
+}
+
+// NewPanelCalendarHandler yeni bir PanelCalendarHandler örneği oluşturur.
+func NewPanelCalendarHandler() *PanelCalendarHandler {
+	return &PanelCalendarHandler{
+		service: services.NewBookingService(),
+	}
+}
+
+// ShowCalendar haftalık/günlük takvim sayfasını gösterir; etkinlikler Events ile yüklenir.
+func (h *PanelCalendarHandler) ShowCalendar(c *fiber.Ctx) error {
+	// View: panel/calendar/index.html
+	return renderer.Render(c, "panel/calendar/index", "layouts/panel", fiber.Map{
+		"Title":     "Takvim",
+		"CsrfToken": c.Locals("csrf"),
+	}, http.StatusOK)
+}
+
+// Events ?start=&end= (RFC3339) aralığında başlayan rezervasyonları JSON olarak döndürür.
+func (h *PanelCalendarHandler) Events(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Oturum bulunamadı."})
+	}
+	from, errFrom := time.Parse(time.RFC3339, c.Query("start"))
+	to, errTo := time.Parse(time.RFC3339, c.Query("end"))
+	if errFrom != nil || errTo != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz tarih aralığı."})
+	}
+
+	bookings, err := h.service.GetProviderCalendar(c.UserContext(), userID, from, to)
+	if err != nil {
+		if errors.Is(err, services.ErrBookingInvalidInput) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		configslog.Log.Error("Panel - CalendarEvents Error", zap.Uint("userID", userID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Takvim yüklenemedi."})
+	}
+
+	now := time.Now()
+	events := make([]calendarEvent, 0, len(bookings))
+	for _, b := range bookings {
+		color := b.Appointment.Detail.ColorCode
+		if color == "" {
+			color = defaultCalendarColor
+		}
+		active := b.Status == models.BookingStatusPending || b.Status == models.BookingStatusConfirmed
+		events = append(events, calendarEvent{
+			ID:            b.ID,
+			Title:         b.Appointment.Detail.Name + " - " + b.CustomerName,
+			Start:         b.StartsAt,
+			End:           b.EndsAt,
+			Color:         color,
+			URL:           fmt.Sprintf("/panel/appointments/bookings/%d", b.ID),
+			Editable:      active && b.StartsAt.After(now),
+			Status:        b.Status,
+			AppointmentID: b.AppointmentID,
+		})
+	}
+	return c.JSON(events)
+}
+
+// MoveBooking sürüklenen rezervasyonu form alanı start (RFC3339) saatine taşır.
+// Saat uygun değilse 409 döner; takvim etkinliği eski yerine geri alır.
+func (h *PanelCalendarHandler) MoveBooking(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Oturum bulunamadı."})
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz ID."})
+	}
+	startsAt, err := time.Parse(time.RFC3339, c.FormValue("start"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz randevu saati."})
+	}
+
+	booking, err := h.service.RescheduleBooking(c.UserContext(), uint(id), userID, startsAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrBookingNotFound), errors.Is(err, services.ErrBookingForbidden):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Rezervasyon bulunamadı."})
+		case errors.Is(err, services.ErrBookingSlotUnavailable), errors.Is(err, services.ErrBookingInvalidStatus):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		}
+		configslog.Log.Error("Panel - MoveBooking Error", zap.Int("bookingID", id), zap.Uint("userID", userID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": services.ErrBookingUpdateFailed.Error()})
+	}
+	return c.JSON(fiber.Map{"id": booking.ID, "start": booking.StartsAt, "end": booking.EndsAt})
+}
@@ -0,0 +1,102 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"davet.link/configs/configslog"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PanelCustomerHandler müşteri katılım geçmişi ve engel listesi için handler.
+type PanelCustomerHandler struct {
+	service services.ICustomerService
+}
+
+// NewPanelCustomerHandler yeni bir PanelCustomerHandler örneği oluşturur.
+func NewPanelCustomerHandler() *PanelCustomerHandler {
+	return &PanelCustomerHandler{
+		service: services.NewCustomerService(),
+	}
+}
+
+// ListCustomers müşterilerin gelmeme sayılarını, engel durumlarını ve otomatik engelleme kuralını gösterir.
+func (h *PanelCustomerHandler) ListCustomers(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	customers, err := h.service.GetCustomers(c.UserContext(), userID)
+	if err != nil {
+		configslog.Log.Error("Panel - ListCustomers Error", zap.Uint("userID", userID), zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Müşteriler getirilemedi.")
+	}
+	limit, err := h.service.GetNoShowRule(c.UserContext(), userID)
+	if err != nil {
+		configslog.Log.Error("Panel - ListCustomers Rule Error", zap.Uint("userID", userID), zap.Error(err))
+	}
+
+	// View: panel/customers/index.html
+	return renderer.Render(c, "panel/customers/index", "layouts/panel", fiber.Map{
+		"Title":            "Müşteriler",
+		"Customers":        customers,
+		"NoShowBlockLimit": limit,
+	}, http.StatusOK)
+}
+
+// UpdateNoShowRule otomatik engelleme için gelmeme sınırını kaydeder.
+func (h *PanelCustomerHandler) UpdateNoShowRule(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	limit, err := strconv.Atoi(c.FormValue("no_show_block_limit", "0"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz gelmeme sınırı.")
+		return c.Redirect("/panel/customers", fiber.StatusSeeOther)
+	}
+	if err := h.service.UpdateNoShowRule(c.UserContext(), userID, limit); err != nil {
+		if !errors.Is(err, services.ErrCustomerInvalidInput) {
+			configslog.Log.Error("Panel - UpdateNoShowRule Error", zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kural kaydedilemedi: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Otomatik engelleme kuralı kaydedildi.")
+	}
+	return c.Redirect("/panel/customers", fiber.StatusSeeOther)
+}
+
+// SetBlocked müşteriyi elle engeller veya engelini kaldırır.
+func (h *PanelCustomerHandler) SetBlocked(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/customers", fiber.StatusSeeOther)
+	}
+
+	blocked := c.FormValue("blocked") == "true"
+	if err := h.service.SetBlocked(c.UserContext(), uint(id), userID, blocked); err != nil {
+		if !errors.Is(err, services.ErrCustomerNotFound) && !errors.Is(err, services.ErrCustomerForbidden) {
+			configslog.Log.Error("Panel - SetBlocked Error", zap.Int("id", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Güncelleme hatası: "+err.Error())
+	} else if blocked {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Müşteri engellendi.")
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Müşterinin engeli kaldırıldı.")
+	}
+	return c.Redirect("/panel/customers", fiber.StatusSeeOther)
+}
@@ -0,0 +1,140 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"io"
+
+	"davet.link/configs/configslog"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/ics"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PanelExternalCalendarHandler dış takvim (ICS) içe aktarma işlemleri için handler.
+type PanelExternalCalendarHandler struct {
+	service services.IExternalCalendarService
+}
+
+// NewPanelExternalCalendarHandler yeni bir PanelExternalCalendarHandler örneği oluşturur.
+func NewPanelExternalCalendarHandler() *PanelExternalCalendarHandler {
+	return &PanelExternalCalendarHandler{service: services.NewExternalCalendarService()}
+}
+
+// externalCalendarRedirect dış takvim işlemlerinden sonra dönülen sayfa.
+const externalCalendarRedirect = "/panel/availability"
+
+// logUnexpectedExternalCalendarError kullanıcı hatası olmayan durumları loglar.
+func logUnexpectedExternalCalendarError(action string, userID uint, err error) {
+	var serviceErr services.ExternalCalendarServiceError
+	if errors.As(err, &serviceErr) && !errors.Is(err, services.ErrExtCalSaveFailed) {
+		return
+	}
+	configslog.Log.Error("Panel - "+action+" Error", zap.Uint("userID", userID), zap.Error(err))
+}
+
+// ImportFile yüklenen .ics dosyasını dış takvim olarak içe aktarır.
+func (h *PanelExternalCalendarHandler) ImportFile(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen bir .ics dosyası seçin.")
+		return c.Redirect(externalCalendarRedirect, fiber.StatusSeeOther)
+	}
+	if fileHeader.Size > ics.MaxFeedSize {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Takvim dosyası en fazla 5 MB olabilir.")
+		return c.Redirect(externalCalendarRedirect, fiber.StatusSeeOther)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Dosya okunamadı.")
+		return c.Redirect(externalCalendarRedirect, fiber.StatusSeeOther)
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, ics.MaxFeedSize+1))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Dosya okunamadı.")
+		return c.Redirect(externalCalendarRedirect, fiber.StatusSeeOther)
+	}
+
+	name := c.FormValue("name")
+	if name == "" {
+		name = fileHeader.Filename
+	}
+	calendar, err := h.service.ImportFile(c.UserContext(), userID, name, data)
+	if err != nil {
+		logUnexpectedExternalCalendarError("ImportFile", userID, err)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Takvim içe aktarılamadı: "+err.Error())
+		return c.Redirect(externalCalendarRedirect, fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Takvim içe aktarıldı. Meşgul zamanlar randevu saatlerinden düşüldü.")
+	configslog.SLog.Infof("Dış takvim yüklendi: ID %d (User ID %d)", calendar.ID, userID)
+	return c.Redirect(externalCalendarRedirect, fiber.StatusFound)
+}
+
+// AddURL bir ICS abonelik adresini ekler; adres arka planda düzenli olarak yenilenir.
+func (h *PanelExternalCalendarHandler) AddURL(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	if _, err := h.service.AddURL(c.UserContext(), userID, c.FormValue("name"), c.FormValue("url")); err != nil {
+		logUnexpectedExternalCalendarError("AddURL", userID, err)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Takvim adresi eklenemedi: "+err.Error())
+		return c.Redirect(externalCalendarRedirect, fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Takvim adresi eklendi ve senkronize edildi.")
+	return c.Redirect(externalCalendarRedirect, fiber.StatusFound)
+}
+
+// Refresh bir dış takvimi hemen yeniden senkronize eder.
+func (h *PanelExternalCalendarHandler) Refresh(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect(externalCalendarRedirect, fiber.StatusSeeOther)
+	}
+
+	if err := h.service.Refresh(c.UserContext(), uint(id), userID); err != nil {
+		logUnexpectedExternalCalendarError("RefreshExternalCalendar", userID, err)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Takvim yenilenemedi: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Takvim yenilendi.")
+	}
+	return c.Redirect(externalCalendarRedirect, fiber.StatusSeeOther)
+}
+
+// Delete bir dış takvimi ve meşgul bloklarını siler.
+func (h *PanelExternalCalendarHandler) Delete(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect(externalCalendarRedirect, fiber.StatusSeeOther)
+	}
+
+	if err := h.service.DeleteCalendar(c.UserContext(), uint(id), userID); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Silme hatası: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Dış takvim silindi.")
+	}
+	return c.Redirect(externalCalendarRedirect, fiber.StatusSeeOther)
+}
@@ -0,0 +1,67 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PanelFormAnalyticsHandler formun yanıt analizleri sayfası için handler.
+type PanelFormAnalyticsHandler struct {
+	service services.IFormAnalyticsService
+}
+
+// NewPanelFormAnalyticsHandler yeni bir PanelFormAnalyticsHandler örneği oluşturur.
+func NewPanelFormAnalyticsHandler() *PanelFormAnalyticsHandler {
+	return &PanelFormAnalyticsHandler{
+		service: services.NewFormAnalyticsService(),
+	}
+}
+
+// ShowAnalytics gönderimlerin zamana göre dağılımını, alan istatistiklerini, sayfa bazlı
+// ayrılma oranlarını ve görüntülenmeden gönderime dönüşümü gösterir.
+// ?from=YYYY-MM-DD&to=YYYY-MM-DD (varsayılan: son 30 gün)
+func (h *PanelFormAnalyticsHandler) ShowAnalytics(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+	formID := uint(id)
+	dateRange, err := services.ParseFormAnalyticsRange(c.Query("from"), c.Query("to"), time.Now())
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		dateRange, _ = services.ParseFormAnalyticsRange("", "", time.Now())
+	}
+
+	form, analytics, err := h.service.GetAnalytics(c.UserContext(), formID, userID, dateRange)
+	if err != nil {
+		if !errors.Is(err, services.ErrFormNotFound) && !errors.Is(err, services.ErrFormForbidden) {
+			configslog.Log.Error("Panel - ShowFormAnalytics Error", zap.Uint("formID", formID), zap.Uint("userID", userID), zap.Error(err))
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Analizler yüklenemedi.")
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya bu formu görüntüleme yetkiniz yok.")
+		}
+		return c.Redirect("/panel/forms")
+	}
+
+	// View: panel/forms/analytics.html
+	return renderer.Render(c, "panel/forms/analytics", "layouts/panel", fiber.Map{
+		"Title":     "Analizler: " + form.Detail.Title,
+		"Form":      form,
+		"Analytics": analytics,
+	}, http.StatusOK)
+}
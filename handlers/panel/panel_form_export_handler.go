@@ -0,0 +1,181 @@
+package handlers // handlers/panel paketi
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PanelFormExportHandler form gönderimlerinin dışa aktarımı ve zamanlanmış gönderimi için handler.
+type PanelFormExportHandler struct {
+	service services.IFormExportService
+}
+
+// NewPanelFormExportHandler yeni bir PanelFormExportHandler örneği oluşturur.
+func NewPanelFormExportHandler() *PanelFormExportHandler {
+	return &PanelFormExportHandler{
+		service: services.NewFormExportService(),
+	}
+}
+
+// exportsPath dışa aktarım sayfasının adresi.
+func exportsPath(formID uint) string {
+	return fmt.Sprintf("/panel/forms/exports/%d", formID)
+}
+
+// exportWeekdays haftalık zamanlamada seçilebilecek günler (Pazartesiden başlayarak).
+var exportWeekdays = []struct {
+	Value int
+	Label string
+}{
+	{1, "Pazartesi"}, {2, "Salı"}, {3, "Çarşamba"}, {4, "Perşembe"}, {5, "Cuma"}, {6, "Cumartesi"}, {0, "Pazar"},
+}
+
+// ShowExports indirme formunu ve zamanlanmış gönderim ayarlarını gösterir.
+func (h *PanelFormExportHandler) ShowExports(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+	form, _, err := h.service.GetExportForm(c.UserContext(), uint(id), userID)
+	if err != nil {
+		if !errors.Is(err, services.ErrFormNotFound) && !errors.Is(err, services.ErrFormForbidden) {
+			configslog.Log.Error("Panel - ShowFormExports Error", zap.Int("formID", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya bu formu görüntüleme yetkiniz yok.")
+		return c.Redirect("/panel/forms")
+	}
+	schedule, err := h.service.GetSchedule(c.UserContext(), form.ID)
+	if err != nil {
+		configslog.Log.Error("Panel - ShowFormExports schedule Error", zap.Uint("formID", form.ID), zap.Error(err))
+	}
+	if schedule == nil {
+		// Kaydedilmemiş zamanlama için varsayılanlar
+		schedule = &models.FormExportSchedule{Frequency: models.FormExportDaily, Weekday: 1, Hour: 8, Format: string(services.FormExportXLSX)}
+	}
+	var lastRunAt *time.Time
+	if schedule.LastRunAt != nil {
+		local := schedule.LastRunAt.Local()
+		lastRunAt = &local
+	}
+
+	// View: panel/forms/exports.html
+	return renderer.Render(c, "panel/forms/exports", "layouts/panel", fiber.Map{
+		"Title":     "Dışa Aktar: " + form.Detail.Title,
+		"Form":      form,
+		"Schedule":  schedule,
+		"NextRunAt": schedule.NextRunAt.Local(),
+		"LastRunAt": lastRunAt,
+		"Weekdays":  exportWeekdays,
+	}, http.StatusOK)
+}
+
+// Download filtreye uyan gönderimleri seçilen biçimde indirir.
+// ?format=csv|xlsx|json&from=YYYY-MM-DD&to=YYYY-MM-DD
+func (h *PanelFormExportHandler) Download(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz ID.")
+	}
+	formID := uint(id)
+	format, err := services.ParseFormExportFormat(c.Query("format", string(services.FormExportXLSX)))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(exportsPath(formID))
+	}
+	filter, err := services.ParseFormExportFilter(c.Query("from"), c.Query("to"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(exportsPath(formID))
+	}
+
+	form, fields, err := h.service.GetExportForm(c.UserContext(), formID, userID)
+	if err != nil {
+		if !errors.Is(err, services.ErrFormNotFound) && !errors.Is(err, services.ErrFormForbidden) {
+			configslog.Log.Error("Panel - DownloadFormExport Error", zap.Uint("formID", formID), zap.Uint("userID", userID), zap.Error(err))
+		}
+		return c.Status(fiber.StatusNotFound).SendString("Form bulunamadı.")
+	}
+
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", services.ExportFileName(form.ID, format, filter)))
+	w := bufio.NewWriter(c)
+	count, err := h.service.WriteExport(c.UserContext(), form, fields, format, filter, w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		configslog.Log.Error("Panel - DownloadFormExport write Error", zap.Uint("formID", formID), zap.Int("written", count), zap.Error(err))
+		c.Response().ResetBody()
+		c.Response().Header.Del(fiber.HeaderContentDisposition)
+		return c.Status(fiber.StatusInternalServerError).SendString("Dışa aktarım oluşturulamadı.")
+	}
+	return nil
+}
+
+// UpdateSchedule zamanlanmış dışa aktarım ayarlarını kaydeder.
+func (h *PanelFormExportHandler) UpdateSchedule(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+	formID := uint(id)
+
+	enabled := c.FormValue("is_enabled", "false")
+	weekday, _ := strconv.Atoi(c.FormValue("weekday"))
+	hour, _ := strconv.Atoi(c.FormValue("hour"))
+	input := services.FormExportScheduleInput{
+		IsEnabled:  enabled == "true" || enabled == "on",
+		Frequency:  models.FormExportFrequency(c.FormValue("frequency")),
+		Weekday:    weekday,
+		Hour:       hour,
+		Format:     c.FormValue("format"),
+		Recipients: c.FormValue("recipients"),
+	}
+
+	if err := h.service.SaveSchedule(c.UserContext(), formID, userID, input); err != nil {
+		switch {
+		case errors.Is(err, services.ErrFormNotFound), errors.Is(err, services.ErrFormForbidden):
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya bu formu düzenleme yetkiniz yok.")
+			return c.Redirect("/panel/forms", fiber.StatusSeeOther)
+		case errors.Is(err, services.ErrFormExportInvalid):
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		default:
+			configslog.Log.Error("Panel - UpdateFormExportSchedule Error", zap.Uint("formID", formID), zap.Uint("userID", userID), zap.Error(err))
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Zamanlama kaydedilemedi.")
+		}
+		return c.Redirect(exportsPath(formID), fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Zamanlanmış gönderim ayarları kaydedildi.")
+	return c.Redirect(exportsPath(formID), fiber.StatusFound)
+}
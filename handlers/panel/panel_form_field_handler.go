@@ -0,0 +1,259 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// formFieldType panelin alan tipi listesindeki bir seçenektir.
+type formFieldType struct {
+	Value models.FormFieldType
+	Label string
+}
+
+// formFieldTypes form oluşturucuda gösterilen alan tipleri (gösterim sırasıyla).
+var formFieldTypes = []formFieldType{
+	{models.FormFieldText, "Kısa Metin"},
+	{models.FormFieldTextarea, "Uzun Metin"},
+	{models.FormFieldEmail, "E-posta"},
+	{models.FormFieldPhone, "Telefon"},
+	{models.FormFieldNumber, "Sayı"},
+	{models.FormFieldDate, "Tarih"},
+	{models.FormFieldSelect, "Açılır Liste"},
+	{models.FormFieldRadio, "Tek Seçim"},
+	{models.FormFieldCheckbox, "Onay Kutusu"},
+	{models.FormFieldRating, "Puan"},
+	{models.FormFieldFile, "Dosya"},
+	{models.FormFieldHidden, "Gizli Alan"},
+	{models.FormFieldSection, "Bölüm Başlığı"},
+	{models.FormFieldPage, "Sayfa Sonu"},
+}
+
+// formFieldTypeLabels alan listesinde tip adını göstermek için.
+var formFieldTypeLabels = func() map[models.FormFieldType]string {
+	labels := make(map[models.FormFieldType]string, len(formFieldTypes))
+	for _, t := range formFieldTypes {
+		labels[t.Value] = t.Label
+	}
+	return labels
+}()
+
+// PanelFormFieldHandler form alanlarını yöneten form oluşturucu için handler.
+type PanelFormFieldHandler struct {
+	service services.IFormFieldService
+}
+
+// NewPanelFormFieldHandler yeni bir PanelFormFieldHandler örneği oluşturur.
+func NewPanelFormFieldHandler() *PanelFormFieldHandler {
+	return &PanelFormFieldHandler{
+		service: services.NewFormFieldService(),
+	}
+}
+
+// optionalInt boş değilse form değerini tam sayıya çevirir.
+func optionalInt(value string) *int {
+	if value = strings.TrimSpace(value); value == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// optionalFloat boş değilse form değerini sayıya çevirir (bkz. services.ParseFormNumber).
+func optionalFloat(value string) *float64 {
+	f, ok := services.ParseFormNumber(value)
+	if !ok {
+		return nil
+	}
+	return &f
+}
+
+// parseFormField form oluşturucudan gelen alan tanımını okur.
+func parseFormField(c *fiber.Ctx) models.FormFieldDefinition {
+	required := c.FormValue("required", "false")
+	prefillSigned := c.FormValue("prefill_signed", "false")
+	points := 0
+	if value := optionalInt(c.FormValue("points")); value != nil {
+		points = *value
+	}
+	return models.FormFieldDefinition{
+		Type:        models.FormFieldType(c.FormValue("type")),
+		Label:       c.FormValue("label"),
+		HelpText:    c.FormValue("help_text"),
+		Placeholder: c.FormValue("placeholder"),
+		Required:    required == "true" || required == "on",
+		Options:     c.FormValue("options"),
+		MinLength:   optionalInt(c.FormValue("min_length")),
+		MaxLength:   optionalInt(c.FormValue("max_length")),
+		MinValue:    optionalFloat(c.FormValue("min_value")),
+		MaxValue:    optionalFloat(c.FormValue("max_value")),
+		Pattern:     c.FormValue("pattern"),
+		Logic:       c.FormValue("logic"), // Koşul düzenleyicisinin ürettiği JSON
+
+		AcceptTypes:   c.FormValue("accept_types"),
+		MaxFileSizeMB: optionalInt(c.FormValue("max_file_size_mb")),
+		MaxFiles:      optionalInt(c.FormValue("max_files")),
+
+		CorrectAnswer: c.FormValue("correct_answer"),
+		Points:        points,
+
+		PrefillParam:  c.FormValue("prefill_param"),
+		PrefillSigned: prefillSigned == "true" || prefillSigned == "on",
+	}
+}
+
+// fieldsPath form oluşturucu sayfasının adresi.
+func fieldsPath(formID uint) string {
+	return fmt.Sprintf("/panel/forms/fields/%d", formID)
+}
+
+// ListFields formun alanlarını, düzenleme ve yeni alan formlarıyla gösterir.
+func (h *PanelFormFieldHandler) ListFields(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+	form, fields, err := h.service.GetFields(c.UserContext(), uint(id), userID)
+	if err != nil {
+		if !errors.Is(err, services.ErrFormNotFound) && !errors.Is(err, services.ErrFormForbidden) {
+			configslog.Log.Error("Panel - ListFormFields Error", zap.Int("formID", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya bu formu düzenleme yetkiniz yok.")
+		return c.Redirect("/panel/forms")
+	}
+
+	// View: panel/forms/fields.html
+	return renderer.Render(c, "panel/forms/fields", "layouts/panel", fiber.Map{
+		"Title":      "Form Alanları: " + form.Detail.Title,
+		"Form":       form,
+		"Fields":     fields,
+		"FieldTypes": formFieldTypes,
+		"TypeLabels": formFieldTypeLabels,
+	}, http.StatusOK)
+}
+
+// CreateField forma yeni bir alan ekler.
+func (h *PanelFormFieldHandler) CreateField(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+	formID := uint(id)
+
+	if err := h.service.CreateField(c.UserContext(), formID, userID, parseFormField(c)); err != nil {
+		if !errors.Is(err, services.ErrFormFieldInvalid) && !errors.Is(err, services.ErrFormForbidden) {
+			configslog.Log.Error("Panel - CreateFormField Error", zap.Uint("formID", formID), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Alan eklenemedi: "+err.Error())
+		return c.Redirect(fieldsPath(formID), fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Alan eklendi.")
+	return c.Redirect(fieldsPath(formID), fiber.StatusFound)
+}
+
+// UpdateField alanın tanımını ve doğrulama kurallarını günceller.
+func (h *PanelFormFieldHandler) UpdateField(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+
+	field, err := h.service.UpdateField(c.UserContext(), uint(id), userID, parseFormField(c))
+	if field == nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Alan bulunamadı veya düzenleme yetkiniz yok.")
+		return c.Redirect("/panel/forms", fiber.StatusSeeOther)
+	}
+	if err != nil {
+		if !errors.Is(err, services.ErrFormFieldInvalid) {
+			configslog.Log.Error("Panel - UpdateFormField Error", zap.Int("fieldID", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Alan güncellenemedi: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Alan güncellendi.")
+	}
+	return c.Redirect(fieldsPath(field.FormID), fiber.StatusSeeOther)
+}
+
+// MoveField alanı bir sıra yukarı veya aşağı taşır (direction=up|down).
+func (h *PanelFormFieldHandler) MoveField(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+
+	field, err := h.service.MoveField(c.UserContext(), uint(id), userID, services.FormFieldMove(c.FormValue("direction")))
+	if field == nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Alan bulunamadı veya düzenleme yetkiniz yok.")
+		return c.Redirect("/panel/forms", fiber.StatusSeeOther)
+	}
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Alan taşınamadı: "+err.Error())
+	}
+	return c.Redirect(fieldsPath(field.FormID), fiber.StatusSeeOther)
+}
+
+// DeleteField bir alanı formdan siler.
+func (h *PanelFormFieldHandler) DeleteField(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+
+	field, err := h.service.DeleteField(c.UserContext(), uint(id), userID)
+	if field == nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Alan bulunamadı veya silme yetkiniz yok.")
+		return c.Redirect("/panel/forms", fiber.StatusSeeOther)
+	}
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Silme hatası: "+err.Error())
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Alan silindi.")
+	}
+	return c.Redirect(fieldsPath(field.FormID), fiber.StatusSeeOther)
+}
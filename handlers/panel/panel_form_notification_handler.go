@@ -0,0 +1,124 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PanelFormNotificationHandler formun gönderim bildirimi ve otomatik yanıt ayarları için handler.
+type PanelFormNotificationHandler struct {
+	service services.IFormNotificationService
+}
+
+// NewPanelFormNotificationHandler yeni bir PanelFormNotificationHandler örneği oluşturur.
+func NewPanelFormNotificationHandler() *PanelFormNotificationHandler {
+	return &PanelFormNotificationHandler{
+		service: services.NewFormNotificationService(),
+	}
+}
+
+// notificationsPath bildirim ayarları sayfasının adresi.
+func notificationsPath(formID uint) string {
+	return fmt.Sprintf("/panel/forms/notifications/%d", formID)
+}
+
+// ShowSettings bildirim adreslerini ve e-posta şablonlarını düzenleme formunu gösterir.
+func (h *PanelFormNotificationHandler) ShowSettings(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+	form, fields, err := h.service.GetSettings(c.UserContext(), uint(id), userID)
+	if err != nil {
+		if !errors.Is(err, services.ErrFormNotFound) && !errors.Is(err, services.ErrFormForbidden) {
+			configslog.Log.Error("Panel - ShowFormNotifications Error", zap.Int("formID", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya bu formu düzenleme yetkiniz yok.")
+		return c.Redirect("/panel/forms")
+	}
+
+	var emailFields []models.FormFieldDefinition
+	for _, field := range fields {
+		if field.Type == models.FormFieldEmail {
+			emailFields = append(emailFields, field)
+		}
+	}
+	var autoresponderFieldID uint
+	if form.Detail.AutoresponderFieldID != nil {
+		autoresponderFieldID = *form.Detail.AutoresponderFieldID
+	}
+
+	// View: panel/forms/notifications.html
+	return renderer.Render(c, "panel/forms/notifications", "layouts/panel", fiber.Map{
+		"Title":                "Bildirimler: " + form.Detail.Title,
+		"Form":                 form,
+		"Detail":               form.Detail,
+		"EmailFields":          emailFields,
+		"AutoresponderFieldID": autoresponderFieldID,
+		"Placeholders":         services.FormMailPlaceholders(fields),
+	}, http.StatusOK)
+}
+
+// UpdateSettings bildirim ayarlarını kaydeder.
+func (h *PanelFormNotificationHandler) UpdateSettings(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+	formID := uint(id)
+
+	autoresponder := c.FormValue("autoresponder_enabled", "false")
+	var autoresponderFieldID *uint
+	if fieldID := optionalInt(c.FormValue("autoresponder_field_id")); fieldID != nil && *fieldID > 0 {
+		value := uint(*fieldID)
+		autoresponderFieldID = &value
+	}
+	settings := services.FormNotificationSettings{
+		NotifyOnSubmitEmail:  c.FormValue("notify_on_submit_email"),
+		NotifySubject:        c.FormValue("notify_subject"),
+		NotifyBody:           c.FormValue("notify_body"),
+		AutoresponderEnabled: autoresponder == "true" || autoresponder == "on",
+		AutoresponderFieldID: autoresponderFieldID,
+		AutoresponderSubject: c.FormValue("autoresponder_subject"),
+		AutoresponderBody:    c.FormValue("autoresponder_body"),
+	}
+
+	if err := h.service.UpdateSettings(c.UserContext(), formID, userID, settings); err != nil {
+		switch {
+		case errors.Is(err, services.ErrFormNotFound), errors.Is(err, services.ErrFormForbidden):
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya bu formu düzenleme yetkiniz yok.")
+			return c.Redirect("/panel/forms", fiber.StatusSeeOther)
+		case errors.Is(err, services.ErrFormNotificationInvalid):
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		default:
+			configslog.Log.Error("Panel - UpdateFormNotifications Error", zap.Uint("formID", formID), zap.Uint("userID", userID), zap.Error(err))
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Bildirim ayarları kaydedilemedi.")
+		}
+		return c.Redirect(notificationsPath(formID), fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Bildirim ayarları kaydedildi.")
+	return c.Redirect(notificationsPath(formID), fiber.StatusFound)
+}
@@ -0,0 +1,109 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PanelFormPrefillHandler formun ön doldurma bağlantılarının oluşturulması için handler.
+type PanelFormPrefillHandler struct {
+	service services.IFormPrefillService
+}
+
+// NewPanelFormPrefillHandler yeni bir PanelFormPrefillHandler örneği oluşturur.
+func NewPanelFormPrefillHandler() *PanelFormPrefillHandler {
+	return &PanelFormPrefillHandler{
+		service: services.NewFormPrefillService(),
+	}
+}
+
+// renderPrefill ön doldurma sayfasını verilen değerler ve oluşturulan bağlantıyla gösterir.
+func (h *PanelFormPrefillHandler) renderPrefill(c *fiber.Ctx, form *models.Form, fields []models.FormFieldDefinition, values map[uint][]string, validDays string, link *services.FormPrefillLink, message string, status int) error {
+	// View: panel/forms/prefill.html
+	return renderer.Render(c, "panel/forms/prefill", "layouts/panel", fiber.Map{
+		"Title":     "Ön Doldurma: " + form.Detail.Title,
+		"Form":      form,
+		"Fields":    fields,
+		"Values":    values,
+		"ValidDays": validDays,
+		"Link":      link,
+		"Error":     message,
+	}, status)
+}
+
+// loadPrefillFields formu ve ön doldurmaya açık alanlarını getirir; hata durumunda kullanıcıyı
+// yönlendirir (ok false).
+func (h *PanelFormPrefillHandler) loadPrefillFields(c *fiber.Ctx, userID uint) (*models.Form, []models.FormFieldDefinition, bool, error) {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return nil, nil, false, c.Redirect("/panel/forms")
+	}
+	form, fields, err := h.service.GetPrefillFields(c.UserContext(), uint(id), userID)
+	if err != nil {
+		if !errors.Is(err, services.ErrFormNotFound) && !errors.Is(err, services.ErrFormForbidden) {
+			configslog.Log.Error("Panel - GetPrefillFields Error", zap.Int("formID", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya bu formu görüntüleme yetkiniz yok.")
+		return nil, nil, false, c.Redirect("/panel/forms")
+	}
+	return form, fields, true, nil
+}
+
+// ShowPrefill ön doldurmaya açık alanlar için bağlantı oluşturma formunu gösterir.
+func (h *PanelFormPrefillHandler) ShowPrefill(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	form, fields, ok, err := h.loadPrefillFields(c, userID)
+	if !ok {
+		return err
+	}
+	return h.renderPrefill(c, form, fields, map[uint][]string{}, "", nil, "", http.StatusOK)
+}
+
+// BuildPrefill girilen değerlerle ön doldurma bağlantısını oluşturur; imzalı alanlara değer
+// verildiyse bağlantı imzalanır.
+func (h *PanelFormPrefillHandler) BuildPrefill(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	form, fields, ok, err := h.loadPrefillFields(c, userID)
+	if !ok {
+		return err
+	}
+
+	values := make(map[uint][]string, len(fields))
+	for _, field := range fields {
+		for _, value := range c.Request().PostArgs().PeekMulti(fmt.Sprintf("field_%d", field.ID)) {
+			values[field.ID] = append(values[field.ID], string(value))
+		}
+	}
+	validDays := c.FormValue("valid_days")
+	days := 0
+	if value := optionalInt(validDays); value != nil {
+		days = *value
+	}
+
+	_, link, err := h.service.BuildPrefillLink(c.UserContext(), form.ID, userID, values, days)
+	if err != nil {
+		if errors.Is(err, services.ErrFormPrefillInvalid) || errors.Is(err, services.ErrFormPrefillNoField) {
+			return h.renderPrefill(c, form, fields, values, validDays, nil, err.Error(), http.StatusUnprocessableEntity)
+		}
+		configslog.Log.Error("Panel - BuildPrefillLink Error", zap.Uint("formID", form.ID), zap.Uint("userID", userID), zap.Error(err))
+		return h.renderPrefill(c, form, fields, values, validDays, nil, "Bağlantı oluşturulurken bir sorun oluştu.", http.StatusInternalServerError)
+	}
+	return h.renderPrefill(c, form, fields, values, validDays, link, "", http.StatusOK)
+}
@@ -0,0 +1,118 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"davet.link/configs/configslog"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PanelFormQuizHandler formun sınav modu ayarları ve puan sıralaması için handler.
+type PanelFormQuizHandler struct {
+	service services.IFormQuizService
+}
+
+// NewPanelFormQuizHandler yeni bir PanelFormQuizHandler örneği oluşturur.
+func NewPanelFormQuizHandler() *PanelFormQuizHandler {
+	return &PanelFormQuizHandler{
+		service: services.NewFormQuizService(),
+	}
+}
+
+// quizPath sınav sayfasının adresi.
+func quizPath(formID uint) string {
+	return fmt.Sprintf("/panel/forms/quiz/%d", formID)
+}
+
+// ShowQuiz sınav ayarlarını, toplu sonuçları, soru bazında doğru oranlarını ve sıralamayı gösterir.
+func (h *PanelFormQuizHandler) ShowQuiz(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+	form, fields, err := h.service.GetQuiz(c.UserContext(), uint(id), userID)
+	if err != nil {
+		if !errors.Is(err, services.ErrFormNotFound) && !errors.Is(err, services.ErrFormForbidden) {
+			configslog.Log.Error("Panel - ShowFormQuiz Error", zap.Int("formID", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya bu formu görüntüleme yetkiniz yok.")
+		return c.Redirect("/panel/forms")
+	}
+	board, err := h.service.GetLeaderboard(c.UserContext(), form.ID, fields)
+	if err != nil {
+		configslog.Log.Error("Panel - ShowFormQuiz leaderboard Error", zap.Uint("formID", form.ID), zap.Error(err))
+		board = &services.QuizLeaderboard{}
+	}
+	scored := 0
+	maxScore := 0
+	for _, field := range fields {
+		if field.IsScored() {
+			scored++
+			maxScore += field.Points
+		}
+	}
+
+	// View: panel/forms/quiz.html
+	return renderer.Render(c, "panel/forms/quiz", "layouts/panel", fiber.Map{
+		"Title":       "Sınav: " + form.Detail.Title,
+		"Form":        form,
+		"Detail":      form.Detail,
+		"Board":       board,
+		"ScoredCount": scored,
+		"MaxScore":    maxScore,
+	}, http.StatusOK)
+}
+
+// UpdateSettings sınav modu ayarlarını kaydeder.
+func (h *PanelFormQuizHandler) UpdateSettings(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+	formID := uint(id)
+
+	isQuiz := c.FormValue("is_quiz", "false")
+	showAnswers := c.FormValue("show_answers", "false")
+	settings := services.FormQuizSettings{
+		IsQuiz:           isQuiz == "true" || isQuiz == "on",
+		PassPercent:      optionalInt(c.FormValue("pass_percent")),
+		TimeLimitMinutes: optionalInt(c.FormValue("time_limit_minutes")),
+		ShowAnswers:      showAnswers == "true" || showAnswers == "on",
+	}
+
+	if err := h.service.UpdateSettings(c.UserContext(), formID, userID, settings); err != nil {
+		switch {
+		case errors.Is(err, services.ErrFormNotFound), errors.Is(err, services.ErrFormForbidden):
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya bu formu düzenleme yetkiniz yok.")
+			return c.Redirect("/panel/forms", fiber.StatusSeeOther)
+		case errors.Is(err, services.ErrQuizInvalid):
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		default:
+			configslog.Log.Error("Panel - UpdateFormQuiz Error", zap.Uint("formID", formID), zap.Uint("userID", userID), zap.Error(err))
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Sınav ayarları kaydedilemedi.")
+		}
+		return c.Redirect(quizPath(formID), fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Sınav ayarları kaydedildi.")
+	return c.Redirect(quizPath(formID), fiber.StatusFound)
+}
@@ -0,0 +1,109 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"davet.link/configs/configslog"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PanelFormSpamHandler formun spam koruması ayarları ve engellenen denemeler için handler.
+type PanelFormSpamHandler struct {
+	service services.IFormSpamService
+}
+
+// NewPanelFormSpamHandler yeni bir PanelFormSpamHandler örneği oluşturur.
+func NewPanelFormSpamHandler() *PanelFormSpamHandler {
+	return &PanelFormSpamHandler{
+		service: services.NewFormSpamService(),
+	}
+}
+
+// spamPath spam koruması sayfasının adresi.
+func spamPath(formID uint) string {
+	return fmt.Sprintf("/panel/forms/spam/%d", formID)
+}
+
+// formInt boş veya hatalı sayısal değeri 0 kabul eder (0 ilgili kontrolü kapatır).
+func formInt(value string) int {
+	if n := optionalInt(value); n != nil {
+		return *n
+	}
+	return 0
+}
+
+// ShowSpam spam koruması ayarlarını ve engellenen denemelerin sebebe göre sayılarını gösterir.
+func (h *PanelFormSpamHandler) ShowSpam(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+	form, report, err := h.service.GetReport(c.UserContext(), uint(id), userID)
+	if err != nil {
+		if !errors.Is(err, services.ErrFormNotFound) && !errors.Is(err, services.ErrFormForbidden) {
+			configslog.Log.Error("Panel - ShowFormSpam Error", zap.Int("formID", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya bu formu görüntüleme yetkiniz yok.")
+		return c.Redirect("/panel/forms")
+	}
+
+	// View: panel/forms/spam.html
+	return renderer.Render(c, "panel/forms/spam", "layouts/panel", fiber.Map{
+		"Title":  "Spam Koruması: " + form.Detail.Title,
+		"Form":   form,
+		"Detail": form.Detail,
+		"Report": report,
+	}, http.StatusOK)
+}
+
+// UpdateSettings spam koruması ayarlarını kaydeder.
+func (h *PanelFormSpamHandler) UpdateSettings(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+	formID := uint(id)
+
+	settings := services.FormSpamSettings{
+		MinFillSeconds:   formInt(c.FormValue("min_fill_seconds")),
+		RateLimitPerIP:   formInt(c.FormValue("rate_limit_per_ip")),
+		RateLimitPerForm: formInt(c.FormValue("rate_limit_per_form")),
+		CaptchaType:      c.FormValue("captcha_type"),
+	}
+
+	if err := h.service.UpdateSettings(c.UserContext(), formID, userID, settings); err != nil {
+		switch {
+		case errors.Is(err, services.ErrFormNotFound), errors.Is(err, services.ErrFormForbidden):
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya bu formu düzenleme yetkiniz yok.")
+			return c.Redirect("/panel/forms", fiber.StatusSeeOther)
+		case errors.Is(err, services.ErrSpamSettingsInvalid):
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		default:
+			configslog.Log.Error("Panel - UpdateFormSpam Error", zap.Uint("formID", formID), zap.Uint("userID", userID), zap.Error(err))
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Spam koruması ayarları kaydedilemedi.")
+		}
+		return c.Redirect(spamPath(formID), fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Spam koruması ayarları kaydedildi.")
+	return c.Redirect(spamPath(formID), fiber.StatusFound)
+}
@@ -0,0 +1,140 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/queryparams"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// submissionListColumns gönderim listesinde sütun olarak gösterilen en fazla alan sayısı.
+const submissionListColumns = 4
+
+// PanelFormSubmissionHandler form gönderimlerinin listelenmesi için handler.
+type PanelFormSubmissionHandler struct {
+	service             services.IFormSubmissionService
+	notificationService services.IFormNotificationService
+}
+
+// NewPanelFormSubmissionHandler yeni bir PanelFormSubmissionHandler örneği oluşturur.
+func NewPanelFormSubmissionHandler() *PanelFormSubmissionHandler {
+	return &PanelFormSubmissionHandler{
+		service:             services.NewFormSubmissionService(),
+		notificationService: services.NewFormNotificationService(),
+	}
+}
+
+// ListSubmissions formun gönderimlerini sayfalı ve aranabilir olarak listeler (?name= cevaplarda arar).
+func (h *PanelFormSubmissionHandler) ListSubmissions(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+
+	params := queryparams.DefaultListParams()
+	params.SortBy = "submitted_at"
+	if err := c.QueryParser(&params); err != nil {
+		params = queryparams.DefaultListParams()
+	}
+
+	form, fields, result, err := h.service.GetSubmissions(c.UserContext(), uint(id), userID, params)
+	if err != nil {
+		if form == nil {
+			if !errors.Is(err, services.ErrFormNotFound) && !errors.Is(err, services.ErrFormForbidden) {
+				configslog.Log.Error("Panel - ListSubmissions Error", zap.Int("formID", id), zap.Uint("userID", userID), zap.Error(err))
+			}
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya gönderimlerini görüntüleme yetkiniz yok.")
+			return c.Redirect("/panel/forms")
+		}
+		configslog.Log.Error("Panel - ListSubmissions Error", zap.Int("formID", id), zap.Uint("userID", userID), zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Gönderimler listelenirken hata oluştu.")
+		return c.Redirect(fieldsPath(form.ID))
+	}
+
+	// İlk birkaç cevap alanı tabloda sütun olarak gösterilir; tüm cevaplar detay sayfasındadır.
+	var columns []models.FormFieldDefinition
+	for _, f := range fields {
+		if f.IsInput() && len(columns) < submissionListColumns {
+			columns = append(columns, f)
+		}
+	}
+
+	// View: panel/forms/submissions.html
+	return renderer.Render(c, "panel/forms/submissions", "layouts/panel", fiber.Map{
+		"Title":   "Gönderimler: " + form.Detail.Title,
+		"Form":    form,
+		"Columns": columns,
+		"Result":  result,
+		"Params":  params,
+	}, http.StatusOK)
+}
+
+// ShowSubmission tek bir gönderimin tüm cevaplarını gösterir.
+func (h *PanelFormSubmissionHandler) ShowSubmission(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+
+	form, submission, err := h.service.GetSubmission(c.UserContext(), uint(id), userID)
+	if err != nil {
+		if !errors.Is(err, services.ErrSubmissionNotFound) && !errors.Is(err, services.ErrSubmissionForbidden) {
+			configslog.Log.Error("Panel - ShowSubmission Error", zap.Int("id", id), zap.Uint("userID", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Gönderim bulunamadı veya görüntüleme yetkiniz yok.")
+		return c.Redirect("/panel/forms")
+	}
+
+	// Cevaplar formun güncel alanlarıyla eşlenir; kaldırılan alanlar ayrıca gösterilir.
+	rows, err := h.service.GetSubmissionAnswers(c.UserContext(), submission)
+	if err != nil {
+		configslog.Log.Error("Panel - ShowSubmission GetSubmissionAnswers Error", zap.Uint("id", submission.ID), zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Gönderim cevapları yüklenirken hata oluştu.")
+		return c.Redirect(fmt.Sprintf("/panel/forms/submissions/%d", form.ID))
+	}
+
+	// Yüklenen dosyalar için sayfa her açıldığında yeni, süreli indirme bağlantıları üretilir.
+	fileURLs := make(map[uint]string)
+	for _, answer := range submission.Answers {
+		for _, file := range answer.Files {
+			fileURLs[file.ID] = h.service.FileURL(file.ID)
+		}
+	}
+
+	// Bildirim ve otomatik yanıt e-postalarının kuyruktaki durumu
+	mails, err := h.notificationService.GetSubmissionMails(c.UserContext(), submission.ID)
+	if err != nil {
+		configslog.Log.Error("Panel - ShowSubmission GetSubmissionMails Error", zap.Uint("id", submission.ID), zap.Error(err))
+	}
+
+	// View: panel/forms/submission.html
+	return renderer.Render(c, "panel/forms/submission", "layouts/panel", fiber.Map{
+		"Title":      fmt.Sprintf("Gönderim #%d", submission.ID),
+		"Form":       form,
+		"Submission": submission,
+		"Rows":       rows,
+		"FileURLs":   fileURLs,
+		"Mails":      mails,
+	}, http.StatusOK)
+}
@@ -0,0 +1,59 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"net/http"
+
+	"davet.link/configs/configslog"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PanelFormVersionHandler formun yayınlanmış sürümlerinin listelenmesi için handler.
+type PanelFormVersionHandler struct {
+	service services.IFormVersionService
+}
+
+// NewPanelFormVersionHandler yeni bir PanelFormVersionHandler örneği oluşturur.
+func NewPanelFormVersionHandler() *PanelFormVersionHandler {
+	return &PanelFormVersionHandler{
+		service: services.NewFormVersionService(),
+	}
+}
+
+// ListVersions formun sürümlerini, değişiklikleri ve her sürümdeki gönderim sayısıyla gösterir.
+func (h *PanelFormVersionHandler) ListVersions(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/forms")
+	}
+	form, versions, err := h.service.GetVersions(c.UserContext(), uint(id), userID)
+	if err != nil {
+		if form == nil {
+			if !errors.Is(err, services.ErrFormNotFound) && !errors.Is(err, services.ErrFormForbidden) {
+				configslog.Log.Error("Panel - ListFormVersions Error", zap.Int("formID", id), zap.Uint("userID", userID), zap.Error(err))
+			}
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Form bulunamadı veya bu formu görüntüleme yetkiniz yok.")
+			return c.Redirect("/panel/forms")
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(fieldsPath(form.ID))
+	}
+
+	// View: panel/forms/versions.html
+	return renderer.Render(c, "panel/forms/versions", "layouts/panel", fiber.Map{
+		"Title":    "Sürümler: " + form.Detail.Title,
+		"Form":     form,
+		"Versions": versions,
+	}, http.StatusOK)
+}
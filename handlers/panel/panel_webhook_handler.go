@@ -0,0 +1,202 @@
+package handlers // handlers/panel paketi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// PanelWebhookHandler giden webhook uç noktaları ve teslimat kayıtları için handler.
+type PanelWebhookHandler struct {
+	service services.IWebhookService
+}
+
+// NewPanelWebhookHandler yeni bir PanelWebhookHandler örneği oluşturur.
+func NewPanelWebhookHandler() *PanelWebhookHandler {
+	return &PanelWebhookHandler{
+		service: services.NewWebhookService(),
+	}
+}
+
+// webhookInput formdan uç nokta bilgilerini okur.
+func webhookInput(c *fiber.Ctx) services.WebhookEndpointInput {
+	input := services.WebhookEndpointInput{
+		Name:     c.FormValue("name"),
+		URL:      c.FormValue("url"),
+		IsActive: c.FormValue("is_active") == "true",
+	}
+	for _, event := range c.Request().PostArgs().PeekMulti("events") {
+		input.Events = append(input.Events, string(event))
+	}
+	return input
+}
+
+// webhookFlashError servis hatasını kullanıcıya gösterilecek mesaja çevirir; beklenmeyen hataları loglar.
+func webhookFlashError(c *fiber.Ctx, action string, userID uint, err error) {
+	var serviceErr services.WebhookServiceError
+	if !errors.As(err, &serviceErr) {
+		configslog.Log.Error("Panel - Webhook "+action+" Error", zap.Uint("userID", userID), zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İşlem sırasında bir sorun oluştu.")
+		return
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+}
+
+// ListWebhooks kullanıcının webhook'larını ve yeni webhook formunu gösterir.
+func (h *PanelWebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	endpoints, err := h.service.GetEndpoints(c.UserContext(), userID)
+	if err != nil {
+		configslog.Log.Error("Panel - ListWebhooks Error", zap.Uint("userID", userID), zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Webhook'lar getirilemedi.")
+	}
+
+	// View: panel/webhooks/index.html
+	return renderer.Render(c, "panel/webhooks/index", "layouts/panel", fiber.Map{
+		"Title":     "Webhook'lar",
+		"Endpoints": endpoints,
+		"Events":    models.WebhookEvents,
+	}, http.StatusOK)
+}
+
+// CreateWebhook yeni bir webhook oluşturur ve teslimat sayfasına yönlendirir.
+func (h *PanelWebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+
+	endpoint, err := h.service.CreateEndpoint(c.UserContext(), userID, webhookInput(c))
+	if err != nil {
+		webhookFlashError(c, "Create", userID, err)
+		return c.Redirect("/panel/webhooks", fiber.StatusSeeOther)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Webhook oluşturuldu. İstekleri doğrulamak için imza anahtarını alıcınıza girin.")
+	return c.Redirect(fmt.Sprintf("/panel/webhooks/deliveries/%d", endpoint.ID), fiber.StatusSeeOther)
+}
+
+// UpdateWebhook webhook'un adını, adresini, olaylarını ve etkinliğini günceller.
+func (h *PanelWebhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/webhooks", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.UpdateEndpoint(c.UserContext(), userID, uint(id), webhookInput(c)); err != nil {
+		webhookFlashError(c, "Update", userID, err)
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Webhook güncellendi.")
+	}
+	return c.Redirect(fmt.Sprintf("/panel/webhooks/deliveries/%d", id), fiber.StatusSeeOther)
+}
+
+// RotateWebhookSecret webhook'a yeni bir imza anahtarı üretir.
+func (h *PanelWebhookHandler) RotateWebhookSecret(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/webhooks", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.RotateSecret(c.UserContext(), userID, uint(id)); err != nil {
+		webhookFlashError(c, "RotateSecret", userID, err)
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Yeni imza anahtarı oluşturuldu; eski anahtarla imzalı istek gönderilmeyecek.")
+	}
+	return c.Redirect(fmt.Sprintf("/panel/webhooks/deliveries/%d", id), fiber.StatusSeeOther)
+}
+
+// DeleteWebhook webhook'u siler.
+func (h *PanelWebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/webhooks", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.DeleteEndpoint(c.UserContext(), userID, uint(id)); err != nil {
+		webhookFlashError(c, "Delete", userID, err)
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Webhook silindi.")
+	}
+	return c.Redirect("/panel/webhooks", fiber.StatusSeeOther)
+}
+
+// ListDeliveries webhook'un ayarlarını ve son teslimat kayıtlarını gösterir.
+func (h *PanelWebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/webhooks", fiber.StatusSeeOther)
+	}
+
+	endpoint, err := h.service.GetEndpoint(c.UserContext(), userID, uint(id))
+	if err != nil {
+		webhookFlashError(c, "GetEndpoint", userID, err)
+		return c.Redirect("/panel/webhooks", fiber.StatusSeeOther)
+	}
+	deliveries, err := h.service.GetDeliveries(c.UserContext(), userID, endpoint.ID)
+	if err != nil {
+		configslog.Log.Error("Panel - ListDeliveries Error", zap.Uint("endpointID", endpoint.ID), zap.Uint("userID", userID), zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Teslimat kayıtları getirilemedi.")
+	}
+
+	// View: panel/webhooks/deliveries.html
+	return renderer.Render(c, "panel/webhooks/deliveries", "layouts/panel", fiber.Map{
+		"Title":      "Webhook: " + endpoint.Name,
+		"Endpoint":   endpoint,
+		"Deliveries": deliveries,
+		"Events":     models.WebhookEvents,
+	}, http.StatusOK)
+}
+
+// ResendDelivery teslimatın olayını aynı gövdeyle yeniden kuyruğa ekler.
+func (h *PanelWebhookHandler) ResendDelivery(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ID.")
+		return c.Redirect("/panel/webhooks", fiber.StatusSeeOther)
+	}
+
+	delivery, err := h.service.Resend(c.UserContext(), userID, uint(id))
+	if err != nil {
+		webhookFlashError(c, "Resend", userID, err)
+		return c.Redirect("/panel/webhooks", fiber.StatusSeeOther)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Teslimat yeniden gönderilmek üzere kuyruğa eklendi.")
+	return c.Redirect(fmt.Sprintf("/panel/webhooks/deliveries/%d", delivery.EndpointID), fiber.StatusSeeOther)
+}
@@ -0,0 +1,122 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"davet.link/configs/configsenv"
+	"davet.link/configs/configslog"
+	"davet.link/services"
+
+	"go.uber.org/zap"
+)
+
+// RunPeriodic fn'i ctx iptal edilene kadar her interval süresinde bir çalıştırır.
+// İlk çalıştırma hemen yapılır; panikler loglanır ve döngü devam eder.
+func RunPeriodic(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context)) {
+	run := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				configslog.Log.Error("Arka plan işi panik ile sonlandı", zap.String("job", name), zap.Any("panic", r))
+			}
+		}()
+		fn(ctx)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	configslog.Log.Info("Arka plan işi başlatıldı", zap.String("job", name), zap.Duration("interval", interval))
+	run()
+	for {
+		select {
+		case <-ctx.Done():
+			configslog.Log.Info("Arka plan işi durduruldu", zap.String("job", name))
+			return
+		case <-ticker.C:
+			run()
+		}
+	}
+}
+
+// envMinutes ortam değişkenini dakika olarak okur; tanımsız veya geçersizse varsayılanı döndürür.
+func envMinutes(key string, def int) time.Duration {
+	if v := configsenv.GetEnvAsInt(key, def); v > 0 {
+		return time.Duration(v) * time.Minute
+	}
+	return time.Duration(def) * time.Minute
+}
+
+// Start uygulamanın arka plan işlerini başlatır. İşler ctx iptal edildiğinde durur.
+func Start(ctx context.Context) {
+	externalCalendarService := services.NewExternalCalendarService()
+	go RunPeriodic(ctx, "external-calendar-refresh", envMinutes("EXTERNAL_CALENDAR_REFRESH_MINUTES", 60), func(ctx context.Context) {
+		synced, failed := externalCalendarService.RefreshAll(ctx)
+		if synced > 0 || failed > 0 {
+			configslog.SLog.Infof("Dış takvimler yenilendi: %d başarılı, %d hatalı", synced, failed)
+		}
+	})
+
+	waitlistService := services.NewWaitlistService()
+	go RunPeriodic(ctx, "waitlist-offer-expiry", envMinutes("WAITLIST_EXPIRY_CHECK_MINUTES", 1), func(ctx context.Context) {
+		expired, err := waitlistService.ProcessExpiredOffers(ctx)
+		if err != nil {
+			configslog.Log.Error("Bekleme listesi teklifleri işlenemedi", zap.Error(err))
+			return
+		}
+		if expired > 0 {
+			configslog.SLog.Infof("Süresi dolan %d bekleme listesi teklifi sıradakilere aktarıldı", expired)
+		}
+	})
+	go RunPeriodic(ctx, "waitlist-freed-slots", envMinutes("WAITLIST_FREED_SLOT_CHECK_MINUTES", 1), func(ctx context.Context) {
+		offered, err := waitlistService.ProcessFreedSlots(ctx)
+		if err != nil {
+			configslog.Log.Error("Boşalan slotlar işlenemedi", zap.Error(err))
+		}
+		if offered > 0 {
+			configslog.SLog.Infof("Boşalan slotlar için %d bekleme listesi teklifi yapıldı", offered)
+		}
+	})
+
+	mailOutboxService := services.NewMailOutboxService()
+	go RunPeriodic(ctx, "mail-outbox", envMinutes("MAIL_OUTBOX_CHECK_MINUTES", 1), func(ctx context.Context) {
+		sent, failed, err := mailOutboxService.ProcessDue(ctx)
+		if err != nil {
+			configslog.Log.Error("E-posta kuyruğu işlenemedi", zap.Error(err))
+		}
+		if sent > 0 || failed > 0 {
+			configslog.SLog.Infof("E-posta kuyruğu işlendi: %d gönderildi, %d başarısız", sent, failed)
+		}
+	})
+
+	webhookService := services.NewWebhookService()
+	go RunPeriodic(ctx, "webhook-delivery", envMinutes("WEBHOOK_CHECK_MINUTES", 1), func(ctx context.Context) {
+		delivered, failed, err := webhookService.ProcessDue(ctx)
+		if err != nil {
+			configslog.Log.Error("Webhook kuyruğu işlenemedi", zap.Error(err))
+		}
+		if delivered > 0 || failed > 0 {
+			configslog.SLog.Infof("Webhook kuyruğu işlendi: %d teslim edildi, %d başarısız", delivered, failed)
+		}
+	})
+
+	formDraftService := services.NewFormDraftService()
+	go RunPeriodic(ctx, "form-draft-purge", envMinutes("FORM_DRAFT_PURGE_CHECK_MINUTES", 60), func(ctx context.Context) {
+		purged, err := formDraftService.PurgeStaleDrafts(ctx)
+		if err != nil {
+			configslog.Log.Error("Eski form taslakları temizlenemedi", zap.Error(err))
+		}
+		if purged > 0 {
+			configslog.SLog.Infof("Saklama süresi dolan %d form taslağı silindi", purged)
+		}
+	})
+	formExportService := services.NewFormExportService()
+	go RunPeriodic(ctx, "form-export-schedule", envMinutes("FORM_EXPORT_CHECK_MINUTES", 5), func(ctx context.Context) {
+		sent, failed, err := formExportService.RunDueSchedules(ctx)
+		if err != nil {
+			configslog.Log.Error("Zamanlanmış dışa aktarımlar işlenemedi", zap.Error(err))
+		}
+		if sent > 0 || failed > 0 {
+			configslog.SLog.Infof("Zamanlanmış dışa aktarımlar işlendi: %d kuyruğa alındı, %d başarısız", sent, failed)
+		}
+	})
+}
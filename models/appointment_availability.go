@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// AppointmentAvailabilityRule bir randevu hizmetinin haftalık çalışma saatidir.
+// Aynı gün için birden fazla aralık tanımlanabilir (örn. öğle arası).
+type AppointmentAvailabilityRule struct {
+	BaseModel
+	AppointmentID uint `gorm:"index;not null"`
+	Weekday       int  `gorm:"type:smallint;not null"` // time.Weekday: 0=Pazar ... 6=Cumartesi
+	StartMinute   int  `gorm:"type:integer;not null"`  // Gün başından itibaren dakika (örn. 540 = 09:00)
+	EndMinute     int  `gorm:"type:integer;not null"`  // Gün başından itibaren dakika (örn. 1020 = 17:00)
+}
+
+// AppointmentAvailabilityOverride belirli bir tarih için haftalık kuralların yerine geçen kayıttır.
+// AppointmentID boşsa sağlayıcının tüm randevu hizmetleri için geçerlidir.
+// IsClosed true ise gün tamamen kapalıdır; değilse aynı tarihteki kayıtların
+// aralıkları o günün çalışma saatleri olarak kullanılır.
+type AppointmentAvailabilityOverride struct {
+	BaseModel
+	ProviderUserID uint      `gorm:"index:idx_avail_override_provider_date;not null"`
+	AppointmentID  *uint     `gorm:"index"`
+	Date           time.Time `gorm:"type:date;index:idx_avail_override_provider_date;not null"`
+	IsClosed       bool      `gorm:"type:boolean;default:false"`
+	StartMinute    int       `gorm:"type:integer;default:0"`
+	EndMinute      int       `gorm:"type:integer;default:0"`
+	Note           string    `gorm:"type:varchar(255)"` // Örn: "Yıllık izin"
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+)
+
+// BookingStatus olası rezervasyon durumlarını tanımlar.
+type BookingStatus string
+
+const (
+	BookingStatusPending   BookingStatus = "pending"   // Onay bekliyor (RequiresApproval)
+	BookingStatusConfirmed BookingStatus = "confirmed" // Onaylandı
+	BookingStatusCancelled BookingStatus = "cancelled" // İptal edildi
+	BookingStatusCompleted BookingStatus = "completed" // Gerçekleşti (bitişten sonra sağlayıcı işaretler)
+	BookingStatusNoShow    BookingStatus = "no_show"   // Müşteri gelmedi (bitişten sonra sağlayıcı işaretler)
+)
+
+// PaymentStatus rezervasyonun ödeme durumunu tanımlar.
+type PaymentStatus string
+
+const (
+	PaymentStatusUnpaid        PaymentStatus = "unpaid"         // Hiç ödeme alınmadı
+	PaymentStatusPartiallyPaid PaymentStatus = "partially_paid" // Kapora veya kısmi ödeme alındı
+	PaymentStatusPaid          PaymentStatus = "paid"           // Tutarın tamamı ödendi
+	PaymentStatusRefunded      PaymentStatus = "refunded"       // Alınan ödemelerin tamamı iade edildi
+)
+
+// ActiveBookingStatuses takvimde yer kaplayan (meşgul sayılan) durumlardır.
+var ActiveBookingStatuses = []BookingStatus{BookingStatusPending, BookingStatusConfirmed}
+
+// AppointmentBooking bir randevu hizmeti için alınmış tek bir rezervasyonu temsil eder.
+type AppointmentBooking struct {
+	BaseModel
+	AppointmentID  uint `gorm:"not null;index"`
+	ProviderUserID uint `gorm:"not null;index:idx_booking_provider_busy"` // Appointment.ProviderUserID kopyası (çakışma sorguları için)
+
+	StartsAt time.Time `gorm:"type:timestamptz;not null;index"`
+	EndsAt   time.Time `gorm:"type:timestamptz;not null"`
+	// Tampon süreler dahil meşgul aralık; çakışma kontrolü bu alanlarla yapılır.
+	BusyStartsAt time.Time `gorm:"type:timestamptz;not null;index:idx_booking_provider_busy"`
+	BusyEndsAt   time.Time `gorm:"type:timestamptz;not null;index:idx_booking_provider_busy"`
+
+	Status        BookingStatus `gorm:"type:varchar(20);not null;default:'pending';index"`
+	CustomerName  string        `gorm:"type:varchar(150);not null"`
+	CustomerEmail string        `gorm:"type:varchar(150);index"`
+	CustomerPhone string        `gorm:"type:varchar(30)"`
+	Notes         string        `gorm:"type:text"`
+	CancelledAt   *time.Time    `gorm:"type:timestamptz"`
+	Sequence      int           `gorm:"type:integer;not null;default:0"` // ICS SEQUENCE; her değişiklikte artar
+
+	// Tekrarlayan seriye ait rezervasyonlarda seri ve serideki sıra (1'den başlar)
+	SeriesID    *uint `gorm:"index"`
+	SeriesIndex int   `gorm:"type:integer;not null;default:0"`
+
+	// Ödeme bilgileri (kuruş). Fiyat ve kapora rezervasyon anında hizmetten kopyalanır;
+	// PaidMinor ödemeler ile iadeler arasındaki nettir ve BookingPayment kayıtlarından hesaplanır.
+	Currency       string        `gorm:"type:varchar(3);not null;default:'TRY'"`
+	AmountDueMinor int64         `gorm:"type:bigint;not null;default:0"`
+	DepositMinor   int64         `gorm:"type:bigint;not null;default:0"`
+	PaidMinor      int64         `gorm:"type:bigint;not null;default:0"`
+	PaymentStatus  PaymentStatus `gorm:"type:varchar(20);not null;default:'unpaid';index"`
+
+	Appointment Appointment                `gorm:"foreignKey:AppointmentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Answers     []AppointmentBookingAnswer `gorm:"foreignKey:BookingID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"` // Rezervasyon sorularının cevapları
+	Payments    []BookingPayment           `gorm:"foreignKey:BookingID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"` // Ödeme ve iade hareketleri
+}
@@ -14,7 +14,7 @@ type AppointmentDetail struct {
 	Name               string     `gorm:"type:varchar(200);not null"`
 	Description        string     `gorm:"type:text"`
 	DurationMinutes    int        `gorm:"type:integer;not null"`
-	Price              float64    `gorm:"type:numeric(12,2);default:0.00"`
+	PriceMinor         int64      `gorm:"type:bigint;not null;default:0"` // Kişi başı ücret, kuruş cinsinden (15000 = 150,00)
 	Currency           string     `gorm:"type:varchar(3);default:'TRY'"`
 	RequiresApproval   bool       `gorm:"type:boolean;default:false"`
 	BufferTimeBefore   int        `gorm:"type:integer;default:0"`
@@ -22,7 +22,22 @@ type AppointmentDetail struct {
 	BookingLeadTime    int        `gorm:"type:integer;default:60"`
 	BookingHorizonDays int        `gorm:"type:integer;default:30"`
 	ColorCode          string     `gorm:"type:varchar(7)"`
+	Timezone           string     `gorm:"type:varchar(50);default:'Europe/Istanbul'"` // Slotların hesaplandığı zaman dilimi
 	CancellationPolicy string     `gorm:"type:text"`
 	PasswordHash       string     `gorm:"type:varchar(255)"`
 	ExpiresAt          *time.Time `gorm:"index;type:timestamptz"`
+
+	// true ise hizmet paralel çalışabilen bir kaynaktır (örn. ayrı oda/ekipman);
+	// sağlayıcının diğer hizmetlerindeki rezervasyonlarla çakışma kontrolüne girmez.
+	AllowParallelBookings bool `gorm:"type:boolean;default:false"`
+	// Bir slota alınabilecek en fazla katılımcı (grup dersleri, atölyeler). 1 ise birebir randevu.
+	Capacity int `gorm:"type:integer;not null;default:1"`
+	// Rezervasyon onayı için alınması beklenen kapora (kuruş). 0 ise kapora istenmez.
+	DepositMinor int64 `gorm:"type:bigint;not null;default:0"`
+	// true ise müşteriler public sayfadan haftalık tekrarlayan rezervasyon oluşturabilir.
+	// Sağlayıcı panelden her zaman seri oluşturabilir.
+	AllowCustomerRecurring bool `gorm:"type:boolean;default:false"`
+	// Bekleme listesindeki müşteriye boşalan slot teklif edildiğinde kabul için tanınan süre (dakika).
+	// Slot bu süre boyunca müşteri adına tutulur.
+	WaitlistClaimMinutes int `gorm:"type:integer;not null;default:120"`
 }
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"strings"
+)
+
+// QuestionType rezervasyon sorusunun cevap biçimini belirtir.
+type QuestionType string
+
+const (
+	QuestionTypeText     QuestionType = "text"     // Serbest metin
+	QuestionTypeChoice   QuestionType = "choice"   // Seçeneklerden biri
+	QuestionTypeCheckbox QuestionType = "checkbox" // Evet/Hayır onay kutusu
+)
+
+// AppointmentQuestion randevu hizmetinin rezervasyon sırasında sorduğu özel sorudur.
+type AppointmentQuestion struct {
+	BaseModel
+	AppointmentID uint         `gorm:"not null;index"`
+	Label         string       `gorm:"type:varchar(255);not null"`
+	Type          QuestionType `gorm:"type:varchar(20);not null;default:'text'"`
+	Options       string       `gorm:"type:text"` // choice için her satırda bir seçenek
+	Required      bool         `gorm:"type:boolean;default:false"`
+	SortOrder     int          `gorm:"type:integer;not null;default:0"`
+}
+
+// OptionList Options alanını boş satırları atlayarak seçenek listesine çevirir.
+func (q AppointmentQuestion) OptionList() []string {
+	var options []string
+	for _, line := range strings.Split(q.Options, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			options = append(options, line)
+		}
+	}
+	return options
+}
+
+// AppointmentBookingAnswer rezervasyonda bir soruya verilen cevaptır.
+// Soru sonradan değişse veya silinse de cevabın anlamı korunsun diye soru metni kopyalanır.
+type AppointmentBookingAnswer struct {
+	BaseModel
+	BookingID  uint   `gorm:"not null;index"`
+	QuestionID uint   `gorm:"not null;index"`
+	Question   string `gorm:"type:varchar(255);not null"`
+	Answer     string `gorm:"type:text"`
+}
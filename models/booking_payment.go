@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// PaymentKind ödeme hareketinin yönünü belirtir.
+type PaymentKind string
+
+const (
+	PaymentKindPayment PaymentKind = "payment" // Tahsilat
+	PaymentKindRefund  PaymentKind = "refund"  // İade
+)
+
+// PaymentMethod ödemenin nasıl alındığını belirtir.
+type PaymentMethod string
+
+const (
+	PaymentMethodCash         PaymentMethod = "cash"          // Nakit
+	PaymentMethodBankTransfer PaymentMethod = "bank_transfer" // Havale / EFT
+	PaymentMethodCard         PaymentMethod = "card"          // Fiziki POS
+	PaymentMethodOnline       PaymentMethod = "online"        // Çevrim içi ödeme sağlayıcısı
+)
+
+// BookingPayment bir rezervasyona ait tek bir ödeme veya iade hareketidir.
+// Tutar her zaman pozitiftir; yön Kind ile belirlenir.
+type BookingPayment struct {
+	BaseModel
+	BookingID   uint          `gorm:"not null;index"`
+	Kind        PaymentKind   `gorm:"type:varchar(10);not null;default:'payment'"`
+	Method      PaymentMethod `gorm:"type:varchar(20);not null"`
+	AmountMinor int64         `gorm:"type:bigint;not null"`
+	Currency    string        `gorm:"type:varchar(3);not null;default:'TRY'"`
+	PaidAt      time.Time     `gorm:"type:timestamptz;not null"`
+	Note        string        `gorm:"type:varchar(500)"`
+
+	// Çevrim içi ödemelerde sağlayıcı adı ve sağlayıcıdaki işlem kimliği
+	Provider    string `gorm:"type:varchar(30)"`
+	ProviderRef string `gorm:"type:varchar(100);index"`
+	// İade hareketlerinde iade edilen ödeme
+	RefundOfID *uint `gorm:"index"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// BookingSeriesStatus tekrarlayan rezervasyon serisinin durumunu tanımlar.
+type BookingSeriesStatus string
+
+const (
+	BookingSeriesStatusActive    BookingSeriesStatus = "active"    // En az bir tekrarı iptal edilmemiş
+	BookingSeriesStatusCancelled BookingSeriesStatus = "cancelled" // Tüm tekrarları iptal edildi
+)
+
+// BookingSeries haftalık veya iki haftalık tekrarlayan rezervasyonların ortak kaydıdır.
+// Her tekrar ayrı bir AppointmentBooking olarak tutulur ve SeriesID ile seriye bağlanır;
+// böylece slot motoru, ödeme ve katılım işlemleri tekil rezervasyonlarla aynı şekilde çalışır.
+type BookingSeries struct {
+	BaseModel
+	AppointmentID  uint `gorm:"not null;index"`
+	ProviderUserID uint `gorm:"not null;index"`
+
+	IntervalWeeks int        `gorm:"type:integer;not null;default:1"` // 1: her hafta, 2: iki haftada bir
+	Count         int        `gorm:"type:integer;not null"`           // İstenen tekrar sayısı (bitiş tarihinden hesaplanmış olabilir)
+	Until         *time.Time `gorm:"type:timestamptz"`                // Bitiş tarihiyle oluşturulduysa son gün
+	FirstStartsAt time.Time  `gorm:"type:timestamptz;not null"`
+
+	Status            BookingSeriesStatus `gorm:"type:varchar(20);not null;default:'active';index"`
+	CustomerName      string              `gorm:"type:varchar(150);not null"`
+	CustomerEmail     string              `gorm:"type:varchar(150)"`
+	CustomerPhone     string              `gorm:"type:varchar(30)"`
+	CreatedByCustomer bool                `gorm:"type:boolean;not null;default:false"` // Public sayfadan müşteri tarafından oluşturuldu
+
+	Appointment Appointment          `gorm:"foreignKey:AppointmentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Bookings    []AppointmentBooking `gorm:"foreignKey:SeriesID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+)
+
+// WaitlistStatus bekleme listesi kaydının durumunu tanımlar.
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting  WaitlistStatus = "waiting"  // Sırada, henüz teklif yapılmadı
+	WaitlistStatusOffered  WaitlistStatus = "offered"  // Boşalan slot teklif edildi, slot süre dolana kadar tutuluyor
+	WaitlistStatusClaimed  WaitlistStatus = "claimed"  // Teklif kabul edildi, rezervasyon oluşturuldu
+	WaitlistStatusDeclined WaitlistStatus = "declined" // Müşteri teklifi reddetti
+	WaitlistStatusExpired  WaitlistStatus = "expired"  // Teklif süresi doldu veya gün geçti
+	WaitlistStatusRemoved  WaitlistStatus = "removed"  // Sağlayıcı listeden çıkardı
+)
+
+// BookingWaitlistEntry dolu bir gün için bekleme listesine yazılan müşteriyi temsil eder.
+// Aynı gündeki rezervasyon iptal edildiğinde boşalan slot kayıt sırasına (ID) göre teklif edilir;
+// teklif süresince slot Offer* alanlarındaki aralıkla tutulur ve slot motorunda meşgul sayılır.
+type BookingWaitlistEntry struct {
+	BaseModel
+	AppointmentID  uint `gorm:"not null;index:idx_waitlist_day"`
+	ProviderUserID uint `gorm:"not null;index"`
+
+	Date   time.Time      `gorm:"type:date;not null;index:idx_waitlist_day"` // Hizmetin zaman dilimindeki gün
+	Status WaitlistStatus `gorm:"type:varchar(20);not null;default:'waiting';index"`
+
+	CustomerName  string `gorm:"type:varchar(150);not null"`
+	CustomerEmail string `gorm:"type:varchar(150);not null"` // Teklif bağlantısı e-postayla gönderilir
+	CustomerPhone string `gorm:"type:varchar(30)"`
+	Notes         string `gorm:"type:text"`
+
+	// Teklif bilgileri: tutulan slot (tampon süreler dahil meşgul aralık) ve gizli kabul anahtarı
+	OfferToken        *string    `gorm:"type:varchar(64);uniqueIndex"`
+	OfferStartsAt     *time.Time `gorm:"type:timestamptz"`
+	OfferEndsAt       *time.Time `gorm:"type:timestamptz"`
+	OfferBusyStartsAt *time.Time `gorm:"type:timestamptz"`
+	OfferBusyEndsAt   *time.Time `gorm:"type:timestamptz"`
+	OfferExpiresAt    *time.Time `gorm:"type:timestamptz;index"`
+	BookingID         *uint      `gorm:"index"` // Teklif kabul edildiğinde oluşturulan rezervasyon
+
+	Appointment Appointment `gorm:"foreignKey:AppointmentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// WaitlistFreedSlot iptal, taşıma veya serbest kalan teklifle boşalan ve henüz bekleme
+// listesine teklif edilmemiş slottur. Slotu boşaltan transaction'da yazılır; arka plan işi
+// teklife çevirdiği transaction'da siler. Böylece süreç çökse de teklif kaybolmaz.
+type WaitlistFreedSlot struct {
+	BaseModel
+	AppointmentID  uint      `gorm:"not null;index"`
+	ProviderUserID uint      `gorm:"not null;index"`
+	StartsAt       time.Time `gorm:"type:timestamptz;not null"`
+	Attempts       int       `gorm:"type:integer;not null;default:0"`
+	NextAttemptAt  time.Time `gorm:"type:timestamptz;not null;index"`
+	LastError      string    `gorm:"type:text"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// CustomerRecord bir sağlayıcının tek bir müşterisine ait katılım geçmişidir.
+// Müşteri normalize edilmiş e-posta veya telefonla (ContactKey) tanınır; rezervasyonda ikisi
+// de varsa her iki kaydın sayaçları birlikte güncellenir.
+type CustomerRecord struct {
+	BaseModel
+	ProviderUserID uint       `gorm:"not null;uniqueIndex:idx_customer_provider_contact"`
+	ContactKey     string     `gorm:"type:varchar(160);not null;uniqueIndex:idx_customer_provider_contact"` // "email:ali@ornek.com" veya "phone:905321234567"
+	CustomerName   string     `gorm:"type:varchar(150)"`                                                    // Son işaretlenen rezervasyondaki ad
+	CompletedCount int        `gorm:"type:integer;not null;default:0"`
+	NoShowCount    int        `gorm:"type:integer;not null;default:0"`
+	LastNoShowAt   *time.Time `gorm:"type:timestamptz"`
+	Blocked        bool       `gorm:"type:boolean;not null;default:false"` // Sağlayıcı tarafından elle engellendi
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// ExternalCalendarSource dış takvimin nereden geldiğini belirtir.
+type ExternalCalendarSource string
+
+const (
+	ExternalCalendarSourceFile ExternalCalendarSource = "file" // Yüklenen .ics dosyası
+	ExternalCalendarSourceURL  ExternalCalendarSource = "url"  // Periyodik olarak indirilen ICS adresi
+)
+
+// ExternalCalendar sağlayıcının içe aktardığı dış takvimdir (Google, Outlook vb.).
+// Etkinlikleri slot hesaplamasında meşgul zaman olarak kullanılır.
+type ExternalCalendar struct {
+	BaseModel
+	UserID        uint                   `gorm:"not null;index"`
+	Name          string                 `gorm:"type:varchar(150);not null"`
+	Source        ExternalCalendarSource `gorm:"type:varchar(10);not null"`
+	URL           string                 `gorm:"type:varchar(1000)"` // Source = url ise
+	Content       string                 `gorm:"type:text"`          // Son başarılı ICS içeriği (yeniden genişletme için)
+	LastSyncedAt  *time.Time             `gorm:"type:timestamptz"`
+	LastSyncError string                 `gorm:"type:text"`
+	BlockCount    int                    `gorm:"type:integer;default:0"`
+}
+
+// ExternalBusyBlock dış takvimden genişletilmiş tek bir meşgul aralıktır.
+type ExternalBusyBlock struct {
+	BaseModel
+	ExternalCalendarID uint      `gorm:"not null;index"`
+	UserID             uint      `gorm:"not null;index:idx_external_busy_user_range"`
+	StartsAt           time.Time `gorm:"type:timestamptz;not null;index:idx_external_busy_user_range"`
+	EndsAt             time.Time `gorm:"type:timestamptz;not null;index:idx_external_busy_user_range"`
+}
@@ -12,5 +12,6 @@ type Form struct {
 	Link Link `gorm:"foreignKey:LinkID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	// Creator User `gorm:"foreignKey:CreatorUserID"` // İsteğe bağlı
 	// Organization Organization `gorm:"foreignKey:OrganizationID"` // İsteğe bağlı
-	Detail FormDetail `gorm:"foreignKey:FormID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Detail FormDetail            `gorm:"foreignKey:FormID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Fields []FormFieldDefinition `gorm:"foreignKey:FormID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"` // Sıralı alan tanımları
 }
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// FormViewStat public form sayfasının günlük görüntülenme sayısıdır. Görüntülenmeden
+// gönderime dönüşüm oranı bu sayaçtan hesaplanır.
+type FormViewStat struct {
+	BaseModel
+	FormID uint      `gorm:"not null;uniqueIndex:idx_form_view_stat_day"`
+	Day    time.Time `gorm:"type:date;not null;uniqueIndex:idx_form_view_stat_day"` // Sunucu saat dilimindeki gün
+	Views  int64     `gorm:"not null;default:0"`
+}
+
+// FormPageStat çok sayfalı formda bir sayfaya o gün ilk kez ulaşan gönderen sayısıdır.
+// Sayfa, başındaki sayfa sonu alanıyla (BreakID) tanımlanır; böylece sayfalar yeniden
+// sıralansa da sayaç doğru sayfada kalır.
+type FormPageStat struct {
+	BaseModel
+	FormID  uint      `gorm:"not null;uniqueIndex:idx_form_page_stat_day"`
+	BreakID uint      `gorm:"not null;uniqueIndex:idx_form_page_stat_day"`
+	Day     time.Time `gorm:"type:date;not null;uniqueIndex:idx_form_page_stat_day"`
+	Reached int64     `gorm:"not null;default:0"`
+}
+
+// FormDailyCount günlük toplam (SQL toplama sonucu; tablo değildir).
+type FormDailyCount struct {
+	Day   time.Time
+	Count int64
+}
+
+// FormAnswerCount bir alanda bir değerin kaç gönderimde seçildiğidir (SQL toplama sonucu).
+// Value boşsa alanı cevaplayan gönderim sayısıdır.
+type FormAnswerCount struct {
+	FieldID uint
+	Value   string
+	Count   int64
+}
+
+// FormNumericStat sayı ve puan alanlarının özetidir (SQL toplama sonucu).
+type FormNumericStat struct {
+	FieldID uint
+	Count   int64
+	Average float64
+	Minimum float64
+	Maximum float64
+}
+
+// FormPageCount bir sayfaya ulaşan gönderen sayısıdır (SQL toplama sonucu).
+type FormPageCount struct {
+	BreakID uint
+	Count   int64
+}
@@ -20,4 +20,35 @@ type FormDetail struct {
 	NotifyOnSubmitEmail string     `gorm:"type:text"`
 	RequiresLogin       bool       `gorm:"type:boolean;default:false"`
 	PasswordHash        string     `gorm:"type:varchar(255)"`
+
+	// Gönderim e-postaları: konu ve gövde {{form}}, {{answers}}, {{field_12}} gibi yer tutucular
+	// içerebilir; boş bırakılırsa varsayılan şablon kullanılır.
+	NotifySubject        string `gorm:"type:varchar(255)"`
+	NotifyBody           string `gorm:"type:text"`
+	AutoresponderEnabled bool   `gorm:"type:boolean;default:false"`
+	AutoresponderFieldID *uint  // Gönderenin adresinin alındığı e-posta alanı (boşsa ilk e-posta alanı)
+	AutoresponderSubject string `gorm:"type:varchar(255)"`
+	AutoresponderBody    string `gorm:"type:text"`
+
+	// Sınav modu: gönderimde alanlardaki doğru cevaplara göre puan hesaplanır ve gönderene
+	// sonuç sayfası gösterilir. Süre sınırı sunucuda, imzalı başlangıç zamanıyla uygulanır.
+	IsQuiz               bool `gorm:"type:boolean;default:false"`
+	QuizPassPercent      *int `gorm:"type:integer"`               // Geçme eşiği (yüzde); nil: eşik yok
+	QuizTimeLimitMinutes *int `gorm:"type:integer"`               // nil: süre sınırı yok
+	QuizShowAnswers      bool `gorm:"type:boolean;default:false"` // Sonuç sayfasında doğru cevaplar gösterilir
+
+	// Spam koruması: gizli tuzak alanı her formda denetlenir. Diğer kontroller 0 ile kapatılır;
+	// gönderim sınırları saatliktir ve süreç belleğinde sayılır.
+	SpamMinFillSeconds int    `gorm:"type:integer;not null;default:3"`  // Sayfa açıldıktan sonra en erken gönderim (sn)
+	RateLimitPerIP     int    `gorm:"type:integer;not null;default:10"` // Aynı IP'den saatte en fazla gönderim
+	RateLimitPerForm   int    `gorm:"type:integer;not null;default:0"`  // Formun saatte toplam gönderim sınırı
+	CaptchaType        string `gorm:"type:varchar(10)"`                 // "", "math" veya "pow" (captcha paketi)
+}
+
+// QuizTimeLimit sınavın süre sınırını döndürür; sınır yoksa 0.
+func (d FormDetail) QuizTimeLimit() time.Duration {
+	if !d.IsQuiz || d.QuizTimeLimitMinutes == nil || *d.QuizTimeLimitMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(*d.QuizTimeLimitMinutes) * time.Minute
 }
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// FormDraft çok sayfalı bir formun yarım kalan cevaplarıdır. Gönderen, Token içeren devam
+// bağlantısıyla kaldığı sayfaya döner. Form gönderilince taslak silinir; tamamlanmayan
+// taslaklar LastSavedAt'ten itibaren saklama süresi dolunca temizlenir.
+type FormDraft struct {
+	BaseModel
+	FormID      uint      `gorm:"not null;index"`
+	Token       string    `gorm:"type:varchar(64);not null;uniqueIndex"` // Devam bağlantısındaki gizli anahtar
+	Page        int       `gorm:"type:integer;not null;default:0"`       // Kaldığı sayfa (0'dan başlar)
+	MaxPage     int       `gorm:"type:integer;not null;default:0"`       // Ulaştığı en ileri sayfa (analiz için)
+	Values      string    `gorm:"type:text"`                             // JSON: alan ID -> değerler
+	Files       string    `gorm:"type:text"`                             // JSON: []FormDraftFile
+	LastSavedAt time.Time `gorm:"type:timestamptz;not null;index"`
+
+	QuizStartedAt *time.Time `gorm:"type:timestamptz"` // Sınav modunda sürenin başladığı an
+}
+
+// FormDraftFile taslaktayken depoya yazılmış, gönderimde cevaba bağlanacak dosyadır.
+type FormDraftFile struct {
+	FieldID     uint   `json:"field"`
+	StorageKey  string `json:"key"`
+	FileName    string `json:"name"`
+	ContentType string `json:"type"`
+	Size        int64  `json:"size"`
+}
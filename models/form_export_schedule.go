@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// FormExportFrequency zamanlanmış dışa aktarımın sıklığı.
+type FormExportFrequency string
+
+const (
+	FormExportDaily  FormExportFrequency = "daily"
+	FormExportWeekly FormExportFrequency = "weekly"
+)
+
+// FormExportSchedule formun yanıtlarının düzenli aralıklarla e-posta eki olarak
+// gönderilmesi ayarıdır. Her form için en fazla bir zamanlama vardır. Her çalıştırmada
+// bir önceki gönderimden (CoveredUntil) çalıştırma zamanına kadarki yanıtlar gönderilir.
+type FormExportSchedule struct {
+	BaseModel
+	FormID       uint                `gorm:"not null;uniqueIndex"`
+	OwnerUserID  uint                `gorm:"not null;index"` // Zamanlamayı kuran kullanıcı (arka plan işinde aktör)
+	Frequency    FormExportFrequency `gorm:"type:varchar(10);not null;default:'daily'"`
+	Weekday      int                 `gorm:"type:integer;not null;default:1"` // Haftalıkta gün (0: Pazar ... 6: Cumartesi)
+	Hour         int                 `gorm:"type:integer;not null;default:8"` // Gönderim saati (sunucu saat dilimi)
+	Format       string              `gorm:"type:varchar(10);not null;default:'xlsx'"`
+	Recipients   string              `gorm:"type:text;not null"` // Virgülle ayrılmış adresler
+	IsEnabled    bool                `gorm:"default:true;index:idx_export_schedule_due"`
+	NextRunAt    time.Time           `gorm:"type:timestamptz;not null;index:idx_export_schedule_due"`
+	CoveredUntil *time.Time          `gorm:"type:timestamptz"` // Son gönderilen dönemin sonu
+	LastRunAt    *time.Time          `gorm:"type:timestamptz"`
+	LastError    string              `gorm:"type:text"`
+}
@@ -0,0 +1,228 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// FormFieldType form alanının cevap biçimini belirtir.
+type FormFieldType string
+
+const (
+	FormFieldText     FormFieldType = "text"     // Tek satır metin
+	FormFieldTextarea FormFieldType = "textarea" // Çok satırlı metin
+	FormFieldEmail    FormFieldType = "email"    // E-posta adresi
+	FormFieldPhone    FormFieldType = "phone"    // Telefon numarası
+	FormFieldNumber   FormFieldType = "number"   // Sayı
+	FormFieldDate     FormFieldType = "date"     // Tarih (YYYY-MM-DD)
+	FormFieldSelect   FormFieldType = "select"   // Açılır listeden bir seçenek
+	FormFieldRadio    FormFieldType = "radio"    // Seçeneklerden biri (radyo düğmeleri)
+	FormFieldCheckbox FormFieldType = "checkbox" // Seçenekler varsa çoklu seçim, yoksa tek onay kutusu
+	FormFieldRating   FormFieldType = "rating"   // 1..MaxValue arası puan (varsayılan 5)
+	FormFieldFile     FormFieldType = "file"     // Dosya yükleme
+	FormFieldHidden   FormFieldType = "hidden"   // Gizli alan; değeri bağlantıdaki parametreden veya yönlendiren adresten alınır
+	FormFieldSection  FormFieldType = "section"  // Bölüm başlığı; cevap alınmaz
+	FormFieldPage     FormFieldType = "page"     // Sayfa sonu; etiketi yeni sayfanın başlığıdır, cevap alınmaz
+)
+
+// DefaultRatingScale puan alanında MaxValue verilmediğinde kullanılan en yüksek puan.
+const DefaultRatingScale = 5
+
+// FormPrefillReferrer ön doldurma parametresi olarak verildiğinde alanın değeri sorgudan değil,
+// formu açan sayfanın adresinden (Referer başlığı) alınır.
+const FormPrefillReferrer = "referrer"
+
+// Dosya alanında sınır verilmediğinde kullanılan değerler.
+const (
+	DefaultMaxFileSizeMB = 10
+	DefaultMaxFiles      = 1
+)
+
+// FormFieldDefinition formun sıralı alan tanımıdır. Doğrulama kuralları tipe göre yorumlanır:
+// metin tiplerinde MinLength/MaxLength ve Pattern, sayı ve puan tiplerinde MinValue/MaxValue.
+type FormFieldDefinition struct {
+	BaseModel
+	FormID      uint          `gorm:"not null;index"`
+	Type        FormFieldType `gorm:"type:varchar(20);not null;default:'text'"`
+	Label       string        `gorm:"type:varchar(255);not null"`
+	HelpText    string        `gorm:"type:text"`
+	Placeholder string        `gorm:"type:varchar(255)"`
+	Required    bool          `gorm:"type:boolean;default:false"`
+	Options     string        `gorm:"type:text"` // select, radio ve checkbox için her satırda bir seçenek
+	SortOrder   int           `gorm:"type:integer;not null;default:0"`
+
+	// Doğrulama kuralları (nil: sınır yok)
+	MinLength *int     `gorm:"type:integer"`
+	MaxLength *int     `gorm:"type:integer"`
+	MinValue  *float64 `gorm:"type:numeric"`
+	MaxValue  *float64 `gorm:"type:numeric"`
+	Pattern   string   `gorm:"type:varchar(255)"` // Metin cevapları için düzenli ifade
+
+	// Dosya alanı sınırları (nil: varsayılan)
+	AcceptTypes   string `gorm:"type:varchar(255)"` // Virgülle ayrılmış MIME tipleri (örn. "application/pdf, image/*"); boş: tümü
+	MaxFileSizeMB *int   `gorm:"type:integer"`      // Dosya başına en büyük boyut
+	MaxFiles      *int   `gorm:"type:integer"`      // Yüklenebilecek en fazla dosya sayısı
+
+	// Koşullu gösterim kuralı (FormFieldLogic JSON'u). Boş: alan her zaman gösterilir.
+	Logic string `gorm:"type:text"`
+
+	// Sınav modu: her satırda kabul edilen bir doğru cevap (çoklu seçimde işaretlenmesi gereken
+	// seçeneklerin tamamı) ve sorunun puanı. Doğru cevabı olmayan alanlar puanlanmaz.
+	CorrectAnswer string `gorm:"type:text"`
+	Points        int    `gorm:"type:integer;not null;default:0"`
+
+	// Ön doldurma: alan, public bağlantıdaki bu adlı sorgu parametresiyle doldurulur
+	// (örn. /anahtar?email=...). İmzalı alanlar yalnızca imzası geçerli bağlantıdan doldurulur
+	// ve gönderende değiştirilemez; gizli alanların değeri her zaman bağlantıdan gelir.
+	PrefillParam  string `gorm:"type:varchar(50)"`
+	PrefillSigned bool   `gorm:"type:boolean;default:false"`
+}
+
+// FormLogicAction koşul sağlandığında alana uygulanan işlemdir.
+type FormLogicAction string
+
+const (
+	FormLogicShow FormLogicAction = "show" // Koşul sağlanırsa göster, aksi halde gizle
+	FormLogicHide FormLogicAction = "hide" // Koşul sağlanırsa gizle (atla)
+)
+
+// FormLogicMatch bir koşul grubundaki koşulların nasıl birleştirileceğini belirtir.
+type FormLogicMatch string
+
+const (
+	FormLogicMatchAll FormLogicMatch = "all" // ve
+	FormLogicMatchAny FormLogicMatch = "any" // veya
+)
+
+// FormConditionOperator başka bir alanın cevabıyla yapılan karşılaştırmadır.
+type FormConditionOperator string
+
+const (
+	FormConditionEquals      FormConditionOperator = "equals"       // Cevaplardan biri değere eşit (büyük/küçük harf duyarsız)
+	FormConditionContains    FormConditionOperator = "contains"     // Cevaplardan biri değeri içeriyor
+	FormConditionGreaterThan FormConditionOperator = "greater_than" // Sayı veya tarih cevabı değerden büyük
+)
+
+// FormCondition tek bir karşılaştırma ya da Conditions doluysa alt koşulların
+// Match ile birleştirildiği bir gruptur.
+type FormCondition struct {
+	FieldID  uint                  `json:"field,omitempty"`
+	Operator FormConditionOperator `json:"op,omitempty"`
+	Value    string                `json:"value,omitempty"`
+
+	Match      FormLogicMatch  `json:"match,omitempty"`
+	Conditions []FormCondition `json:"conditions,omitempty"`
+}
+
+// IsGroup koşulun alt koşullardan oluşan bir grup olup olmadığını bildirir.
+func (c FormCondition) IsGroup() bool {
+	return len(c.Conditions) > 0
+}
+
+// FormFieldLogic alanın (veya bölüm başlığında bölümün) koşullu gösterim kuralıdır.
+type FormFieldLogic struct {
+	Action FormLogicAction `json:"action"`
+	When   FormCondition   `json:"when"`
+}
+
+// AcceptList AcceptTypes alanını MIME tipi listesine çevirir.
+func (f FormFieldDefinition) AcceptList() []string {
+	var types []string
+	for _, t := range strings.Split(f.AcceptTypes, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// FileSizeLimit dosya alanında dosya başına izin verilen en büyük boyutu (bayt) döndürür.
+func (f FormFieldDefinition) FileSizeLimit() int64 {
+	mb := DefaultMaxFileSizeMB
+	if f.MaxFileSizeMB != nil && *f.MaxFileSizeMB > 0 {
+		mb = *f.MaxFileSizeMB
+	}
+	return int64(mb) << 20
+}
+
+// FileCountLimit dosya alanına yüklenebilecek en fazla dosya sayısını döndürür.
+func (f FormFieldDefinition) FileCountLimit() int {
+	if f.MaxFiles != nil && *f.MaxFiles > 0 {
+		return *f.MaxFiles
+	}
+	return DefaultMaxFiles
+}
+
+// ParsedLogic Logic sütununu çözümler; kural yoksa nil döner.
+func (f FormFieldDefinition) ParsedLogic() (*FormFieldLogic, error) {
+	if strings.TrimSpace(f.Logic) == "" {
+		return nil, nil
+	}
+	var logic FormFieldLogic
+	if err := json.Unmarshal([]byte(f.Logic), &logic); err != nil {
+		return nil, err
+	}
+	return &logic, nil
+}
+
+// OptionList Options alanını boş satırları atlayarak seçenek listesine çevirir.
+func (f FormFieldDefinition) OptionList() []string {
+	var options []string
+	for _, line := range strings.Split(f.Options, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			options = append(options, line)
+		}
+	}
+	return options
+}
+
+// HasOptions alanın seçeneklerden değer alıp almadığını bildirir.
+func (f FormFieldDefinition) HasOptions() bool {
+	switch f.Type {
+	case FormFieldSelect, FormFieldRadio:
+		return true
+	case FormFieldCheckbox:
+		return f.Options != ""
+	}
+	return false
+}
+
+// IsInput alanın cevap alıp almadığını bildirir (bölüm başlıkları ve sayfa sonları cevap almaz).
+func (f FormFieldDefinition) IsInput() bool {
+	return f.Type != FormFieldSection && f.Type != FormFieldPage
+}
+
+// IsHidden alanın gönderene gösterilmeyen, değeri bağlantıdan alınan bir alan olup olmadığını bildirir.
+func (f FormFieldDefinition) IsHidden() bool {
+	return f.Type == FormFieldHidden
+}
+
+// IsPrefillLocked alanın değerinin gönderen tarafından girilemediğini bildirir: gizli alanlar
+// ve imzalı ön doldurma alanları yalnızca bağlantıdan doldurulur.
+func (f FormFieldDefinition) IsPrefillLocked() bool {
+	return f.IsHidden() || (f.PrefillParam != "" && f.PrefillSigned)
+}
+
+// CorrectAnswerList CorrectAnswer alanını boş satırları atlayarak listeye çevirir.
+func (f FormFieldDefinition) CorrectAnswerList() []string {
+	var answers []string
+	for _, line := range strings.Split(f.CorrectAnswer, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			answers = append(answers, line)
+		}
+	}
+	return answers
+}
+
+// IsScored alanın sınav modunda puanlanıp puanlanmadığını bildirir.
+func (f FormFieldDefinition) IsScored() bool {
+	return f.Points > 0 && strings.TrimSpace(f.CorrectAnswer) != ""
+}
+
+// RatingScale puan alanının en yüksek değerini döndürür.
+func (f FormFieldDefinition) RatingScale() int {
+	if f.MaxValue != nil && *f.MaxValue >= 2 {
+		return int(*f.MaxValue)
+	}
+	return DefaultRatingScale
+}
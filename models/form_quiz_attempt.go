@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// FormQuizAttempt gönderenin sınavı ilk açtığı anı saklar. Süre bu kayıttan ölçülür; formu
+// yeniden açmak süreyi sıfırlamaz. Gönderim kaydedilince deneme silinir; gönderilmeyen
+// denemeler taslaklarla aynı saklama süresi dolunca temizlenir.
+type FormQuizAttempt struct {
+	BaseModel
+	FormID        uint      `gorm:"not null;uniqueIndex:idx_quiz_attempt_respondent"`
+	RespondentKey string    `gorm:"type:varchar(80);not null;uniqueIndex:idx_quiz_attempt_respondent"` // FormSubmission.RespondentKey ile aynı anahtar
+	StartedAt     time.Time `gorm:"type:timestamptz;not null;index"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// FormSpamReason engellenen gönderim denemesinin sebebidir.
+type FormSpamReason string
+
+const (
+	FormSpamHoneypot FormSpamReason = "honeypot"  // Gizli tuzak alanı doldurulmuş
+	FormSpamTimeTrap FormSpamReason = "time_trap" // Çok hızlı gönderim veya geçersiz sayfa jetonu
+	FormSpamCaptcha  FormSpamReason = "captcha"   // Doğrulama cevabı hatalı, eksik veya süresi dolmuş
+	FormSpamRateIP   FormSpamReason = "rate_ip"   // IP başına saatlik sınır aşıldı
+	FormSpamRateForm FormSpamReason = "rate_form" // Formun saatlik sınırı aşıldı
+)
+
+// FormSpamReasons panelde gösterim sırası.
+var FormSpamReasons = []FormSpamReason{FormSpamHoneypot, FormSpamTimeTrap, FormSpamCaptcha, FormSpamRateIP, FormSpamRateForm}
+
+// Label sebebin paneldeki adı.
+func (r FormSpamReason) Label() string {
+	switch r {
+	case FormSpamHoneypot:
+		return "Tuzak alanı"
+	case FormSpamTimeTrap:
+		return "Süre tuzağı"
+	case FormSpamCaptcha:
+		return "Doğrulama"
+	case FormSpamRateIP:
+		return "IP sınırı"
+	case FormSpamRateForm:
+		return "Form sınırı"
+	}
+	return string(r)
+}
+
+// FormSpamStat formda bir sebeple engellenen gönderim denemelerinin günlük sayısıdır.
+type FormSpamStat struct {
+	BaseModel
+	FormID  uint           `gorm:"not null;uniqueIndex:idx_form_spam_stat_day"`
+	Reason  FormSpamReason `gorm:"type:varchar(20);not null;uniqueIndex:idx_form_spam_stat_day"`
+	Day     time.Time      `gorm:"type:date;not null;uniqueIndex:idx_form_spam_stat_day"`
+	Blocked int64          `gorm:"not null;default:0"`
+}
+
+// FormSpamCount bir sebeple engellenen deneme sayısıdır (SQL toplama sonucu).
+type FormSpamCount struct {
+	Reason FormSpamReason
+	Count  int64
+}
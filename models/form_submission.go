@@ -0,0 +1,134 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormSubmission public form sayfasından yapılan tek bir gönderimdir.
+type FormSubmission struct {
+	BaseModel
+	FormID      uint      `gorm:"not null;index:idx_submission_form_time;index:idx_submission_respondent;index:idx_submission_fingerprint"`
+	SubmittedAt time.Time `gorm:"type:timestamptz;not null;index:idx_submission_form_time"`
+	IPAddress   string    `gorm:"type:varchar(45)"`
+	UserAgent   string    `gorm:"type:varchar(500)"`
+
+	// Kişi başı sınır için gönderen: giriş yapmış kullanıcı veya anonim çerez/parmak izi özeti.
+	// Anonim gönderimlerde IP ve tarayıcı parmak izi ayrıca tutulur; sınır ikisine birlikte uygulanır.
+	UserID                *uint  `gorm:"index"`
+	RespondentKey         string `gorm:"type:varchar(80);index:idx_submission_respondent"`
+	RespondentFingerprint string `gorm:"type:varchar(80);index:idx_submission_fingerprint"`
+
+	// Gönderimin doldurulduğu form sürümü (sürümlemeden önceki gönderimlerde nil)
+	FormVersionID *uint        `gorm:"index"`
+	FormVersion   *FormVersion `gorm:"foreignKey:FormVersionID"`
+
+	// Sınav sonucu (sınav modu kapalı formlarda nil)
+	Score           *int       `gorm:"type:integer"`
+	MaxScore        *int       `gorm:"type:integer"`
+	Passed          *bool      `gorm:"type:boolean"` // Geçme eşiği yoksa nil
+	StartedAt       *time.Time `gorm:"type:timestamptz"`
+	DurationSeconds *int       `gorm:"type:integer"` // Başlangıçtan gönderime geçen süre
+
+	Answers []FormSubmissionAnswer `gorm:"foreignKey:SubmissionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// AnswerFor gönderimde verilen alana ait cevabı döndürür (cevap yoksa nil).
+func (s FormSubmission) AnswerFor(fieldID uint) *FormSubmissionAnswer {
+	for i := range s.Answers {
+		if s.Answers[i].FieldID == fieldID {
+			return &s.Answers[i]
+		}
+	}
+	return nil
+}
+
+// VersionNumber gönderimin doldurulduğu sürümün numarasını döndürür; sürüm yüklenmediyse 0.
+func (s FormSubmission) VersionNumber() int {
+	if s.FormVersion == nil {
+		return 0
+	}
+	return s.FormVersion.Number
+}
+
+// ScorePercent sınav puanının tam puana oranını (yüzde) döndürür.
+func (s FormSubmission) ScorePercent() int {
+	if s.Score == nil || s.MaxScore == nil || *s.MaxScore <= 0 {
+		return 0
+	}
+	return *s.Score * 100 / *s.MaxScore
+}
+
+// IsPassed sınavın geçme eşiği varsa gönderimin eşiği geçip geçmediğini bildirir.
+func (s FormSubmission) IsPassed() bool {
+	return s.Passed != nil && *s.Passed
+}
+
+// DurationText sınav süresini "3 dk 20 sn" biçiminde döndürür; süre ölçülmediyse boş.
+func (s FormSubmission) DurationText() string {
+	if s.DurationSeconds == nil {
+		return ""
+	}
+	minutes, seconds := *s.DurationSeconds/60, *s.DurationSeconds%60
+	if minutes == 0 {
+		return fmt.Sprintf("%d sn", seconds)
+	}
+	return fmt.Sprintf("%d dk %d sn", minutes, seconds)
+}
+
+// FormSubmissionAnswer bir alana verilen cevaptır. Value her tip için okunabilir metni tutar
+// (çoklu seçimde her satırda bir seçenek); sayı, puan, tarih ve onay kutusu cevapları ayrıca
+// tipli sütunlarda saklanır. Alan sonradan değişse veya silinse de anlamı korunsun diye
+// alan etiketi ve tipi kopyalanır.
+type FormSubmissionAnswer struct {
+	BaseModel
+	SubmissionID uint          `gorm:"not null;index"`
+	FieldID      uint          `gorm:"not null;index"`
+	FieldLabel   string        `gorm:"type:varchar(255);not null"`
+	FieldType    FormFieldType `gorm:"type:varchar(20);not null"`
+	SortOrder    int           `gorm:"type:integer;not null;default:0"`
+
+	Value       string     `gorm:"type:text"`
+	NumberValue *float64   `gorm:"type:numeric"`
+	DateValue   *time.Time `gorm:"type:date"`
+	BoolValue   *bool      `gorm:"type:boolean"`
+
+	// Sınav modunda puanlanan sorularda
+	IsCorrect *bool `gorm:"type:boolean"`
+	Points    *int  `gorm:"type:integer"` // Kazanılan puan
+
+	Files []FormSubmissionFile `gorm:"foreignKey:AnswerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"` // Dosya alanı cevaplarında
+}
+
+// Correct cevabın sınavda doğru sayılıp sayılmadığını bildirir.
+func (a FormSubmissionAnswer) Correct() bool {
+	return a.IsCorrect != nil && *a.IsCorrect
+}
+
+// FormSubmissionFile dosya alanına yüklenen bir dosyanın kaydıdır. İçerik depoda
+// (pkg/storage) StorageKey altında durur; indirme imzalı ve süreli adresle yapılır.
+type FormSubmissionFile struct {
+	BaseModel
+	AnswerID    uint   `gorm:"not null;index"`
+	FormID      uint   `gorm:"not null;index"`
+	FieldID     uint   `gorm:"not null"`
+	StorageKey  string `gorm:"type:varchar(500);not null;uniqueIndex"`
+	FileName    string `gorm:"type:varchar(255);not null"` // Gönderenin verdiği ad (gösterim ve indirme için)
+	ContentType string `gorm:"type:varchar(100);not null"` // İçerikten tespit edilen tip
+	Size        int64  `gorm:"not null"`
+}
+
+// FormQuizStats sınav gönderimlerinin toplu sonucudur (SQL toplaması).
+type FormQuizStats struct {
+	Count           int64
+	PassedCount     int64
+	AveragePercent  float64
+	AverageDuration float64 // Saniye
+}
+
+// FormQuizQuestionStat bir sorunun kaç gönderimde cevaplandığı ve kaçında doğru olduğudur.
+type FormQuizQuestionStat struct {
+	FieldID  uint
+	Answered int64
+	Correct  int64
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrFormVersionImmutable yayınlanmış bir sürümün değiştirilmeye çalışıldığını bildirir.
+var ErrFormVersionImmutable = errors.New("form sürümü değiştirilemez")
+
+// FormVersion formun alan tanımlarının yayınlanmış, değiştirilemez bir kopyasıdır. Alanlar
+// her değiştiğinde yeni numaralı bir sürüm oluşturulur; gönderimler doldurdukları sürüme
+// bağlanır. Alan kimlikleri sürümler arasında değişmez, cevaplar bu kimliklerle eşlenir.
+type FormVersion struct {
+	BaseModel
+	FormID      uint      `gorm:"not null;uniqueIndex:idx_form_version_number"`
+	Number      int       `gorm:"type:integer;not null;uniqueIndex:idx_form_version_number"` // Formda 1'den başlayarak artar
+	Fields      string    `gorm:"type:text;not null"`                                        // []FormVersionField JSON'u
+	Checksum    string    `gorm:"type:varchar(64);not null"`                                 // Fields'in SHA-256 özeti
+	PublishedAt time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// BeforeUpdate yayınlanmış sürümlerin güncellenmesini engeller.
+func (v *FormVersion) BeforeUpdate(tx *gorm.DB) error {
+	return ErrFormVersionImmutable
+}
+
+// FieldList sürümdeki alan tanımlarını döndürür; bozuk kayıtta nil.
+func (v FormVersion) FieldList() []FormVersionField {
+	var fields []FormVersionField
+	if err := json.Unmarshal([]byte(v.Fields), &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// FormVersionField bir sürümde saklanan alan tanımıdır. Gösterim, doğrulama, koşul ve sınav
+// ayarlarının tamamı kopyalanır; kayıt zamanları sürümün parçası değildir.
+type FormVersionField struct {
+	ID          uint          `json:"id"`
+	Type        FormFieldType `json:"type"`
+	Label       string        `json:"label"`
+	HelpText    string        `json:"help_text,omitempty"`
+	Placeholder string        `json:"placeholder,omitempty"`
+	Required    bool          `json:"required,omitempty"`
+	Options     string        `json:"options,omitempty"`
+	SortOrder   int           `json:"sort_order"`
+
+	MinLength *int     `json:"min_length,omitempty"`
+	MaxLength *int     `json:"max_length,omitempty"`
+	MinValue  *float64 `json:"min_value,omitempty"`
+	MaxValue  *float64 `json:"max_value,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+
+	AcceptTypes   string `json:"accept_types,omitempty"`
+	MaxFileSizeMB *int   `json:"max_file_size_mb,omitempty"`
+	MaxFiles      *int   `json:"max_files,omitempty"`
+
+	Logic         string `json:"logic,omitempty"`
+	CorrectAnswer string `json:"correct_answer,omitempty"`
+	Points        int    `json:"points,omitempty"`
+
+	PrefillParam  string `json:"prefill_param,omitempty"`
+	PrefillSigned bool   `json:"prefill_signed,omitempty"`
+}
+
+// NewFormVersionField alan tanımının sürümde saklanacak kopyasını oluşturur.
+func NewFormVersionField(field FormFieldDefinition) FormVersionField {
+	return FormVersionField{
+		ID:            field.ID,
+		Type:          field.Type,
+		Label:         field.Label,
+		HelpText:      field.HelpText,
+		Placeholder:   field.Placeholder,
+		Required:      field.Required,
+		Options:       field.Options,
+		SortOrder:     field.SortOrder,
+		MinLength:     field.MinLength,
+		MaxLength:     field.MaxLength,
+		MinValue:      field.MinValue,
+		MaxValue:      field.MaxValue,
+		Pattern:       field.Pattern,
+		AcceptTypes:   field.AcceptTypes,
+		MaxFileSizeMB: field.MaxFileSizeMB,
+		MaxFiles:      field.MaxFiles,
+		Logic:         field.Logic,
+		CorrectAnswer: field.CorrectAnswer,
+		Points:        field.Points,
+		PrefillParam:  field.PrefillParam,
+		PrefillSigned: field.PrefillSigned,
+	}
+}
+
+// IsInput alanın cevap alıp almadığını bildirir (bölüm başlığı ve sayfa sonu almaz).
+func (f FormVersionField) IsInput() bool {
+	return f.Type != FormFieldSection && f.Type != FormFieldPage
+}
+
+// FormVersionCount bir sürüme bağlı gönderim sayısıdır (SQL toplaması).
+type FormVersionCount struct {
+	FormVersionID uint
+	Count         int64
+}
@@ -0,0 +1,12 @@
+package models
+
+// InvitationGuest bir davetiyenin davetlisidir. RSVPToken davetliye özel LCV bağlantısındaki
+// tahmin edilemez anahtardır; LCV yalnızca bu anahtarla verilir.
+type InvitationGuest struct {
+	BaseModel
+	InvitationID uint   `gorm:"not null;index"`
+	Name         string `gorm:"type:varchar(150);not null"`
+	Email        string `gorm:"type:varchar(150)"`
+	Phone        string `gorm:"type:varchar(30)"`
+	RSVPToken    string `gorm:"type:varchar(64);not null;uniqueIndex"`
+}
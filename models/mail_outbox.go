@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+)
+
+// MailOutboxStatus kuyruktaki e-postanın durumu.
+type MailOutboxStatus string
+
+const (
+	MailOutboxPending MailOutboxStatus = "pending" // Gönderilmeyi (veya yeniden denenmeyi) bekliyor
+	MailOutboxSent    MailOutboxStatus = "sent"
+	MailOutboxFailed  MailOutboxStatus = "failed" // Deneme hakkı bitti
+)
+
+// MailOutbox gönderilmek üzere kuyruğa alınmış e-postadır. İstek sırasında yalnızca kayıt
+// oluşturulur; gönderimi arka plan işi yapar, SMTP hatasında artan aralıklarla yeniden dener.
+type MailOutbox struct {
+	BaseModel
+	OwnerUserID   uint             `gorm:"not null;index"` // E-postanın adına gönderildiği kullanıcı (hook'lar için aktör)
+	Kind          string           `gorm:"type:varchar(50);not null;index"`
+	SourceID      *uint            `gorm:"index"`              // Örn. form gönderimi ID'si
+	Recipients    string           `gorm:"type:text;not null"` // Virgülle ayrılmış adresler
+	ReplyTo       string           `gorm:"type:varchar(255)"`
+	Subject       string           `gorm:"type:varchar(255);not null"`
+	TextBody      string           `gorm:"type:text;not null"`
+	Status        MailOutboxStatus `gorm:"type:varchar(20);not null;default:'pending';index:idx_mail_outbox_due"`
+	Attempts      int              `gorm:"type:integer;not null;default:0"`
+	NextAttemptAt time.Time        `gorm:"type:timestamptz;not null;index:idx_mail_outbox_due"`
+	LastError     string           `gorm:"type:text"`
+	SentAt        *time.Time       `gorm:"type:timestamptz"`
+
+	Attachments []MailOutboxAttachment `gorm:"foreignKey:MailID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// MailOutboxAttachment kuyruktaki e-postanın ekidir (örn. zamanlanmış dışa aktarım dosyası,
+// takvim daveti).
+type MailOutboxAttachment struct {
+	BaseModel
+	MailID      uint   `gorm:"not null;index"`
+	FileName    string `gorm:"type:varchar(255);not null"`
+	ContentType string `gorm:"type:varchar(255);not null"`
+	Data        []byte `gorm:"type:bytea;not null"`
+	Inline      bool   `gorm:"not null;default:false"` // Takvim davetleri gövde yanında ayrı parça olarak sunulur
+}
@@ -0,0 +1,10 @@
+package models
+
+// ProviderSetting randevu sağlayıcısının tüm hizmetlerini etkileyen ayarlarıdır.
+type ProviderSetting struct {
+	BaseModel
+	UserID                uint    `gorm:"uniqueIndex;not null"`
+	ObservePublicHolidays bool    `gorm:"type:boolean;default:false"`      // Resmi tatillerde randevu alınmasın
+	CalendarFeedToken     *string `gorm:"type:varchar(64);uniqueIndex"`    // Gizli ICS abonelik adresi; nil ise besleme kapalı
+	NoShowBlockLimit      int     `gorm:"type:integer;not null;default:0"` // Bu kadar gelmeme sonrası çevrim içi rezervasyon engellenir; 0 ise kapalı
+}
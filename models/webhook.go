@@ -0,0 +1,103 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// WebhookEvent uç noktaların abone olabildiği olay türüdür.
+type WebhookEvent string
+
+const (
+	WebhookEventFormSubmitted    WebhookEvent = "form.submitted"    // Form gönderimi alındı
+	WebhookEventRSVPUpdated      WebhookEvent = "rsvp.updated"      // Davetiye LCV yanıtı verildi veya değişti
+	WebhookEventBookingCreated   WebhookEvent = "booking.created"   // Rezervasyon oluşturuldu
+	WebhookEventBookingCancelled WebhookEvent = "booking.cancelled" // Rezervasyon iptal edildi
+)
+
+// WebhookEvents desteklenen olay türleridir (panelde gösterim sırasıyla).
+var WebhookEvents = []WebhookEvent{
+	WebhookEventFormSubmitted,
+	WebhookEventRSVPUpdated,
+	WebhookEventBookingCreated,
+	WebhookEventBookingCancelled,
+}
+
+// Label olay türünün paneldeki adını döndürür.
+func (e WebhookEvent) Label() string {
+	switch e {
+	case WebhookEventFormSubmitted:
+		return "Form gönderildi"
+	case WebhookEventRSVPUpdated:
+		return "LCV yanıtı güncellendi"
+	case WebhookEventBookingCreated:
+		return "Rezervasyon oluşturuldu"
+	case WebhookEventBookingCancelled:
+		return "Rezervasyon iptal edildi"
+	}
+	return string(e)
+}
+
+// WebhookEndpoint kullanıcının olayları aldığı adrestir. İstekler Secret ile HMAC-SHA256
+// olarak imzalanır (bkz. pkg/webhook).
+type WebhookEndpoint struct {
+	BaseModel
+	UserID   uint   `gorm:"not null;index"`
+	Name     string `gorm:"type:varchar(100);not null"`
+	URL      string `gorm:"type:varchar(2048);not null"`
+	Secret   string `gorm:"type:varchar(64);not null"`
+	Events   string `gorm:"type:text;not null"` // Virgülle ayrılmış WebhookEvent listesi
+	IsActive bool   `gorm:"type:boolean;not null;default:true"`
+}
+
+// EventList uç noktanın abone olduğu olayları döndürür.
+func (e WebhookEndpoint) EventList() []WebhookEvent {
+	var events []WebhookEvent
+	for _, event := range strings.Split(e.Events, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, WebhookEvent(event))
+		}
+	}
+	return events
+}
+
+// Subscribes uç noktanın olaya abone olup olmadığını bildirir.
+func (e WebhookEndpoint) Subscribes(event WebhookEvent) bool {
+	for _, subscribed := range e.EventList() {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus teslimatın durumu.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending" // Gönderilmeyi (veya yeniden denenmeyi) bekliyor
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed" // Deneme hakkı bitti
+)
+
+// WebhookDelivery bir olayın bir uç noktaya teslimatıdır; kuyruk ve teslimat kaydı olarak
+// kullanılır. Olay kaydıyla aynı transaction'da oluşturulur, gönderimi arka plan işi yapar ve
+// hatada artan aralıklarla yeniden dener. Yeniden gönderim aynı olay için yeni bir kayıt açar.
+type WebhookDelivery struct {
+	BaseModel
+	EndpointID     uint                  `gorm:"not null;index"`
+	Event          WebhookEvent          `gorm:"type:varchar(50);not null"`
+	EventID        string                `gorm:"type:varchar(40);not null;index"` // Olayın kimliği; yeniden gönderimde aynı kalır
+	Payload        string                `gorm:"type:text;not null"`              // Gönderilen JSON gövdesi
+	Status         WebhookDeliveryStatus `gorm:"type:varchar(20);not null;default:'pending';index:idx_webhook_delivery_due"`
+	Attempts       int                   `gorm:"type:integer;not null;default:0"`
+	NextAttemptAt  time.Time             `gorm:"type:timestamptz;not null;index:idx_webhook_delivery_due"`
+	LastStatusCode int                   `gorm:"type:integer;not null;default:0"` // Alıcının son HTTP durum kodu; ağ hatasında 0
+	LastError      string                `gorm:"type:text"`
+	LastResponse   string                `gorm:"type:text"` // Alıcının son yanıt gövdesi (kısaltılmış)
+	DurationMs     int                   `gorm:"type:integer;not null;default:0"`
+	DeliveredAt    *time.Time            `gorm:"type:timestamptz"`
+	ResentFromID   *uint                 `gorm:"index"` // Elle yeniden gönderimde kaynak teslimat
+
+	Endpoint WebhookEndpoint `gorm:"foreignKey:EndpointID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,134 @@
+// Package captcha dış servise ihtiyaç duymayan, sunucuda doğrulanan insan doğrulamalarıdır.
+// Meydan okumalar durumsuzdur: gerekli bilgi HMAC ile imzalanmış jetonda taşınır, sunucu
+// yalnızca kullanılmış jetonları süreleri dolana kadar bellekte tutar.
+package captcha
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("captcha: geçersiz doğrulama jetonu")
+	ErrExpired      = errors.New("captcha: doğrulamanın süresi doldu")
+	ErrWrongAnswer  = errors.New("captcha: doğrulama cevabı hatalı")
+	ErrReplayed     = errors.New("captcha: doğrulama jetonu daha önce kullanıldı")
+)
+
+// Doğrulama türleri (formlarda saklanan değerler).
+const (
+	KindMath        = "math"
+	KindProofOfWork = "pow"
+)
+
+// Challenge kullanıcıya (veya tarayıcıya) gösterilen meydan okumadır. Token formdaki gizli
+// alanda geri gönderilir; cevap ayrı alandan gelir.
+type Challenge struct {
+	Kind       string
+	Token      string
+	Prompt     string // Matematik sorusunun metni (örn. "7 + 5 kaç eder?")
+	Difficulty int    // İş kanıtında özetin başında sıfır olması gereken bit sayısı
+}
+
+// Captcha bir doğrulama türünün üreticisi ve doğrulayıcısıdır.
+type Captcha interface {
+	Kind() string
+	Challenge(now time.Time) (Challenge, error)
+	Verify(token, answer string, now time.Time) error
+}
+
+// tokenSigner jeton alanlarını imzalar ve doğrular; kullanılmış jetonları süreleri dolana
+// kadar hatırlayarak aynı çözümün tekrar kullanılmasını engeller.
+type tokenSigner struct {
+	secret []byte
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	spent map[string]time.Time // jeton -> son geçerlilik zamanı
+}
+
+func newTokenSigner(kind string, secret []byte, ttl time.Duration) *tokenSigner {
+	// Türler aynı anahtarı paylaşsa da birinin jetonu diğerinde geçmesin diye anahtar türe bağlanır.
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("captcha:" + kind))
+	return &tokenSigner{secret: mac.Sum(nil), ttl: ttl, spent: make(map[string]time.Time)}
+}
+
+// mac verilen metnin imzasını döndürür.
+func (s *tokenSigner) mac(value string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// nonce jetonu benzersiz kılan rastgele değer.
+func nonce() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// sign alanları son geçerlilik zamanıyla birleştirip imzalar: "alan1.alan2.expires.imza".
+func (s *tokenSigner) sign(now time.Time, fields ...string) string {
+	payload := strings.Join(append(fields, strconv.FormatInt(now.Add(s.ttl).Unix(), 10)), ".")
+	return payload + "." + s.mac(payload)
+}
+
+// open jetonun imzasını ve süresini doğrular, imzalı alanları döndürür.
+func (s *tokenSigner) open(token string, fieldCount int, now time.Time) ([]string, time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != fieldCount+2 {
+		return nil, time.Time{}, ErrInvalidToken
+	}
+	payload := strings.Join(parts[:len(parts)-1], ".")
+	if !hmac.Equal([]byte(parts[len(parts)-1]), []byte(s.mac(payload))) {
+		return nil, time.Time{}, ErrInvalidToken
+	}
+	expires, err := strconv.ParseInt(parts[fieldCount], 10, 64)
+	if err != nil {
+		return nil, time.Time{}, ErrInvalidToken
+	}
+	expiresAt := time.Unix(expires, 0)
+	if now.After(expiresAt) {
+		return nil, time.Time{}, ErrExpired
+	}
+	return parts[:fieldCount], expiresAt, nil
+}
+
+// spend jetonu kullanılmış olarak işaretler; daha önce kullanıldıysa ErrReplayed döner.
+// Süresi dolan kayıtlar her çağrıda temizlenir.
+func (s *tokenSigner) spend(token string, expiresAt time.Time, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for t, exp := range s.spent {
+		if now.After(exp) {
+			delete(s.spent, t)
+		}
+	}
+	if _, ok := s.spent[token]; ok {
+		return ErrReplayed
+	}
+	s.spent[token] = expiresAt
+	return nil
+}
+
+// New türüne göre doğrulayıcı oluşturur; bilinmeyen türde nil döner.
+func New(kind string, secret []byte, ttl time.Duration, difficulty int) Captcha {
+	switch kind {
+	case KindMath:
+		return NewMath(secret, ttl)
+	case KindProofOfWork:
+		return NewProofOfWork(secret, ttl, difficulty)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package captcha
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// solveMath soru metnindeki işlemi çözer.
+func solveMath(t *testing.T, prompt string) string {
+	t.Helper()
+	var a, b int
+	var op string
+	if _, err := fmt.Sscanf(prompt, "%d %s %d", &a, &op, &b); err != nil {
+		t.Fatalf("soru çözümlenemedi %q: %v", prompt, err)
+	}
+	if op == "-" {
+		return strconv.Itoa(a - b)
+	}
+	return strconv.Itoa(a + b)
+}
+
+func TestMath(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMath([]byte("gizli"), 10*time.Minute)
+
+	ch, err := m.Challenge(now)
+	if err != nil {
+		t.Fatalf("Challenge: %v", err)
+	}
+	answer := solveMath(t, ch.Prompt)
+	if err := m.Verify(ch.Token, answer, now.Add(time.Minute)); err != nil {
+		t.Fatalf("doğru cevap reddedildi: %v", err)
+	}
+	if err := m.Verify(ch.Token, answer, now.Add(time.Minute)); !errors.Is(err, ErrReplayed) {
+		t.Errorf("tekrar kullanılan jeton için ErrReplayed bekleniyordu, alınan: %v", err)
+	}
+
+	ch, _ = m.Challenge(now)
+	correct, _ := strconv.Atoi(solveMath(t, ch.Prompt))
+	if err := m.Verify(ch.Token, strconv.Itoa(correct+1), now); !errors.Is(err, ErrWrongAnswer) {
+		t.Errorf("yanlış cevap için ErrWrongAnswer bekleniyordu, alınan: %v", err)
+	}
+	if err := m.Verify(ch.Token, strconv.Itoa(correct), now); !errors.Is(err, ErrReplayed) {
+		t.Errorf("yanlış cevaptan sonra jeton harcanmalıydı, alınan: %v", err)
+	}
+
+	ch, _ = m.Challenge(now)
+	if err := m.Verify(ch.Token, solveMath(t, ch.Prompt), now.Add(11*time.Minute)); !errors.Is(err, ErrExpired) {
+		t.Errorf("süresi dolan jeton için ErrExpired bekleniyordu, alınan: %v", err)
+	}
+	if err := NewMath([]byte("başka"), 10*time.Minute).Verify(ch.Token, solveMath(t, ch.Prompt), now); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("farklı anahtarla jeton kabul edildi: %v", err)
+	}
+	if err := m.Verify("bozuk.jeton", "3", now); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("bozuk jeton için ErrInvalidToken bekleniyordu, alınan: %v", err)
+	}
+}
+
+func TestProofOfWork(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	p := NewProofOfWork([]byte("gizli"), 10*time.Minute, 8)
+
+	ch, err := p.Challenge(now)
+	if err != nil {
+		t.Fatalf("Challenge: %v", err)
+	}
+	if ch.Difficulty != 8 {
+		t.Fatalf("zorluk 8 bekleniyordu, alınan %d", ch.Difficulty)
+	}
+	solution := Solve(ch.Token, ch.Difficulty)
+	if err := p.Verify(ch.Token, solution, now); err != nil {
+		t.Fatalf("geçerli çözüm reddedildi: %v", err)
+	}
+	if err := p.Verify(ch.Token, solution, now); !errors.Is(err, ErrReplayed) {
+		t.Errorf("tekrar kullanılan çözüm için ErrReplayed bekleniyordu, alınan: %v", err)
+	}
+
+	ch, _ = p.Challenge(now)
+	bad := ""
+	for counter := 0; bad == ""; counter++ {
+		candidate := strconv.Itoa(counter)
+		if leadingZeroBits(sha256.Sum256([]byte(ch.Token+":"+candidate))) < ch.Difficulty {
+			bad = candidate
+		}
+	}
+	if err := p.Verify(ch.Token, bad, now); !errors.Is(err, ErrWrongAnswer) {
+		t.Errorf("geçersiz çözüm için ErrWrongAnswer bekleniyordu, alınan: %v", err)
+	}
+
+	// Zorluk jetonun imzalı kısmındadır; değiştirilirse jeton geçersizdir.
+	ch, _ = p.Challenge(now)
+	tampered := strings.Replace(ch.Token, ".8.", ".1.", 1)
+	if err := p.Verify(tampered, Solve(tampered, 1), now); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("zorluğu değiştirilen jeton için ErrInvalidToken bekleniyordu, alınan: %v", err)
+	}
+
+	// Bir türün jetonu diğerinde geçmez.
+	m := NewMath([]byte("gizli"), 10*time.Minute)
+	ch, _ = p.Challenge(now)
+	if err := m.Verify(ch.Token, "1", now); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("iş kanıtı jetonu matematik doğrulamasında kabul edildi: %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if c := New(KindMath, []byte("k"), time.Minute, 0); c == nil || c.Kind() != KindMath {
+		t.Errorf("math doğrulayıcısı bekleniyordu")
+	}
+	if c := New(KindProofOfWork, []byte("k"), time.Minute, 0); c == nil || c.Kind() != KindProofOfWork {
+		t.Errorf("pow doğrulayıcısı bekleniyordu")
+	}
+	if c := New("", []byte("k"), time.Minute, 0); c != nil {
+		t.Errorf("bilinmeyen tür için nil bekleniyordu")
+	}
+}
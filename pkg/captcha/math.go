@@ -0,0 +1,85 @@
+package captcha
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Math küçük bir toplama veya çıkarma sorusu sorar. Cevap jetonda açık tutulmaz; yalnızca
+// gizli anahtarla alınmış özeti bulunur, bu yüzden jetondan cevap çıkarılamaz.
+type Math struct {
+	signer *tokenSigner
+}
+
+// NewMath verilen anahtar ve geçerlilik süresiyle matematik doğrulayıcısı oluşturur.
+func NewMath(secret []byte, ttl time.Duration) *Math {
+	return &Math{signer: newTokenSigner(KindMath, secret, ttl)}
+}
+
+func (m *Math) Kind() string { return KindMath }
+
+// randomInt [min, max] aralığında rastgele sayı üretir.
+func randomInt(min, max int64) (int64, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(max-min+1))
+	if err != nil {
+		return 0, err
+	}
+	return n.Int64() + min, nil
+}
+
+// answerHash nonce'a bağlı cevap özeti.
+func (m *Math) answerHash(nonce string, answer int64) string {
+	return m.signer.mac("answer:" + nonce + ":" + strconv.FormatInt(answer, 10))
+}
+
+// Challenge yeni bir soru üretir. Çıkarmada sonuç negatif olmasın diye büyük sayı önce yazılır.
+func (m *Math) Challenge(now time.Time) (Challenge, error) {
+	a, err := randomInt(2, 15)
+	if err != nil {
+		return Challenge{}, err
+	}
+	b, err := randomInt(1, 9)
+	if err != nil {
+		return Challenge{}, err
+	}
+	op, err := randomInt(0, 1)
+	if err != nil {
+		return Challenge{}, err
+	}
+	n, err := nonce()
+	if err != nil {
+		return Challenge{}, err
+	}
+	prompt := fmt.Sprintf("%d + %d kaç eder?", a, b)
+	answer := a + b
+	if op == 1 {
+		prompt = fmt.Sprintf("%d - %d kaç eder?", a, b)
+		answer = a - b
+	}
+	return Challenge{
+		Kind:   KindMath,
+		Token:  m.signer.sign(now, n, m.answerHash(n, answer)),
+		Prompt: prompt,
+	}, nil
+}
+
+// Verify cevabı doğrular. Jeton cevap doğru olsun olmasın harcanır; aynı soruya cevaplar
+// tek tek denenemez.
+func (m *Math) Verify(token, answer string, now time.Time) error {
+	fields, expiresAt, err := m.signer.open(token, 2, now)
+	if err != nil {
+		return err
+	}
+	if err := m.signer.spend(token, expiresAt, now); err != nil {
+		return err
+	}
+	value, err := strconv.ParseInt(strings.TrimSpace(answer), 10, 64)
+	if err != nil || m.answerHash(fields[0], value) != fields[1] {
+		return ErrWrongAnswer
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package captcha
+
+import (
+	"crypto/sha256"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Varsayılan ve en yüksek iş kanıtı zorluğu (bit). 16 bit, tarayıcıda ortalama 65 bin
+// özet hesabıdır; kullanıcı için bir iki saniye, toplu gönderim için pahalıdır.
+const (
+	DefaultDifficulty = 16
+	MaxDifficulty     = 26
+)
+
+// ProofOfWork tarayıcıdan, jetonla birleştirildiğinde SHA-256 özetinin başında Difficulty
+// kadar sıfır bit olan bir sayaç bulmasını ister. Kullanıcı bir şey yazmaz; çözüm formdaki
+// betik tarafından hesaplanır.
+type ProofOfWork struct {
+	signer     *tokenSigner
+	difficulty int
+}
+
+// NewProofOfWork verilen anahtar, geçerlilik süresi ve zorlukla iş kanıtı doğrulayıcısı oluşturur.
+func NewProofOfWork(secret []byte, ttl time.Duration, difficulty int) *ProofOfWork {
+	if difficulty <= 0 {
+		difficulty = DefaultDifficulty
+	}
+	if difficulty > MaxDifficulty {
+		difficulty = MaxDifficulty
+	}
+	return &ProofOfWork{signer: newTokenSigner(KindProofOfWork, secret, ttl), difficulty: difficulty}
+}
+
+func (p *ProofOfWork) Kind() string { return KindProofOfWork }
+
+// Challenge yeni bir iş kanıtı jetonu üretir. Zorluk jetona imzalı yazılır; ayar sonradan
+// değişse de verilmiş jetonlar kendi zorluklarıyla doğrulanır.
+func (p *ProofOfWork) Challenge(now time.Time) (Challenge, error) {
+	n, err := nonce()
+	if err != nil {
+		return Challenge{}, err
+	}
+	return Challenge{
+		Kind:       KindProofOfWork,
+		Token:      p.signer.sign(now, n, strconv.Itoa(p.difficulty)),
+		Difficulty: p.difficulty,
+	}, nil
+}
+
+// leadingZeroBits özetin başındaki sıfır bit sayısı.
+func leadingZeroBits(sum [sha256.Size]byte) int {
+	count := 0
+	for _, b := range sum {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+	return count
+}
+
+// Solve çözümü bulur; tarayıcıdaki betiğin sunucu tarafı karşılığıdır (testler ve araçlar için).
+func Solve(token string, difficulty int) string {
+	for counter := 0; ; counter++ {
+		answer := strconv.Itoa(counter)
+		if leadingZeroBits(sha256.Sum256([]byte(token+":"+answer))) >= difficulty {
+			return answer
+		}
+	}
+}
+
+// Verify jetonun imzasını ve sayacın zorluğu karşıladığını doğrular; geçerli çözüm bir
+// daha kullanılamaz.
+func (p *ProofOfWork) Verify(token, answer string, now time.Time) error {
+	fields, expiresAt, err := p.signer.open(token, 2, now)
+	if err != nil {
+		return err
+	}
+	difficulty, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return ErrInvalidToken
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" || len(answer) > 20 {
+		return ErrWrongAnswer
+	}
+	if leadingZeroBits(sha256.Sum256([]byte(token+":"+answer))) < difficulty {
+		return ErrWrongAnswer
+	}
+	return p.signer.spend(token, expiresAt, now)
+}
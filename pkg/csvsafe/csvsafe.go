@@ -0,0 +1,26 @@
+// Package csvsafe kullanıcıların yazdığı metinleri CSV dosyalarına güvenle yazmak için
+// yardımcılar içerir. "=", "+", "-", "@" (ve sekme/satır başı) ile başlayan hücreler tablo
+// programlarında formül olarak çalıştırılabilir (CSV injection); bu hücrelerin başına
+// tek tırnak eklenerek metin olarak açılmaları sağlanır.
+package csvsafe
+
+import "strings"
+
+// formulaPrefixes hücrenin formül olarak yorumlanmasına yol açan ilk karakterlerdir.
+const formulaPrefixes = "=+-@\t\r"
+
+// Cell hücre değerini formül olarak yorumlanmayacak hale getirir.
+func Cell(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// Row satırdaki her hücreye Cell uygular. Verilen dilim değiştirilir ve döndürülür.
+func Row(record []string) []string {
+	for i := range record {
+		record[i] = Cell(record[i])
+	}
+	return record
+}
@@ -0,0 +1,32 @@
+package csvsafe
+
+import "testing"
+
+func TestCell(t *testing.T) {
+	cases := map[string]string{
+		"":                     "",
+		"Ayşe Yılmaz":          "Ayşe Yılmaz",
+		"=HYPERLINK(\"x\")":    "'=HYPERLINK(\"x\")",
+		"+90 555 000 00 00":    "'+90 555 000 00 00",
+		"-2+3":                 "'-2+3",
+		"@SUM(A1:A2)":          "'@SUM(A1:A2)",
+		"\t=1":                 "'\t=1",
+		"ali@example.com":      "ali@example.com",
+		"not: =1 ortada kalır": "not: =1 ortada kalır",
+	}
+	for in, want := range cases {
+		if got := Cell(in); got != want {
+			t.Errorf("Cell(%q) = %q, beklenen %q", in, got, want)
+		}
+	}
+}
+
+func TestRow(t *testing.T) {
+	got := Row([]string{"1", "=cmd", "a@b.c"})
+	want := []string{"1", "'=cmd", "a@b.c"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Row()[%d] = %q, beklenen %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package holidays
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Holiday tek bir resmi tatil gününü temsil eder.
+// Date her zaman UTC gece yarısıdır; yalnızca yıl/ay/gün anlamlıdır.
+type Holiday struct {
+	Date    time.Time
+	Name    string
+	HalfDay bool // Arife günleri: öğleden sonra (13:00 sonrası) tatil
+}
+
+// HalfDayStartMinute yarım gün tatillerin başladığı saattir (dakika cinsinden, 13:00).
+const HalfDayStartMinute = 13 * 60
+
+// officialReligiousDates Diyanet tarafından ilan edilmiş bayram başlangıçlarıdır.
+// Tablo dışında kalan yıllar için tabular Hicri takvim hesabı kullanılır;
+// bu hesap resmi tarihten bir gün sapabilir.
+var officialReligiousDates = map[int][2]string{
+	// yıl: {Ramazan Bayramı 1. gün, Kurban Bayramı 1. gün}
+	2020: {"2020-05-24", "2020-07-31"},
+	2021: {"2021-05-13", "2021-07-20"},
+	2022: {"2022-05-02", "2022-07-09"},
+	2023: {"2023-04-21", "2023-06-28"},
+	2024: {"2024-04-10", "2024-06-16"},
+	2025: {"2025-03-30", "2025-06-06"},
+	2026: {"2026-03-20", "2026-05-27"},
+	2027: {"2027-03-09", "2027-05-16"},
+	2028: {"2028-02-26", "2028-05-05"},
+	2029: {"2029-02-14", "2029-04-24"},
+	2030: {"2030-02-04", "2030-04-13"},
+}
+
+// TurkishPublicHolidays [fromYear, toYear] aralığındaki Türkiye resmi tatillerini
+// (sabit ve dini bayramlar dahil) tarih sırasıyla döndürür.
+func TurkishPublicHolidays(fromYear, toYear int) []Holiday {
+	var result []Holiday
+	for year := fromYear; year <= toYear; year++ {
+		result = append(result, fixedHolidays(year)...)
+
+		ramazan, kurban := religiousHolidayStarts(year)
+		for _, start := range ramazan {
+			result = append(result, feast(start, "Ramazan Bayramı", 3)...)
+		}
+		for _, start := range kurban {
+			result = append(result, feast(start, "Kurban Bayramı", 4)...)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date.Before(result[j].Date) })
+	return result
+}
+
+// OfficialYears Diyanet tablosunun kapsadığı ilk ve son yılı döndürür. Bu aralık dışındaki
+// yıllarda dini bayram tarihleri hesapla bulunur ve yaklaşıktır.
+func OfficialYears() (int, int) {
+	from, to := 0, 0
+	for year := range officialReligiousDates {
+		if from == 0 || year < from {
+			from = year
+		}
+		if year > to {
+			to = year
+		}
+	}
+	return from, to
+}
+
+// IsOfficialYear yılın dini bayram tarihlerinin Diyanet tablosundan geldiğini bildirir.
+func IsOfficialYear(year int) bool {
+	_, ok := officialReligiousDates[year]
+	return ok
+}
+
+// ByDate tatilleri "2006-01-02" anahtarlı bir map'e dönüştürür.
+func ByDate(list []Holiday) map[string]Holiday {
+	m := make(map[string]Holiday, len(list))
+	for _, h := range list {
+		key := h.Date.Format("2006-01-02")
+		// Aynı güne denk gelen tam gün tatil, yarım günü ezer.
+		if existing, ok := m[key]; ok && !existing.HalfDay {
+			continue
+		}
+		m[key] = h
+	}
+	return m
+}
+
+func fixedHolidays(year int) []Holiday {
+	d := func(month time.Month, day int) time.Time { return time.Date(year, month, day, 0, 0, 0, 0, time.UTC) }
+	return []Holiday{
+		{Date: d(time.January, 1), Name: "Yılbaşı"},
+		{Date: d(time.April, 23), Name: "Ulusal Egemenlik ve Çocuk Bayramı"},
+		{Date: d(time.May, 1), Name: "Emek ve Dayanışma Günü"},
+		{Date: d(time.May, 19), Name: "Atatürk'ü Anma, Gençlik ve Spor Bayramı"},
+		{Date: d(time.July, 15), Name: "Demokrasi ve Milli Birlik Günü"},
+		{Date: d(time.August, 30), Name: "Zafer Bayramı"},
+		{Date: d(time.October, 28), Name: "Cumhuriyet Bayramı Arifesi", HalfDay: true},
+		{Date: d(time.October, 29), Name: "Cumhuriyet Bayramı"},
+	}
+}
+
+// feast arife (yarım gün) ve bayram günlerini üretir.
+func feast(firstDay time.Time, name string, days int) []Holiday {
+	list := []Holiday{{Date: firstDay.AddDate(0, 0, -1), Name: name + " Arifesi", HalfDay: true}}
+	for i := 0; i < days; i++ {
+		list = append(list, Holiday{Date: firstDay.AddDate(0, 0, i), Name: name})
+	}
+	return list
+}
+
+// religiousHolidayStarts verilen Miladi yıldaki Ramazan ve Kurban bayramlarının ilk günlerini
+// döndürür. Hicri yıl Miladi yıldan kısa olduğundan aynı bayram bir yılda iki kez görülebilir.
+func religiousHolidayStarts(year int) ([]time.Time, []time.Time) {
+	if known, ok := officialReligiousDates[year]; ok {
+		ramazan, _ := time.Parse("2006-01-02", known[0])
+		kurban, _ := time.Parse("2006-01-02", known[1])
+		return []time.Time{ramazan}, []time.Time{kurban}
+	}
+	return tabularReligiousHolidayStarts(year)
+}
+
+// tabularReligiousHolidayStarts bayram başlangıçlarını tabular Hicri takvimle hesaplar.
+func tabularReligiousHolidayStarts(year int) ([]time.Time, []time.Time) {
+	// Hicri yıl yaklaşık olarak (miladi - 622) * 33/32 civarındadır; komşu yılları da deneriz.
+	approx := int(float64(year-622)*33.0/32.0) + 1
+	var ramazan, kurban []time.Time
+	for hy := approx - 2; hy <= approx+2; hy++ {
+		if r := hijriToGregorian(hy, 10, 1); r.Year() == year {
+			ramazan = append(ramazan, r) // 1 Şevval
+		}
+		if k := hijriToGregorian(hy, 12, 10); k.Year() == year {
+			kurban = append(kurban, k) // 10 Zilhicce
+		}
+	}
+	return ramazan, kurban
+}
+
+// hijriToGregorian tabular (aritmetik) Hicri takvim tarihini Miladi tarihe çevirir.
+func hijriToGregorian(year, month, day int) time.Time {
+	jdn := day + int(math.Ceil(29.5*float64(month-1))) + (year-1)*354 + (3+11*year)/30 + 1948439
+	return julianDayToDate(jdn)
+}
+
+// julianDayToDate Julian gün numarasını UTC gece yarısı tarihine çevirir.
+func julianDayToDate(jdn int) time.Time {
+	// JDN 0 = 24 Kasım 4714 MÖ (proleptik Gregoryen)
+	return time.Date(-4713, time.November, 24, 0, 0, 0, 0, time.UTC).AddDate(0, 0, jdn)
+}
@@ -0,0 +1,158 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestTurkishPublicHolidaysKnownYears(t *testing.T) {
+	tests := []struct {
+		year    int
+		date    string
+		name    string
+		halfDay bool
+	}{
+		{2024, "2024-01-01", "Yılbaşı", false},
+		{2024, "2024-04-09", "Ramazan Bayramı Arifesi", true},
+		{2024, "2024-04-10", "Ramazan Bayramı", false},
+		{2024, "2024-04-12", "Ramazan Bayramı", false},
+		{2024, "2024-06-15", "Kurban Bayramı Arifesi", true},
+		{2024, "2024-06-19", "Kurban Bayramı", false},
+		{2024, "2024-10-28", "Cumhuriyet Bayramı Arifesi", true},
+		{2024, "2024-10-29", "Cumhuriyet Bayramı", false},
+		{2025, "2025-03-29", "Ramazan Bayramı Arifesi", true},
+		{2025, "2025-04-01", "Ramazan Bayramı", false},
+		{2025, "2025-06-09", "Kurban Bayramı", false},
+		{2026, "2026-03-19", "Ramazan Bayramı Arifesi", true},
+		{2026, "2026-05-27", "Kurban Bayramı", false},
+		{2026, "2026-05-30", "Kurban Bayramı", false},
+		{2026, "2026-07-15", "Demokrasi ve Milli Birlik Günü", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			byDate := ByDate(TurkishPublicHolidays(tt.year, tt.year))
+			h, ok := byDate[tt.date]
+			if !ok {
+				t.Fatalf("%s tatil olarak bulunamadı", tt.date)
+			}
+			if h.Name != tt.name || h.HalfDay != tt.halfDay {
+				t.Errorf("beklenen %q (yarım gün %v), alınan %q (yarım gün %v)", tt.name, tt.halfDay, h.Name, h.HalfDay)
+			}
+		})
+	}
+}
+
+func TestTurkishPublicHolidaysNonHolidays(t *testing.T) {
+	byDate := ByDate(TurkishPublicHolidays(2024, 2026))
+	for _, d := range []string{"2024-04-13", "2024-06-20", "2025-04-02", "2026-05-31", "2026-10-30"} {
+		if h, ok := byDate[d]; ok {
+			t.Errorf("%s tatil olmamalı, alınan %q", d, h.Name)
+		}
+	}
+}
+
+func TestTurkishPublicHolidaysCountAndOrder(t *testing.T) {
+	list := TurkishPublicHolidays(2024, 2026)
+	// Her yıl 8 sabit gün, Ramazan Bayramı için arife + 3 gün, Kurban Bayramı için arife + 4 gün.
+	if want := 3 * 17; len(list) != want {
+		t.Fatalf("beklenen %d gün, alınan %d", want, len(list))
+	}
+	for i := 1; i < len(list); i++ {
+		if list[i].Date.Before(list[i-1].Date) {
+			t.Fatalf("liste sıralı değil: %s, %s'den sonra geliyor", list[i].Date.Format("2006-01-02"), list[i-1].Date.Format("2006-01-02"))
+		}
+	}
+}
+
+// Tablo dışındaki yıllarda hesap resmi tarihten en fazla bir gün sapabilir. Tablodaki yıllar
+// da hesapla karşılaştırılır; böylece tabloya yanlış girilen bir tarih yakalanır.
+func TestReligiousHolidayStartsOutsideTable(t *testing.T) {
+	tests := []struct {
+		year            int
+		ramazan, kurban string
+	}{
+		{2019, "2019-06-04", "2019-08-11"},
+	}
+	for year, known := range officialReligiousDates {
+		tests = append(tests, struct {
+			year            int
+			ramazan, kurban string
+		}{year, known[0], known[1]})
+	}
+	for _, tt := range tests {
+		ramazan, kurban := tabularReligiousHolidayStarts(tt.year)
+		if len(ramazan) != 1 || len(kurban) != 1 {
+			t.Errorf("%d: her bayram bir kez beklenirdi, alınan %d Ramazan, %d Kurban", tt.year, len(ramazan), len(kurban))
+			continue
+		}
+		for _, c := range []struct {
+			name      string
+			got, want time.Time
+		}{{"Ramazan", ramazan[0], date(tt.ramazan)}, {"Kurban", kurban[0], date(tt.kurban)}} {
+			if diff := c.got.Sub(c.want); diff < -24*time.Hour || diff > 24*time.Hour {
+				t.Errorf("%d %s: beklenen %s (±1 gün), alınan %s", tt.year, c.name, c.want.Format("2006-01-02"), c.got.Format("2006-01-02"))
+			}
+		}
+	}
+}
+
+// 2033'te Ramazan Bayramı hem Ocak'ta (Hicri 1454) hem Aralık'ta (Hicri 1455) başlar;
+// ikisi de takvimde bulunmalıdır.
+func TestTurkishPublicHolidaysFeastTwiceInYear(t *testing.T) {
+	list := TurkishPublicHolidays(2033, 2033)
+	byDate := ByDate(list)
+	for _, tt := range []struct {
+		date    string
+		name    string
+		halfDay bool
+	}{
+		{"2033-01-02", "Ramazan Bayramı Arifesi", true},
+		{"2033-01-03", "Ramazan Bayramı", false},
+		{"2033-01-05", "Ramazan Bayramı", false},
+		{"2033-12-22", "Ramazan Bayramı Arifesi", true},
+		{"2033-12-23", "Ramazan Bayramı", false},
+	} {
+		h, ok := byDate[tt.date]
+		if !ok {
+			t.Errorf("%s tatil olarak bulunamadı", tt.date)
+			continue
+		}
+		if h.Name != tt.name || h.HalfDay != tt.halfDay {
+			t.Errorf("%s: beklenen %q (yarım gün %v), alınan %q (yarım gün %v)", tt.date, tt.name, tt.halfDay, h.Name, h.HalfDay)
+		}
+	}
+	// 8 sabit gün, iki Ramazan Bayramı (arife + 3 gün) ve bir Kurban Bayramı (arife + 4 gün).
+	if want := 8 + 2*4 + 5; len(list) != want {
+		t.Errorf("beklenen %d gün, alınan %d", want, len(list))
+	}
+}
+
+func TestOfficialYears(t *testing.T) {
+	from, to := OfficialYears()
+	if from != 2020 || to != 2030 {
+		t.Errorf("beklenen 2020-2030, alınan %d-%d", from, to)
+	}
+	if !IsOfficialYear(2026) || IsOfficialYear(2033) {
+		t.Errorf("IsOfficialYear tabloyla uyuşmuyor")
+	}
+}
+
+func TestByDateFullDayWins(t *testing.T) {
+	d := date("2026-05-26")
+	for _, list := range [][]Holiday{
+		{{Date: d, Name: "Tam", HalfDay: false}, {Date: d, Name: "Yarım", HalfDay: true}},
+		{{Date: d, Name: "Yarım", HalfDay: true}, {Date: d, Name: "Tam", HalfDay: false}},
+	} {
+		if h := ByDate(list)["2026-05-26"]; h.Name != "Tam" {
+			t.Errorf("tam gün tatil yarım günü ezmeli, alınan %q", h.Name)
+		}
+	}
+}
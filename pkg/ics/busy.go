@@ -0,0 +1,65 @@
+package ics
+
+import (
+	"sort"
+	"time"
+)
+
+// Period dış takvimden gelen tek bir meşgul zaman aralığıdır.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// BusyPeriods etkinlikleri [from, to) aralığında meşgul dönemlere açar.
+// Tekrarlayan etkinlikler RRULE/EXDATE ile genişletilir; RECURRENCE-ID taşıyan kayıtlar
+// ilgili örneğin yerini alır. İptal edilmiş ve TRANSP:TRANSPARENT etkinlikler meşgul sayılmaz.
+func BusyPeriods(events []ParsedEvent, from, to time.Time) []Period {
+	// Tek örnek değişiklikleri UID + orijinal başlangıç ile eşlenir
+	overridden := make(map[string]map[int64]bool)
+	for _, e := range events {
+		if e.RecurrenceID.IsZero() {
+			continue
+		}
+		if overridden[e.UID] == nil {
+			overridden[e.UID] = make(map[int64]bool)
+		}
+		overridden[e.UID][e.RecurrenceID.Unix()] = true
+	}
+
+	var periods []Period
+	add := func(start, end time.Time) {
+		if !end.After(start) {
+			return
+		}
+		if start.Before(to) && end.After(from) {
+			periods = append(periods, Period{Start: start.UTC(), End: end.UTC()})
+		}
+	}
+
+	for _, e := range events {
+		if e.Cancelled || e.Transparent {
+			continue
+		}
+		duration := e.End.Sub(e.Start)
+		if e.RRule == nil || !e.RecurrenceID.IsZero() {
+			add(e.Start, e.End)
+			continue
+		}
+
+		excluded := make(map[int64]bool, len(e.ExDates))
+		for _, ex := range e.ExDates {
+			excluded[ex.Unix()] = true
+		}
+		for _, start := range e.RRule.Occurrences(e.Start, to) {
+			key := start.Unix()
+			if excluded[key] || overridden[e.UID][key] {
+				continue
+			}
+			add(start, start.Add(duration))
+		}
+	}
+
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Start.Before(periods[j].Start) })
+	return periods
+}
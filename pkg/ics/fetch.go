@@ -0,0 +1,58 @@
+package ics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// MaxFeedSize indirilecek bir takvim dosyasının en büyük boyutu (bayt).
+const MaxFeedSize = 5 << 20
+
+// NormalizeFeedURL webcal:// adreslerini https:// adresine çevirir ve yalnızca http(s) kabul eder.
+func NormalizeFeedURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(strings.ToLower(raw), "webcal://") {
+		raw = "https://" + raw[len("webcal://"):]
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("ics: geçersiz takvim adresi")
+	}
+	return u.String(), nil
+}
+
+// Fetch bir ICS adresini indirir. Yanıt MaxFeedSize'tan büyükse hata döner. Kullanıcının girdiği
+// adresler için client safehttp.NewClient ile oluşturulmalıdır.
+func Fetch(ctx context.Context, client *http.Client, feedURL string) ([]byte, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/calendar, */*;q=0.5")
+	req.Header.Set("User-Agent", "davet.link-calendar-sync/1.0")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ics: takvim indirilemedi (HTTP %d)", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxFeedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxFeedSize {
+		return nil, fmt.Errorf("ics: takvim dosyası çok büyük")
+	}
+	return data, nil
+}
@@ -0,0 +1,84 @@
+package ics
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"davet.link/pkg/safehttp"
+)
+
+func TestFetchFromHTTPServer(t *testing.T) {
+	fixture, err := os.ReadFile(filepath.Join("testdata", "outlook_monthly.ics"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/calendar.ics":
+			w.Header().Set("Content-Type", "text/calendar")
+			_, _ = w.Write(fixture)
+		case "/large.ics":
+			_, _ = w.Write(bytes.Repeat([]byte("A"), MaxFeedSize+10))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	data, err := Fetch(ctx, srv.Client(), srv.URL+"/calendar.ics")
+	if err != nil {
+		t.Fatalf("Fetch hata döndü: %v", err)
+	}
+	events, err := Parse(bytes.NewReader(data), time.UTC)
+	if err != nil {
+		t.Fatalf("Parse hata döndü: %v", err)
+	}
+	if got := BusyPeriods(events, utc("2026-01-01T00:00:00Z"), utc("2026-03-01T00:00:00Z")); len(got) != 2 {
+		t.Errorf("dönem sayısı = %d, beklenen 2", len(got))
+	}
+
+	if _, err := Fetch(ctx, srv.Client(), srv.URL+"/missing.ics"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("404 için hata bekleniyordu, alınan: %v", err)
+	}
+	if _, err := Fetch(ctx, srv.Client(), srv.URL+"/large.ics"); err == nil {
+		t.Error("boyut sınırını aşan yanıt için hata bekleniyordu")
+	}
+}
+
+func TestNormalizeFeedURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{"webcal://calendar.example.com/a.ics", "https://calendar.example.com/a.ics", false},
+		{" https://example.com/feed.ics ", "https://example.com/feed.ics", false},
+		{"ftp://example.com/feed.ics", "", true},
+		{"file:///etc/passwd", "", true},
+		{"not a url", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeFeedURL(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeFeedURL(%q) = %q, %v; beklenen %q (hata: %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestPublicClientRejectsLoopback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"))
+	}))
+	defer srv.Close()
+
+	if _, err := Fetch(context.Background(), safehttp.NewClient(5*time.Second, 3), srv.URL); err == nil {
+		t.Fatal("loopback adresine istek engellenmeliydi")
+	}
+}
@@ -0,0 +1,143 @@
+// Package ics RFC 5545 iCalendar (ICS) çıktısı üretir.
+package ics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Takvim METHOD değerleri (RFC 5546 iTIP).
+const (
+	MethodPublish = "PUBLISH" // Abonelik beslemeleri
+	MethodRequest = "REQUEST" // Davet / güncelleme
+	MethodCancel  = "CANCEL"  // İptal
+)
+
+// Event takvimdeki tek bir VEVENT kaydıdır.
+type Event struct {
+	UID           string
+	Sequence      int
+	Start         time.Time
+	End           time.Time
+	Summary       string
+	Description   string
+	Location      string
+	Organizer     string // e-posta
+	OrganizerName string
+	Attendee      string // e-posta
+	AttendeeName  string
+	Cancelled     bool
+	Created       time.Time
+	LastModified  time.Time
+}
+
+// Calendar VCALENDAR nesnesidir.
+type Calendar struct {
+	ProdID string
+	Name   string
+	Method string
+	Events []Event
+}
+
+const dateTimeFormat = "20060102T150405Z"
+
+// Bytes takvimi CRLF satır sonlarıyla ICS biçiminde döndürür.
+func (c Calendar) Bytes() []byte {
+	return []byte(c.String())
+}
+
+// String takvimi ICS metni olarak döndürür.
+func (c Calendar) String() string {
+	var b strings.Builder
+	w := func(line string) {
+		b.WriteString(fold(line))
+		b.WriteString("\r\n")
+	}
+
+	prodID := c.ProdID
+	if prodID == "" {
+		prodID = "-//davet.link//Randevu//TR"
+	}
+	w("BEGIN:VCALENDAR")
+	w("VERSION:2.0")
+	w("PRODID:" + prodID)
+	w("CALSCALE:GREGORIAN")
+	if c.Method != "" {
+		w("METHOD:" + c.Method)
+	}
+	if c.Name != "" {
+		w("X-WR-CALNAME:" + escape(c.Name))
+	}
+
+	now := time.Now().UTC().Format(dateTimeFormat)
+	for _, e := range c.Events {
+		w("BEGIN:VEVENT")
+		w("UID:" + e.UID)
+		w("DTSTAMP:" + now)
+		w(fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+		w("DTSTART:" + e.Start.UTC().Format(dateTimeFormat))
+		w("DTEND:" + e.End.UTC().Format(dateTimeFormat))
+		w("SUMMARY:" + escape(e.Summary))
+		if e.Description != "" {
+			w("DESCRIPTION:" + escape(e.Description))
+		}
+		if e.Location != "" {
+			w("LOCATION:" + escape(e.Location))
+		}
+		if e.Organizer != "" {
+			w(fmt.Sprintf("ORGANIZER;CN=%s:mailto:%s", quoteParam(e.OrganizerName), e.Organizer))
+		}
+		if e.Attendee != "" {
+			w(fmt.Sprintf("ATTENDEE;CN=%s;ROLE=REQ-PARTICIPANT;RSVP=FALSE:mailto:%s", quoteParam(e.AttendeeName), e.Attendee))
+		}
+		if !e.Created.IsZero() {
+			w("CREATED:" + e.Created.UTC().Format(dateTimeFormat))
+		}
+		if !e.LastModified.IsZero() {
+			w("LAST-MODIFIED:" + e.LastModified.UTC().Format(dateTimeFormat))
+		}
+		if e.Cancelled || c.Method == MethodCancel {
+			w("STATUS:CANCELLED")
+		} else {
+			w("STATUS:CONFIRMED")
+		}
+		w("END:VEVENT")
+	}
+	w("END:VCALENDAR")
+	return b.String()
+}
+
+// escape TEXT değerlerindeki özel karakterleri kaçışlar.
+func escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// quoteParam parametre değerlerini (CN) tırnak içine alır.
+func quoteParam(s string) string {
+	if s == "" {
+		return `""`
+	}
+	return `"` + strings.ReplaceAll(s, `"`, "'") + `"`
+}
+
+// fold 75 oktetten uzun satırları RFC 5545 3.1'e göre katlar (UTF-8 karakterleri bölmeden).
+func fold(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
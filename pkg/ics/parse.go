@@ -0,0 +1,334 @@
+package ics
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCalendar girdi bir VCALENDAR değilse döner.
+var ErrInvalidCalendar = errors.New("ics: geçerli bir takvim dosyası değil")
+
+// ParsedEvent dış bir takvimden okunan VEVENT kaydıdır.
+type ParsedEvent struct {
+	UID          string
+	Summary      string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	RecurrenceID time.Time // Tekrarlayan bir etkinliğin tek bir örneğini değiştiren kayıtlarda dolu
+	RRule        *RRule
+	ExDates      []time.Time
+	Transparent  bool // TRANSP:TRANSPARENT - meşgul sayılmaz
+	Cancelled    bool // STATUS:CANCELLED
+}
+
+// windowsZones Outlook/Exchange'in kullandığı yaygın Windows zaman dilimi adlarının IANA karşılıkları.
+var windowsZones = map[string]string{
+	"Turkey Standard Time":           "Europe/Istanbul",
+	"GTB Standard Time":              "Europe/Bucharest",
+	"E. Europe Standard Time":        "Europe/Chisinau",
+	"FLE Standard Time":              "Europe/Kiev",
+	"W. Europe Standard Time":        "Europe/Berlin",
+	"Central Europe Standard Time":   "Europe/Budapest",
+	"Central European Standard Time": "Europe/Warsaw",
+	"Romance Standard Time":          "Europe/Paris",
+	"GMT Standard Time":              "Europe/London",
+	"Russian Standard Time":          "Europe/Moscow",
+	"Arabian Standard Time":          "Asia/Dubai",
+	"Eastern Standard Time":          "America/New_York",
+	"Central Standard Time":          "America/Chicago",
+	"Mountain Standard Time":         "America/Denver",
+	"Pacific Standard Time":          "America/Los_Angeles",
+	"UTC":                            "UTC",
+	"Coordinated Universal Time":     "UTC",
+}
+
+// loadLocation TZID değerini time.Location'a çevirir; bilinmiyorsa fallback döner.
+func loadLocation(tzid string, fallback *time.Location) *time.Location {
+	tzid = strings.Trim(tzid, `"`)
+	tzid = strings.TrimPrefix(tzid, "/") // Bazı istemciler "/Europe/Istanbul" yazar
+	if tzid == "" {
+		return fallback
+	}
+	if loc, err := time.LoadLocation(tzid); err == nil {
+		return loc
+	}
+	if name, ok := windowsZones[tzid]; ok {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return fallback
+}
+
+// property tek bir içerik satırıdır (NAME;PARAM=VALUE:DEĞER).
+type property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// unfold katlanmış satırları birleştirir (RFC 5545 3.1).
+func unfold(r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// parseProperty bir içerik satırını ayrıştırır. Tırnak içindeki ':' ve ';' karakterleri korunur.
+func parseProperty(line string) (property, bool) {
+	inQuote := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuote = !inQuote
+		} else if r == ':' && !inQuote {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return property{}, false
+	}
+	head, value := line[:colon], line[colon+1:]
+
+	var parts []string
+	inQuote = false
+	start := 0
+	for i, r := range head {
+		if r == '"' {
+			inQuote = !inQuote
+		} else if r == ';' && !inQuote {
+			parts = append(parts, head[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, head[start:])
+
+	p := property{Name: strings.ToUpper(parts[0]), Params: map[string]string{}, Value: value}
+	for _, param := range parts[1:] {
+		if k, v, ok := strings.Cut(param, "="); ok {
+			p.Params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return p, true
+}
+
+// Parse bir ICS akışındaki VEVENT kayıtlarını okur. Kayan (floating) zamanlar ve tüm gün
+// etkinlikleri defaultLoc zaman diliminde yorumlanır.
+func Parse(r io.Reader, defaultLoc *time.Location) ([]ParsedEvent, error) {
+	if defaultLoc == nil {
+		defaultLoc = time.UTC
+	}
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, ErrInvalidCalendar
+	}
+
+	var (
+		events   []ParsedEvent
+		current  *ParsedEvent
+		duration time.Duration
+		hasEnd   bool
+		depth    int // VEVENT içindeki alt bileşenler (VALARM vb.)
+	)
+	for _, line := range lines {
+		p, ok := parseProperty(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case p.Name == "BEGIN" && strings.EqualFold(p.Value, "VEVENT"):
+			current = &ParsedEvent{}
+			duration, hasEnd, depth = 0, false, 0
+			continue
+		case p.Name == "END" && strings.EqualFold(p.Value, "VEVENT") && current != nil:
+			if current.Start.IsZero() {
+				current = nil
+				continue
+			}
+			if !hasEnd {
+				switch {
+				case duration > 0:
+					current.End = current.Start.Add(duration)
+				case current.AllDay:
+					current.End = current.Start.AddDate(0, 0, 1)
+				default:
+					current.End = current.Start
+				}
+			}
+			events = append(events, *current)
+			current = nil
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if p.Name == "BEGIN" {
+			depth++
+			continue
+		}
+		if p.Name == "END" {
+			depth--
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+
+		switch p.Name {
+		case "UID":
+			current.UID = p.Value
+		case "SUMMARY":
+			current.Summary = unescape(p.Value)
+		case "DTSTART":
+			t, allDay, err := parseDateTime(p, defaultLoc)
+			if err != nil {
+				return nil, err
+			}
+			current.Start, current.AllDay = t, allDay
+		case "DTEND":
+			t, _, err := parseDateTime(p, defaultLoc)
+			if err != nil {
+				return nil, err
+			}
+			current.End, hasEnd = t, true
+		case "DURATION":
+			d, err := parseDuration(p.Value)
+			if err != nil {
+				return nil, err
+			}
+			duration = d
+		case "RECURRENCE-ID":
+			t, _, err := parseDateTime(p, defaultLoc)
+			if err != nil {
+				return nil, err
+			}
+			current.RecurrenceID = t
+		case "RRULE":
+			rule, err := ParseRRule(p.Value, defaultLoc)
+			if err != nil {
+				return nil, err
+			}
+			current.RRule = rule
+		case "EXDATE":
+			for _, v := range strings.Split(p.Value, ",") {
+				t, _, err := parseDateTime(property{Name: p.Name, Params: p.Params, Value: v}, defaultLoc)
+				if err != nil {
+					return nil, err
+				}
+				current.ExDates = append(current.ExDates, t)
+			}
+		case "TRANSP":
+			current.Transparent = strings.EqualFold(p.Value, "TRANSPARENT")
+		case "STATUS":
+			current.Cancelled = strings.EqualFold(p.Value, "CANCELLED")
+		}
+	}
+	return events, nil
+}
+
+// parseDateTime DATE veya DATE-TIME değerini TZID parametresini dikkate alarak çözer.
+func parseDateTime(p property, defaultLoc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(p.Value)
+	loc := loadLocation(p.Params["TZID"], defaultLoc)
+
+	if strings.EqualFold(p.Params["VALUE"], "DATE") || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, defaultLoc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("ics: %s tarihi çözülemedi: %q", p.Name, value)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("ics: %s zamanı çözülemedi: %q", p.Name, value)
+		}
+		return t, false, nil
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("ics: %s zamanı çözülemedi: %q", p.Name, value)
+	}
+	return t, false, nil
+}
+
+// parseDuration RFC 5545 DURATION değerini (örn. PT1H30M, P1D, -PT15M) çözer.
+func parseDuration(value string) (time.Duration, error) {
+	v := strings.ToUpper(strings.TrimSpace(value))
+	sign := time.Duration(1)
+	if strings.HasPrefix(v, "-") {
+		sign = -1
+	}
+	v = strings.TrimLeft(v, "+-")
+	if !strings.HasPrefix(v, "P") {
+		return 0, fmt.Errorf("ics: geçersiz süre: %q", value)
+	}
+	v = v[1:]
+
+	var total time.Duration
+	inTime := false
+	num := ""
+	for _, r := range v {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return 0, fmt.Errorf("ics: geçersiz süre: %q", value)
+		}
+		num = ""
+		switch {
+		case r == 'W':
+			total += time.Duration(n) * 7 * 24 * time.Hour
+		case r == 'D':
+			total += time.Duration(n) * 24 * time.Hour
+		case r == 'H' && inTime:
+			total += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			total += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			total += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("ics: geçersiz süre: %q", value)
+		}
+	}
+	return sign * total, nil
+}
+
+// unescape TEXT değerlerindeki kaçış karakterlerini çözer.
+func unescape(s string) string {
+	r := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return r.Replace(s)
+}
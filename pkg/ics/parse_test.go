@@ -0,0 +1,147 @@
+package ics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func mustParseFixture(t *testing.T, name string) []ParsedEvent {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("fixture açılamadı: %v", err)
+	}
+	defer f.Close()
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Fatalf("zaman dilimi yüklenemedi: %v", err)
+	}
+	events, err := Parse(f, istanbul)
+	if err != nil {
+		t.Fatalf("Parse(%s) hata döndü: %v", name, err)
+	}
+	return events
+}
+
+func utc(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func assertPeriods(t *testing.T, got []Period, want []Period) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("dönem sayısı = %d, beklenen %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Errorf("dönem %d = %s - %s, beklenen %s - %s", i,
+				got[i].Start.Format(time.RFC3339), got[i].End.Format(time.RFC3339),
+				want[i].Start.Format(time.RFC3339), want[i].End.Format(time.RFC3339))
+		}
+	}
+}
+
+func TestParseGoogleWeekly(t *testing.T) {
+	events := mustParseFixture(t, "google_weekly.ics")
+	if len(events) != 5 {
+		t.Fatalf("etkinlik sayısı = %d, beklenen 5", len(events))
+	}
+	if want := "Haftalık ekip toplantısı, proje durumu ve yol haritası değerlendirmesi"; events[0].Summary != want {
+		t.Errorf("katlanmış SUMMARY = %q, beklenen %q", events[0].Summary, want)
+	}
+
+	got := BusyPeriods(events, utc("2026-03-01T00:00:00Z"), utc("2026-04-01T00:00:00Z"))
+	assertPeriods(t, got, []Period{
+		{utc("2026-03-02T07:00:00Z"), utc("2026-03-02T08:00:00Z")},
+		// 4 Mart EXDATE ile çıkarıldı, 9 Mart RECURRENCE-ID ile 14:00'e taşındı
+		{utc("2026-03-09T11:00:00Z"), utc("2026-03-09T12:00:00Z")},
+		{utc("2026-03-11T07:00:00Z"), utc("2026-03-11T08:00:00Z")},
+		{utc("2026-03-16T07:00:00Z"), utc("2026-03-16T08:00:00Z")},
+		{utc("2026-03-18T07:00:00Z"), utc("2026-03-18T08:00:00Z")},
+		// Tüm gün etkinlik varsayılan zaman diliminde (İstanbul) yorumlanır
+		{utc("2026-03-19T21:00:00Z"), utc("2026-03-20T21:00:00Z")},
+	})
+}
+
+func TestParseOutlookMonthlyLastFriday(t *testing.T) {
+	events := mustParseFixture(t, "outlook_monthly.ics")
+	got := BusyPeriods(events, utc("2026-01-01T00:00:00Z"), utc("2027-01-01T00:00:00Z"))
+	assertPeriods(t, got, []Period{
+		{utc("2026-01-30T13:00:00Z"), utc("2026-01-30T14:30:00Z")},
+		{utc("2026-02-27T13:00:00Z"), utc("2026-02-27T14:30:00Z")},
+		{utc("2026-03-27T13:00:00Z"), utc("2026-03-27T14:30:00Z")},
+		{utc("2026-04-24T13:00:00Z"), utc("2026-04-24T14:30:00Z")},
+	})
+}
+
+func TestParseDailyAcrossDST(t *testing.T) {
+	events := mustParseFixture(t, "daily_dst.ics")
+	got := BusyPeriods(events, utc("2026-03-01T00:00:00Z"), utc("2026-04-30T00:00:00Z"))
+	// 09:00 Berlin duvar saati yaz saatine geçişte korunur
+	assertPeriods(t, got, []Period{
+		{utc("2026-03-27T08:00:00Z"), utc("2026-03-27T08:30:00Z")},
+		{utc("2026-03-29T07:00:00Z"), utc("2026-03-29T07:30:00Z")},
+		{utc("2026-03-31T07:00:00Z"), utc("2026-03-31T07:30:00Z")},
+	})
+}
+
+func TestBusyPeriodsRespectsWindow(t *testing.T) {
+	events := mustParseFixture(t, "google_weekly.ics")
+	got := BusyPeriods(events, utc("2026-03-10T00:00:00Z"), utc("2026-03-12T00:00:00Z"))
+	assertPeriods(t, got, []Period{
+		{utc("2026-03-11T07:00:00Z"), utc("2026-03-11T08:00:00Z")},
+	})
+}
+
+func TestParseInvalid(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "invalid.ics"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := Parse(f, time.UTC); err != ErrInvalidCalendar {
+		t.Fatalf("Parse hata = %v, beklenen ErrInvalidCalendar", err)
+	}
+}
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"FREQ=WEEKLY;BYDAY=MO,2TU,-1FR;INTERVAL=2", false},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=12", false},
+		{"FREQ=YEARLY;BYMONTH=4;BYDAY=1SU", false},
+		{"FREQ=HOURLY", true},
+		{"FREQ=DAILY;INTERVAL=0", true},
+		{"FREQ=WEEKLY;BYDAY=XX", true},
+	}
+	for _, tt := range tests {
+		_, err := ParseRRule(tt.value, time.UTC)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRRule(%q) hata = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT1H30M": 90 * time.Minute,
+		"P1D":     24 * time.Hour,
+		"P1W":     7 * 24 * time.Hour,
+		"-PT15M":  -15 * time.Minute,
+		"P1DT2H":  26 * time.Hour,
+	}
+	for value, want := range tests {
+		got, err := parseDuration(value)
+		if err != nil || got != want {
+			t.Errorf("parseDuration(%q) = %v, %v; beklenen %v", value, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package ics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency RRULE FREQ değeridir.
+type Frequency string
+
+const (
+	FreqDaily   Frequency = "DAILY"
+	FreqWeekly  Frequency = "WEEKLY"
+	FreqMonthly Frequency = "MONTHLY"
+	FreqYearly  Frequency = "YEARLY"
+)
+
+// WeekdayNum BYDAY içindeki bir gündür; N sıfırdan farklıysa ayın/yılın N. günüdür (-1 = son).
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// RRule desteklenen RRULE alt kümesidir: FREQ (DAILY/WEEKLY/MONTHLY/YEARLY), INTERVAL,
+// COUNT, UNTIL, BYDAY, BYMONTHDAY ve BYMONTH.
+type RRule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// maxRecurrencePeriods sonsuz ya da hatalı kurallara karşı üst sınırdır.
+const maxRecurrencePeriods = 20000
+
+// ParseRRule "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10" biçimindeki kuralı çözer.
+func ParseRRule(value string, defaultLoc *time.Location) (*RRule, error) {
+	rule := &RRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		switch strings.ToUpper(k) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(v))
+		case "INTERVAL":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("ics: geçersiz INTERVAL: %q", v)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("ics: geçersiz COUNT: %q", v)
+			}
+			rule.Count = n
+		case "UNTIL":
+			t, _, err := parseDateTime(property{Name: "UNTIL", Params: map[string]string{}, Value: v}, defaultLoc)
+			if err != nil {
+				return nil, err
+			}
+			if len(v) == 8 {
+				// Tüm gün UNTIL: o günün sonuna kadar dahil
+				t = t.AddDate(0, 0, 1).Add(-time.Second)
+			}
+			rule.Until = t
+		case "BYDAY":
+			for _, d := range strings.Split(v, ",") {
+				d = strings.ToUpper(strings.TrimSpace(d))
+				if len(d) < 2 {
+					return nil, fmt.Errorf("ics: geçersiz BYDAY: %q", v)
+				}
+				wd, ok := weekdayCodes[d[len(d)-2:]]
+				if !ok {
+					return nil, fmt.Errorf("ics: geçersiz BYDAY: %q", v)
+				}
+				n := 0
+				if prefix := d[:len(d)-2]; prefix != "" {
+					var err error
+					if n, err = strconv.Atoi(prefix); err != nil {
+						return nil, fmt.Errorf("ics: geçersiz BYDAY: %q", v)
+					}
+				}
+				rule.ByDay = append(rule.ByDay, WeekdayNum{N: n, Weekday: wd})
+			}
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(v, ",") {
+				n, err := strconv.Atoi(strings.TrimSpace(d))
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("ics: geçersiz BYMONTHDAY: %q", v)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, m := range strings.Split(v, ",") {
+				n, err := strconv.Atoi(strings.TrimSpace(m))
+				if err != nil || n < 1 || n > 12 {
+					return nil, fmt.Errorf("ics: geçersiz BYMONTH: %q", v)
+				}
+				rule.ByMonth = append(rule.ByMonth, time.Month(n))
+			}
+		}
+	}
+	switch rule.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	default:
+		return nil, fmt.Errorf("ics: desteklenmeyen FREQ: %q", rule.Freq)
+	}
+	return rule, nil
+}
+
+// Occurrences dtstart'tan başlayarak kurala uyan örneklerin başlangıçlarını, until
+// zamanından önce başlayanlar için sırayla döndürür. dtstart her zaman ilk örnektir.
+// Saatler dtstart'ın zaman diliminde duvar saati olarak korunur (yaz saati geçişleri dahil).
+func (r *RRule) Occurrences(dtstart time.Time, until time.Time) []time.Time {
+	var result []time.Time
+	emitted := 0
+	emit := func(t time.Time) bool {
+		if !r.Until.IsZero() && t.After(r.Until) {
+			return false
+		}
+		if r.Count > 0 && emitted >= r.Count {
+			return false
+		}
+		if !t.Before(until) {
+			return false
+		}
+		emitted++
+		result = append(result, t)
+		return true
+	}
+
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		candidates := r.periodCandidates(dtstart, period)
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+		for _, t := range candidates {
+			if t.Before(dtstart) {
+				continue
+			}
+			if !emit(t) {
+				return result
+			}
+		}
+	}
+	return result
+}
+
+// periodCandidates period. tekrar dönemindeki (gün/hafta/ay/yıl) aday başlangıçları üretir.
+func (r *RRule) periodCandidates(dtstart time.Time, period int) []time.Time {
+	loc := dtstart.Location()
+	h, mi, s := dtstart.Clock()
+	at := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, h, mi, s, 0, loc) }
+	step := period * r.Interval
+
+	switch r.Freq {
+	case FreqDaily:
+		t := at(dtstart.Year(), dtstart.Month(), dtstart.Day()+step)
+		if r.matchesMonth(t.Month()) && r.matchesWeekday(t.Weekday()) && r.matchesMonthDay(t) {
+			return []time.Time{t}
+		}
+		return nil
+
+	case FreqWeekly:
+		// Haftalar pazartesi başlar (WKST=MO)
+		offset := (int(dtstart.Weekday()) + 6) % 7
+		weekStart := at(dtstart.Year(), dtstart.Month(), dtstart.Day()-offset+7*step)
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []WeekdayNum{{Weekday: dtstart.Weekday()}}
+		}
+		var out []time.Time
+		for _, d := range days {
+			t := at(weekStart.Year(), weekStart.Month(), weekStart.Day()+(int(d.Weekday)+6)%7)
+			if r.matchesMonth(t.Month()) {
+				out = append(out, t)
+			}
+		}
+		return out
+
+	case FreqMonthly:
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(step), 1, 0, 0, 0, 0, loc)
+		if !r.matchesMonth(first.Month()) {
+			return nil
+		}
+		return r.monthCandidates(first.Year(), first.Month(), dtstart, at)
+
+	case FreqYearly:
+		year := dtstart.Year() + step
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []time.Month{dtstart.Month()}
+		}
+		var out []time.Time
+		for _, m := range months {
+			out = append(out, r.monthCandidates(year, m, dtstart, at)...)
+		}
+		return out
+	}
+	return nil
+}
+
+// monthCandidates bir ay içindeki BYMONTHDAY / BYDAY adaylarını üretir.
+func (r *RRule) monthCandidates(year int, month time.Month, dtstart time.Time, at func(int, time.Month, int) time.Time) []time.Time {
+	daysIn := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	var out []time.Time
+
+	if len(r.ByMonthDay) > 0 {
+		for _, d := range r.ByMonthDay {
+			if d < 0 {
+				d = daysIn + d + 1
+			}
+			if d >= 1 && d <= daysIn {
+				t := at(year, month, d)
+				if r.matchesWeekday(t.Weekday()) {
+					out = append(out, t)
+				}
+			}
+		}
+		return out
+	}
+
+	if len(r.ByDay) > 0 {
+		for _, wd := range r.ByDay {
+			var days []int
+			for d := 1; d <= daysIn; d++ {
+				if time.Date(year, month, d, 0, 0, 0, 0, time.UTC).Weekday() == wd.Weekday {
+					days = append(days, d)
+				}
+			}
+			switch {
+			case wd.N > 0 && wd.N <= len(days):
+				out = append(out, at(year, month, days[wd.N-1]))
+			case wd.N < 0 && -wd.N <= len(days):
+				out = append(out, at(year, month, days[len(days)+wd.N]))
+			case wd.N == 0:
+				for _, d := range days {
+					out = append(out, at(year, month, d))
+				}
+			}
+		}
+		return out
+	}
+
+	// Varsayılan: dtstart'ın ay günü; o gün ayda yoksa atlanır (örn. 31 Şubat)
+	if dtstart.Day() <= daysIn {
+		out = append(out, at(year, month, dtstart.Day()))
+	}
+	return out
+}
+
+func (r *RRule) matchesMonth(m time.Month) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, bm := range r.ByMonth {
+		if bm == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RRule) matchesWeekday(wd time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, d := range r.ByDay {
+		if d.Weekday == wd {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RRule) matchesMonthDay(t time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	daysIn := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, d := range r.ByMonthDay {
+		if d == t.Day() || (d < 0 && daysIn+d+1 == t.Day()) {
+			return true
+		}
+	}
+	return false
+}
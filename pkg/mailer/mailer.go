@@ -0,0 +1,160 @@
+// Package mailer SMTP üzerinden e-posta gönderimi sağlar.
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"davet.link/configs/configsenv"
+	"davet.link/configs/configslog"
+)
+
+// Attachment e-postaya eklenecek dosyadır.
+type Attachment struct {
+	Filename    string
+	ContentType string // örn. "text/calendar; method=REQUEST"
+	Data        []byte
+	Inline      bool // true ise ayrı bir MIME parçası olarak gövde yanında sunulur (takvim davetleri)
+}
+
+// Message gönderilecek e-postadır.
+type Message struct {
+	To          []string
+	Subject     string
+	TextBody    string
+	HTMLBody    string
+	ReplyTo     string
+	Attachments []Attachment
+}
+
+// Mailer e-posta gönderimi için arayüz.
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// New ortam değişkenlerine göre bir Mailer döndürür.
+// SMTP_HOST tanımlı değilse e-postalar yalnızca loglanır (geliştirme ortamı).
+func New() Mailer {
+	host := configsenv.GetEnvWithDefault("SMTP_HOST", "")
+	if host == "" {
+		return logMailer{}
+	}
+	return &smtpMailer{
+		host:     host,
+		port:     configsenv.GetEnvAsInt("SMTP_PORT", 587),
+		username: configsenv.GetEnvWithDefault("SMTP_USERNAME", ""),
+		password: configsenv.GetEnvWithDefault("SMTP_PASSWORD", ""),
+		from:     configsenv.GetEnvWithDefault("SMTP_FROM", "davet.link <no-reply@davet.link>"),
+	}
+}
+
+// logMailer e-postaları göndermek yerine loglar.
+type logMailer struct{}
+
+func (logMailer) Send(msg Message) error {
+	configslog.SLog.Infof("SMTP yapılandırılmamış, e-posta gönderilmedi: to=%v subject=%q ekler=%d", msg.To, msg.Subject, len(msg.Attachments))
+	return nil
+}
+
+// smtpMailer net/smtp ile gönderim yapar.
+type smtpMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+func (m *smtpMailer) Send(msg Message) error {
+	if len(msg.To) == 0 {
+		return fmt.Errorf("mailer: alıcı yok")
+	}
+	body, err := Build(m.from, msg)
+	if err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	return smtp.SendMail(fmt.Sprintf("%s:%d", m.host, m.port), auth, envelopeAddress(m.from), msg.To, body)
+}
+
+// envelopeAddress "Ad <adres>" biçiminden adresi ayıklar.
+func envelopeAddress(from string) string {
+	if i := strings.LastIndex(from, "<"); i >= 0 {
+		return strings.TrimSuffix(from[i+1:], ">")
+	}
+	return from
+}
+
+// Build mesajı RFC 5322 / MIME biçiminde oluşturur.
+func Build(from string, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&buf, "%s: %s\r\n", k, v) }
+
+	header("From", from)
+	header("To", strings.Join(msg.To, ", "))
+	if msg.ReplyTo != "" {
+		header("Reply-To", msg.ReplyTo)
+	}
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+
+	mixed := boundary()
+	header("Content-Type", fmt.Sprintf("multipart/mixed; boundary=%q", mixed))
+	buf.WriteString("\r\n")
+
+	// Gövde: metin/HTML alternatifleri + satır içi takvim parçaları
+	alt := boundary()
+	fmt.Fprintf(&buf, "--%s\r\nContent-Type: multipart/alternative; boundary=%q\r\n\r\n", mixed, alt)
+	writePart(&buf, alt, "text/plain; charset=utf-8", []byte(msg.TextBody))
+	if msg.HTMLBody != "" {
+		writePart(&buf, alt, "text/html; charset=utf-8", []byte(msg.HTMLBody))
+	}
+	for _, a := range msg.Attachments {
+		if a.Inline {
+			writePart(&buf, alt, a.ContentType+"; charset=utf-8", a.Data)
+		}
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", alt)
+
+	for _, a := range msg.Attachments {
+		fmt.Fprintf(&buf, "--%s\r\n", mixed)
+		fmt.Fprintf(&buf, "Content-Type: %s; name=%q\r\n", a.ContentType, a.Filename)
+		fmt.Fprintf(&buf, "Content-Disposition: attachment; filename=%q\r\n", a.Filename)
+		buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+		writeBase64(&buf, a.Data)
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", mixed)
+	return buf.Bytes(), nil
+}
+
+func writePart(buf *bytes.Buffer, b, contentType string, data []byte) {
+	fmt.Fprintf(buf, "--%s\r\nContent-Type: %s\r\nContent-Transfer-Encoding: base64\r\n\r\n", b, contentType)
+	writeBase64(buf, data)
+}
+
+// writeBase64 veriyi 76 karakterlik satırlar halinde base64 yazar.
+func writeBase64(buf *bytes.Buffer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+}
+
+func boundary() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return "dl-" + hex.EncodeToString(b)
+}
@@ -0,0 +1,117 @@
+// Package money tutarları kuruş gibi en küçük para birimi cinsinden tam sayı olarak işler.
+// Kayan noktalı sayılar yuvarlama hatası yaptığından tutarlar her yerde int64 tutulur.
+package money
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidAmount tutar metni çözülemediğinde döner.
+var ErrInvalidAmount = errors.New("money: geçersiz tutar")
+
+// Parse "1.250,50", "1250.50", "1250,5" veya "1250" biçimindeki tutarı en küçük birime
+// (örn. kuruş) çevirir. Son ayraçtan sonra 1-2 hane varsa ondalık ayraç kabul edilir;
+// binlik gruplar diğer ayraçla yazılmalı ve 3 haneli olmalıdır. "150.123" gibi tek binlik
+// ayraçlı ve ondalıksız tutarlar ondalık mı binlik mi olduğu anlaşılamadığından reddedilir.
+// Boş metin 0 döner.
+func Parse(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	s = strings.ReplaceAll(s, " ", "")
+	if s == "" {
+		return 0, nil
+	}
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if strings.Trim(s, "0123456789.,") != "" {
+		return 0, ErrInvalidAmount
+	}
+
+	whole, fraction := s, ""
+	if i := strings.LastIndexAny(s, ".,"); i >= 0 && len(s)-i-1 >= 1 && len(s)-i-1 <= 2 {
+		whole, fraction = s[:i], s[i+1:]
+		if strings.IndexByte(whole, s[i]) >= 0 {
+			return 0, ErrInvalidAmount
+		}
+	}
+	whole, ok := ungroup(whole, fraction != "")
+	if !ok {
+		return 0, ErrInvalidAmount
+	}
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > (1<<62)/100 {
+		return 0, ErrInvalidAmount
+	}
+	cents, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil || cents < 0 {
+		return 0, ErrInvalidAmount
+	}
+	amount := units*100 + cents
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// ungroup tam kısımdaki binlik ayraçları kaldırır. Ayraçlar tek tip olmalı, ilk grup 1-3,
+// sonrakiler tam 3 haneli olmalıdır. Ondalık kısım yoksa tek ayraç belirsiz sayılır.
+func ungroup(whole string, hasFraction bool) (string, bool) {
+	sep := strings.IndexAny(whole, ".,")
+	if sep < 0 {
+		return whole, whole != ""
+	}
+	groups := strings.Split(whole, whole[sep:sep+1])
+	if !hasFraction && len(groups) < 3 {
+		return "", false
+	}
+	for i, group := range groups {
+		if strings.ContainsAny(group, ".,") || group == "" || len(group) > 3 || (i > 0 && len(group) != 3) {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+// Format tutarı "1.250,50 TRY" biçiminde gösterir. currency boşsa yalnızca sayı döner.
+func Format(minor int64, currency string) string {
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	digits := strconv.FormatInt(minor/100, 10)
+	var grouped strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(d)
+	}
+	text := sign + grouped.String() + "," + leftPad(strconv.FormatInt(minor%100, 10))
+	if currency != "" {
+		text += " " + currency
+	}
+	return text
+}
+
+// FormatInput tutarı form alanında düzenlenebilecek "1250,50" biçiminde döndürür.
+func FormatInput(minor int64) string {
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return sign + strconv.FormatInt(minor/100, 10) + "," + leftPad(strconv.FormatInt(minor%100, 10))
+}
+
+func leftPad(cents string) string {
+	if len(cents) < 2 {
+		return "0" + cents
+	}
+	return cents
+}
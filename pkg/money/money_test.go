@@ -0,0 +1,93 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in   string
+		want int64
+	}{
+		{"", 0},
+		{"  ", 0},
+		{"1250", 125000},
+		{"1250,5", 125050},
+		{"1250,50", 125050},
+		{"1250.50", 125050},
+		{"1.250,50", 125050},
+		{"1,250.50", 125050},
+		{"1.250.000", 125000000},
+		{"1.250.000,75", 125000075},
+		{"1 250,50", 125050},
+		{"0,05", 5},
+		{"-12,30", -1230},
+	}
+	for _, tc := range cases {
+		got, err := Parse(tc.in)
+		if err != nil {
+			t.Errorf("Parse(%q) hata döndü: %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Parse(%q) = %d, beklenen %d", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseRejectsMalformed(t *testing.T) {
+	for _, in := range []string{
+		"1.2.3",
+		"1,+5",
+		"+5",
+		"5-",
+		"--5",
+		"-",
+		"abc",
+		"12a",
+		"150.123",   // tek ayraç ve 3 hane: ondalık mı binlik mi belirsiz
+		"1,250",     // aynı belirsizlik
+		"1250.",     // sonda ayraç
+		",50",       // tam kısım yok
+		"1.250.50",  // ondalık ayraç binlik ayraçla aynı
+		"1,25,000",  // 3 haneden kısa grup
+		"1.2500,00", // 3 haneden uzun grup
+		"1.250,000", // ondalık kısım 2 haneden uzun
+		"1.250,000.00",
+		"99999999999999999999",
+	} {
+		if got, err := Parse(in); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("Parse(%q) = %d, %v; beklenen ErrInvalidAmount", in, got, err)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := []struct {
+		minor    int64
+		currency string
+		want     string
+	}{
+		{0, "", "0,00"},
+		{5, "TRY", "0,05 TRY"},
+		{125050, "TRY", "1.250,50 TRY"},
+		{125000000, "", "1.250.000,00"},
+		{-1230, "EUR", "-12,30 EUR"},
+	}
+	for _, tc := range cases {
+		if got := Format(tc.minor, tc.currency); got != tc.want {
+			t.Errorf("Format(%d, %q) = %q, beklenen %q", tc.minor, tc.currency, got, tc.want)
+		}
+	}
+}
+
+func TestFormatInputRoundTrip(t *testing.T) {
+	for _, minor := range []int64{0, 7, 125050, 125000000, -1230} {
+		text := FormatInput(minor)
+		got, err := Parse(text)
+		if err != nil || got != minor {
+			t.Errorf("Parse(FormatInput(%d)) = %d, %v; beklenen %d", minor, got, err, minor)
+		}
+	}
+}
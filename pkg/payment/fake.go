@@ -0,0 +1,98 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FakeDeclineToken bu anahtarla yapılan ödemeler reddedilir (hata senaryolarını denemek için).
+const FakeDeclineToken = "tok_decline"
+
+// FakeProvider ödemeleri bellekte tutan, ağ çağrısı yapmayan sağlayıcıdır.
+// Yerel geliştirmede ve testlerde gerçek bir ödeme altyapısının yerine kullanılır.
+type FakeProvider struct {
+	mu       sync.Mutex
+	seq      int
+	charges  map[string]*Charge
+	refunded map[string]int64
+	byKey    map[string]string // IdempotencyKey -> Charge ID
+}
+
+// NewFakeProvider boş bir FakeProvider oluşturur.
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{
+		charges:  make(map[string]*Charge),
+		refunded: make(map[string]int64),
+		byKey:    make(map[string]string),
+	}
+}
+
+// Name sağlayıcı adı.
+func (p *FakeProvider) Name() string { return "fake" }
+
+// Charge ödemeyi kaydeder. SourceToken FakeDeclineToken ise ErrDeclined döner.
+func (p *FakeProvider) Charge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.AmountMinor <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if req.SourceToken == FakeDeclineToken {
+		return nil, ErrDeclined
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if req.IdempotencyKey != "" {
+		if id, ok := p.byKey[req.IdempotencyKey]; ok {
+			charge := *p.charges[id]
+			return &charge, nil
+		}
+	}
+	p.seq++
+	charge := &Charge{
+		ID:          fmt.Sprintf("fake_ch_%d", p.seq),
+		AmountMinor: req.AmountMinor,
+		Currency:    req.Currency,
+		CreatedAt:   time.Now().UTC(),
+	}
+	p.charges[charge.ID] = charge
+	if req.IdempotencyKey != "" {
+		p.byKey[req.IdempotencyKey] = charge.ID
+	}
+	result := *charge
+	return &result, nil
+}
+
+// Refund bir ödemenin tamamını veya bir kısmını iade eder.
+func (p *FakeProvider) Refund(ctx context.Context, chargeID string, amountMinor int64) (*Refund, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if amountMinor <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	charge, ok := p.charges[chargeID]
+	if !ok {
+		return nil, ErrChargeNotFound
+	}
+	if p.refunded[chargeID]+amountMinor > charge.AmountMinor {
+		return nil, ErrRefundTooLarge
+	}
+	p.refunded[chargeID] += amountMinor
+	p.seq++
+	return &Refund{
+		ID:          fmt.Sprintf("fake_re_%d", p.seq),
+		ChargeID:    chargeID,
+		AmountMinor: amountMinor,
+		CreatedAt:   time.Now().UTC(),
+	}, nil
+}
+
+var _ Provider = (*FakeProvider)(nil)
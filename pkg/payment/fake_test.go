@@ -0,0 +1,109 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFakeCharge(t *testing.T) {
+	p := NewFakeProvider()
+	ctx := context.Background()
+
+	charge, err := p.Charge(ctx, ChargeRequest{AmountMinor: 5000, Currency: "TRY", SourceToken: "tok_ok"})
+	if err != nil {
+		t.Fatalf("Charge hata döndü: %v", err)
+	}
+	if charge.ID == "" || charge.AmountMinor != 5000 || charge.Currency != "TRY" {
+		t.Errorf("beklenmeyen ödeme: %+v", charge)
+	}
+
+	if _, err := p.Charge(ctx, ChargeRequest{AmountMinor: 5000, SourceToken: FakeDeclineToken}); !errors.Is(err, ErrDeclined) {
+		t.Errorf("reddedilen anahtar için hata %v, beklenen ErrDeclined", err)
+	}
+	for _, amount := range []int64{0, -100} {
+		if _, err := p.Charge(ctx, ChargeRequest{AmountMinor: amount}); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("tutar %d için hata %v, beklenen ErrInvalidAmount", amount, err)
+		}
+	}
+}
+
+func TestFakeChargeIdempotency(t *testing.T) {
+	p := NewFakeProvider()
+	ctx := context.Background()
+
+	first, err := p.Charge(ctx, ChargeRequest{AmountMinor: 1000, IdempotencyKey: "booking-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := p.Charge(ctx, ChargeRequest{AmountMinor: 1000, IdempotencyKey: "booking-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != first.ID {
+		t.Errorf("aynı anahtarla ikinci ödeme %q, beklenen %q", again.ID, first.ID)
+	}
+	other, err := p.Charge(ctx, ChargeRequest{AmountMinor: 1000, IdempotencyKey: "booking-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.ID == first.ID {
+		t.Error("farklı anahtarlar aynı ödemeyi döndürdü")
+	}
+}
+
+func TestFakeRefund(t *testing.T) {
+	p := NewFakeProvider()
+	ctx := context.Background()
+	charge, err := p.Charge(ctx, ChargeRequest{AmountMinor: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refund, err := p.Refund(ctx, charge.ID, 400)
+	if err != nil {
+		t.Fatalf("kısmi iade hata döndü: %v", err)
+	}
+	if refund.ChargeID != charge.ID || refund.AmountMinor != 400 {
+		t.Errorf("beklenmeyen iade: %+v", refund)
+	}
+	if _, err := p.Refund(ctx, charge.ID, 700); !errors.Is(err, ErrRefundTooLarge) {
+		t.Errorf("fazla iade için hata %v, beklenen ErrRefundTooLarge", err)
+	}
+	if _, err := p.Refund(ctx, charge.ID, 600); err != nil {
+		t.Errorf("kalan tutarın iadesi hata döndü: %v", err)
+	}
+	if _, err := p.Refund(ctx, charge.ID, 1); !errors.Is(err, ErrRefundTooLarge) {
+		t.Errorf("tamamı iade edilmiş ödeme için hata %v, beklenen ErrRefundTooLarge", err)
+	}
+	if _, err := p.Refund(ctx, "fake_ch_yok", 100); !errors.Is(err, ErrChargeNotFound) {
+		t.Errorf("bilinmeyen ödeme için hata %v, beklenen ErrChargeNotFound", err)
+	}
+	if _, err := p.Refund(ctx, charge.ID, 0); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("sıfır iade için hata %v, beklenen ErrInvalidAmount", err)
+	}
+}
+
+func TestFakeCanceledContext(t *testing.T) {
+	p := NewFakeProvider()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.Charge(ctx, ChargeRequest{AmountMinor: 1000}); !errors.Is(err, context.Canceled) {
+		t.Errorf("iptal edilmiş context için hata %v, beklenen context.Canceled", err)
+	}
+	if _, err := p.Refund(ctx, "fake_ch_1", 100); !errors.Is(err, context.Canceled) {
+		t.Errorf("iptal edilmiş context için hata %v, beklenen context.Canceled", err)
+	}
+}
+
+// New süreç boyunca tek sağlayıcı döndürür; bir servisin aldığı ödeme diğerinden iade edilebilir.
+func TestNewSharesProvider(t *testing.T) {
+	t.Setenv("PAYMENT_PROVIDER", "fake")
+	first, second := New(), New()
+	if first == nil {
+		t.Fatal("PAYMENT_PROVIDER=fake iken sağlayıcı dönmeli")
+	}
+	if first != second {
+		t.Error("New her çağrıda aynı sağlayıcıyı döndürmeli")
+	}
+}
@@ -0,0 +1,75 @@
+// Package payment çevrim içi ödeme sağlayıcıları için ortak arayüzü tanımlar.
+// Gerçek bir ödeme altyapısı (iyzico, Stripe vb.) Provider arayüzünü uygulayarak eklenir;
+// geliştirme ve testlerde bellek içi FakeProvider kullanılır.
+package payment
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"davet.link/configs/configsenv"
+)
+
+var (
+	ErrDeclined       = errors.New("payment: ödeme reddedildi")
+	ErrChargeNotFound = errors.New("payment: ödeme kaydı bulunamadı")
+	ErrInvalidAmount  = errors.New("payment: geçersiz tutar")
+	ErrRefundTooLarge = errors.New("payment: iade tutarı ödenen tutarı aşıyor")
+)
+
+// ChargeRequest bir ödeme alma isteğidir. Tutarlar en küçük para biriminde (kuruş) verilir.
+type ChargeRequest struct {
+	AmountMinor    int64
+	Currency       string
+	Description    string
+	CustomerEmail  string
+	SourceToken    string // Sağlayıcının ödeme formundan dönen kart/oturum anahtarı
+	IdempotencyKey string // Aynı isteğin tekrarında ikinci kez çekim yapılmaması için
+}
+
+// Charge sağlayıcıda gerçekleşmiş bir ödemedir.
+type Charge struct {
+	ID          string
+	AmountMinor int64
+	Currency    string
+	CreatedAt   time.Time
+}
+
+// Refund sağlayıcıda gerçekleşmiş bir iadedir.
+type Refund struct {
+	ID          string
+	ChargeID    string
+	AmountMinor int64
+	CreatedAt   time.Time
+}
+
+// Provider çevrim içi ödeme sağlayıcısı arayüzü.
+type Provider interface {
+	// Name kayıtlarda saklanan sağlayıcı adıdır (örn. "fake").
+	Name() string
+	Charge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	Refund(ctx context.Context, chargeID string, amountMinor int64) (*Refund, error)
+}
+
+// configured süreç boyunca paylaşılan sağlayıcıdır. Bir servisin aldığı ödeme başka bir servisten
+// iade edilebilmeli; bellek içi FakeProvider'da bu ancak tek örnekle mümkündür.
+var configured struct {
+	once     sync.Once
+	provider Provider
+}
+
+// New PAYMENT_PROVIDER ortam değişkenine göre sağlayıcı döndürür. Sağlayıcı ilk çağrıda
+// oluşturulur ve sonraki çağrılarda aynı örnek döner. Değişken boşsa veya tanınmıyorsa nil
+// döner ve çevrim içi ödeme kapalı kabul edilir; yalnızca elle girilen ödemeler kullanılabilir.
+// "fake" yerel geliştirme içindir, gerçek tahsilat yapmaz.
+func New() Provider {
+	configured.once.Do(func() {
+		switch configsenv.GetEnvWithDefault("PAYMENT_PROVIDER", "") {
+		case "fake":
+			configured.provider = NewFakeProvider()
+		}
+	})
+	return configured.provider
+}
@@ -0,0 +1,90 @@
+// Package ratelimit süreç belleğinde tutulan sabit pencereli istek sınırlayıcısıdır. Sayaçlar
+// süreç başınadır; birden fazla örnek çalışırken her örnek kendi sayacını tutar.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Rule bir anahtar için pencere başına izin verilen istek sayısıdır. Limit 0 veya negatifse
+// kural uygulanmaz.
+type Rule struct {
+	Key    string
+	Limit  int
+	Window time.Duration
+}
+
+type counter struct {
+	count int
+	until time.Time // Pencerenin bitişi
+}
+
+// Limiter anahtar başına sayaç tutar.
+type Limiter struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastPrune time.Time
+}
+
+// New boş bir sınırlayıcı oluşturur.
+func New() *Limiter {
+	return &Limiter{counters: make(map[string]*counter)}
+}
+
+// current anahtarın güncel penceresindeki sayacı döndürür; pencere geçtiyse sıfırlar.
+func (l *Limiter) current(rule Rule, now time.Time) *counter {
+	c, ok := l.counters[rule.Key]
+	if !ok || !now.Before(c.until) {
+		c = &counter{until: now.Add(rule.Window)}
+		l.counters[rule.Key] = c
+	}
+	return c
+}
+
+// prune süresi dolan sayaçları en fazla dakikada bir temizler.
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Minute {
+		return
+	}
+	l.lastPrune = now
+	for key, c := range l.counters {
+		if !now.Before(c.until) {
+			delete(l.counters, key)
+		}
+	}
+}
+
+// Allow tüm kurallar izin veriyorsa her birinin sayacını bir artırır ve true döner. Bir kural
+// dolmuşsa hiçbir sayaç artırılmaz; engelleyen kural ve false döner.
+func (l *Limiter) Allow(now time.Time, rules ...Rule) (Rule, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+
+	counters := make([]*counter, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Limit <= 0 || rule.Window <= 0 {
+			continue
+		}
+		c := l.current(rule, now)
+		if c.count >= rule.Limit {
+			return rule, false
+		}
+		counters = append(counters, c)
+	}
+	for _, c := range counters {
+		c.count++
+	}
+	return Rule{}, true
+}
+
+// RetryAfter anahtarın penceresinin bitmesine kalan süre; sayaç yoksa 0.
+func (l *Limiter) RetryAfter(key string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if c, ok := l.counters[key]; ok && now.Before(c.until) {
+		return c.until.Sub(now)
+	}
+	return 0
+}
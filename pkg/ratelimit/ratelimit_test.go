@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	l := New()
+	ip := Rule{Key: "ip", Limit: 2, Window: time.Hour}
+	form := Rule{Key: "form", Limit: 3, Window: time.Hour}
+
+	for i := 0; i < 2; i++ {
+		if _, ok := l.Allow(now, ip, form); !ok {
+			t.Fatalf("%d. istek reddedildi", i+1)
+		}
+	}
+	blocked, ok := l.Allow(now, ip, form)
+	if ok || blocked.Key != "ip" {
+		t.Fatalf("ip sınırı engellemeliydi: ok=%v kural=%q", ok, blocked.Key)
+	}
+
+	// Engellenen istek form sayacını artırmaz: başka bir IP hâlâ bir istek yapabilir.
+	other := Rule{Key: "ip2", Limit: 2, Window: time.Hour}
+	if _, ok := l.Allow(now, other, form); !ok {
+		t.Fatalf("form sınırına ulaşılmadan istek reddedildi")
+	}
+	blocked, ok = l.Allow(now, other, form)
+	if ok || blocked.Key != "form" {
+		t.Fatalf("form sınırı engellemeliydi: ok=%v kural=%q", ok, blocked.Key)
+	}
+
+	if got := l.RetryAfter("ip", now.Add(20*time.Minute)); got != 40*time.Minute {
+		t.Errorf("kalan süre 40dk bekleniyordu, alınan %v", got)
+	}
+
+	// Pencere bitince sayaç sıfırlanır.
+	if _, ok := l.Allow(now.Add(time.Hour), ip, form); !ok {
+		t.Errorf("yeni pencerede istek reddedildi")
+	}
+}
+
+func TestLimiterDisabledRule(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	l := New()
+	for i := 0; i < 100; i++ {
+		if _, ok := l.Allow(now, Rule{Key: "kapalı", Limit: 0, Window: time.Hour}); !ok {
+			t.Fatalf("sınırsız kural isteği reddetti")
+		}
+	}
+}
+
+func TestLimiterPrune(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	l := New()
+	l.Allow(now, Rule{Key: "eski", Limit: 1, Window: time.Minute})
+	l.Allow(now.Add(2*time.Minute), Rule{Key: "yeni", Limit: 1, Window: time.Minute})
+	if _, ok := l.counters["eski"]; ok {
+		t.Errorf("süresi dolan sayaç temizlenmedi")
+	}
+}
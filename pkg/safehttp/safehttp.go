@@ -0,0 +1,93 @@
+// Package safehttp kullanıcıların girdiği adreslere (takvim aboneliği, webhook) istek atmak için
+// yalnızca genel internet adreslerine bağlanan HTTP istemcisi sağlar. İç ağdaki servislere
+// istek atılmasını (SSRF) bağlantı anında, çözümlenen IP adresine bakarak engeller; bu kontrol
+// yönlendirmelerle gidilen adresler için de uygulanır.
+package safehttp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrBlockedAddress   = errors.New("safehttp: adrese bağlantıya izin verilmiyor")
+	ErrTooManyRedirects = errors.New("safehttp: çok fazla yönlendirme")
+	ErrRedirectScheme   = errors.New("safehttp: yalnızca http(s) adreslerine yönlendirme izlenir")
+)
+
+// Standart kütüphanenin sınıflandırmadığı, genel internette olmayan aralıklar.
+var (
+	thisNetwork = mustCIDR("0.0.0.0/8")     // "Bu ağ"; bazı sistemlerde yerel makineye gider
+	sharedCGNAT = mustCIDR("100.64.0.0/10") // Operatör NAT'ı (RFC 6598)
+	nat64Prefix = mustCIDR("64:ff9b::/96")  // IPv4 adresini gömen NAT64 öneki (RFC 6052)
+)
+
+func mustCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// IsPublicIP adresin genel internette olup olmadığını bildirir; loopback, özel ağ, operatör
+// NAT'ı, 0.0.0.0/8, link-local, multicast ve belirsiz adresler için false döner. NAT64
+// adreslerinde gömülü IPv4 adresi kontrol edilir.
+func IsPublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return false
+	}
+	if thisNetwork.Contains(ip) || sharedCGNAT.Contains(ip) {
+		return false
+	}
+	if nat64Prefix.Contains(ip) {
+		return IsPublicIP(net.IP(ip.To16()[12:]))
+	}
+	return true
+}
+
+// control yalnızca genel adreslere bağlantıya izin verir.
+func control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !IsPublicIP(net.ParseIP(host)) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	return nil
+}
+
+// NewClient yalnızca genel internet adreslerine bağlanan bir HTTP istemcisi döndürür.
+// maxRedirects sıfırsa yönlendirmeler izlenmez ve 3xx yanıt olduğu gibi döner; aksi halde en
+// fazla bu kadar http(s) yönlendirmesi izlenir. Ortamdaki proxy ayarları kullanılmaz.
+func NewClient(timeout time.Duration, maxRedirects int) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: control,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if maxRedirects <= 0 {
+				return http.ErrUseLastResponse
+			}
+			if len(via) > maxRedirects {
+				return ErrTooManyRedirects
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return ErrRedirectScheme
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package safehttp
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	cases := map[string]bool{
+		"93.184.216.34":      true,
+		"2606:4700::1111":    true,
+		"127.0.0.1":          false,
+		"::1":                false,
+		"10.1.2.3":           false,
+		"172.16.0.1":         false,
+		"192.168.1.10":       false,
+		"169.254.169.254":    false, // Bulut metadata servisi
+		"fe80::1":            false,
+		"fd00::1":            false,
+		"0.0.0.0":            false,
+		"224.0.0.1":          false,
+		"0.1.2.3":            false,
+		"100.64.0.1":         false, // Operatör NAT'ı
+		"100.127.255.254":    false,
+		"100.128.0.1":        true,
+		"64:ff9b::a00:1":     false, // NAT64 içinde 10.0.0.1
+		"64:ff9b::7f00:1":    false, // NAT64 içinde 127.0.0.1
+		"64:ff9b::a9fe:a9fe": false, // NAT64 içinde 169.254.169.254
+		"64:ff9b::5db8:d822": true,  // NAT64 içinde 93.184.216.34
+		"::ffff:10.0.0.1":    false,
+	}
+	for addr, want := range cases {
+		if got := IsPublicIP(net.ParseIP(addr)); got != want {
+			t.Errorf("IsPublicIP(%s) = %v, beklenen %v", addr, got, want)
+		}
+	}
+	if IsPublicIP(nil) {
+		t.Error("IsPublicIP(nil) true döndü")
+	}
+}
+
+func TestClientRejectsLoopback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	_, err := NewClient(5*time.Second, 0).Get(srv.URL)
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("loopback için ErrBlockedAddress bekleniyordu, alınan: %v", err)
+	}
+}
+
+func TestClientRedirectPolicy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		case "/once":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		case "/ftp":
+			http.Redirect(w, r, "ftp://example.com/feed", http.StatusFound)
+		}
+	}))
+	defer srv.Close()
+
+	// Testte loopback'e bağlanabilmek için yalnızca yönlendirme politikası kullanılır.
+	client := func(maxRedirects int) *http.Client {
+		c := NewClient(time.Second, maxRedirects)
+		c.Transport = srv.Client().Transport
+		return c
+	}
+
+	resp, err := client(0).Get(srv.URL + "/once")
+	if err != nil || resp.StatusCode != http.StatusFound {
+		t.Errorf("maxRedirects=0 için 302 yanıtı bekleniyordu, alınan: %v %v", resp, err)
+	}
+	if resp, err := client(3).Get(srv.URL + "/once"); err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("tek yönlendirme izlenmeliydi: %v %v", resp, err)
+	}
+	if _, err := client(3).Get(srv.URL + "/loop"); !errors.Is(err, ErrTooManyRedirects) {
+		t.Errorf("ErrTooManyRedirects bekleniyordu, alınan: %v", err)
+	}
+	if _, err := client(3).Get(srv.URL + "/ftp"); !errors.Is(err, ErrRedirectScheme) {
+		t.Errorf("ErrRedirectScheme bekleniyordu, alınan: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package slots
+
+import (
+	"sort"
+	"time"
+)
+
+// Interval [Start, End) yarı açık zaman aralığını temsil eder.
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Overlaps iki aralığın kesişip kesişmediğini döndürür.
+func (i Interval) Overlaps(other Interval) bool {
+	return i.Start.Before(other.End) && other.Start.Before(i.End)
+}
+
+// Options slot üretimi için parametrelerdir.
+type Options struct {
+	Duration     time.Duration // Randevu süresi
+	Step         time.Duration // Slot başlangıçları arasındaki adım (0 ise Duration kullanılır)
+	BufferBefore time.Duration // Randevu öncesi tampon
+	BufferAfter  time.Duration // Randevu sonrası tampon
+	NotBefore    time.Time     // Bu zamandan önce başlayan slotlar üretilmez (lead time)
+	NotAfter     time.Time     // Bu zamandan sonra başlayan slotlar üretilmez (horizon), sıfırsa sınırsız
+}
+
+// Generate çalışma pencereleri içinde, meşgul aralıklarla (tamponlar dahil)
+// çakışmayan randevu slotlarını üretir.
+func Generate(windows []Interval, busy []Interval, opt Options) []Interval {
+	if opt.Duration <= 0 {
+		return nil
+	}
+	step := opt.Step
+	if step <= 0 {
+		step = opt.Duration
+	}
+
+	var result []Interval
+	for _, w := range Merge(windows) {
+		for start := w.Start; !start.Add(opt.Duration).After(w.End); start = start.Add(step) {
+			if !opt.NotBefore.IsZero() && start.Before(opt.NotBefore) {
+				continue
+			}
+			if !opt.NotAfter.IsZero() && start.After(opt.NotAfter) {
+				break
+			}
+			slot := Interval{Start: start, End: start.Add(opt.Duration)}
+			blocked := Interval{Start: slot.Start.Add(-opt.BufferBefore), End: slot.End.Add(opt.BufferAfter)}
+			if !overlapsAny(blocked, busy) {
+				result = append(result, slot)
+			}
+		}
+	}
+	return result
+}
+
+// Merge aralıkları sıralar ve kesişen/bitişik olanları birleştirir.
+func Merge(list []Interval) []Interval {
+	if len(list) == 0 {
+		return nil
+	}
+	sorted := make([]Interval, 0, len(list))
+	for _, i := range list {
+		if i.End.After(i.Start) {
+			sorted = append(sorted, i)
+		}
+	}
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].Start.Before(sorted[b].Start) })
+
+	var merged []Interval
+	for _, i := range sorted {
+		if n := len(merged); n > 0 && !i.Start.After(merged[n-1].End) {
+			if i.End.After(merged[n-1].End) {
+				merged[n-1].End = i.End
+			}
+			continue
+		}
+		merged = append(merged, i)
+	}
+	return merged
+}
+
+// Subtract pencerelerden meşgul aralıkları çıkarır.
+func Subtract(windows []Interval, busy []Interval) []Interval {
+	result := Merge(windows)
+	for _, b := range Merge(busy) {
+		var next []Interval
+		for _, w := range result {
+			if !w.Overlaps(b) {
+				next = append(next, w)
+				continue
+			}
+			if w.Start.Before(b.Start) {
+				next = append(next, Interval{Start: w.Start, End: b.Start})
+			}
+			if b.End.Before(w.End) {
+				next = append(next, Interval{Start: b.End, End: w.End})
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+// DayWindow verilen gün için dakika cinsinden başlangıç/bitişi gerçek zamana çevirir.
+// day, hedef zaman dilimindeki gün başlangıcı (gece yarısı) olmalıdır.
+func DayWindow(day time.Time, startMinute, endMinute int) Interval {
+	y, m, d := day.Date()
+	loc := day.Location()
+	return Interval{
+		Start: time.Date(y, m, d, startMinute/60, startMinute%60, 0, 0, loc),
+		End:   time.Date(y, m, d, endMinute/60, endMinute%60, 0, 0, loc),
+	}
+}
+
+func overlapsAny(i Interval, list []Interval) bool {
+	for _, other := range list {
+		if i.Overlaps(other) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package slots
+
+import (
+	"testing"
+	"time"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, 10, 5, hour, minute, 0, 0, time.UTC)
+}
+
+func starts(list []Interval, loc *time.Location) []string {
+	var out []string
+	for _, i := range list {
+		out = append(out, i.Start.In(loc).Format("15:04"))
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGenerate(t *testing.T) {
+	day := []Interval{{Start: at(9, 0), End: at(12, 0)}}
+	tests := []struct {
+		name    string
+		windows []Interval
+		busy    []Interval
+		opt     Options
+		want    []string
+	}{
+		{
+			name:    "pencereyi tam dolduran slotlar",
+			windows: day,
+			opt:     Options{Duration: time.Hour},
+			want:    []string{"09:00", "10:00", "11:00"},
+		},
+		{
+			name:    "pencere sonunu aşan slot üretilmez",
+			windows: []Interval{{Start: at(9, 0), End: at(11, 30)}},
+			opt:     Options{Duration: time.Hour},
+			want:    []string{"09:00", "10:00"},
+		},
+		{
+			name:    "adım süreden kısa",
+			windows: []Interval{{Start: at(9, 0), End: at(10, 30)}},
+			opt:     Options{Duration: time.Hour, Step: 15 * time.Minute},
+			want:    []string{"09:00", "09:15", "09:30"},
+		},
+		{
+			name:    "öğle arası",
+			windows: []Interval{{Start: at(9, 0), End: at(12, 0)}, {Start: at(13, 0), End: at(15, 0)}},
+			opt:     Options{Duration: time.Hour},
+			want:    []string{"09:00", "10:00", "11:00", "13:00", "14:00"},
+		},
+		{
+			name:    "meşgul aralık ve bitişik slotlar",
+			windows: day,
+			busy:    []Interval{{Start: at(10, 0), End: at(11, 0)}},
+			opt:     Options{Duration: time.Hour},
+			want:    []string{"09:00", "11:00"},
+		},
+		{
+			name:    "tamponlar komşu slotları kapatır",
+			windows: day,
+			busy:    []Interval{{Start: at(10, 0), End: at(11, 0)}},
+			opt:     Options{Duration: 30 * time.Minute, BufferBefore: 15 * time.Minute, BufferAfter: 15 * time.Minute},
+			want:    []string{"09:00", "11:30"},
+		},
+		{
+			name:    "lead time ve ufuk",
+			windows: day,
+			opt:     Options{Duration: 30 * time.Minute, NotBefore: at(9, 45), NotAfter: at(11, 0)},
+			want:    []string{"10:00", "10:30", "11:00"},
+		},
+		{
+			name:    "kesişen pencereler birleştirilir",
+			windows: []Interval{{Start: at(9, 0), End: at(10, 30)}, {Start: at(10, 0), End: at(11, 0)}},
+			opt:     Options{Duration: time.Hour},
+			want:    []string{"09:00", "10:00"},
+		},
+		{
+			name:    "süre sıfırsa slot yok",
+			windows: day,
+			opt:     Options{},
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := starts(Generate(tt.windows, tt.busy, tt.opt), time.UTC)
+			if !equal(got, tt.want) {
+				t.Errorf("beklenen %v, alınan %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	windows := []Interval{{Start: at(9, 0), End: at(17, 0)}}
+	busy := []Interval{{Start: at(12, 0), End: at(13, 0)}, {Start: at(8, 0), End: at(9, 30)}, {Start: at(16, 30), End: at(18, 0)}}
+	got := Subtract(windows, busy)
+	want := []Interval{{Start: at(9, 30), End: at(12, 0)}, {Start: at(13, 0), End: at(16, 30)}}
+	if len(got) != len(want) {
+		t.Fatalf("beklenen %v, alınan %v", want, got)
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Errorf("%d. aralık: beklenen %v, alınan %v", i, want[i], got[i])
+		}
+	}
+}
+
+// Yaz saati geçişlerinde pencereler duvar saatiyle kurulur; ileri alınan saatte slot
+// üretilmez, geri alınan saat iki ayrı slot olarak görünür.
+func TestGenerateAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("zaman dilimi verisi yok: %v", err)
+	}
+	tests := []struct {
+		name string
+		day  time.Time
+		want []string
+		real time.Duration
+	}{
+		{"ileri alma", time.Date(2026, 3, 29, 0, 0, 0, 0, loc), []string{"00:00", "01:00", "03:00"}, 3 * time.Hour},
+		{"geri alma", time.Date(2026, 10, 25, 0, 0, 0, 0, loc), []string{"00:00", "01:00", "02:00", "02:00", "03:00"}, 5 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := DayWindow(tt.day, 0, 4*60)
+			if got := window.End.Sub(window.Start); got != tt.real {
+				t.Fatalf("pencere süresi: beklenen %v, alınan %v", tt.real, got)
+			}
+			got := starts(Generate([]Interval{window}, nil, Options{Duration: time.Hour}), loc)
+			if !equal(got, tt.want) {
+				t.Errorf("beklenen %v, alınan %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDayWindow(t *testing.T) {
+	loc := time.FixedZone("TRT", 3*60*60)
+	w := DayWindow(time.Date(2026, 10, 5, 0, 0, 0, 0, loc), 9*60+30, 18*60)
+	if w.Start.UTC() != at(6, 30) || w.End.UTC() != at(15, 0) {
+		t.Errorf("beklenen 06:30-15:00 UTC, alınan %v-%v", w.Start.UTC(), w.End.UTC())
+	}
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// LocalStorage dosyaları yerel dosya sisteminde bir kök dizin altında saklar.
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage kök dizini (yoksa) oluşturur ve yerel depoyu döndürür.
+func NewLocalStorage(root string) (*LocalStorage, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(abs, 0o750); err != nil {
+		return nil, fmt.Errorf("storage: kök dizin oluşturulamadı: %w", err)
+	}
+	return &LocalStorage{root: abs}, nil
+}
+
+// Name arka uç adı.
+func (s *LocalStorage) Name() string { return "local" }
+
+// path anahtarı kök dizin altındaki dosya yoluna çevirir.
+func (s *LocalStorage) path(key string) (string, error) {
+	if err := ValidKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// Put dosyayı önce geçici bir dosyaya yazar, tamamlanınca yerine taşır; yarım kalan
+// yüklemeler okunabilir bir dosya bırakmaz. size >= 0 ise yazılan boyut doğrulanır.
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // Rename sonrası etkisizdir
+
+	written, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("storage: beklenen %d bayt, yazılan %d bayt", size, written)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get dosyayı okumak için açar.
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, err
+	}
+	return f, &Object{Key: key, Size: info.Size()}, nil
+}
+
+// Delete dosyayı siler; dosya yoksa hata döndürmez.
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+var _ Storage = (*LocalStorage)(nil)
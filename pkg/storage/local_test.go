@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalStoragePutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := "forms/7/abc/özgeçmiş.pdf"
+	if err := store.Put(ctx, key, strings.NewReader("%PDF-1.4 içerik"), int64(len("%PDF-1.4 içerik")), "application/pdf"); err != nil {
+		t.Fatalf("Put hata döndü: %v", err)
+	}
+	rc, obj, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get hata döndü: %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "%PDF-1.4 içerik" || obj.Size != int64(len(data)) {
+		t.Errorf("okunan = %q (%d bayt), beklenen yazılan içerik", data, obj.Size)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete hata döndü: %v", err)
+	}
+	if _, _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("silinen dosya için ErrNotFound bekleniyordu, alınan: %v", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("olmayan dosyayı silmek hata döndürmemeli: %v", err)
+	}
+}
+
+func TestLocalStorageSizeMismatch(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(context.Background(), "a/b.txt", strings.NewReader("kısa"), 100, "text/plain"); err == nil {
+		t.Fatal("boyut uyuşmazlığında hata bekleniyordu")
+	}
+	if _, _, err := store.Get(context.Background(), "a/b.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("yarım kalan yükleme okunabilir olmamalı, alınan: %v", err)
+	}
+}
+
+func TestValidKey(t *testing.T) {
+	for _, key := range []string{"", "/etc/passwd", "../x", "a/../../b", "a//b", "a\\b", "a/./b"} {
+		if err := ValidKey(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("ValidKey(%q) = %v, beklenen ErrInvalidKey", key, err)
+		}
+	}
+	if err := ValidKey("forms/1/x/cv.pdf"); err != nil {
+		t.Errorf("geçerli anahtar reddedildi: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrURLExpired       = errors.New("storage: indirme bağlantısının süresi doldu")
+	ErrInvalidSignature = errors.New("storage: geçersiz indirme bağlantısı")
+)
+
+// URLSigner uygulama adreslerini HMAC-SHA256 ile imzalar. İmza, adresin yolunu ve son
+// geçerlilik zamanını kapsar; bağlantı oturum gerektirmez ama süresi dolunca geçersizdir.
+type URLSigner struct {
+	secret []byte
+}
+
+// NewURLSigner verilen gizli anahtarla imzalayıcı oluşturur.
+func NewURLSigner(secret []byte) *URLSigner {
+	return &URLSigner{secret: secret}
+}
+
+// signature yol ve geçerlilik zamanı için imzayı hesaplar.
+func (s *URLSigner) signature(path string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(path + "\n" + strconv.FormatInt(expires, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Sign yola "expires" ve "signature" sorgu parametrelerini ekler.
+func (s *URLSigner) Sign(path string, expiresAt time.Time) string {
+	expires := expiresAt.Unix()
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(expires, 10))
+	q.Set("signature", s.signature(path, expires))
+	return path + "?" + q.Encode()
+}
+
+// Verify yol için verilen imzayı ve geçerlilik zamanını doğrular.
+func (s *URLSigner) Verify(path, expires, signature string, now time.Time) error {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || signature == "" {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signature(path, exp))) {
+		return ErrInvalidSignature
+	}
+	if now.Unix() > exp {
+		return ErrURLExpired
+	}
+	return nil
+}
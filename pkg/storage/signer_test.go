@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestURLSigner(t *testing.T) {
+	signer := NewURLSigner([]byte("gizli"))
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	signed := signer.Sign("/files/forms/42", now.Add(15*time.Minute))
+
+	path, rawQuery, _ := strings.Cut(signed, "?")
+	q, _ := url.ParseQuery(rawQuery)
+	if err := signer.Verify(path, q.Get("expires"), q.Get("signature"), now); err != nil {
+		t.Fatalf("geçerli imza reddedildi: %v", err)
+	}
+	if err := signer.Verify(path, q.Get("expires"), q.Get("signature"), now.Add(16*time.Minute)); !errors.Is(err, ErrURLExpired) {
+		t.Errorf("süresi dolan bağlantı için ErrURLExpired bekleniyordu, alınan: %v", err)
+	}
+	if err := signer.Verify("/files/forms/43", q.Get("expires"), q.Get("signature"), now); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("başka dosya için imza kabul edildi: %v", err)
+	}
+	if err := signer.Verify(path, "9999999999", q.Get("signature"), now); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("değiştirilen süre kabul edildi: %v", err)
+	}
+	if err := NewURLSigner([]byte("başka")).Verify(path, q.Get("expires"), q.Get("signature"), now); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("farklı anahtarla imza kabul edildi: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+// Package storage yüklenen dosyaların saklandığı arka uçlar için ortak arayüzü tanımlar.
+// Yerel dosya sistemi (LocalStorage) ve S3 uyumlu nesne depoları (S3Storage; AWS S3,
+// MinIO, Cloudflare R2 vb.) desteklenir. Dosyalar doğrudan depodan değil, uygulamanın
+// imzalı ve süreli adresleri (URLSigner) üzerinden indirilir.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("storage: dosya bulunamadı")
+	ErrInvalidKey = errors.New("storage: geçersiz dosya anahtarı")
+)
+
+// Object depodaki bir dosyanın bilgisidir.
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string // Yerel depoda boş olabilir; kayıtlı tip veritabanında tutulur
+}
+
+// Storage dosya saklama arka ucu arayüzü. Anahtarlar "forms/12/ab12.../cv.pdf" gibi
+// eğik çizgiyle ayrılmış göreli yollardır.
+type Storage interface {
+	// Name kayıtlarda ve loglarda kullanılan arka uç adıdır (örn. "local", "s3").
+	Name() string
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// ValidKey anahtarın göreli, boş parça veya ".." içermeyen bir yol olduğunu doğrular.
+func ValidKey(key string) error {
+	if key == "" || len(key) > 500 || strings.HasPrefix(key, "/") || strings.ContainsAny(key, "\\\x00") {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// New STORAGE_DRIVER ortam değişkenine göre depo döndürür. "s3" için S3_* değişkenleri
+// okunur; boş veya tanınmayan değerde STORAGE_LOCAL_DIR (varsayılan ./storage) altındaki
+// yerel depo kullanılır.
+func New() (Storage, error) {
+	switch os.Getenv("STORAGE_DRIVER") {
+	case "s3":
+		return NewS3Storage(S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PathStyle: os.Getenv("S3_PATH_STYLE") != "false",
+		})
+	default:
+		dir := os.Getenv("STORAGE_LOCAL_DIR")
+		if dir == "" {
+			dir = "./storage"
+		}
+		return NewLocalStorage(dir)
+	}
+}
@@ -1,9 +1,13 @@
 package templatehelpers
 
 import (
+	"fmt"
 	"net/url"
+	"strings"
 	"text/template"
 	"time"
+
+	"davet.link/pkg/money"
 )
 
 func TemplateHelpers() template.FuncMap {
@@ -71,6 +75,33 @@ func TemplateHelpers() template.FuncMap {
 			}
 			return t.Format("02.01.2006 15:04")
 		},
+
+		"FormatMinutes": func(minutes int) string {
+			return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+		},
+
+		// Kuruş cinsinden tutarı "1.250,50 TRY" biçiminde gösterir
+		"FormatMoney": func(minor int64, currency string) string {
+			return money.Format(minor, currency)
+		},
+
+		// Bayt cinsinden boyutu "340 KB" veya "1,2 MB" biçiminde gösterir
+		"FormatFileSize": func(size int64) string {
+			if size < 1<<20 {
+				return fmt.Sprintf("%d KB", (size+1023)/1024)
+			}
+			return strings.Replace(fmt.Sprintf("%.1f MB", float64(size)/(1<<20)), ".", ",", 1)
+		},
+
+		// Listede değer varsa true döner (kayıtlı çoklu seçimleri işaretlemek için)
+		"Contains": func(list []string, value string) bool {
+			for _, item := range list {
+				if item == value {
+					return true
+				}
+			}
+			return false
+		},
 	}
 	return fm
 }
@@ -0,0 +1,160 @@
+// Package webhook giden webhook isteklerini imzalar ve gönderir.
+//
+// Her istek JSON gövdesiyle POST edilir. İmza başlığı "t=<unix>,v1=<hex>" biçimindedir;
+// v1, uç noktanın gizli anahtarıyla "<unix>.<gövde>" metninin HMAC-SHA256 özetidir. Alıcı
+// zamanı da kontrol ederek eski isteklerin yeniden oynatılmasını engelleyebilir.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// İstek başlıkları.
+const (
+	SignatureHeader = "X-Davet-Signature" // t=<unix>,v1=<hex HMAC-SHA256>
+	EventHeader     = "X-Davet-Event"     // Örn. form.submitted
+	DeliveryHeader  = "X-Davet-Delivery"  // Teslimat kaydının kimliği; yeniden denemelerde aynı kalır
+)
+
+// MaxResponseSize teslimat kaydında saklanmak üzere okunan yanıt gövdesinin en büyük boyutu (bayt).
+const MaxResponseSize = 4 << 10
+
+var (
+	ErrInvalidSignature = errors.New("webhook: imza geçersiz")
+	ErrSignatureExpired = errors.New("webhook: imzanın zamanı tolerans dışında")
+)
+
+// StatusError alıcının 2xx dışında bir durum koduyla yanıt verdiğini bildirir.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook: alıcı HTTP %d döndürdü", e.StatusCode)
+}
+
+// NormalizeURL adresin geçerli bir http(s) adresi olduğunu doğrular ve boşlukları temizler.
+func NormalizeURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", errors.New("webhook: geçersiz adres")
+	}
+	return u.String(), nil
+}
+
+// Sign gövde için verilen anda imza başlığının değerini üretir.
+func Sign(secret string, at time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + timestamp + ",v1=" + signature(secret, timestamp, body)
+}
+
+func signature(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify imza başlığını gövdeye ve gizli anahtara göre doğrular. tolerance sıfırdan büyükse
+// imzanın zamanı now'a en fazla bu kadar uzak olabilir. Alıcılar için örnek uygulamadır.
+func Verify(secret string, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+	expected := signature(secret, timestamp, body)
+	valid := false
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			valid = true
+		}
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	if tolerance > 0 {
+		if diff := now.Sub(time.Unix(unix, 0)); diff > tolerance || diff < -tolerance {
+			return ErrSignatureExpired
+		}
+	}
+	return nil
+}
+
+// Request gönderilecek tek bir webhook isteğidir.
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID string
+	Body       []byte
+}
+
+// Response alıcının yanıtıdır. Body en fazla MaxResponseSize bayttır.
+type Response struct {
+	StatusCode int
+	Body       string
+	Duration   time.Duration
+}
+
+// Send isteği imzalayıp POST eder. Kullanıcının girdiği adresler için client
+// safehttp.NewClient(timeout, 0) ile oluşturulmalıdır; yönlendirmeler izlenmez ve 3xx yanıt
+// başarısız teslimat sayılır. Ağ hatasında yanıt nil, 2xx dışı yanıtta yanıtla
+// birlikte *StatusError döner.
+func Send(ctx context.Context, client *http.Client, req Request, now time.Time) (*Response, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "davet.link-webhook/1.0")
+	httpReq.Header.Set(EventHeader, req.Event)
+	httpReq.Header.Set(DeliveryHeader, req.DeliveryID)
+	httpReq.Header.Set(SignatureHeader, Sign(req.Secret, now, req.Body))
+
+	started := time.Now()
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, MaxResponseSize))
+	result := &Response{
+		StatusCode: resp.StatusCode,
+		Body:       strings.ToValidUTF8(string(data), ""),
+		Duration:   time.Since(started),
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return result, &StatusError{StatusCode: resp.StatusCode}
+	}
+	return result, nil
+}
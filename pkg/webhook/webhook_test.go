@@ -0,0 +1,145 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"davet.link/pkg/safehttp"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"event":"form.submitted"}`)
+	header := Sign("gizli", now, body)
+
+	if err := Verify("gizli", header, body, now.Add(time.Minute), 5*time.Minute); err != nil {
+		t.Fatalf("geçerli imza reddedildi: %v", err)
+	}
+	if err := Verify("gizli", header, []byte(`{"event":"booking.created"}`), now, 5*time.Minute); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("değiştirilen gövde kabul edildi: %v", err)
+	}
+	if err := Verify("başka", header, body, now, 5*time.Minute); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("farklı anahtarla imza kabul edildi: %v", err)
+	}
+	if err := Verify("gizli", header, body, now.Add(10*time.Minute), 5*time.Minute); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("eski imza için ErrSignatureExpired bekleniyordu, alınan: %v", err)
+	}
+	tampered := strings.Replace(header, "t=", "t=1", 1)
+	if err := Verify("gizli", tampered, body, now, 0); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("değiştirilen zaman kabul edildi: %v", err)
+	}
+	if err := Verify("gizli", "", body, now, 0); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("boş başlık kabul edildi: %v", err)
+	}
+}
+
+func TestSendSignsRequest(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"id":"evt_1","event":"booking.cancelled"}`)
+	var received struct {
+		event, delivery, contentType string
+		err                          error
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		received.event = r.Header.Get(EventHeader)
+		received.delivery = r.Header.Get(DeliveryHeader)
+		received.contentType = r.Header.Get("Content-Type")
+		received.err = Verify("gizli", r.Header.Get(SignatureHeader), data, time.Now(), 5*time.Minute)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("tamam"))
+	}))
+	defer srv.Close()
+
+	resp, err := Send(context.Background(), srv.Client(), Request{
+		URL: srv.URL, Secret: "gizli", Event: "booking.cancelled", DeliveryID: "42", Body: body,
+	}, now)
+	if err != nil {
+		t.Fatalf("Send hata döndü: %v", err)
+	}
+	if resp.StatusCode != http.StatusAccepted || resp.Body != "tamam" {
+		t.Errorf("yanıt = %d %q, beklenen 202 \"tamam\"", resp.StatusCode, resp.Body)
+	}
+	if received.err != nil {
+		t.Errorf("alıcı imzayı doğrulayamadı: %v", received.err)
+	}
+	if received.event != "booking.cancelled" || received.delivery != "42" || received.contentType != "application/json" {
+		t.Errorf("başlıklar = %q %q %q", received.event, received.delivery, received.contentType)
+	}
+}
+
+func TestSendReportsFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/error":
+			http.Error(w, strings.Repeat("x", MaxResponseSize+100), http.StatusInternalServerError)
+		case "/redirect":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	req := Request{Secret: "gizli", Event: "form.submitted", DeliveryID: "1", Body: []byte(`{}`)}
+
+	req.URL = srv.URL + "/error"
+	resp, err := Send(ctx, srv.Client(), req, time.Now())
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("500 için StatusError bekleniyordu, alınan: %v", err)
+	}
+	if resp == nil || len(resp.Body) != MaxResponseSize {
+		t.Errorf("yanıt gövdesi %d bayta kısaltılmalıydı", MaxResponseSize)
+	}
+
+	// Webhook istemcisi yönlendirmeleri izlemez; 3xx başarısız teslimat sayılır.
+	client := safehttp.NewClient(time.Second, 0)
+	client.Transport = srv.Client().Transport
+	req.URL = srv.URL + "/redirect"
+	if _, err := Send(ctx, client, req, time.Now()); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusFound {
+		t.Errorf("yönlendirme için StatusError bekleniyordu, alınan: %v", err)
+	}
+
+	slow := srv.Client()
+	slow.Timeout = 50 * time.Millisecond
+	req.URL = srv.URL + "/slow"
+	if resp, err := Send(ctx, slow, req, time.Now()); err == nil || resp != nil {
+		t.Errorf("zaman aşımı için ağ hatası bekleniyordu, alınan: %v", err)
+	}
+}
+
+func TestPublicClientRejectsLoopback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	req := Request{URL: srv.URL, Secret: "gizli", Event: "form.submitted", DeliveryID: "1", Body: []byte(`{}`)}
+	if _, err := Send(context.Background(), safehttp.NewClient(5*time.Second, 0), req, time.Now()); err == nil {
+		t.Fatal("loopback adresine istek engellenmeliydi")
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{" https://hooks.example.com/davet ", "https://hooks.example.com/davet", false},
+		{"http://example.com/a?b=1", "http://example.com/a?b=1", false},
+		{"ftp://example.com/", "", true},
+		{"example.com/hook", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeURL(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeURL(%q) = %q, %v; beklenen %q (hata: %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
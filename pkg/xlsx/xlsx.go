@@ -0,0 +1,208 @@
+// Package xlsx tek sayfalık basit Excel (Office Open XML) dosyaları üretir. Satırlar akış
+// halinde yazılır; büyük dışa aktarımlar belleğe alınmadan oluşturulabilir.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ContentType .xlsx dosyalarının MIME tipi.
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+const (
+	maxCellLength    = 32767 // Excel'in hücre başına karakter sınırı
+	maxFormulaString = 255   // Formül içindeki metin sabitlerinin sınırı
+	maxSheetName     = 31
+)
+
+// Cell bir hücredir: metin, sayı veya bağlantı.
+type Cell struct {
+	Text   string
+	Number *float64
+	Link   string // Doluysa hücre Text'i gösteren tıklanabilir bir bağlantıdır
+}
+
+// Text metin hücresi oluşturur.
+func Text(value string) Cell {
+	return Cell{Text: value}
+}
+
+// Number sayı hücresi oluşturur.
+func Number(value float64) Cell {
+	return Cell{Number: &value}
+}
+
+// Link bağlantı hücresi oluşturur.
+func Link(url string, text string) Cell {
+	return Cell{Text: text, Link: url}
+}
+
+// Writer satırları tek bir çalışma sayfasına yazar. Close çağrılmadan dosya geçerli değildir.
+type Writer struct {
+	zw     *zip.Writer
+	sheet  *bufio.Writer
+	row    int
+	header bool
+	closed bool
+}
+
+// NewWriter sayfa adıyla yeni bir Writer oluşturur. header true ise ilk satır kalın yazılır
+// ve kaydırmada sabit kalır.
+func NewWriter(w io.Writer, sheetName string, header bool) (*Writer, error) {
+	zw := zip.NewWriter(w)
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", fmt.Sprintf(workbookXML, escape(sheetTitle(sheetName)))},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+		{"xl/styles.xml", stylesXML},
+	}
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return nil, err
+		}
+	}
+	// Sayfa en son yazılır; böylece satırlar doğrudan zip akışına gider.
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sw := &Writer{zw: zw, sheet: bufio.NewWriter(f), header: header}
+	sw.sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sw.sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if header {
+		sw.sheet.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	}
+	sw.sheet.WriteString(`<sheetData>`)
+	return sw, nil
+}
+
+// WriteRow bir satır ekler.
+func (w *Writer) WriteRow(cells []Cell) error {
+	if w.closed {
+		return errors.New("xlsx: kapalı dosyaya yazılamaz")
+	}
+	w.row++
+	style := ""
+	if w.header && w.row == 1 {
+		style = ` s="1"`
+	}
+	fmt.Fprintf(w.sheet, `<row r="%d">`, w.row)
+	for i, cell := range cells {
+		ref := ColumnName(i) + strconv.Itoa(w.row)
+		switch {
+		case cell.Number != nil:
+			fmt.Fprintf(w.sheet, `<c r="%s"%s><v>%s</v></c>`, ref, style, strconv.FormatFloat(*cell.Number, 'f', -1, 64))
+		case cell.Link != "" && utf8.RuneCountInString(cell.Link) <= maxFormulaString && utf8.RuneCountInString(cell.Text) <= maxFormulaString:
+			text := cell.Text
+			if text == "" {
+				text = cell.Link
+			}
+			formula := fmt.Sprintf(`HYPERLINK("%s","%s")`, formulaString(cell.Link), formulaString(text))
+			fmt.Fprintf(w.sheet, `<c r="%s" t="str"%s><f>%s</f><v>%s</v></c>`, ref, style, escape(formula), escape(clean(text)))
+		default:
+			text := cell.Text
+			if cell.Link != "" {
+				// Formüle sığmayan bağlantı düz metin olarak yazılır.
+				text = strings.TrimSpace(cell.Text + " " + cell.Link)
+			}
+			if text == "" {
+				continue
+			}
+			fmt.Fprintf(w.sheet, `<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">%s</t></is></c>`, ref, style, escape(clean(text)))
+		}
+	}
+	_, err := w.sheet.WriteString(`</row>`)
+	return err
+}
+
+// Close sayfayı ve zip arşivini tamamlar.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	w.sheet.WriteString(`</sheetData></worksheet>`)
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zw.Close()
+}
+
+// ColumnName sıfırdan başlayan sütun sırasını Excel adına çevirir (0 -> A, 26 -> AA).
+func ColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// sheetTitle sayfa adını Excel kurallarına uydurur.
+func sheetTitle(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return ' '
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		name = "Sayfa1"
+	}
+	if utf8.RuneCountInString(name) > maxSheetName {
+		name = string([]rune(name)[:maxSheetName])
+	}
+	return name
+}
+
+// clean XML'de geçersiz kontrol karakterlerini atar ve hücre sınırına kısaltır.
+func clean(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' || r >= 0x20 && r != 0xFFFE && r != 0xFFFF {
+			return r
+		}
+		return -1
+	}, strings.ToValidUTF8(value, ""))
+	if utf8.RuneCountInString(value) > maxCellLength {
+		value = string([]rune(value)[:maxCellLength])
+	}
+	return value
+}
+
+func formulaString(value string) string {
+	return strings.ReplaceAll(clean(value), `"`, `""`)
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "\r", "&#13;")
+
+func escape(value string) string {
+	return xmlEscaper.Replace(value)
+}
+
+const contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`
+
+const rootRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+const workbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const workbookRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`
+
+// stylesXML: 0 varsayılan, 1 kalın başlık.
+const stylesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs></styleSheet>`
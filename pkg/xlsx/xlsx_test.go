@@ -0,0 +1,95 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestColumnName(t *testing.T) {
+	cases := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for index, want := range cases {
+		if got := ColumnName(index); got != want {
+			t.Errorf("ColumnName(%d) = %q, want %q", index, got, want)
+		}
+	}
+}
+
+func TestWriterProducesValidPackage(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "Yanıtlar: 2024/05", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := [][]Cell{
+		{Text("No"), Text("Ad"), Text("Puan"), Text("Dosya")},
+		{Number(1), Text(`Ali & "Veli" <x>`), Number(4.5), Link("https://example.com/f?a=1&b=2", "cv.pdf")},
+		{Number(2), Text("satır\nsonu\x00"), Text(""), Link("https://example.com/"+strings.Repeat("x", 300), "uzun")},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(body)
+
+		// Her parça iyi biçimli XML olmalı.
+		dec := xml.NewDecoder(bytes.NewReader(body))
+		for {
+			if _, err := dec.Token(); err != nil {
+				if err != io.EOF {
+					t.Fatalf("%s geçersiz XML: %v", f.Name, err)
+				}
+				break
+			}
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("%s eksik", name)
+		}
+	}
+
+	if !strings.Contains(parts["xl/workbook.xml"], `name="Yanıtlar  2024 05"`) {
+		t.Errorf("sayfa adı temizlenmedi: %s", parts["xl/workbook.xml"])
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr" s="1">`,
+		`<c r="A2"><v>1</v></c>`,
+		`<c r="C2"><v>4.5</v></c>`,
+		`Ali &amp; &quot;Veli&quot; &lt;x&gt;`,
+		`HYPERLINK(&quot;https://example.com/f?a=1&amp;b=2&quot;,&quot;cv.pdf&quot;)`,
+		`<t xml:space="preserve">uzun https://example.com/xxx`,
+		`state="frozen"`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sayfada %q bulunamadı", want)
+		}
+	}
+	if strings.Contains(sheet, "\x00") {
+		t.Error("kontrol karakteri temizlenmedi")
+	}
+	if strings.Contains(sheet, `r="C3"`) {
+		t.Error("boş metin hücresi yazılmamalı")
+	}
+}
@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IAppointmentBookingRepository randevu rezervasyonları için veritabanı arayüzü.
+type IAppointmentBookingRepository interface {
+	Create(ctx context.Context, booking *models.AppointmentBooking) error
+	FindByID(ctx context.Context, id uint) (*models.AppointmentBooking, error)
+	FindDetailByID(ctx context.Context, id uint) (*models.AppointmentBooking, error)
+	FindBusyInRange(ctx context.Context, providerUserID uint, appointmentID uint, includeShared bool, from, to time.Time) ([]models.AppointmentBooking, error)
+	FindActiveByAppointmentInRange(ctx context.Context, appointmentID uint, from, to time.Time) ([]models.AppointmentBooking, error)
+	FindActiveBySession(ctx context.Context, appointmentID uint, startsAt time.Time) ([]models.AppointmentBooking, error)
+	FindByProviderInRange(ctx context.Context, providerUserID uint, statuses []models.BookingStatus, from, to time.Time) ([]models.AppointmentBooking, error)
+	Update(ctx context.Context, booking *models.AppointmentBooking, data map[string]interface{}) error
+}
+
+// AppointmentBookingRepository IAppointmentBookingRepository arayüzünü uygular.
+type AppointmentBookingRepository struct {
+	db *gorm.DB
+}
+
+// NewAppointmentBookingRepository yeni bir AppointmentBookingRepository örneği oluşturur.
+func NewAppointmentBookingRepository() IAppointmentBookingRepository {
+	return &AppointmentBookingRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *AppointmentBookingRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create yeni bir rezervasyon kaydı oluşturur.
+func (r *AppointmentBookingRepository) Create(ctx context.Context, booking *models.AppointmentBooking) error {
+	if booking == nil || booking.AppointmentID == 0 || booking.ProviderUserID == 0 {
+		return errors.New("geçersiz rezervasyon kaydı")
+	}
+	return r.getDB(ctx).Create(booking).Error
+}
+
+// FindByID belirli bir rezervasyonu bulur.
+func (r *AppointmentBookingRepository) FindByID(ctx context.Context, id uint) (*models.AppointmentBooking, error) {
+	if id == 0 {
+		return nil, errors.New("geçersiz Booking ID")
+	}
+	var booking models.AppointmentBooking
+	err := r.getDB(ctx).First(&booking, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("AppointmentBookingRepository.FindByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &booking, nil
+}
+
+// FindDetailByID rezervasyonu hizmet detayı, soru cevapları ve ödeme hareketleriyle birlikte getirir.
+func (r *AppointmentBookingRepository) FindDetailByID(ctx context.Context, id uint) (*models.AppointmentBooking, error) {
+	if id == 0 {
+		return nil, errors.New("geçersiz Booking ID")
+	}
+	var booking models.AppointmentBooking
+	err := r.getDB(ctx).
+		Preload("Appointment.Detail").
+		Preload("Answers", func(db *gorm.DB) *gorm.DB { return db.Order("id asc") }).
+		Preload("Payments", func(db *gorm.DB) *gorm.DB { return db.Order("paid_at asc, id asc") }).
+		First(&booking, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("AppointmentBookingRepository.FindDetailByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &booking, nil
+}
+
+// FindBusyInRange [from, to) aralığıyla kesişen aktif rezervasyonları getirir.
+// Verilen hizmetin kendi rezervasyonları her zaman döner. includeShared true ise sağlayıcının
+// paralel rezervasyona kapalı (AllowParallelBookings = false) diğer hizmetlerindeki rezervasyonlar da eklenir.
+func (r *AppointmentBookingRepository) FindBusyInRange(ctx context.Context, providerUserID uint, appointmentID uint, includeShared bool, from, to time.Time) ([]models.AppointmentBooking, error) {
+	if providerUserID == 0 || appointmentID == 0 {
+		return nil, errors.New("geçersiz Provider User ID veya Appointment ID")
+	}
+	query := r.getDB(ctx).
+		Where("provider_user_id = ? AND status IN ?", providerUserID, models.ActiveBookingStatuses).
+		Where("busy_starts_at < ? AND busy_ends_at > ?", to, from)
+	if includeShared {
+		sharedAppointments := r.getDB(ctx).Model(&models.AppointmentDetail{}).
+			Select("appointment_id").
+			Where("allow_parallel_bookings = ?", false)
+		query = query.Where("(appointment_id = ? OR appointment_id IN (?))", appointmentID, sharedAppointments)
+	} else {
+		query = query.Where("appointment_id = ?", appointmentID)
+	}
+
+	var bookings []models.AppointmentBooking
+	if err := query.Order("busy_starts_at asc").Find(&bookings).Error; err != nil {
+		configslog.Log.Error("AppointmentBookingRepository.FindBusyInRange: DB error",
+			zap.Uint("providerUserID", providerUserID), zap.Uint("appointmentID", appointmentID), zap.Error(err))
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// FindActiveByAppointmentInRange hizmetin [from, to) aralığında başlayan aktif rezervasyonlarını
+// başlangıç saati ve kayıt sırasına göre getirir.
+func (r *AppointmentBookingRepository) FindActiveByAppointmentInRange(ctx context.Context, appointmentID uint, from, to time.Time) ([]models.AppointmentBooking, error) {
+	if appointmentID == 0 {
+		return nil, errors.New("geçersiz Appointment ID")
+	}
+	var bookings []models.AppointmentBooking
+	err := r.getDB(ctx).
+		Where("appointment_id = ? AND status IN ?", appointmentID, models.ActiveBookingStatuses).
+		Where("starts_at >= ? AND starts_at < ?", from, to).
+		Order("starts_at asc, created_at asc").
+		Find(&bookings).Error
+	if err != nil {
+		configslog.Log.Error("AppointmentBookingRepository.FindActiveByAppointmentInRange: DB error", zap.Uint("appointmentID", appointmentID), zap.Error(err))
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// FindActiveBySession tek bir seansın (aynı hizmet, aynı başlangıç saati) aktif rezervasyonlarını getirir.
+func (r *AppointmentBookingRepository) FindActiveBySession(ctx context.Context, appointmentID uint, startsAt time.Time) ([]models.AppointmentBooking, error) {
+	if appointmentID == 0 {
+		return nil, errors.New("geçersiz Appointment ID")
+	}
+	var bookings []models.AppointmentBooking
+	err := r.getDB(ctx).
+		Where("appointment_id = ? AND starts_at = ? AND status IN ?", appointmentID, startsAt, models.ActiveBookingStatuses).
+		Order("created_at asc").
+		Find(&bookings).Error
+	if err != nil {
+		configslog.Log.Error("AppointmentBookingRepository.FindActiveBySession: DB error", zap.Uint("appointmentID", appointmentID), zap.Error(err))
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// FindByProviderInRange sağlayıcının tüm hizmetlerinde [from, to) aralığında başlayan, verilen
+// durumlardaki rezervasyonlarını hizmet detayları ve soru cevaplarıyla birlikte getirir.
+func (r *AppointmentBookingRepository) FindByProviderInRange(ctx context.Context, providerUserID uint, statuses []models.BookingStatus, from, to time.Time) ([]models.AppointmentBooking, error) {
+	if providerUserID == 0 {
+		return nil, errors.New("geçersiz Provider User ID")
+	}
+	var bookings []models.AppointmentBooking
+	err := r.getDB(ctx).
+		Where("provider_user_id = ? AND status IN ?", providerUserID, statuses).
+		Where("starts_at >= ? AND starts_at < ?", from, to).
+		Preload("Appointment.Detail").
+		Preload("Answers").
+		Order("starts_at asc").
+		Find(&bookings).Error
+	if err != nil {
+		configslog.Log.Error("AppointmentBookingRepository.FindByProviderInRange: DB error", zap.Uint("providerUserID", providerUserID), zap.Error(err))
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// Update rezervasyonun verilen alanlarını günceller.
+func (r *AppointmentBookingRepository) Update(ctx context.Context, booking *models.AppointmentBooking, data map[string]interface{}) error {
+	if booking == nil || booking.ID == 0 {
+		return errors.New("güncellenecek rezervasyon geçerli değil")
+	}
+	result := r.getDB(ctx).Model(booking).Updates(data)
+	if result.Error != nil {
+		configslog.Log.Error("AppointmentBookingRepository.Update: DB error", zap.Uint("id", booking.ID), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+var _ IAppointmentBookingRepository = (*AppointmentBookingRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewAppointmentBookingRepositoryTx(tx *gorm.DB) IAppointmentBookingRepository {
+	return &AppointmentBookingRepository{db: tx}
+}
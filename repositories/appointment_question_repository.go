@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IAppointmentQuestionRepository rezervasyon soruları için veritabanı arayüzü.
+type IAppointmentQuestionRepository interface {
+	FindByAppointmentID(ctx context.Context, appointmentID uint) ([]models.AppointmentQuestion, error)
+	FindByID(ctx context.Context, id uint) (*models.AppointmentQuestion, error)
+	Create(ctx context.Context, question *models.AppointmentQuestion) error
+	Delete(ctx context.Context, question *models.AppointmentQuestion, deletedByUserID uint) error
+}
+
+// AppointmentQuestionRepository IAppointmentQuestionRepository arayüzünü uygular.
+type AppointmentQuestionRepository struct {
+	db *gorm.DB
+}
+
+// NewAppointmentQuestionRepository yeni bir AppointmentQuestionRepository örneği oluşturur.
+func NewAppointmentQuestionRepository() IAppointmentQuestionRepository {
+	return &AppointmentQuestionRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *AppointmentQuestionRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// FindByAppointmentID hizmetin sorularını gösterim sırasıyla getirir.
+func (r *AppointmentQuestionRepository) FindByAppointmentID(ctx context.Context, appointmentID uint) ([]models.AppointmentQuestion, error) {
+	if appointmentID == 0 {
+		return nil, errors.New("geçersiz Appointment ID")
+	}
+	var questions []models.AppointmentQuestion
+	err := r.getDB(ctx).Where("appointment_id = ?", appointmentID).Order("sort_order asc, id asc").Find(&questions).Error
+	if err != nil {
+		configslog.Log.Error("AppointmentQuestionRepository.FindByAppointmentID: DB error", zap.Uint("appointmentID", appointmentID), zap.Error(err))
+		return nil, err
+	}
+	return questions, nil
+}
+
+// FindByID belirli bir soruyu bulur.
+func (r *AppointmentQuestionRepository) FindByID(ctx context.Context, id uint) (*models.AppointmentQuestion, error) {
+	if id == 0 {
+		return nil, errors.New("geçersiz Question ID")
+	}
+	var question models.AppointmentQuestion
+	err := r.getDB(ctx).First(&question, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("AppointmentQuestionRepository.FindByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &question, nil
+}
+
+// Create yeni bir soru oluşturur.
+func (r *AppointmentQuestionRepository) Create(ctx context.Context, question *models.AppointmentQuestion) error {
+	if question == nil || question.AppointmentID == 0 {
+		return errors.New("geçersiz soru kaydı")
+	}
+	return r.getDB(ctx).Create(question).Error
+}
+
+// Delete soruyu siler (soft delete). Önceki rezervasyonlardaki cevaplar korunur.
+func (r *AppointmentQuestionRepository) Delete(ctx context.Context, question *models.AppointmentQuestion, deletedByUserID uint) error {
+	if question == nil || question.ID == 0 {
+		return errors.New("silinecek soru geçerli değil")
+	}
+	now := time.Now().UTC()
+	updateData := map[string]interface{}{"deleted_at": now, "deleted_by": &deletedByUserID}
+	result := r.getDB(ctx).Model(question).Where("id = ? AND deleted_at IS NULL", question.ID).Updates(updateData)
+	if result.Error != nil {
+		configslog.Log.Error("AppointmentQuestionRepository.Delete: Update sırasında hata", zap.Uint("id", question.ID), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+var _ IAppointmentQuestionRepository = (*AppointmentQuestionRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewAppointmentQuestionRepositoryTx(tx *gorm.DB) IAppointmentQuestionRepository {
+	return &AppointmentQuestionRepository{db: tx}
+}
@@ -152,7 +152,7 @@ func (r *AppointmentRepository) FindAllByUserIDPaginated(ctx context.Context, pr
 		"is_enabled":       "appointments.is_enabled",
 		"name":             "appointment_details.name",
 		"duration_minutes": "appointment_details.duration_minutes",
-		"price":            "appointment_details.price",
+		"price":            "appointment_details.price_minor",
 	}
 	orderColumn := "appointments.created_at" // Varsayılan
 	if dbCol, ok := allowedSortColumns[sortBy]; ok {
@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IAvailabilityRepository haftalık kurallar, tarih istisnaları ve sağlayıcı ayarları için arayüz.
+type IAvailabilityRepository interface {
+	FindRulesByAppointmentID(ctx context.Context, appointmentID uint) ([]models.AppointmentAvailabilityRule, error)
+	ReplaceRules(ctx context.Context, appointmentID uint, rules []models.AppointmentAvailabilityRule) error
+	FindOverridesInRange(ctx context.Context, providerUserID uint, from, to time.Time) ([]models.AppointmentAvailabilityOverride, error)
+	FindOverrideByID(ctx context.Context, id uint) (*models.AppointmentAvailabilityOverride, error)
+	CreateOverride(ctx context.Context, override *models.AppointmentAvailabilityOverride) error
+	DeleteOverride(ctx context.Context, override *models.AppointmentAvailabilityOverride, deletedByUserID uint) error
+	FindProviderSetting(ctx context.Context, userID uint) (*models.ProviderSetting, error)
+	FindProviderSettingByFeedToken(ctx context.Context, token string) (*models.ProviderSetting, error)
+	SaveProviderSetting(ctx context.Context, setting *models.ProviderSetting) error
+}
+
+// AvailabilityRepository IAvailabilityRepository arayüzünü uygular.
+type AvailabilityRepository struct {
+	db *gorm.DB
+}
+
+// NewAvailabilityRepository yeni bir AvailabilityRepository örneği oluşturur.
+func NewAvailabilityRepository() IAvailabilityRepository {
+	return &AvailabilityRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *AvailabilityRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// FindRulesByAppointmentID randevu hizmetinin haftalık kurallarını gün ve saat sırasıyla getirir.
+func (r *AvailabilityRepository) FindRulesByAppointmentID(ctx context.Context, appointmentID uint) ([]models.AppointmentAvailabilityRule, error) {
+	if appointmentID == 0 {
+		return nil, errors.New("geçersiz Appointment ID")
+	}
+	var rules []models.AppointmentAvailabilityRule
+	err := r.getDB(ctx).Where("appointment_id = ?", appointmentID).
+		Order("weekday asc, start_minute asc").
+		Find(&rules).Error
+	if err != nil {
+		configslog.Log.Error("AvailabilityRepository.FindRulesByAppointmentID: DB error", zap.Uint("appointmentID", appointmentID), zap.Error(err))
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ReplaceRules randevu hizmetinin tüm haftalık kurallarını verilen liste ile değiştirir.
+// Eski kurallar kalıcı olarak silinir; kurallar geçmişe dönük iz tutmaz.
+func (r *AvailabilityRepository) ReplaceRules(ctx context.Context, appointmentID uint, rules []models.AppointmentAvailabilityRule) error {
+	if appointmentID == 0 {
+		return errors.New("geçersiz Appointment ID")
+	}
+	return r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("appointment_id = ?", appointmentID).Delete(&models.AppointmentAvailabilityRule{}).Error; err != nil {
+			configslog.Log.Error("AvailabilityRepository.ReplaceRules: Silme hatası", zap.Uint("appointmentID", appointmentID), zap.Error(err))
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		for i := range rules {
+			rules[i].AppointmentID = appointmentID
+		}
+		return tx.Create(&rules).Error // BeforeCreate hook çalışır
+	})
+}
+
+// FindOverridesInRange sağlayıcının [from, to] tarih aralığındaki tüm istisnalarını getirir.
+// Hem sağlayıcı geneli hem de hizmete özel kayıtlar döner; ayrımı servis yapar.
+func (r *AvailabilityRepository) FindOverridesInRange(ctx context.Context, providerUserID uint, from, to time.Time) ([]models.AppointmentAvailabilityOverride, error) {
+	if providerUserID == 0 {
+		return nil, errors.New("geçersiz Provider User ID")
+	}
+	var overrides []models.AppointmentAvailabilityOverride
+	err := r.getDB(ctx).Where("provider_user_id = ? AND date BETWEEN ? AND ?", providerUserID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date asc, start_minute asc").
+		Find(&overrides).Error
+	if err != nil {
+		configslog.Log.Error("AvailabilityRepository.FindOverridesInRange: DB error", zap.Uint("providerUserID", providerUserID), zap.Error(err))
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// FindOverrideByID belirli bir istisna kaydını bulur.
+func (r *AvailabilityRepository) FindOverrideByID(ctx context.Context, id uint) (*models.AppointmentAvailabilityOverride, error) {
+	if id == 0 {
+		return nil, errors.New("geçersiz Override ID")
+	}
+	var override models.AppointmentAvailabilityOverride
+	err := r.getDB(ctx).First(&override, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("AvailabilityRepository.FindOverrideByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &override, nil
+}
+
+// CreateOverride yeni bir tarih istisnası oluşturur.
+func (r *AvailabilityRepository) CreateOverride(ctx context.Context, override *models.AppointmentAvailabilityOverride) error {
+	if override == nil || override.ProviderUserID == 0 {
+		return errors.New("geçersiz istisna kaydı")
+	}
+	return r.getDB(ctx).Create(override).Error
+}
+
+// DeleteOverride istisna kaydını siler (soft delete).
+func (r *AvailabilityRepository) DeleteOverride(ctx context.Context, override *models.AppointmentAvailabilityOverride, deletedByUserID uint) error {
+	if override == nil || override.ID == 0 {
+		return errors.New("silinecek istisna geçerli değil")
+	}
+	now := time.Now().UTC()
+	updateData := map[string]interface{}{"deleted_at": now, "deleted_by": &deletedByUserID}
+	result := r.getDB(ctx).Model(override).Where("id = ? AND deleted_at IS NULL", override.ID).Updates(updateData)
+	if result.Error != nil {
+		configslog.Log.Error("AvailabilityRepository.DeleteOverride: Update sırasında hata", zap.Uint("id", override.ID), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindProviderSetting sağlayıcı ayarlarını getirir. Kayıt yoksa ErrNotFound döner.
+func (r *AvailabilityRepository) FindProviderSetting(ctx context.Context, userID uint) (*models.ProviderSetting, error) {
+	if userID == 0 {
+		return nil, errors.New("geçersiz User ID")
+	}
+	var setting models.ProviderSetting
+	err := r.getDB(ctx).Where("user_id = ?", userID).First(&setting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("AvailabilityRepository.FindProviderSetting: DB error", zap.Uint("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// FindProviderSettingByFeedToken gizli takvim besleme anahtarına göre sağlayıcı ayarını bulur.
+func (r *AvailabilityRepository) FindProviderSettingByFeedToken(ctx context.Context, token string) (*models.ProviderSetting, error) {
+	if token == "" {
+		return nil, ErrNotFound
+	}
+	var setting models.ProviderSetting
+	err := r.getDB(ctx).Where("calendar_feed_token = ?", token).First(&setting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("AvailabilityRepository.FindProviderSettingByFeedToken: DB error", zap.Error(err))
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// SaveProviderSetting sağlayıcı ayarlarını oluşturur veya günceller.
+func (r *AvailabilityRepository) SaveProviderSetting(ctx context.Context, setting *models.ProviderSetting) error {
+	if setting == nil || setting.UserID == 0 {
+		return errors.New("geçersiz sağlayıcı ayarı")
+	}
+	return r.getDB(ctx).Save(setting).Error
+}
+
+var _ IAvailabilityRepository = (*AvailabilityRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewAvailabilityRepositoryTx(tx *gorm.DB) IAvailabilityRepository {
+	return &AvailabilityRepository{db: tx}
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IBookingPaymentRepository rezervasyon ödeme hareketleri için veritabanı arayüzü.
+type IBookingPaymentRepository interface {
+	Create(ctx context.Context, payment *models.BookingPayment) error
+	FindByID(ctx context.Context, id uint) (*models.BookingPayment, error)
+	FindByBookingID(ctx context.Context, bookingID uint) ([]models.BookingPayment, error)
+}
+
+// BookingPaymentRepository IBookingPaymentRepository arayüzünü uygular.
+type BookingPaymentRepository struct {
+	db *gorm.DB
+}
+
+// NewBookingPaymentRepository yeni bir BookingPaymentRepository örneği oluşturur.
+func NewBookingPaymentRepository() IBookingPaymentRepository {
+	return &BookingPaymentRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *BookingPaymentRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create yeni bir ödeme veya iade hareketi kaydeder. Hareketler değiştirilmez ve silinmez;
+// hatalı bir giriş ters yönde yeni bir hareketle düzeltilir.
+func (r *BookingPaymentRepository) Create(ctx context.Context, payment *models.BookingPayment) error {
+	if payment == nil || payment.BookingID == 0 || payment.AmountMinor <= 0 {
+		return errors.New("geçersiz ödeme kaydı")
+	}
+	return r.getDB(ctx).Create(payment).Error
+}
+
+// FindByID belirli bir ödeme hareketini bulur.
+func (r *BookingPaymentRepository) FindByID(ctx context.Context, id uint) (*models.BookingPayment, error) {
+	if id == 0 {
+		return nil, errors.New("geçersiz Payment ID")
+	}
+	var payment models.BookingPayment
+	err := r.getDB(ctx).First(&payment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("BookingPaymentRepository.FindByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// FindByBookingID rezervasyonun tüm ödeme hareketlerini tarih sırasıyla getirir.
+func (r *BookingPaymentRepository) FindByBookingID(ctx context.Context, bookingID uint) ([]models.BookingPayment, error) {
+	if bookingID == 0 {
+		return nil, errors.New("geçersiz Booking ID")
+	}
+	var payments []models.BookingPayment
+	err := r.getDB(ctx).Where("booking_id = ?", bookingID).Order("paid_at asc, id asc").Find(&payments).Error
+	if err != nil {
+		configslog.Log.Error("BookingPaymentRepository.FindByBookingID: DB error", zap.Uint("bookingID", bookingID), zap.Error(err))
+		return nil, err
+	}
+	return payments, nil
+}
+
+var _ IBookingPaymentRepository = (*BookingPaymentRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewBookingPaymentRepositoryTx(tx *gorm.DB) IBookingPaymentRepository {
+	return &BookingPaymentRepository{db: tx}
+}
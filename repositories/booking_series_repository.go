@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IBookingSeriesRepository tekrarlayan rezervasyon serileri için veritabanı arayüzü.
+type IBookingSeriesRepository interface {
+	Create(ctx context.Context, series *models.BookingSeries) error
+	FindByID(ctx context.Context, id uint) (*models.BookingSeries, error)
+	Update(ctx context.Context, series *models.BookingSeries, data map[string]interface{}) error
+}
+
+// BookingSeriesRepository IBookingSeriesRepository arayüzünü uygular.
+type BookingSeriesRepository struct {
+	db *gorm.DB
+}
+
+// NewBookingSeriesRepository yeni bir BookingSeriesRepository örneği oluşturur.
+func NewBookingSeriesRepository() IBookingSeriesRepository {
+	return &BookingSeriesRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *BookingSeriesRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create yeni bir seri kaydı oluşturur. Tekrarlar (Bookings) ayrıca oluşturulur.
+func (r *BookingSeriesRepository) Create(ctx context.Context, series *models.BookingSeries) error {
+	if series == nil || series.AppointmentID == 0 || series.ProviderUserID == 0 {
+		return errors.New("geçersiz seri kaydı")
+	}
+	return r.getDB(ctx).Omit("Bookings").Create(series).Error
+}
+
+// FindByID seriyi hizmet detayı ve başlangıç sırasına göre tekrarlarıyla birlikte getirir.
+func (r *BookingSeriesRepository) FindByID(ctx context.Context, id uint) (*models.BookingSeries, error) {
+	if id == 0 {
+		return nil, errors.New("geçersiz Series ID")
+	}
+	var series models.BookingSeries
+	err := r.getDB(ctx).
+		Preload("Appointment.Detail").
+		Preload("Bookings", func(db *gorm.DB) *gorm.DB { return db.Order("starts_at asc") }).
+		First(&series, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("BookingSeriesRepository.FindByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &series, nil
+}
+
+// Update serinin verilen alanlarını günceller.
+func (r *BookingSeriesRepository) Update(ctx context.Context, series *models.BookingSeries, data map[string]interface{}) error {
+	if series == nil || series.ID == 0 {
+		return errors.New("güncellenecek seri geçerli değil")
+	}
+	err := r.getDB(ctx).Model(series).Updates(data).Error
+	if err != nil {
+		configslog.Log.Error("BookingSeriesRepository.Update: DB error", zap.Uint("id", series.ID), zap.Error(err))
+	}
+	return err
+}
+
+var _ IBookingSeriesRepository = (*BookingSeriesRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewBookingSeriesRepositoryTx(tx *gorm.DB) IBookingSeriesRepository {
+	return &BookingSeriesRepository{db: tx}
+}
@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause" // Lock için
+)
+
+// IBookingWaitlistRepository bekleme listesi kayıtları için veritabanı arayüzü.
+type IBookingWaitlistRepository interface {
+	Create(ctx context.Context, entry *models.BookingWaitlistEntry) error
+	FindByID(ctx context.Context, id uint) (*models.BookingWaitlistEntry, error)
+	FindByOfferToken(ctx context.Context, token string) (*models.BookingWaitlistEntry, error)
+	FindByIDForUpdate(ctx context.Context, id uint) (*models.BookingWaitlistEntry, error)
+	FindOpenForDay(ctx context.Context, appointmentID uint, date time.Time) ([]models.BookingWaitlistEntry, error)
+	FindByAppointmentFrom(ctx context.Context, appointmentID uint, from time.Time) ([]models.BookingWaitlistEntry, error)
+	FindHeldInRange(ctx context.Context, providerUserID uint, appointmentID uint, includeShared bool, from, to, now time.Time) ([]models.BookingWaitlistEntry, error)
+	FindExpiredOffers(ctx context.Context, now time.Time) ([]models.BookingWaitlistEntry, error)
+	ExpirePastDays(ctx context.Context, before time.Time) (int64, error)
+	Update(ctx context.Context, entry *models.BookingWaitlistEntry, data map[string]interface{}) error
+}
+
+// BookingWaitlistRepository IBookingWaitlistRepository arayüzünü uygular.
+type BookingWaitlistRepository struct {
+	db *gorm.DB
+}
+
+// NewBookingWaitlistRepository yeni bir BookingWaitlistRepository örneği oluşturur.
+func NewBookingWaitlistRepository() IBookingWaitlistRepository {
+	return &BookingWaitlistRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *BookingWaitlistRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create yeni bir bekleme listesi kaydı oluşturur.
+func (r *BookingWaitlistRepository) Create(ctx context.Context, entry *models.BookingWaitlistEntry) error {
+	if entry == nil || entry.AppointmentID == 0 || entry.ProviderUserID == 0 {
+		return errors.New("geçersiz bekleme listesi kaydı")
+	}
+	return r.getDB(ctx).Create(entry).Error
+}
+
+// FindByID belirli bir bekleme listesi kaydını bulur.
+func (r *BookingWaitlistRepository) FindByID(ctx context.Context, id uint) (*models.BookingWaitlistEntry, error) {
+	if id == 0 {
+		return nil, errors.New("geçersiz Waitlist ID")
+	}
+	var entry models.BookingWaitlistEntry
+	err := r.getDB(ctx).First(&entry, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("BookingWaitlistRepository.FindByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindByOfferToken gizli teklif anahtarına ait kaydı hizmet detaylarıyla getirir.
+func (r *BookingWaitlistRepository) FindByOfferToken(ctx context.Context, token string) (*models.BookingWaitlistEntry, error) {
+	if token == "" {
+		return nil, ErrNotFound
+	}
+	var entry models.BookingWaitlistEntry
+	err := r.getDB(ctx).Preload("Appointment.Detail").Where("offer_token = ?", token).First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("BookingWaitlistRepository.FindByOfferToken: DB error", zap.Error(err))
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindByIDForUpdate kaydı satır kilidiyle getirir (transaction içinde kullanılmalıdır).
+func (r *BookingWaitlistRepository) FindByIDForUpdate(ctx context.Context, id uint) (*models.BookingWaitlistEntry, error) {
+	var entry models.BookingWaitlistEntry
+	err := r.getDB(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&entry, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("BookingWaitlistRepository.FindByIDForUpdate: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindOpenForDay hizmetin verilen gündeki bekleyen ve teklif yapılmış kayıtlarını sıra (kayıt) düzeninde getirir.
+func (r *BookingWaitlistRepository) FindOpenForDay(ctx context.Context, appointmentID uint, date time.Time) ([]models.BookingWaitlistEntry, error) {
+	var entries []models.BookingWaitlistEntry
+	err := r.getDB(ctx).
+		Where("appointment_id = ? AND date = ? AND status IN ?", appointmentID, date,
+			[]models.WaitlistStatus{models.WaitlistStatusWaiting, models.WaitlistStatusOffered}).
+		Order("id asc").
+		Find(&entries).Error
+	if err != nil {
+		configslog.Log.Error("BookingWaitlistRepository.FindOpenForDay: DB error", zap.Uint("appointmentID", appointmentID), zap.Error(err))
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindByAppointmentFrom hizmetin verilen günden itibaren tüm bekleme listesi kayıtlarını gün ve sıraya göre getirir.
+func (r *BookingWaitlistRepository) FindByAppointmentFrom(ctx context.Context, appointmentID uint, from time.Time) ([]models.BookingWaitlistEntry, error) {
+	if appointmentID == 0 {
+		return nil, errors.New("geçersiz Appointment ID")
+	}
+	var entries []models.BookingWaitlistEntry
+	err := r.getDB(ctx).
+		Where("appointment_id = ? AND date >= ?", appointmentID, from).
+		Order("date asc, id asc").
+		Find(&entries).Error
+	if err != nil {
+		configslog.Log.Error("BookingWaitlistRepository.FindByAppointmentFrom: DB error", zap.Uint("appointmentID", appointmentID), zap.Error(err))
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindHeldInRange [from, to) aralığıyla kesişen ve süresi dolmamış teklif tutmalarını getirir.
+// Kapsam AppointmentBookingRepository.FindBusyInRange ile aynıdır.
+func (r *BookingWaitlistRepository) FindHeldInRange(ctx context.Context, providerUserID uint, appointmentID uint, includeShared bool, from, to, now time.Time) ([]models.BookingWaitlistEntry, error) {
+	query := r.getDB(ctx).
+		Where("provider_user_id = ? AND status = ? AND offer_expires_at > ?", providerUserID, models.WaitlistStatusOffered, now).
+		Where("offer_busy_starts_at < ? AND offer_busy_ends_at > ?", to, from)
+	if includeShared {
+		sharedAppointments := r.getDB(ctx).Model(&models.AppointmentDetail{}).
+			Select("appointment_id").
+			Where("allow_parallel_bookings = ?", false)
+		query = query.Where("(appointment_id = ? OR appointment_id IN (?))", appointmentID, sharedAppointments)
+	} else {
+		query = query.Where("appointment_id = ?", appointmentID)
+	}
+
+	var entries []models.BookingWaitlistEntry
+	if err := query.Order("offer_busy_starts_at asc").Find(&entries).Error; err != nil {
+		configslog.Log.Error("BookingWaitlistRepository.FindHeldInRange: DB error",
+			zap.Uint("providerUserID", providerUserID), zap.Uint("appointmentID", appointmentID), zap.Error(err))
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindExpiredOffers kabul süresi dolmuş ama hâlâ teklif durumundaki kayıtları getirir.
+func (r *BookingWaitlistRepository) FindExpiredOffers(ctx context.Context, now time.Time) ([]models.BookingWaitlistEntry, error) {
+	var entries []models.BookingWaitlistEntry
+	err := r.getDB(ctx).
+		Where("status = ? AND offer_expires_at <= ?", models.WaitlistStatusOffered, now).
+		Order("id asc").
+		Find(&entries).Error
+	if err != nil {
+		configslog.Log.Error("BookingWaitlistRepository.FindExpiredOffers: DB error", zap.Error(err))
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ExpirePastDays günü geçmiş bekleyen kayıtları toplu olarak expired durumuna çeker.
+// Arka plan işinden çağrıldığı için aktör yoktur; BaseModel hook'ları atlanır.
+func (r *BookingWaitlistRepository) ExpirePastDays(ctx context.Context, before time.Time) (int64, error) {
+	result := r.getDB(ctx).Model(&models.BookingWaitlistEntry{}).
+		Where("status = ? AND date < ?", models.WaitlistStatusWaiting, before).
+		UpdateColumns(map[string]interface{}{"status": models.WaitlistStatusExpired, "updated_at": time.Now().UTC()})
+	if result.Error != nil {
+		configslog.Log.Error("BookingWaitlistRepository.ExpirePastDays: DB error", zap.Error(result.Error))
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// Update bekleme listesi kaydının verilen alanlarını günceller.
+func (r *BookingWaitlistRepository) Update(ctx context.Context, entry *models.BookingWaitlistEntry, data map[string]interface{}) error {
+	if entry == nil || entry.ID == 0 {
+		return errors.New("güncellenecek bekleme listesi kaydı geçerli değil")
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	err := r.getDB(ctx).Model(entry).Updates(data).Error
+	if err != nil {
+		configslog.Log.Error("BookingWaitlistRepository.Update: DB error", zap.Uint("id", entry.ID), zap.Error(err))
+	}
+	return err
+}
+
+var _ IBookingWaitlistRepository = (*BookingWaitlistRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewBookingWaitlistRepositoryTx(tx *gorm.DB) IBookingWaitlistRepository {
+	return &BookingWaitlistRepository{db: tx}
+}
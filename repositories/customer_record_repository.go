@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause" // Lock için
+)
+
+// ICustomerRecordRepository müşteri katılım kayıtları için veritabanı arayüzü.
+type ICustomerRecordRepository interface {
+	FindByID(ctx context.Context, id uint) (*models.CustomerRecord, error)
+	FindByProvider(ctx context.Context, providerUserID uint) ([]models.CustomerRecord, error)
+	FindByContactKeys(ctx context.Context, providerUserID uint, keys []string) ([]models.CustomerRecord, error)
+	FindByContactKeyForUpdate(ctx context.Context, providerUserID uint, key string) (*models.CustomerRecord, error)
+	Create(ctx context.Context, record *models.CustomerRecord) error
+	Update(ctx context.Context, record *models.CustomerRecord, data map[string]interface{}) error
+}
+
+// CustomerRecordRepository ICustomerRecordRepository arayüzünü uygular.
+type CustomerRecordRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomerRecordRepository yeni bir CustomerRecordRepository örneği oluşturur.
+func NewCustomerRecordRepository() ICustomerRecordRepository {
+	return &CustomerRecordRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *CustomerRecordRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// FindByID belirli bir müşteri kaydını bulur.
+func (r *CustomerRecordRepository) FindByID(ctx context.Context, id uint) (*models.CustomerRecord, error) {
+	if id == 0 {
+		return nil, errors.New("geçersiz Customer ID")
+	}
+	var record models.CustomerRecord
+	err := r.getDB(ctx).First(&record, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("CustomerRecordRepository.FindByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &record, nil
+}
+
+// FindByProvider sağlayıcının müşteri kayıtlarını en çok gelmeyenden başlayarak listeler.
+func (r *CustomerRecordRepository) FindByProvider(ctx context.Context, providerUserID uint) ([]models.CustomerRecord, error) {
+	if providerUserID == 0 {
+		return nil, errors.New("geçersiz User ID")
+	}
+	var records []models.CustomerRecord
+	err := r.getDB(ctx).Where("provider_user_id = ?", providerUserID).
+		Order("blocked desc, no_show_count desc, updated_at desc").
+		Find(&records).Error
+	if err != nil {
+		configslog.Log.Error("CustomerRecordRepository.FindByProvider: DB error", zap.Uint("providerUserID", providerUserID), zap.Error(err))
+		return nil, err
+	}
+	return records, nil
+}
+
+// FindByContactKeys verilen iletişim anahtarlarına ait kayıtları getirir.
+func (r *CustomerRecordRepository) FindByContactKeys(ctx context.Context, providerUserID uint, keys []string) ([]models.CustomerRecord, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	var records []models.CustomerRecord
+	err := r.getDB(ctx).Where("provider_user_id = ? AND contact_key IN ?", providerUserID, keys).Find(&records).Error
+	if err != nil {
+		configslog.Log.Error("CustomerRecordRepository.FindByContactKeys: DB error", zap.Uint("providerUserID", providerUserID), zap.Error(err))
+		return nil, err
+	}
+	return records, nil
+}
+
+// FindByContactKeyForUpdate kaydı satır kilidiyle getirir (transaction içinde kullanılmalıdır).
+// Kayıt yoksa ErrNotFound döner.
+func (r *CustomerRecordRepository) FindByContactKeyForUpdate(ctx context.Context, providerUserID uint, key string) (*models.CustomerRecord, error) {
+	var record models.CustomerRecord
+	err := r.getDB(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("provider_user_id = ? AND contact_key = ?", providerUserID, key).
+		First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("CustomerRecordRepository.FindByContactKeyForUpdate: DB error", zap.Uint("providerUserID", providerUserID), zap.Error(err))
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Create yeni bir müşteri kaydı oluşturur.
+func (r *CustomerRecordRepository) Create(ctx context.Context, record *models.CustomerRecord) error {
+	if record == nil || record.ProviderUserID == 0 || record.ContactKey == "" {
+		return errors.New("geçersiz müşteri kaydı")
+	}
+	return r.getDB(ctx).Create(record).Error
+}
+
+// Update müşteri kaydının verilen alanlarını günceller.
+func (r *CustomerRecordRepository) Update(ctx context.Context, record *models.CustomerRecord, data map[string]interface{}) error {
+	if record == nil || record.ID == 0 {
+		return errors.New("güncellenecek müşteri kaydı geçerli değil")
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	err := r.getDB(ctx).Model(record).Updates(data).Error
+	if err != nil {
+		configslog.Log.Error("CustomerRecordRepository.Update: DB error", zap.Uint("id", record.ID), zap.Error(err))
+	}
+	return err
+}
+
+var _ ICustomerRecordRepository = (*CustomerRecordRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewCustomerRecordRepositoryTx(tx *gorm.DB) ICustomerRecordRepository {
+	return &CustomerRecordRepository{db: tx}
+}
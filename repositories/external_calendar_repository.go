@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IExternalCalendarRepository dış takvimler ve meşgul blokları için veritabanı arayüzü.
+type IExternalCalendarRepository interface {
+	FindByUserID(ctx context.Context, userID uint) ([]models.ExternalCalendar, error)
+	FindByID(ctx context.Context, id uint) (*models.ExternalCalendar, error)
+	FindAll(ctx context.Context) ([]models.ExternalCalendar, error)
+	Create(ctx context.Context, calendar *models.ExternalCalendar) error
+	Update(ctx context.Context, calendar *models.ExternalCalendar, data map[string]interface{}) error
+	Delete(ctx context.Context, calendar *models.ExternalCalendar, deletedByUserID uint) error
+	ReplaceBlocks(ctx context.Context, calendar *models.ExternalCalendar, blocks []models.ExternalBusyBlock) error
+	FindBlocksInRange(ctx context.Context, userID uint, from, to time.Time) ([]models.ExternalBusyBlock, error)
+}
+
+// ExternalCalendarRepository IExternalCalendarRepository arayüzünü uygular.
+type ExternalCalendarRepository struct {
+	db *gorm.DB
+}
+
+// NewExternalCalendarRepository yeni bir ExternalCalendarRepository örneği oluşturur.
+func NewExternalCalendarRepository() IExternalCalendarRepository {
+	return &ExternalCalendarRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *ExternalCalendarRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// FindByUserID kullanıcının dış takvimlerini getirir. Content alanı listede yüklenmez.
+func (r *ExternalCalendarRepository) FindByUserID(ctx context.Context, userID uint) ([]models.ExternalCalendar, error) {
+	if userID == 0 {
+		return nil, errors.New("geçersiz User ID")
+	}
+	var calendars []models.ExternalCalendar
+	err := r.getDB(ctx).Omit("content").Where("user_id = ?", userID).Order("created_at asc").Find(&calendars).Error
+	if err != nil {
+		configslog.Log.Error("ExternalCalendarRepository.FindByUserID: DB error", zap.Uint("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return calendars, nil
+}
+
+// FindByID belirli bir dış takvimi bulur.
+func (r *ExternalCalendarRepository) FindByID(ctx context.Context, id uint) (*models.ExternalCalendar, error) {
+	if id == 0 {
+		return nil, errors.New("geçersiz External Calendar ID")
+	}
+	var calendar models.ExternalCalendar
+	err := r.getDB(ctx).First(&calendar, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("ExternalCalendarRepository.FindByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &calendar, nil
+}
+
+// FindAll tüm dış takvimleri getirir (arka plan yenileme işi için).
+func (r *ExternalCalendarRepository) FindAll(ctx context.Context) ([]models.ExternalCalendar, error) {
+	var calendars []models.ExternalCalendar
+	if err := r.getDB(ctx).Order("id asc").Find(&calendars).Error; err != nil {
+		configslog.Log.Error("ExternalCalendarRepository.FindAll: DB error", zap.Error(err))
+		return nil, err
+	}
+	return calendars, nil
+}
+
+// Create yeni bir dış takvim kaydı oluşturur.
+func (r *ExternalCalendarRepository) Create(ctx context.Context, calendar *models.ExternalCalendar) error {
+	if calendar == nil || calendar.UserID == 0 {
+		return errors.New("geçersiz dış takvim kaydı")
+	}
+	return r.getDB(ctx).Create(calendar).Error
+}
+
+// Update dış takvimin verilen alanlarını günceller.
+func (r *ExternalCalendarRepository) Update(ctx context.Context, calendar *models.ExternalCalendar, data map[string]interface{}) error {
+	if calendar == nil || calendar.ID == 0 {
+		return errors.New("güncellenecek dış takvim geçerli değil")
+	}
+	return r.getDB(ctx).Model(calendar).Updates(data).Error
+}
+
+// Delete dış takvimi siler (soft delete) ve meşgul bloklarını kalıcı olarak temizler.
+func (r *ExternalCalendarRepository) Delete(ctx context.Context, calendar *models.ExternalCalendar, deletedByUserID uint) error {
+	if calendar == nil || calendar.ID == 0 {
+		return errors.New("silinecek dış takvim geçerli değil")
+	}
+	return r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("external_calendar_id = ?", calendar.ID).Delete(&models.ExternalBusyBlock{}).Error; err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		updateData := map[string]interface{}{"deleted_at": now, "deleted_by": &deletedByUserID}
+		result := tx.Model(calendar).Where("id = ? AND deleted_at IS NULL", calendar.ID).Updates(updateData)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// ReplaceBlocks takvimin tüm meşgul bloklarını verilen liste ile değiştirir.
+// Bloklar her senkronizasyonda yeniden üretildiğinden eski kayıtlar kalıcı olarak silinir.
+func (r *ExternalCalendarRepository) ReplaceBlocks(ctx context.Context, calendar *models.ExternalCalendar, blocks []models.ExternalBusyBlock) error {
+	if calendar == nil || calendar.ID == 0 {
+		return errors.New("geçersiz dış takvim")
+	}
+	return r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("external_calendar_id = ?", calendar.ID).Delete(&models.ExternalBusyBlock{}).Error; err != nil {
+			configslog.Log.Error("ExternalCalendarRepository.ReplaceBlocks: Silme hatası", zap.Uint("calendarID", calendar.ID), zap.Error(err))
+			return err
+		}
+		if len(blocks) == 0 {
+			return nil
+		}
+		for i := range blocks {
+			blocks[i].ExternalCalendarID = calendar.ID
+			blocks[i].UserID = calendar.UserID
+		}
+		return tx.CreateInBatches(&blocks, 500).Error // BeforeCreate hook çalışır
+	})
+}
+
+// FindBlocksInRange kullanıcının [from, to) aralığıyla kesişen dış meşgul bloklarını getirir.
+func (r *ExternalCalendarRepository) FindBlocksInRange(ctx context.Context, userID uint, from, to time.Time) ([]models.ExternalBusyBlock, error) {
+	if userID == 0 {
+		return nil, errors.New("geçersiz User ID")
+	}
+	var blocks []models.ExternalBusyBlock
+	err := r.getDB(ctx).
+		Where("user_id = ? AND starts_at < ? AND ends_at > ?", userID, to, from).
+		Order("starts_at asc").
+		Find(&blocks).Error
+	if err != nil {
+		configslog.Log.Error("ExternalCalendarRepository.FindBlocksInRange: DB error", zap.Uint("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return blocks, nil
+}
+
+var _ IExternalCalendarRepository = (*ExternalCalendarRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewExternalCalendarRepositoryTx(tx *gorm.DB) IExternalCalendarRepository {
+	return &ExternalCalendarRepository{db: tx}
+}
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IFormAnalyticsRepository form analizleri için sayaçlar ve SQL toplamaları arayüzü.
+// Toplamalar gönderimleri belleğe almadan veritabanında hesaplanır; from dahil, to hariçtir.
+type IFormAnalyticsRepository interface {
+	IncrementView(ctx context.Context, formID uint, day time.Time) error
+	IncrementPageReached(ctx context.Context, formID uint, breakID uint, day time.Time) error
+	DailyViews(ctx context.Context, formID uint, fromDay, toDay time.Time) ([]models.FormDailyCount, error)
+	DailySubmissions(ctx context.Context, formID uint, from, to time.Time, utcOffset int) ([]models.FormDailyCount, error)
+	AnswerCounts(ctx context.Context, formID uint, fieldIDs []uint, from, to time.Time) ([]models.FormAnswerCount, error)
+	AnsweredCounts(ctx context.Context, formID uint, fieldIDs []uint, from, to time.Time) ([]models.FormAnswerCount, error)
+	NumericStats(ctx context.Context, formID uint, fieldIDs []uint, from, to time.Time) ([]models.FormNumericStat, error)
+	PageReached(ctx context.Context, formID uint, fromDay, toDay time.Time) ([]models.FormPageCount, error)
+}
+
+// FormAnalyticsRepository IFormAnalyticsRepository arayüzünü uygular.
+type FormAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewFormAnalyticsRepository yeni bir FormAnalyticsRepository örneği oluşturur.
+func NewFormAnalyticsRepository() IFormAnalyticsRepository {
+	return &FormAnalyticsRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *FormAnalyticsRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// IncrementView formun günlük görüntülenme sayacını tek sorguda (upsert) bir artırır.
+func (r *FormAnalyticsRepository) IncrementView(ctx context.Context, formID uint, day time.Time) error {
+	stat := models.FormViewStat{FormID: formID, Day: day, Views: 1}
+	return r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "form_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"views":      gorm.Expr("form_view_stats.views + 1"),
+			"updated_at": time.Now().UTC(),
+		}),
+	}).Create(&stat).Error
+}
+
+// IncrementPageReached sayfaya ulaşanların günlük sayacını tek sorguda (upsert) bir artırır.
+func (r *FormAnalyticsRepository) IncrementPageReached(ctx context.Context, formID uint, breakID uint, day time.Time) error {
+	stat := models.FormPageStat{FormID: formID, BreakID: breakID, Day: day, Reached: 1}
+	return r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "form_id"}, {Name: "break_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"reached":    gorm.Expr("form_page_stats.reached + 1"),
+			"updated_at": time.Now().UTC(),
+		}),
+	}).Create(&stat).Error
+}
+
+// DailyViews gün aralığındaki görüntülenme sayılarını döndürür.
+func (r *FormAnalyticsRepository) DailyViews(ctx context.Context, formID uint, fromDay, toDay time.Time) ([]models.FormDailyCount, error) {
+	var counts []models.FormDailyCount
+	err := r.getDB(ctx).Model(&models.FormViewStat{}).
+		Select("day, views AS count").
+		Where("form_id = ? AND day >= ? AND day < ?", formID, fromDay, toDay).
+		Order("day asc").Scan(&counts).Error
+	if err != nil {
+		configslog.Log.Error("FormAnalyticsRepository.DailyViews: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return counts, nil
+}
+
+// DailySubmissions gönderimleri güne göre sayar. Gün, utcOffset saniye kaydırılmış yerel
+// saate göre belirlenir; veritabanının saat dilimi ayarından bağımsızdır.
+func (r *FormAnalyticsRepository) DailySubmissions(ctx context.Context, formID uint, from, to time.Time, utcOffset int) ([]models.FormDailyCount, error) {
+	var counts []models.FormDailyCount
+	err := r.getDB(ctx).Model(&models.FormSubmission{}).
+		Select("((submitted_at AT TIME ZONE 'UTC') + ? * INTERVAL '1 second')::date AS day, COUNT(*) AS count", utcOffset).
+		Where("form_id = ? AND submitted_at >= ? AND submitted_at < ?", formID, from, to).
+		Group("day").Order("day asc").Scan(&counts).Error
+	if err != nil {
+		configslog.Log.Error("FormAnalyticsRepository.DailySubmissions: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return counts, nil
+}
+
+// answersInRange aralıktaki gönderimlerin verilen alanlara ait cevaplarını seçen sorgu.
+func (r *FormAnalyticsRepository) answersInRange(ctx context.Context, formID uint, fieldIDs []uint, from, to time.Time) *gorm.DB {
+	return r.getDB(ctx).Table("form_submission_answers AS a").
+		Joins("JOIN form_submissions AS s ON s.id = a.submission_id AND s.deleted_at IS NULL").
+		Where("s.form_id = ? AND s.submitted_at >= ? AND s.submitted_at < ?", formID, from, to).
+		Where("a.deleted_at IS NULL AND a.field_id IN ?", fieldIDs)
+}
+
+// AnswerCounts seçim ve puan alanlarında her değerin kaç gönderimde seçildiğini sayar.
+// Çoklu seçim cevapları satırlara ayrılarak her seçenek ayrı sayılır.
+func (r *FormAnalyticsRepository) AnswerCounts(ctx context.Context, formID uint, fieldIDs []uint, from, to time.Time) ([]models.FormAnswerCount, error) {
+	var counts []models.FormAnswerCount
+	if len(fieldIDs) == 0 {
+		return counts, nil
+	}
+	err := r.answersInRange(ctx, formID, fieldIDs, from, to).
+		Joins("CROSS JOIN LATERAL unnest(string_to_array(a.value, E'\\n')) AS v(value)").
+		Select("a.field_id, TRIM(v.value) AS value, COUNT(*) AS count").
+		Where("TRIM(v.value) <> ''").
+		Group("a.field_id, TRIM(v.value)").Order("a.field_id, count desc").
+		Scan(&counts).Error
+	if err != nil {
+		configslog.Log.Error("FormAnalyticsRepository.AnswerCounts: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return counts, nil
+}
+
+// AnsweredCounts her alanı boş bırakmadan cevaplayan gönderim sayısını döndürür.
+func (r *FormAnalyticsRepository) AnsweredCounts(ctx context.Context, formID uint, fieldIDs []uint, from, to time.Time) ([]models.FormAnswerCount, error) {
+	var counts []models.FormAnswerCount
+	if len(fieldIDs) == 0 {
+		return counts, nil
+	}
+	err := r.answersInRange(ctx, formID, fieldIDs, from, to).
+		Select("a.field_id, COUNT(DISTINCT a.submission_id) AS count").
+		Where("a.value <> ''").
+		Group("a.field_id").Scan(&counts).Error
+	if err != nil {
+		configslog.Log.Error("FormAnalyticsRepository.AnsweredCounts: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return counts, nil
+}
+
+// NumericStats sayı ve puan alanlarının ortalama, en küçük ve en büyük değerlerini hesaplar.
+func (r *FormAnalyticsRepository) NumericStats(ctx context.Context, formID uint, fieldIDs []uint, from, to time.Time) ([]models.FormNumericStat, error) {
+	var stats []models.FormNumericStat
+	if len(fieldIDs) == 0 {
+		return stats, nil
+	}
+	err := r.answersInRange(ctx, formID, fieldIDs, from, to).
+		Select("a.field_id, COUNT(a.number_value) AS count, AVG(a.number_value) AS average, MIN(a.number_value) AS minimum, MAX(a.number_value) AS maximum").
+		Where("a.number_value IS NOT NULL").
+		Group("a.field_id").Scan(&stats).Error
+	if err != nil {
+		configslog.Log.Error("FormAnalyticsRepository.NumericStats: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return stats, nil
+}
+
+// PageReached gün aralığında her sayfaya ulaşanların toplamını döndürür.
+func (r *FormAnalyticsRepository) PageReached(ctx context.Context, formID uint, fromDay, toDay time.Time) ([]models.FormPageCount, error) {
+	var counts []models.FormPageCount
+	err := r.getDB(ctx).Model(&models.FormPageStat{}).
+		Select("break_id, SUM(reached) AS count").
+		Where("form_id = ? AND day >= ? AND day < ?", formID, fromDay, toDay).
+		Group("break_id").Scan(&counts).Error
+	if err != nil {
+		configslog.Log.Error("FormAnalyticsRepository.PageReached: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return counts, nil
+}
+
+var _ IFormAnalyticsRepository = (*FormAnalyticsRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewFormAnalyticsRepositoryTx(tx *gorm.DB) IFormAnalyticsRepository {
+	return &FormAnalyticsRepository{db: tx}
+}
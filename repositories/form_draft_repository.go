@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IFormDraftRepository form taslakları için veritabanı arayüzü.
+type IFormDraftRepository interface {
+	FindByToken(ctx context.Context, formID uint, token string) (*models.FormDraft, error)
+	Create(ctx context.Context, draft *models.FormDraft) error
+	Update(ctx context.Context, draft *models.FormDraft, data map[string]interface{}) error
+	Delete(ctx context.Context, draft *models.FormDraft) error
+	FindStale(ctx context.Context, savedBefore time.Time, limit int) ([]models.FormDraft, error)
+}
+
+// FormDraftRepository IFormDraftRepository arayüzünü uygular.
+type FormDraftRepository struct {
+	db *gorm.DB
+}
+
+// NewFormDraftRepository yeni bir FormDraftRepository örneği oluşturur.
+func NewFormDraftRepository() IFormDraftRepository {
+	return &FormDraftRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *FormDraftRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// FindByToken formun devam anahtarıyla taslağını bulur.
+func (r *FormDraftRepository) FindByToken(ctx context.Context, formID uint, token string) (*models.FormDraft, error) {
+	if formID == 0 || token == "" {
+		return nil, ErrNotFound
+	}
+	var draft models.FormDraft
+	err := r.getDB(ctx).Where("form_id = ? AND token = ?", formID, token).First(&draft).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("FormDraftRepository.FindByToken: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// Create yeni bir taslak oluşturur.
+func (r *FormDraftRepository) Create(ctx context.Context, draft *models.FormDraft) error {
+	if draft == nil || draft.FormID == 0 || draft.Token == "" {
+		return errors.New("geçersiz form taslağı")
+	}
+	return r.getDB(ctx).Create(draft).Error
+}
+
+// Update taslağın verilen sütunlarını günceller.
+func (r *FormDraftRepository) Update(ctx context.Context, draft *models.FormDraft, data map[string]interface{}) error {
+	if draft == nil || draft.ID == 0 {
+		return errors.New("güncellenecek form taslağı geçerli değil")
+	}
+	result := r.getDB(ctx).Model(draft).Updates(data)
+	if result.Error != nil {
+		configslog.Log.Error("FormDraftRepository.Update: DB error", zap.Uint("id", draft.ID), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Delete taslağı kalıcı olarak siler. Taslaklar kişisel veri içerdiğinden soft delete kullanılmaz.
+func (r *FormDraftRepository) Delete(ctx context.Context, draft *models.FormDraft) error {
+	if draft == nil || draft.ID == 0 {
+		return errors.New("silinecek form taslağı geçerli değil")
+	}
+	return r.getDB(ctx).Unscoped().Delete(&models.FormDraft{}, draft.ID).Error
+}
+
+// FindStale en son verilen zamandan önce kaydedilmiş (terk edilmiş) taslakları getirir.
+func (r *FormDraftRepository) FindStale(ctx context.Context, savedBefore time.Time, limit int) ([]models.FormDraft, error) {
+	var drafts []models.FormDraft
+	err := r.getDB(ctx).Where("last_saved_at < ?", savedBefore).Order("id asc").Limit(limit).Find(&drafts).Error
+	if err != nil {
+		configslog.Log.Error("FormDraftRepository.FindStale: DB error", zap.Error(err))
+		return nil, err
+	}
+	return drafts, nil
+}
+
+var _ IFormDraftRepository = (*FormDraftRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewFormDraftRepositoryTx(tx *gorm.DB) IFormDraftRepository {
+	return &FormDraftRepository{db: tx}
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IFormExportScheduleRepository zamanlanmış dışa aktarımlar için veritabanı arayüzü.
+type IFormExportScheduleRepository interface {
+	FindByFormID(ctx context.Context, formID uint) (*models.FormExportSchedule, error)
+	Create(ctx context.Context, schedule *models.FormExportSchedule) error
+	Update(ctx context.Context, schedule *models.FormExportSchedule, data map[string]interface{}) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.FormExportSchedule, error)
+}
+
+// FormExportScheduleRepository IFormExportScheduleRepository arayüzünü uygular.
+type FormExportScheduleRepository struct {
+	db *gorm.DB
+}
+
+// NewFormExportScheduleRepository yeni bir FormExportScheduleRepository örneği oluşturur.
+func NewFormExportScheduleRepository() IFormExportScheduleRepository {
+	return &FormExportScheduleRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *FormExportScheduleRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// FindByFormID formun dışa aktarım zamanlamasını getirir.
+func (r *FormExportScheduleRepository) FindByFormID(ctx context.Context, formID uint) (*models.FormExportSchedule, error) {
+	if formID == 0 {
+		return nil, ErrNotFound
+	}
+	var schedule models.FormExportSchedule
+	err := r.getDB(ctx).Where("form_id = ?", formID).First(&schedule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("FormExportScheduleRepository.FindByFormID: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// Create yeni bir zamanlama oluşturur.
+func (r *FormExportScheduleRepository) Create(ctx context.Context, schedule *models.FormExportSchedule) error {
+	if schedule == nil || schedule.FormID == 0 {
+		return errors.New("geçersiz dışa aktarım zamanlaması")
+	}
+	return r.getDB(ctx).Create(schedule).Error
+}
+
+// Update zamanlamanın verilen sütunlarını günceller.
+func (r *FormExportScheduleRepository) Update(ctx context.Context, schedule *models.FormExportSchedule, data map[string]interface{}) error {
+	if schedule == nil || schedule.ID == 0 {
+		return errors.New("güncellenecek dışa aktarım zamanlaması geçerli değil")
+	}
+	result := r.getDB(ctx).Model(schedule).Updates(data)
+	if result.Error != nil {
+		configslog.Log.Error("FormExportScheduleRepository.Update: DB error", zap.Uint("id", schedule.ID), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ClaimDue zamanı gelmiş etkin zamanlamaları alır ve lease süresince tekrar alınmaması için
+// çalıştırma zamanını ileri çeker. Dönen kayıtlarda NextRunAt, ileri çekilmeden önceki
+// (işlenecek) zamandır.
+func (r *FormExportScheduleRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.FormExportSchedule, error) {
+	var schedules []models.FormExportSchedule
+	err := r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("is_enabled = ? AND next_run_at <= ?", true, now).
+			Order("next_run_at asc").Limit(limit).Find(&schedules).Error
+		if err != nil || len(schedules) == 0 {
+			return err
+		}
+		ids := make([]uint, len(schedules))
+		for i := range schedules {
+			ids[i] = schedules[i].ID
+		}
+		// Yalnızca kilit amaçlı: hook'lar (ve aktör) gerekmediğinden UpdateColumn kullanılır.
+		return tx.Model(&models.FormExportSchedule{}).Where("id IN ?", ids).UpdateColumn("next_run_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		configslog.Log.Error("FormExportScheduleRepository.ClaimDue: DB error", zap.Error(err))
+		return nil, err
+	}
+	return schedules, nil
+}
+
+var _ IFormExportScheduleRepository = (*FormExportScheduleRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewFormExportScheduleRepositoryTx(tx *gorm.DB) IFormExportScheduleRepository {
+	return &FormExportScheduleRepository{db: tx}
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IFormFieldRepository form alan tanımları için veritabanı arayüzü.
+type IFormFieldRepository interface {
+	FindByFormID(ctx context.Context, formID uint) ([]models.FormFieldDefinition, error)
+	FindByID(ctx context.Context, id uint) (*models.FormFieldDefinition, error)
+	Create(ctx context.Context, field *models.FormFieldDefinition) error
+	Update(ctx context.Context, field *models.FormFieldDefinition, data map[string]interface{}) error
+	Delete(ctx context.Context, field *models.FormFieldDefinition, deletedByUserID uint) error
+}
+
+// FormFieldRepository IFormFieldRepository arayüzünü uygular.
+type FormFieldRepository struct {
+	db *gorm.DB
+}
+
+// NewFormFieldRepository yeni bir FormFieldRepository örneği oluşturur.
+func NewFormFieldRepository() IFormFieldRepository {
+	return &FormFieldRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *FormFieldRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// FindByFormID formun alanlarını gösterim sırasıyla getirir.
+func (r *FormFieldRepository) FindByFormID(ctx context.Context, formID uint) ([]models.FormFieldDefinition, error) {
+	if formID == 0 {
+		return nil, errors.New("geçersiz Form ID")
+	}
+	var fields []models.FormFieldDefinition
+	err := r.getDB(ctx).Where("form_id = ?", formID).Order("sort_order asc, id asc").Find(&fields).Error
+	if err != nil {
+		configslog.Log.Error("FormFieldRepository.FindByFormID: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return fields, nil
+}
+
+// FindByID belirli bir alan tanımını bulur.
+func (r *FormFieldRepository) FindByID(ctx context.Context, id uint) (*models.FormFieldDefinition, error) {
+	if id == 0 {
+		return nil, errors.New("geçersiz Field ID")
+	}
+	var field models.FormFieldDefinition
+	err := r.getDB(ctx).First(&field, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("FormFieldRepository.FindByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &field, nil
+}
+
+// Create yeni bir alan tanımı oluşturur.
+func (r *FormFieldRepository) Create(ctx context.Context, field *models.FormFieldDefinition) error {
+	if field == nil || field.FormID == 0 {
+		return errors.New("geçersiz form alanı kaydı")
+	}
+	return r.getDB(ctx).Create(field).Error
+}
+
+// Update alan tanımının verilen sütunlarını günceller.
+func (r *FormFieldRepository) Update(ctx context.Context, field *models.FormFieldDefinition, data map[string]interface{}) error {
+	if field == nil || field.ID == 0 {
+		return errors.New("güncellenecek form alanı geçerli değil")
+	}
+	result := r.getDB(ctx).Model(field).Updates(data)
+	if result.Error != nil {
+		configslog.Log.Error("FormFieldRepository.Update: DB error", zap.Uint("id", field.ID), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Delete alan tanımını siler (soft delete).
+func (r *FormFieldRepository) Delete(ctx context.Context, field *models.FormFieldDefinition, deletedByUserID uint) error {
+	if field == nil || field.ID == 0 {
+		return errors.New("silinecek form alanı geçerli değil")
+	}
+	now := time.Now().UTC()
+	updateData := map[string]interface{}{"deleted_at": now, "deleted_by": &deletedByUserID}
+	result := r.getDB(ctx).Model(field).Where("id = ? AND deleted_at IS NULL", field.ID).Updates(updateData)
+	if result.Error != nil {
+		configslog.Log.Error("FormFieldRepository.Delete: Update sırasında hata", zap.Uint("id", field.ID), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+var _ IFormFieldRepository = (*FormFieldRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewFormFieldRepositoryTx(tx *gorm.DB) IFormFieldRepository {
+	return &FormFieldRepository{db: tx}
+}
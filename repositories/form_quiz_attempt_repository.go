@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IFormQuizAttemptRepository sınav başlangıç kayıtları için veritabanı arayüzü.
+type IFormQuizAttemptRepository interface {
+	Start(ctx context.Context, formID uint, respondentKey string, now time.Time) (*models.FormQuizAttempt, error)
+	FindByRespondent(ctx context.Context, formID uint, respondentKey string) (*models.FormQuizAttempt, error)
+	DeleteByRespondent(ctx context.Context, formID uint, respondentKey string) error
+	DeleteStartedBefore(ctx context.Context, startedBefore time.Time) (int64, error)
+}
+
+// FormQuizAttemptRepository IFormQuizAttemptRepository arayüzünü uygular.
+type FormQuizAttemptRepository struct {
+	db *gorm.DB
+}
+
+// NewFormQuizAttemptRepository yeni bir FormQuizAttemptRepository örneği oluşturur.
+func NewFormQuizAttemptRepository() IFormQuizAttemptRepository {
+	return &FormQuizAttemptRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *FormQuizAttemptRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Start gönderenin denemesini yoksa now ile oluşturur ve kayıtlı denemeyi döndürür. Kayıt
+// varsa başlangıç zamanı değişmez; eşzamanlı açılışlarda da tek kayıt kalır.
+func (r *FormQuizAttemptRepository) Start(ctx context.Context, formID uint, respondentKey string, now time.Time) (*models.FormQuizAttempt, error) {
+	if formID == 0 || respondentKey == "" {
+		return nil, errors.New("geçersiz sınav denemesi")
+	}
+	attempt := models.FormQuizAttempt{FormID: formID, RespondentKey: respondentKey, StartedAt: now}
+	err := r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "form_id"}, {Name: "respondent_key"}},
+		DoNothing: true,
+	}).Create(&attempt).Error
+	if err != nil {
+		configslog.Log.Error("FormQuizAttemptRepository.Start: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return r.FindByRespondent(ctx, formID, respondentKey)
+}
+
+// FindByRespondent gönderenin formdaki denemesini bulur.
+func (r *FormQuizAttemptRepository) FindByRespondent(ctx context.Context, formID uint, respondentKey string) (*models.FormQuizAttempt, error) {
+	if formID == 0 || respondentKey == "" {
+		return nil, ErrNotFound
+	}
+	var attempt models.FormQuizAttempt
+	err := r.getDB(ctx).Where("form_id = ? AND respondent_key = ?", formID, respondentKey).First(&attempt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("FormQuizAttemptRepository.FindByRespondent: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// DeleteByRespondent gönderenin denemesini kalıcı olarak siler; sonraki açılış yeni deneme başlatır.
+func (r *FormQuizAttemptRepository) DeleteByRespondent(ctx context.Context, formID uint, respondentKey string) error {
+	return r.getDB(ctx).Unscoped().
+		Where("form_id = ? AND respondent_key = ?", formID, respondentKey).
+		Delete(&models.FormQuizAttempt{}).Error
+}
+
+// DeleteStartedBefore verilen zamandan önce başlatılmış (terk edilmiş) denemeleri siler.
+func (r *FormQuizAttemptRepository) DeleteStartedBefore(ctx context.Context, startedBefore time.Time) (int64, error) {
+	result := r.getDB(ctx).Unscoped().Where("started_at < ?", startedBefore).Delete(&models.FormQuizAttempt{})
+	if result.Error != nil {
+		configslog.Log.Error("FormQuizAttemptRepository.DeleteStartedBefore: DB error", zap.Error(result.Error))
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+var _ IFormQuizAttemptRepository = (*FormQuizAttemptRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewFormQuizAttemptRepositoryTx(tx *gorm.DB) IFormQuizAttemptRepository {
+	return &FormQuizAttemptRepository{db: tx}
+}
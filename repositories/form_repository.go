@@ -28,7 +28,7 @@ type IFormRepository interface {
 	Delete(ctx context.Context, form *models.Form, deletedByUserID uint) error
 	CountByUserID(ctx context.Context, userID uint) (int64, error) // CreatorUserID'ye göre
 	CountAll(ctx context.Context) (int64, error)                   // Admin için tümü
-	// TODO: İlişkili FormFieldDefinition ve FormSubmission için metodlar eklenebilir.
+	// Alan tanımları ve gönderimler için IFormFieldRepository ve IFormSubmissionRepository kullanılır.
 }
 
 // FormRepository IFormRepository arayüzünü uygular.
@@ -259,12 +259,21 @@ func (r *FormRepository) Delete(ctx context.Context, form *models.Form, deletedB
 
 	// Transaction içinde DeletedBy'ı ayarla ve soft delete yap
 	return db.Transaction(func(tx *gorm.DB) error {
-		// TODO: İlişkili FieldDefinitions ve Submissions kayıtlarını sil (önce bunlar)
-		// if err := tx.Where("form_id = ?", form.ID).Delete(&models.FormFieldDefinition{}).Error; err != nil { return err }
+		// İlişkili alan tanımlarını ve gönderimleri sil (önce bunlar)
+		now := time.Now().UTC()
+		fieldData := map[string]interface{}{"deleted_at": now, "deleted_by": &deletedByUserID}
+		if err := tx.Model(&models.FormFieldDefinition{}).Where("form_id = ? AND deleted_at IS NULL", form.ID).Updates(fieldData).Error; err != nil {
+			configslog.Log.Error("FormRepository.Delete: Alan tanımları silinemedi", zap.Uint("id", form.ID), zap.Error(err))
+			return err
+		}
+		// İlişkili gönderimleri sil
+		if err := tx.Model(&models.FormSubmission{}).Where("form_id = ? AND deleted_at IS NULL", form.ID).Updates(fieldData).Error; err != nil {
+			configslog.Log.Error("FormRepository.Delete: Gönderimler silinemedi", zap.Uint("id", form.ID), zap.Error(err))
+			return err
+		}
 		// if err := tx.Where("form_id = ?", form.ID).Delete(&models.FormSubmission{}).Error; err != nil { return err }
 
 		// Soft delete için DeletedBy ve DeletedAt'ı ayarla
-		now := time.Now().UTC()
 		updateData := map[string]interface{}{"deleted_at": now, "deleted_by": &deletedByUserID}
 		result := tx.Model(form).Where("id = ? AND deleted_at IS NULL", form.ID).Updates(updateData)
 		if result.Error != nil {
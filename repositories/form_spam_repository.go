@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IFormSpamRepository engellenen form gönderimi sayaçları için arayüz.
+type IFormSpamRepository interface {
+	IncrementBlocked(ctx context.Context, formID uint, reason models.FormSpamReason, day time.Time) error
+	BlockedCounts(ctx context.Context, formID uint, fromDay time.Time) ([]models.FormSpamCount, error)
+}
+
+// FormSpamRepository IFormSpamRepository arayüzünü uygular.
+type FormSpamRepository struct {
+	db *gorm.DB
+}
+
+// NewFormSpamRepository yeni bir FormSpamRepository örneği oluşturur.
+func NewFormSpamRepository() IFormSpamRepository {
+	return &FormSpamRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *FormSpamRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// IncrementBlocked sebebin günlük engelleme sayacını tek sorguda (upsert) bir artırır.
+func (r *FormSpamRepository) IncrementBlocked(ctx context.Context, formID uint, reason models.FormSpamReason, day time.Time) error {
+	stat := models.FormSpamStat{FormID: formID, Reason: reason, Day: day, Blocked: 1}
+	return r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "form_id"}, {Name: "reason"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"blocked":    gorm.Expr("form_spam_stats.blocked + 1"),
+			"updated_at": time.Now().UTC(),
+		}),
+	}).Create(&stat).Error
+}
+
+// BlockedCounts fromDay (dahil) sonrasındaki engellemeleri sebebe göre toplar; fromDay sıfırsa tümünü.
+func (r *FormSpamRepository) BlockedCounts(ctx context.Context, formID uint, fromDay time.Time) ([]models.FormSpamCount, error) {
+	var counts []models.FormSpamCount
+	query := r.getDB(ctx).Model(&models.FormSpamStat{}).
+		Select("reason, SUM(blocked) AS count").
+		Where("form_id = ?", formID)
+	if !fromDay.IsZero() {
+		query = query.Where("day >= ?", fromDay)
+	}
+	if err := query.Group("reason").Scan(&counts).Error; err != nil {
+		configslog.Log.Error("FormSpamRepository.BlockedCounts: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return counts, nil
+}
+
+var _ IFormSpamRepository = (*FormSpamRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewFormSpamRepositoryTx(tx *gorm.DB) IFormSpamRepository {
+	return &FormSpamRepository{db: tx}
+}
@@ -0,0 +1,267 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+	"davet.link/pkg/queryparams" // Pagination için
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IFormSubmissionRepository form gönderimleri için veritabanı arayüzü.
+type IFormSubmissionRepository interface {
+	Create(ctx context.Context, submission *models.FormSubmission) error
+	FindByID(ctx context.Context, id uint) (*models.FormSubmission, error)
+	FindByFormIDPaginated(ctx context.Context, formID uint, params queryparams.ListParams) ([]models.FormSubmission, int64, error)
+	CountByFormID(ctx context.Context, formID uint) (int64, error)
+	CountByRespondent(ctx context.Context, formID uint, respondentKey string, fingerprint string) (int64, error)
+	FindFileByID(ctx context.Context, id uint) (*models.FormSubmissionFile, error)
+	FindForExport(ctx context.Context, formID uint, from, to *time.Time, afterID uint, limit int) ([]models.FormSubmission, error)
+	FindAnsweredFields(ctx context.Context, formID uint) ([]models.FormSubmissionAnswer, error)
+	FindQuizLeaderboard(ctx context.Context, formID uint, limit int) ([]models.FormSubmission, error)
+	QuizStats(ctx context.Context, formID uint) (*models.FormQuizStats, error)
+	QuizQuestionStats(ctx context.Context, formID uint) ([]models.FormQuizQuestionStat, error)
+}
+
+// FormSubmissionRepository IFormSubmissionRepository arayüzünü uygular.
+type FormSubmissionRepository struct {
+	db *gorm.DB
+}
+
+// NewFormSubmissionRepository yeni bir FormSubmissionRepository örneği oluşturur.
+func NewFormSubmissionRepository() IFormSubmissionRepository {
+	return &FormSubmissionRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *FormSubmissionRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// preloadAnswers cevapları alan sırasıyla yükler.
+func preloadAnswers(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order asc, id asc")
+}
+
+// preloadVersionNumber listelerde gönderimin sürümünden yalnızca numarasını yükler.
+func preloadVersionNumber(db *gorm.DB) *gorm.DB {
+	return db.Select("id", "number")
+}
+
+// Create gönderimi cevaplarıyla birlikte oluşturur.
+func (r *FormSubmissionRepository) Create(ctx context.Context, submission *models.FormSubmission) error {
+	if submission == nil || submission.FormID == 0 {
+		return errors.New("geçersiz form gönderimi")
+	}
+	return r.getDB(ctx).Create(submission).Error
+}
+
+// FindByID gönderimi cevapları ve doldurulduğu sürümle birlikte bulur.
+func (r *FormSubmissionRepository) FindByID(ctx context.Context, id uint) (*models.FormSubmission, error) {
+	if id == 0 {
+		return nil, errors.New("geçersiz Submission ID")
+	}
+	var submission models.FormSubmission
+	err := r.getDB(ctx).Preload("Answers", preloadAnswers).Preload("Answers.Files").Preload("FormVersion").First(&submission, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("FormSubmissionRepository.FindByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// FindByFormIDPaginated formun gönderimlerini sayfalayarak getirir. params.Name verilirse
+// cevaplarından biri aranan metni içeren gönderimler listelenir (Türkçe karakter duyarsız).
+func (r *FormSubmissionRepository) FindByFormIDPaginated(ctx context.Context, formID uint, params queryparams.ListParams) ([]models.FormSubmission, int64, error) {
+	if formID == 0 {
+		return nil, 0, errors.New("geçersiz Form ID")
+	}
+	var submissions []models.FormSubmission
+	var totalCount int64
+
+	query := r.getDB(ctx).Model(&models.FormSubmission{}).Where("form_submissions.form_id = ?", formID)
+	if search := strings.TrimSpace(params.Name); search != "" {
+		query = query.Where(`EXISTS (SELECT 1 FROM form_submission_answers a
+			WHERE a.submission_id = form_submissions.id AND a.deleted_at IS NULL
+			AND unaccent(lower(a.value)) ILIKE unaccent(?))`, "%"+strings.ToLower(search)+"%")
+	}
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		configslog.Log.Error("FormSubmissionRepository.Count: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, 0, err
+	}
+	if totalCount == 0 {
+		return submissions, 0, nil
+	}
+
+	// Sıralama: yalnızca izin verilen sütunlar
+	allowedSortColumns := map[string]string{
+		"id":           "form_submissions.id",
+		"submitted_at": "form_submissions.submitted_at",
+	}
+	orderColumn, ok := allowedSortColumns[params.SortBy]
+	if !ok {
+		orderColumn = "form_submissions.submitted_at"
+	}
+	orderBy := strings.ToLower(params.OrderBy)
+	if orderBy != "asc" && orderBy != "desc" {
+		orderBy = queryparams.DefaultOrderBy
+	}
+
+	err := query.Preload("Answers", preloadAnswers).Preload("FormVersion", preloadVersionNumber).
+		Order(orderColumn + " " + orderBy).
+		Limit(params.PerPage).Offset(params.CalculateOffset()).
+		Find(&submissions).Error
+	if err != nil {
+		configslog.Log.Error("FormSubmissionRepository.Find (Paginated): DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, totalCount, err
+	}
+	return submissions, totalCount, nil
+}
+
+// FindForExport formun gönderimlerini dışa aktarım için ID sırasıyla parça parça getirir.
+// from dahil, to hariç tutulur; afterID bir önceki parçanın son gönderimidir.
+func (r *FormSubmissionRepository) FindForExport(ctx context.Context, formID uint, from, to *time.Time, afterID uint, limit int) ([]models.FormSubmission, error) {
+	if formID == 0 {
+		return nil, errors.New("geçersiz Form ID")
+	}
+	query := r.getDB(ctx).Where("form_id = ? AND id > ?", formID, afterID)
+	if from != nil {
+		query = query.Where("submitted_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("submitted_at < ?", *to)
+	}
+	var submissions []models.FormSubmission
+	err := query.Preload("Answers", preloadAnswers).Preload("Answers.Files").Preload("FormVersion", preloadVersionNumber).
+		Order("id asc").Limit(limit).Find(&submissions).Error
+	if err != nil {
+		configslog.Log.Error("FormSubmissionRepository.FindForExport: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// FindAnsweredFields formun gönderimlerinde cevabı bulunan her alan için en son kaydedilen
+// etiket ve tipi döndürür. Silinmiş alanların cevaplarını dışa aktarmak için kullanılır.
+func (r *FormSubmissionRepository) FindAnsweredFields(ctx context.Context, formID uint) ([]models.FormSubmissionAnswer, error) {
+	var answers []models.FormSubmissionAnswer
+	err := r.getDB(ctx).Model(&models.FormSubmissionAnswer{}).
+		Select("DISTINCT ON (form_submission_answers.field_id) form_submission_answers.field_id, form_submission_answers.field_label, form_submission_answers.field_type, form_submission_answers.sort_order").
+		Joins("JOIN form_submissions ON form_submissions.id = form_submission_answers.submission_id").
+		Where("form_submissions.form_id = ? AND form_submissions.deleted_at IS NULL", formID).
+		Order("form_submission_answers.field_id, form_submission_answers.id desc").
+		Find(&answers).Error
+	if err != nil {
+		configslog.Log.Error("FormSubmissionRepository.FindAnsweredFields: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return answers, nil
+}
+
+// FindQuizLeaderboard puanlanmış gönderimleri yüksek puandan düşüğe getirir. Eşit puanda
+// daha kısa sürede bitiren, o da eşitse daha önce gönderen öne geçer.
+func (r *FormSubmissionRepository) FindQuizLeaderboard(ctx context.Context, formID uint, limit int) ([]models.FormSubmission, error) {
+	var submissions []models.FormSubmission
+	err := r.getDB(ctx).Where("form_id = ? AND score IS NOT NULL", formID).
+		Preload("Answers", preloadAnswers).
+		Order("score desc, duration_seconds asc nulls last, submitted_at asc").
+		Limit(limit).Find(&submissions).Error
+	if err != nil {
+		configslog.Log.Error("FormSubmissionRepository.FindQuizLeaderboard: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// QuizStats puanlanmış gönderimlerin sayısını, geçenleri, ortalama başarı yüzdesini ve
+// ortalama süresini veritabanında hesaplar.
+func (r *FormSubmissionRepository) QuizStats(ctx context.Context, formID uint) (*models.FormQuizStats, error) {
+	var stats models.FormQuizStats
+	err := r.getDB(ctx).Model(&models.FormSubmission{}).
+		Select("COUNT(*) AS count, COUNT(*) FILTER (WHERE passed) AS passed_count, "+
+			"COALESCE(AVG(score * 100.0 / NULLIF(max_score, 0)), 0) AS average_percent, "+
+			"COALESCE(AVG(duration_seconds), 0) AS average_duration").
+		Where("form_id = ? AND score IS NOT NULL", formID).
+		Scan(&stats).Error
+	if err != nil {
+		configslog.Log.Error("FormSubmissionRepository.QuizStats: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// QuizQuestionStats her puanlanan soru için cevaplayan ve doğru cevaplayan gönderim sayısını döndürür.
+func (r *FormSubmissionRepository) QuizQuestionStats(ctx context.Context, formID uint) ([]models.FormQuizQuestionStat, error) {
+	var stats []models.FormQuizQuestionStat
+	err := r.getDB(ctx).Table("form_submission_answers AS a").
+		Joins("JOIN form_submissions AS s ON s.id = a.submission_id AND s.deleted_at IS NULL").
+		Select("a.field_id, COUNT(*) AS answered, COUNT(*) FILTER (WHERE a.is_correct) AS correct").
+		Where("s.form_id = ? AND a.deleted_at IS NULL AND a.is_correct IS NOT NULL", formID).
+		Group("a.field_id").Scan(&stats).Error
+	if err != nil {
+		configslog.Log.Error("FormSubmissionRepository.QuizQuestionStats: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return stats, nil
+}
+
+// CountByFormID formun gönderim sayısını döndürür.
+func (r *FormSubmissionRepository) CountByFormID(ctx context.Context, formID uint) (int64, error) {
+	var count int64
+	err := r.getDB(ctx).Model(&models.FormSubmission{}).Where("form_id = ?", formID).Count(&count).Error
+	return count, err
+}
+
+// CountByRespondent aynı gönderenin forma yaptığı gönderim sayısını döndürür. fingerprint
+// verilirse (anonim gönderen) anahtarı veya parmak izi eşleşen gönderimlerin tümü sayılır;
+// böylece çerezi silmek de IP değiştirmek de tek başına sınırı aşmaya yetmez.
+func (r *FormSubmissionRepository) CountByRespondent(ctx context.Context, formID uint, respondentKey string, fingerprint string) (int64, error) {
+	var count int64
+	query := r.getDB(ctx).Model(&models.FormSubmission{}).Where("form_id = ?", formID)
+	if fingerprint == "" {
+		query = query.Where("respondent_key = ?", respondentKey)
+	} else {
+		// Parmak izi sütunundan önceki çerezsiz gönderimlerde parmak izi anahtarın kendisidir.
+		query = query.Where("respondent_key IN ? OR respondent_fingerprint = ?", []string{respondentKey, fingerprint}, fingerprint)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// FindFileByID yüklenen dosya kaydını bulur.
+func (r *FormSubmissionRepository) FindFileByID(ctx context.Context, id uint) (*models.FormSubmissionFile, error) {
+	if id == 0 {
+		return nil, errors.New("geçersiz File ID")
+	}
+	var file models.FormSubmissionFile
+	err := r.getDB(ctx).First(&file, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("FormSubmissionRepository.FindFileByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &file, nil
+}
+
+var _ IFormSubmissionRepository = (*FormSubmissionRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewFormSubmissionRepositoryTx(tx *gorm.DB) IFormSubmissionRepository {
+	return &FormSubmissionRepository{db: tx}
+}
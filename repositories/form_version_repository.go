@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IFormVersionRepository form sürümleri için veritabanı arayüzü. Sürümler yalnızca eklenir.
+type IFormVersionRepository interface {
+	Create(ctx context.Context, version *models.FormVersion) error
+	FindLatest(ctx context.Context, formID uint) (*models.FormVersion, error)
+	FindByFormID(ctx context.Context, formID uint) ([]models.FormVersion, error)
+	CountSubmissions(ctx context.Context, formID uint) ([]models.FormVersionCount, error)
+}
+
+// FormVersionRepository IFormVersionRepository arayüzünü uygular.
+type FormVersionRepository struct {
+	db *gorm.DB
+}
+
+// NewFormVersionRepository yeni bir FormVersionRepository örneği oluşturur.
+func NewFormVersionRepository() IFormVersionRepository {
+	return &FormVersionRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *FormVersionRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create yeni bir sürüm kaydı oluşturur.
+func (r *FormVersionRepository) Create(ctx context.Context, version *models.FormVersion) error {
+	if version == nil || version.FormID == 0 || version.Number <= 0 {
+		return errors.New("geçersiz form sürümü")
+	}
+	return r.getDB(ctx).Create(version).Error
+}
+
+// FindLatest formun en yüksek numaralı sürümünü bulur.
+func (r *FormVersionRepository) FindLatest(ctx context.Context, formID uint) (*models.FormVersion, error) {
+	var version models.FormVersion
+	err := r.getDB(ctx).Where("form_id = ?", formID).Order("number desc").First(&version).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("FormVersionRepository.FindLatest: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return &version, nil
+}
+
+// FindByFormID formun tüm sürümlerini yeniden eskiye getirir.
+func (r *FormVersionRepository) FindByFormID(ctx context.Context, formID uint) ([]models.FormVersion, error) {
+	var versions []models.FormVersion
+	if err := r.getDB(ctx).Where("form_id = ?", formID).Order("number desc").Find(&versions).Error; err != nil {
+		configslog.Log.Error("FormVersionRepository.FindByFormID: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return versions, nil
+}
+
+// CountSubmissions formun gönderimlerini doldurdukları sürüme göre sayar.
+func (r *FormVersionRepository) CountSubmissions(ctx context.Context, formID uint) ([]models.FormVersionCount, error) {
+	var counts []models.FormVersionCount
+	err := r.getDB(ctx).Model(&models.FormSubmission{}).
+		Select("form_version_id, COUNT(*) AS count").
+		Where("form_id = ? AND form_version_id IS NOT NULL", formID).
+		Group("form_version_id").Scan(&counts).Error
+	if err != nil {
+		configslog.Log.Error("FormVersionRepository.CountSubmissions: DB error", zap.Uint("formID", formID), zap.Error(err))
+		return nil, err
+	}
+	return counts, nil
+}
+
+var _ IFormVersionRepository = (*FormVersionRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewFormVersionRepositoryTx(tx *gorm.DB) IFormVersionRepository {
+	return &FormVersionRepository{db: tx}
+}
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IInvitationGuestRepository davetliler için veritabanı arayüzü.
+type IInvitationGuestRepository interface {
+	Create(ctx context.Context, guest *models.InvitationGuest) error
+	FindByRSVPToken(ctx context.Context, invitationID uint, token string) (*models.InvitationGuest, error)
+}
+
+// InvitationGuestRepository IInvitationGuestRepository arayüzünü uygular.
+type InvitationGuestRepository struct {
+	db *gorm.DB
+}
+
+// NewInvitationGuestRepository yeni bir InvitationGuestRepository örneği oluşturur.
+func NewInvitationGuestRepository() IInvitationGuestRepository {
+	return &InvitationGuestRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *InvitationGuestRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create yeni bir davetli oluşturur.
+func (r *InvitationGuestRepository) Create(ctx context.Context, guest *models.InvitationGuest) error {
+	if err := r.getDB(ctx).Create(guest).Error; err != nil {
+		configslog.Log.Error("InvitationGuestRepository.Create: DB error", zap.Uint("invitationID", guest.InvitationID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// FindByRSVPToken davetiyenin LCV anahtarı verilen davetlisini bulur.
+func (r *InvitationGuestRepository) FindByRSVPToken(ctx context.Context, invitationID uint, token string) (*models.InvitationGuest, error) {
+	if invitationID == 0 || token == "" {
+		return nil, ErrNotFound
+	}
+	var guest models.InvitationGuest
+	err := r.getDB(ctx).Where("invitation_id = ? AND rsvp_token = ?", invitationID, token).First(&guest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("InvitationGuestRepository.FindByRSVPToken: DB error", zap.Uint("invitationID", invitationID), zap.Error(err))
+		return nil, err
+	}
+	return &guest, nil
+}
+
+var _ IInvitationGuestRepository = (*InvitationGuestRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewInvitationGuestRepositoryTx(tx *gorm.DB) IInvitationGuestRepository {
+	return &InvitationGuestRepository{db: tx}
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IMailOutboxRepository e-posta kuyruğu için veritabanı arayüzü.
+type IMailOutboxRepository interface {
+	Create(ctx context.Context, mail *models.MailOutbox) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.MailOutbox, error)
+	Update(ctx context.Context, mail *models.MailOutbox, data map[string]interface{}) error
+	FindBySource(ctx context.Context, kinds []string, sourceID uint) ([]models.MailOutbox, error)
+}
+
+// MailOutboxRepository IMailOutboxRepository arayüzünü uygular.
+type MailOutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewMailOutboxRepository yeni bir MailOutboxRepository örneği oluşturur.
+func NewMailOutboxRepository() IMailOutboxRepository {
+	return &MailOutboxRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *MailOutboxRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create e-postayı kuyruğa ekler.
+func (r *MailOutboxRepository) Create(ctx context.Context, mail *models.MailOutbox) error {
+	if mail == nil || mail.Recipients == "" {
+		return errors.New("geçersiz kuyruk e-postası")
+	}
+	return r.getDB(ctx).Create(mail).Error
+}
+
+// ClaimDue zamanı gelmiş bekleyen e-postaları alır ve lease süresince başka bir işçinin
+// almaması için sonraki deneme zamanını ileri çeker. Satırlar SKIP LOCKED ile kilitlendiğinden
+// birden fazla uygulama örneği aynı e-postayı göndermez.
+func (r *MailOutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.MailOutbox, error) {
+	var mails []models.MailOutbox
+	err := r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.MailOutboxPending, now).
+			Order("next_attempt_at asc").Limit(limit).Find(&mails).Error
+		if err != nil || len(mails) == 0 {
+			return err
+		}
+		ids := make([]uint, len(mails))
+		for i := range mails {
+			ids[i] = mails[i].ID
+		}
+		// Yalnızca kuyruk defteri: hook'lar (ve aktör) gerekmediğinden UpdateColumn kullanılır.
+		if err := tx.Model(&models.MailOutbox{}).Where("id IN ?", ids).UpdateColumn("next_attempt_at", now.Add(lease)).Error; err != nil {
+			return err
+		}
+		var attachments []models.MailOutboxAttachment
+		if err := tx.Where("mail_id IN ?", ids).Order("id asc").Find(&attachments).Error; err != nil {
+			return err
+		}
+		byMail := make(map[uint][]models.MailOutboxAttachment)
+		for _, attachment := range attachments {
+			byMail[attachment.MailID] = append(byMail[attachment.MailID], attachment)
+		}
+		for i := range mails {
+			mails[i].Attachments = byMail[mails[i].ID]
+		}
+		return nil
+	})
+	if err != nil {
+		configslog.Log.Error("MailOutboxRepository.ClaimDue: DB error", zap.Error(err))
+		return nil, err
+	}
+	return mails, nil
+}
+
+// Update kuyruk kaydının verilen sütunlarını günceller.
+func (r *MailOutboxRepository) Update(ctx context.Context, mail *models.MailOutbox, data map[string]interface{}) error {
+	if mail == nil || mail.ID == 0 {
+		return errors.New("güncellenecek kuyruk e-postası geçerli değil")
+	}
+	result := r.getDB(ctx).Model(mail).Updates(data)
+	if result.Error != nil {
+		configslog.Log.Error("MailOutboxRepository.Update: DB error", zap.Uint("id", mail.ID), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindBySource bir kayda (örn. form gönderimi) ait kuyruk e-postalarını getirir.
+func (r *MailOutboxRepository) FindBySource(ctx context.Context, kinds []string, sourceID uint) ([]models.MailOutbox, error) {
+	var mails []models.MailOutbox
+	err := r.getDB(ctx).Where("kind IN ? AND source_id = ?", kinds, sourceID).Order("id asc").Find(&mails).Error
+	if err != nil {
+		configslog.Log.Error("MailOutboxRepository.FindBySource: DB error", zap.Uint("sourceID", sourceID), zap.Error(err))
+		return nil, err
+	}
+	return mails, nil
+}
+
+var _ IMailOutboxRepository = (*MailOutboxRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewMailOutboxRepositoryTx(tx *gorm.DB) IMailOutboxRepository {
+	return &MailOutboxRepository{db: tx}
+}
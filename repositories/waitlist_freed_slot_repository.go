@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IWaitlistFreedSlotRepository teklif bekleyen boşalmış slotlar için veritabanı arayüzü.
+type IWaitlistFreedSlotRepository interface {
+	Create(ctx context.Context, slot *models.WaitlistFreedSlot) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WaitlistFreedSlot, error)
+	Update(ctx context.Context, slot *models.WaitlistFreedSlot, data map[string]interface{}) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// WaitlistFreedSlotRepository IWaitlistFreedSlotRepository arayüzünü uygular.
+type WaitlistFreedSlotRepository struct {
+	db *gorm.DB
+}
+
+// NewWaitlistFreedSlotRepository yeni bir WaitlistFreedSlotRepository örneği oluşturur.
+func NewWaitlistFreedSlotRepository() IWaitlistFreedSlotRepository {
+	return &WaitlistFreedSlotRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *WaitlistFreedSlotRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create boşalan slotu kuyruğa ekler.
+func (r *WaitlistFreedSlotRepository) Create(ctx context.Context, slot *models.WaitlistFreedSlot) error {
+	return r.getDB(ctx).Create(slot).Error
+}
+
+// ClaimDue zamanı gelmiş slotları alır ve lease süresince başka bir işçinin almaması için
+// sonraki deneme zamanını ileri çeker. Satırlar SKIP LOCKED ile kilitlenir.
+func (r *WaitlistFreedSlotRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WaitlistFreedSlot, error) {
+	var slots []models.WaitlistFreedSlot
+	err := r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("next_attempt_at <= ?", now).
+			Order("id asc").Limit(limit).Find(&slots).Error
+		if err != nil || len(slots) == 0 {
+			return err
+		}
+		ids := make([]uint, len(slots))
+		for i := range slots {
+			ids[i] = slots[i].ID
+		}
+		// Yalnızca kuyruk defteri: hook'lar (ve aktör) gerekmediğinden UpdateColumn kullanılır.
+		return tx.Model(&models.WaitlistFreedSlot{}).Where("id IN ?", ids).UpdateColumn("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		configslog.Log.Error("WaitlistFreedSlotRepository.ClaimDue: DB error", zap.Error(err))
+		return nil, err
+	}
+	return slots, nil
+}
+
+// Update başarısız denemenin sayaçlarını günceller.
+func (r *WaitlistFreedSlotRepository) Update(ctx context.Context, slot *models.WaitlistFreedSlot, data map[string]interface{}) error {
+	return r.getDB(ctx).Model(slot).Updates(data).Error
+}
+
+// Delete teklife çevrilen slotu kuyruktan kalıcı olarak siler.
+func (r *WaitlistFreedSlotRepository) Delete(ctx context.Context, id uint) error {
+	return r.getDB(ctx).Unscoped().Delete(&models.WaitlistFreedSlot{}, id).Error
+}
+
+var _ IWaitlistFreedSlotRepository = (*WaitlistFreedSlotRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewWaitlistFreedSlotRepositoryTx(tx *gorm.DB) IWaitlistFreedSlotRepository {
+	return &WaitlistFreedSlotRepository{db: tx}
+}
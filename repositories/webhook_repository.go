@@ -0,0 +1,222 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs"            // DB bağlantısı için
+	"davet.link/configs/configslog" // Loglama için
+	"davet.link/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IWebhookRepository webhook uç noktaları ve teslimat kuyruğu için veritabanı arayüzü.
+type IWebhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint, data map[string]interface{}) error
+	DeleteEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint, deletedByUserID uint) error
+	FindEndpointByID(ctx context.Context, id uint) (*models.WebhookEndpoint, error)
+	FindEndpointsByUserID(ctx context.Context, userID uint) ([]models.WebhookEndpoint, error)
+	FindActiveEndpoints(ctx context.Context, userID uint) ([]models.WebhookEndpoint, error)
+
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery, data map[string]interface{}) error
+	FindDeliveryByID(ctx context.Context, id uint) (*models.WebhookDelivery, error)
+	FindDeliveriesByEndpointID(ctx context.Context, endpointID uint, limit int) ([]models.WebhookDelivery, error)
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error)
+}
+
+// WebhookRepository IWebhookRepository arayüzünü uygular.
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository yeni bir WebhookRepository örneği oluşturur.
+func NewWebhookRepository() IWebhookRepository {
+	return &WebhookRepository{db: configs.GetDB()}
+}
+
+// Context ile çalışan DB örneği döndüren yardımcı fonksiyon
+func (r *WebhookRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// CreateEndpoint yeni bir uç nokta oluşturur.
+func (r *WebhookRepository) CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	if endpoint == nil || endpoint.UserID == 0 || endpoint.URL == "" {
+		return errors.New("geçersiz webhook uç noktası")
+	}
+	return r.getDB(ctx).Create(endpoint).Error
+}
+
+// UpdateEndpoint uç noktanın verilen sütunlarını günceller.
+func (r *WebhookRepository) UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint, data map[string]interface{}) error {
+	if endpoint == nil || endpoint.ID == 0 {
+		return errors.New("güncellenecek webhook uç noktası geçerli değil")
+	}
+	result := r.getDB(ctx).Model(endpoint).Updates(data)
+	if result.Error != nil {
+		configslog.Log.Error("WebhookRepository.UpdateEndpoint: DB error", zap.Uint("id", endpoint.ID), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteEndpoint uç noktayı soft delete ile siler. Teslimat kayıtları log olarak kalır.
+func (r *WebhookRepository) DeleteEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint, deletedByUserID uint) error {
+	if endpoint == nil || endpoint.ID == 0 {
+		return errors.New("silinecek webhook uç noktası geçerli değil")
+	}
+	now := time.Now().UTC()
+	updateData := map[string]interface{}{"deleted_at": now, "deleted_by": &deletedByUserID}
+	result := r.getDB(ctx).Model(endpoint).Where("id = ? AND deleted_at IS NULL", endpoint.ID).Updates(updateData)
+	if result.Error != nil {
+		configslog.Log.Error("WebhookRepository.DeleteEndpoint: Update sırasında hata", zap.Uint("id", endpoint.ID), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindEndpointByID uç noktayı ID ile bulur.
+func (r *WebhookRepository) FindEndpointByID(ctx context.Context, id uint) (*models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+	if err := r.getDB(ctx).First(&endpoint, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("WebhookRepository.FindEndpointByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+// FindEndpointsByUserID kullanıcının tüm uç noktalarını oluşturulma sırasıyla getirir.
+func (r *WebhookRepository) FindEndpointsByUserID(ctx context.Context, userID uint) ([]models.WebhookEndpoint, error) {
+	var endpoints []models.WebhookEndpoint
+	if err := r.getDB(ctx).Where("user_id = ?", userID).Order("id asc").Find(&endpoints).Error; err != nil {
+		configslog.Log.Error("WebhookRepository.FindEndpointsByUserID: DB error", zap.Uint("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// FindActiveEndpoints kullanıcının etkin uç noktalarını getirir.
+func (r *WebhookRepository) FindActiveEndpoints(ctx context.Context, userID uint) ([]models.WebhookEndpoint, error) {
+	var endpoints []models.WebhookEndpoint
+	if err := r.getDB(ctx).Where("user_id = ? AND is_active = ?", userID, true).Order("id asc").Find(&endpoints).Error; err != nil {
+		configslog.Log.Error("WebhookRepository.FindActiveEndpoints: DB error", zap.Uint("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// CreateDelivery teslimatı kuyruğa ekler.
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if delivery == nil || delivery.EndpointID == 0 || delivery.Payload == "" {
+		return errors.New("geçersiz webhook teslimatı")
+	}
+	return r.getDB(ctx).Create(delivery).Error
+}
+
+// UpdateDelivery teslimatın verilen sütunlarını günceller.
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery, data map[string]interface{}) error {
+	if delivery == nil || delivery.ID == 0 {
+		return errors.New("güncellenecek webhook teslimatı geçerli değil")
+	}
+	result := r.getDB(ctx).Model(delivery).Updates(data)
+	if result.Error != nil {
+		configslog.Log.Error("WebhookRepository.UpdateDelivery: DB error", zap.Uint("id", delivery.ID), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindDeliveryByID teslimatı uç noktasıyla birlikte bulur. Uç nokta silinmiş olsa da yüklenir
+// (sahiplik kontrolü için); silinip silinmediği Endpoint.DeletedAt ile anlaşılır.
+func (r *WebhookRepository) FindDeliveryByID(ctx context.Context, id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := r.getDB(ctx).Preload("Endpoint", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).First(&delivery, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		configslog.Log.Error("WebhookRepository.FindDeliveryByID: DB error", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// FindDeliveriesByEndpointID uç noktanın en yeni limit teslimatını getirir.
+func (r *WebhookRepository) FindDeliveriesByEndpointID(ctx context.Context, endpointID uint, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.getDB(ctx).Where("endpoint_id = ?", endpointID).Order("id desc").Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		configslog.Log.Error("WebhookRepository.FindDeliveriesByEndpointID: DB error", zap.Uint("endpointID", endpointID), zap.Error(err))
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDue zamanı gelmiş bekleyen teslimatları uç noktalarıyla (silinmiş olsalar da) birlikte alır ve lease
+// süresince başka bir işçinin almaması için sonraki deneme zamanını ileri çeker. Satırlar
+// SKIP LOCKED ile kilitlendiğinden birden fazla uygulama örneği aynı teslimatı göndermez.
+func (r *WebhookRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Order("next_attempt_at asc").Limit(limit).Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+		ids := make([]uint, len(deliveries))
+		endpointIDs := make([]uint, 0, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].ID
+			endpointIDs = append(endpointIDs, deliveries[i].EndpointID)
+		}
+		// Yalnızca kuyruk defteri: hook'lar (ve aktör) gerekmediğinden UpdateColumn kullanılır.
+		if err := tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).UpdateColumn("next_attempt_at", now.Add(lease)).Error; err != nil {
+			return err
+		}
+		var endpoints []models.WebhookEndpoint
+		if err := tx.Unscoped().Where("id IN ?", endpointIDs).Find(&endpoints).Error; err != nil {
+			return err
+		}
+		byID := make(map[uint]models.WebhookEndpoint, len(endpoints))
+		for _, endpoint := range endpoints {
+			byID[endpoint.ID] = endpoint
+		}
+		for i := range deliveries {
+			deliveries[i].Endpoint = byID[deliveries[i].EndpointID]
+		}
+		return nil
+	})
+	if err != nil {
+		configslog.Log.Error("WebhookRepository.ClaimDue: DB error", zap.Error(err))
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+var _ IWebhookRepository = (*WebhookRepository)(nil)
+
+// Transaction'lı Repository için yardımcı constructor
+func NewWebhookRepositoryTx(tx *gorm.DB) IWebhookRepository {
+	return &WebhookRepository{db: tx}
+}
@@ -10,11 +10,36 @@ import (
 func registerPublicLinkRoutes(app *fiber.App) {
 	// Public link handler'ından bir örnek oluştur
 	publicHandler := handlers.NewPublicLinkHandler() // Bu handler oluşturulmalı
+	appointmentHandler := handlers.NewPublicAppointmentHandler()
+	calendarHandler := handlers.NewPublicCalendarHandler()
+	waitlistHandler := handlers.NewPublicWaitlistHandler()
+	formHandler := handlers.NewPublicFormHandler()
+	rsvpHandler := handlers.NewPublicRSVPHandler()
+
+	// Sağlayıcının gizli ICS abonelik adresi (takvim uygulamaları için)
+	app.Get("/calendar/:token", calendarHandler.GetFeed)
+	// Bekleme listesi teklifleri: gizli anahtarlı kabul / ret sayfası
+	app.Get("/waitlist/claim/:token", waitlistHandler.ShowClaim)
+	app.Post("/waitlist/claim/:token", waitlistHandler.Claim)
+	app.Post("/waitlist/decline/:token", waitlistHandler.Decline)
+	// Form gönderimlerine yüklenen dosyalar: panelde üretilen imzalı ve süreli indirme bağlantısı
+	app.Get("/files/forms/:id", formHandler.DownloadFile)
 
 	// Ana rota: :key parametresi ile link anahtarını yakala
 	// Bu rota diğer özel rotalardan (örn. /auth, /dashboard) SONRA tanımlanmalı.
 	app.Get("/:key", publicHandler.HandleLink)
+	// Form linkleri: cevapların gönderilmesi (alan tanımlarına göre sunucuda doğrulanır)
+	app.Post("/:key", formHandler.SubmitForm)
 
-	// Belki linklere özel başka alt rotalar da olabilir?
-	// Örnek: app.Post("/:key/rsvp", publicHandler.HandleRsvpPost)
+	// Randevu linkleri: seçilen gün için uygun saatler (JSON)
+	app.Get("/:key/slots", appointmentHandler.GetSlots)
+	// Randevu linkleri: seçilen slot için rezervasyon oluşturma
+	app.Post("/:key/book", appointmentHandler.CreateBooking)
+	// Randevu linkleri: tekrarlayan (haftalık / iki haftalık) rezervasyon önizleme ve oluşturma
+	app.Post("/:key/book/series/preview", appointmentHandler.PreviewSeries)
+	app.Post("/:key/book/series", appointmentHandler.CreateSeries)
+	// Randevu linkleri: dolu gün için bekleme listesine katılma
+	app.Post("/:key/waitlist", waitlistHandler.JoinWaitlist)
+	// Davetiye linkleri: davetliye özel gizli anahtarla LCV yanıtı
+	app.Post("/:key/rsvp/:token", rsvpHandler.SubmitRSVP)
 }
@@ -17,6 +17,25 @@ func registerPanelRoutes(app *fiber.App) {
 	appointmentHandler := panel_handlers.NewPanelAppointmentHandler()
 	formHandler := panel_handlers.NewPanelFormHandler()
 	cardHandler := panel_handlers.NewPanelCardHandler() // Yeni Card handler
+	availabilityHandler := panel_handlers.NewPanelAvailabilityHandler()
+	bookingHandler := panel_handlers.NewPanelBookingHandler()
+	externalCalendarHandler := panel_handlers.NewPanelExternalCalendarHandler()
+	questionHandler := panel_handlers.NewPanelBookingQuestionHandler()
+	paymentHandler := panel_handlers.NewPanelBookingPaymentHandler()
+	customerHandler := panel_handlers.NewPanelCustomerHandler()
+	seriesHandler := panel_handlers.NewPanelBookingSeriesHandler()
+	waitlistHandler := panel_handlers.NewPanelBookingWaitlistHandler()
+	calendarHandler := panel_handlers.NewPanelCalendarHandler()
+	formFieldHandler := panel_handlers.NewPanelFormFieldHandler()
+	submissionHandler := panel_handlers.NewPanelFormSubmissionHandler()
+	notificationHandler := panel_handlers.NewPanelFormNotificationHandler()
+	exportHandler := panel_handlers.NewPanelFormExportHandler()
+	analyticsHandler := panel_handlers.NewPanelFormAnalyticsHandler()
+	quizHandler := panel_handlers.NewPanelFormQuizHandler()
+	spamHandler := panel_handlers.NewPanelFormSpamHandler()
+	versionHandler := panel_handlers.NewPanelFormVersionHandler()
+	prefillHandler := panel_handlers.NewPanelFormPrefillHandler()
+	webhookHandler := panel_handlers.NewPanelWebhookHandler()
 
 	// /panel grubu oluştur ve middleware'leri uygula
 	panelGroup := app.Group("/panel")
@@ -46,7 +65,58 @@ func registerPanelRoutes(app *fiber.App) {
 	panelGroup.Post("/appointments/update/:id", appointmentHandler.UpdateAppointment)    // POST /panel/appointments/update/{id}
 	panelGroup.Post("/appointments/delete/:id", appointmentHandler.DeleteAppointment)    // POST /panel/appointments/delete/{id}
 	panelGroup.Delete("/appointments/delete/:id", appointmentHandler.DeleteAppointment)  // DELETE /panel/appointments/delete/{id}
-	// TODO: Randevu rezervasyonları (bookings) için rotalar
+
+	// --- Müsaitlik: Haftalık Saatler, Tarih İstisnaları ve Tatiller ---
+	panelGroup.Get("/appointments/availability/:id", availabilityHandler.ShowAppointmentAvailability)    // GET /panel/appointments/availability/{id}
+	panelGroup.Post("/appointments/availability/:id", availabilityHandler.UpdateAppointmentAvailability) // POST /panel/appointments/availability/{id}
+	panelGroup.Get("/availability", availabilityHandler.ShowProviderAvailability)                        // GET /panel/availability
+	panelGroup.Post("/availability/settings", availabilityHandler.UpdateProviderSettings)                // POST /panel/availability/settings
+	panelGroup.Post("/availability/overrides/create", availabilityHandler.CreateOverride)                // POST /panel/availability/overrides/create
+	panelGroup.Post("/availability/overrides/delete/:id", availabilityHandler.DeleteOverride)            // POST /panel/availability/overrides/delete/{id}
+	panelGroup.Post("/availability/calendar-feed", availabilityHandler.RegenerateCalendarFeed)           // POST /panel/availability/calendar-feed
+
+	// --- Dış Takvimler (ICS içe aktarma) ---
+	panelGroup.Post("/availability/calendars/import", externalCalendarHandler.ImportFile)   // POST /panel/availability/calendars/import (multipart)
+	panelGroup.Post("/availability/calendars/url", externalCalendarHandler.AddURL)          // POST /panel/availability/calendars/url
+	panelGroup.Post("/availability/calendars/refresh/:id", externalCalendarHandler.Refresh) // POST /panel/availability/calendars/refresh/{id}
+	panelGroup.Post("/availability/calendars/delete/:id", externalCalendarHandler.Delete)   // POST /panel/availability/calendars/delete/{id}
+
+	// --- Randevu Seansları ve Katılımcı Listeleri ---
+	panelGroup.Get("/appointments/sessions/:id", bookingHandler.ListSessions)               // GET /panel/appointments/sessions/{id}
+	panelGroup.Get("/appointments/sessions/:id/roster.csv", bookingHandler.ExportRoster)    // GET /panel/appointments/sessions/{id}/roster.csv?start=
+	panelGroup.Post("/appointments/bookings/confirm/:id", bookingHandler.ConfirmBooking)    // POST /panel/appointments/bookings/confirm/{id}
+	panelGroup.Get("/appointments/bookings/:id", bookingHandler.ShowBooking)                // GET /panel/appointments/bookings/{id}
+	panelGroup.Post("/appointments/bookings/cancel/:id", bookingHandler.CancelBooking)      // POST /panel/appointments/bookings/cancel/{id}
+	panelGroup.Post("/appointments/bookings/attendance/:id", bookingHandler.MarkAttendance) // POST /panel/appointments/bookings/attendance/{id}
+
+	// --- Tekrarlayan Rezervasyonlar ---
+	panelGroup.Get("/appointments/series/create/:id", seriesHandler.ShowCreateSeries) // GET /panel/appointments/series/create/{appointmentID}
+	panelGroup.Post("/appointments/series/create/:id", seriesHandler.CreateSeries)    // POST /panel/appointments/series/create/{appointmentID} (action=preview|create)
+	panelGroup.Get("/appointments/series/:id", seriesHandler.ShowSeries)              // GET /panel/appointments/series/{id}
+	panelGroup.Post("/appointments/series/cancel/:id", seriesHandler.CancelSeries)    // POST /panel/appointments/series/cancel/{bookingID} (scope=one|following|all)
+
+	// --- Bekleme Listesi ---
+	panelGroup.Get("/appointments/waitlist/:id", waitlistHandler.ListWaitlist)        // GET /panel/appointments/waitlist/{appointmentID}
+	panelGroup.Post("/appointments/waitlist/remove/:id", waitlistHandler.RemoveEntry) // POST /panel/appointments/waitlist/remove/{entryID}
+
+	// --- Takvim (tüm hizmetler) ---
+	panelGroup.Get("/calendar", calendarHandler.ShowCalendar)                   // GET /panel/calendar
+	panelGroup.Get("/calendar/events", calendarHandler.Events)                  // GET /panel/calendar/events?start=&end=
+	panelGroup.Post("/calendar/bookings/:id/move", calendarHandler.MoveBooking) // POST /panel/calendar/bookings/{id}/move
+
+	// --- Rezervasyon Soruları ---
+	panelGroup.Get("/appointments/questions/:id", questionHandler.ListQuestions)          // GET /panel/appointments/questions/{id}
+	panelGroup.Post("/appointments/questions/:id", questionHandler.CreateQuestion)        // POST /panel/appointments/questions/{id}
+	panelGroup.Post("/appointments/questions/delete/:id", questionHandler.DeleteQuestion) // POST /panel/appointments/questions/delete/{id}
+
+	// --- Rezervasyon Ödemeleri ---
+	panelGroup.Post("/appointments/bookings/:id/payments", paymentHandler.RecordPayment) // POST /panel/appointments/bookings/{id}/payments
+	panelGroup.Post("/appointments/payments/refund/:id", paymentHandler.RefundPayment)   // POST /panel/appointments/payments/refund/{id}
+
+	// --- Müşteriler (gelmeme takibi ve engel listesi) ---
+	panelGroup.Get("/customers", customerHandler.ListCustomers)          // GET /panel/customers
+	panelGroup.Post("/customers/rule", customerHandler.UpdateNoShowRule) // POST /panel/customers/rule
+	panelGroup.Post("/customers/block/:id", customerHandler.SetBlocked)  // POST /panel/customers/block/{id}
 
 	// --- Kullanıcının Kendi Formları ---
 	panelGroup.Get("/forms", formHandler.ListForms)                 // GET /panel/forms
@@ -56,7 +126,53 @@ func registerPanelRoutes(app *fiber.App) {
 	panelGroup.Post("/forms/update/:id", formHandler.UpdateForm)    // POST /panel/forms/update/{id}
 	panelGroup.Post("/forms/delete/:id", formHandler.DeleteForm)    // POST /panel/forms/delete/{id}
 	panelGroup.Delete("/forms/delete/:id", formHandler.DeleteForm)  // DELETE /panel/forms/delete/{id}
-	// TODO: Form gönderimleri (submissions) için rotalar
+
+	// --- Form Alanları (form oluşturucu) ---
+	panelGroup.Get("/forms/fields/:id", formFieldHandler.ListFields)          // GET /panel/forms/fields/{formID}
+	panelGroup.Post("/forms/fields/:id", formFieldHandler.CreateField)        // POST /panel/forms/fields/{formID}
+	panelGroup.Post("/forms/fields/update/:id", formFieldHandler.UpdateField) // POST /panel/forms/fields/update/{fieldID}
+	panelGroup.Post("/forms/fields/move/:id", formFieldHandler.MoveField)     // POST /panel/forms/fields/move/{fieldID} (direction=up|down)
+	panelGroup.Post("/forms/fields/delete/:id", formFieldHandler.DeleteField) // POST /panel/forms/fields/delete/{fieldID}
+
+	// --- Form Gönderimleri ---
+	panelGroup.Get("/forms/submissions/:id", submissionHandler.ListSubmissions)     // GET /panel/forms/submissions/{formID}?name=&page=&perPage=
+	panelGroup.Get("/forms/submissions/view/:id", submissionHandler.ShowSubmission) // GET /panel/forms/submissions/view/{submissionID}
+
+	// --- Form Bildirimleri (yeni yanıt e-postası ve otomatik yanıt) ---
+	panelGroup.Get("/forms/notifications/:id", notificationHandler.ShowSettings)    // GET /panel/forms/notifications/{formID}
+	panelGroup.Post("/forms/notifications/:id", notificationHandler.UpdateSettings) // POST /panel/forms/notifications/{formID}
+
+	// --- Form Dışa Aktarımı (indirme ve zamanlanmış e-posta) ---
+	panelGroup.Get("/forms/exports/:id", exportHandler.ShowExports)              // GET /panel/forms/exports/{formID}
+	panelGroup.Get("/forms/exports/:id/download", exportHandler.Download)        // GET /panel/forms/exports/{formID}/download?format=&from=&to=
+	panelGroup.Post("/forms/exports/:id/schedule", exportHandler.UpdateSchedule) // POST /panel/forms/exports/{formID}/schedule
+
+	// --- Form Analizleri ---
+	panelGroup.Get("/forms/analytics/:id", analyticsHandler.ShowAnalytics) // GET /panel/forms/analytics/{formID}?from=&to=
+
+	// --- Form Sınav Modu ---
+	panelGroup.Get("/forms/quiz/:id", quizHandler.ShowQuiz)        // GET /panel/forms/quiz/{formID}
+	panelGroup.Post("/forms/quiz/:id", quizHandler.UpdateSettings) // POST /panel/forms/quiz/{formID}
+
+	// --- Form Spam Koruması ---
+	panelGroup.Get("/forms/spam/:id", spamHandler.ShowSpam)        // GET /panel/forms/spam/{formID}
+	panelGroup.Post("/forms/spam/:id", spamHandler.UpdateSettings) // POST /panel/forms/spam/{formID}
+
+	// --- Form Sürümleri ---
+	panelGroup.Get("/forms/versions/:id", versionHandler.ListVersions) // GET /panel/forms/versions/{formID}
+
+	// --- Form Ön Doldurma Bağlantıları ---
+	panelGroup.Get("/forms/prefill/:id", prefillHandler.ShowPrefill)   // GET /panel/forms/prefill/{formID}
+	panelGroup.Post("/forms/prefill/:id", prefillHandler.BuildPrefill) // POST /panel/forms/prefill/{formID}
+
+	// --- Webhook'lar (giden olay bildirimleri ve teslimat kayıtları) ---
+	panelGroup.Get("/webhooks", webhookHandler.ListWebhooks)                          // GET /panel/webhooks
+	panelGroup.Post("/webhooks", webhookHandler.CreateWebhook)                        // POST /panel/webhooks
+	panelGroup.Post("/webhooks/update/:id", webhookHandler.UpdateWebhook)             // POST /panel/webhooks/update/{id}
+	panelGroup.Post("/webhooks/rotate/:id", webhookHandler.RotateWebhookSecret)       // POST /panel/webhooks/rotate/{id}
+	panelGroup.Post("/webhooks/delete/:id", webhookHandler.DeleteWebhook)             // POST /panel/webhooks/delete/{id}
+	panelGroup.Get("/webhooks/deliveries/:id", webhookHandler.ListDeliveries)         // GET /panel/webhooks/deliveries/{id}
+	panelGroup.Post("/webhooks/deliveries/resend/:id", webhookHandler.ResendDelivery) // POST /panel/webhooks/deliveries/resend/{deliveryID}
 
 	// --- Kullanıcının Kendi Kartvizitleri ---
 	panelGroup.Get("/cards", cardHandler.ListCards)                 // GET /panel/cards
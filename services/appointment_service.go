@@ -4,11 +4,14 @@ import (
 	"context" // Context eklendi
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"davet.link/configs"
 	"davet.link/configs/configslog"
 	"davet.link/models"
+	"davet.link/pkg/money"
 	"davet.link/pkg/queryparams"
 	"davet.link/repositories"
 
@@ -41,6 +44,9 @@ const (
 	ErrAppGenericUserError AppointmentServiceError = "kullanıcı işlemi sırasında hata"
 )
 
+// currencyCodePattern ISO 4217 para birimi kodu (örn. TRY, EUR).
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
 // IAppointmentService randevu hizmeti işlemleri için arayüz.
 type IAppointmentService interface {
 	CreateAppointment(ctx context.Context, providerUserID uint, orgID *uint, detailData models.AppointmentDetail) (*models.Appointment, error) // Detail struct olarak
@@ -93,7 +99,43 @@ func ValidateAppointmentDetail(detail models.AppointmentDetail) error {
 	if detail.BufferTimeBefore < 0 || detail.BufferTimeAfter < 0 {
 		return fmt.Errorf("%w: tampon süreler negatif olamaz", ErrAppInvalidInput)
 	}
-	// TODO: Price format, Currency code, ColorCode format vb.
+	if detail.Capacity < 0 {
+		return fmt.Errorf("%w: kapasite negatif olamaz", ErrAppInvalidInput)
+	}
+	if detail.Timezone != "" {
+		if _, err := time.LoadLocation(detail.Timezone); err != nil {
+			return fmt.Errorf("%w: geçersiz zaman dilimi", ErrAppInvalidInput)
+		}
+	}
+	if detail.PriceMinor < 0 || detail.DepositMinor < 0 {
+		return fmt.Errorf("%w: ücret ve kapora negatif olamaz", ErrAppInvalidInput)
+	}
+	if detail.DepositMinor > detail.PriceMinor {
+		return fmt.Errorf("%w: kapora ücretten büyük olamaz", ErrAppInvalidInput)
+	}
+	if detail.Currency != "" && !currencyCodePattern.MatchString(detail.Currency) {
+		return fmt.Errorf("%w: para birimi 3 harfli ISO kodu olmalı (örn. TRY)", ErrAppInvalidInput)
+	}
+	// TODO: ColorCode format vb.
+	return nil
+}
+
+// ParseAppointmentPrices formdan gelen "150,00" biçimindeki ücret ve kapora metinlerini
+// kuruşa çevirip detaya yazar. Para birimi büyük harfe çevrilir; boşsa mevcut değer korunur.
+func ParseAppointmentPrices(detail *models.AppointmentDetail, price, deposit, currency string) error {
+	priceMinor, err := money.Parse(price)
+	if err != nil {
+		return fmt.Errorf("%w: geçersiz ücret", ErrAppInvalidInput)
+	}
+	depositMinor, err := money.Parse(deposit)
+	if err != nil {
+		return fmt.Errorf("%w: geçersiz kapora", ErrAppInvalidInput)
+	}
+	detail.PriceMinor = priceMinor
+	detail.DepositMinor = depositMinor
+	if currency = strings.ToUpper(strings.TrimSpace(currency)); currency != "" {
+		detail.Currency = currency
+	}
 	return nil
 }
 
@@ -309,6 +351,20 @@ func (s *AppointmentService) UpdateAppointment(ctx context.Context, id uint, upd
 		existingDetail.Name = detailData.Name
 		existingDetail.Description = detailData.Description
 		existingDetail.DurationMinutes = detailData.DurationMinutes
+		if detailData.Timezone != "" { // Formda yoksa mevcut zaman dilimi korunur; geçerliliği yukarıda doğrulandı
+			existingDetail.Timezone = detailData.Timezone
+		}
+		existingDetail.AllowParallelBookings = detailData.AllowParallelBookings
+		existingDetail.Capacity = detailData.Capacity
+		existingDetail.AllowCustomerRecurring = detailData.AllowCustomerRecurring
+		if detailData.WaitlistClaimMinutes > 0 {
+			existingDetail.WaitlistClaimMinutes = detailData.WaitlistClaimMinutes
+		}
+		existingDetail.PriceMinor = detailData.PriceMinor
+		existingDetail.DepositMinor = detailData.DepositMinor
+		if detailData.Currency != "" {
+			existingDetail.Currency = detailData.Currency
+		}
 		// ... (diğer tüm AppointmentDetail alanları) ...
 		existingDetail.ExpiresAt = detailData.ExpiresAt
 		// Şifre hashleme (eğer değiştiyse)
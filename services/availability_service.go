@@ -0,0 +1,591 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"davet.link/configs/configsenv"
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/holidays"
+	"davet.link/pkg/slots"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+// AvailabilityServiceError özel servis hataları
+type AvailabilityServiceError string
+
+func (e AvailabilityServiceError) Error() string { return string(e) }
+
+const (
+	ErrAvailInvalidRule      AvailabilityServiceError = "geçersiz çalışma saati kuralı"
+	ErrAvailInvalidOverride  AvailabilityServiceError = "geçersiz tarih istisnası"
+	ErrAvailOverrideNotFound AvailabilityServiceError = "tarih istisnası bulunamadı"
+	ErrAvailForbidden        AvailabilityServiceError = "bu işlem için yetkiniz yok"
+	ErrAvailUpdateFailed     AvailabilityServiceError = "müsaitlik bilgileri güncellenemedi"
+	ErrAvailInvalidDate      AvailabilityServiceError = "geçersiz tarih"
+	ErrAvailFeedNotFound     AvailabilityServiceError = "takvim beslemesi bulunamadı"
+)
+
+// DefaultAppointmentTimezone randevu hizmetinde zaman dilimi yoksa kullanılır.
+const DefaultAppointmentTimezone = "Europe/Istanbul"
+
+// IAvailabilityService çalışma saatleri, tarih istisnaları ve slot üretimi için arayüz.
+type IAvailabilityService interface {
+	GetWeeklyRules(ctx context.Context, appointmentID uint, requestingUserID uint) ([]models.AppointmentAvailabilityRule, error)
+	UpdateWeeklyRules(ctx context.Context, appointmentID uint, updatingUserID uint, rules []models.AppointmentAvailabilityRule) error
+	GetOverrides(ctx context.Context, providerUserID uint, from, to time.Time) ([]models.AppointmentAvailabilityOverride, error)
+	CreateOverride(ctx context.Context, providerUserID uint, override models.AppointmentAvailabilityOverride) error
+	DeleteOverride(ctx context.Context, id uint, deletingUserID uint) error
+	GetProviderSetting(ctx context.Context, userID uint) (*models.ProviderSetting, error)
+	UpdateProviderSetting(ctx context.Context, userID uint, observePublicHolidays bool) error
+	RegenerateCalendarFeedToken(ctx context.Context, userID uint) (string, error)
+	GetProviderSettingByFeedToken(ctx context.Context, token string) (*models.ProviderSetting, error)
+	GetDayWindows(ctx context.Context, appointment *models.Appointment, day time.Time) ([]slots.Interval, error)
+	GetAvailableSlots(ctx context.Context, appointment *models.Appointment, day time.Time) ([]AvailableSlot, error)
+	GetSeriesSlots(ctx context.Context, appointment *models.Appointment, day time.Time) ([]AvailableSlot, error)
+	GetBusyIntervals(ctx context.Context, appointment *models.Appointment, from, to time.Time) ([]slots.Interval, error)
+}
+
+// AvailableSlot rezervasyona açık bir slot ve içinde kalan yer sayısıdır.
+type AvailableSlot struct {
+	slots.Interval
+	SeatsLeft int `json:"seats_left"`
+}
+
+// AvailabilityService IAvailabilityService arayüzünü uygular.
+type AvailabilityService struct {
+	repo               repositories.IAvailabilityRepository
+	bookingRepo        repositories.IAppointmentBookingRepository
+	externalRepo       repositories.IExternalCalendarRepository
+	waitlistRepo       repositories.IBookingWaitlistRepository
+	appointmentService IAppointmentService
+	now                func() time.Time
+}
+
+// NewAvailabilityService yeni bir AvailabilityService örneği oluşturur.
+func NewAvailabilityService() IAvailabilityService {
+	return &AvailabilityService{
+		repo:               repositories.NewAvailabilityRepository(),
+		bookingRepo:        repositories.NewAppointmentBookingRepository(),
+		externalRepo:       repositories.NewExternalCalendarRepository(),
+		waitlistRepo:       repositories.NewBookingWaitlistRepository(),
+		appointmentService: NewAppointmentService(),
+		now:                time.Now,
+	}
+}
+
+// --- Resmi Tatiller ---
+
+// holidayCalendar HOLIDAYS_YEAR_FROM / HOLIDAYS_YEAR_TO aralığındaki yılların tatil
+// takvimleridir. İlk kullanımda bir kez hesaplanır ve sonra yalnızca okunur.
+var holidayCalendar struct {
+	once  sync.Once
+	years map[int]map[string]holidays.Holiday
+}
+
+// holidayYearRange tatil takviminin yıl aralığını okur ve Diyanet tablosunun kapsadığı yıllara sıkıştırır.
+func holidayYearRange() (int, int) {
+	currentYear := time.Now().Year()
+	from := configsenv.GetEnvAsInt("HOLIDAYS_YEAR_FROM", currentYear)
+	to := configsenv.GetEnvAsInt("HOLIDAYS_YEAR_TO", currentYear+2)
+	if to < from {
+		configslog.SLog.Warnf("HOLIDAYS_YEAR_TO (%d) HOLIDAYS_YEAR_FROM (%d) değerinden küçük, tek yıl kullanılacak.", to, from)
+		to = from
+	}
+	officialFrom, officialTo := holidays.OfficialYears()
+	clampedFrom := min(max(from, officialFrom), officialTo)
+	clampedTo := min(max(to, officialFrom), officialTo)
+	if clampedFrom != from || clampedTo != to {
+		configslog.SLog.Warnf("Tatil yılı aralığı %d-%d, Diyanet tablosuna göre %d-%d olarak sınırlandı; dışındaki yıllarda resmi tatil uygulanmaz.", from, to, clampedFrom, clampedTo)
+	}
+	return clampedFrom, clampedTo
+}
+
+// holidaysForYear yalnızca verilen yıla düşen tatilleri tarih anahtarlı olarak döndürür. Komşu
+// yıllar da hesaplanır; böylece Aralık'ta başlayıp Ocak'a taşan bayramlar ve önceki yıla düşen
+// arifeler de bulunur.
+func holidaysForYear(year int) map[string]holidays.Holiday {
+	var list []holidays.Holiday
+	for _, h := range holidays.TurkishPublicHolidays(year-1, year+1) {
+		if h.Date.Year() == year {
+			list = append(list, h)
+		}
+	}
+	return holidays.ByDate(list)
+}
+
+// buildHolidayCalendar [from, to] aralığındaki her yılın takvimini hesaplar.
+func buildHolidayCalendar(from, to int) map[int]map[string]holidays.Holiday {
+	years := make(map[int]map[string]holidays.Holiday, to-from+1)
+	for year := from; year <= to; year++ {
+		years[year] = holidaysForYear(year)
+	}
+	return years
+}
+
+// publicHolidays verilen yılın tatil takvimini tarih anahtarlı olarak döndürür. Yapılandırılan
+// aralık dışındaki yıllar için nil döner; o yıllarda resmi tatil uygulanmaz.
+func publicHolidays(year int) map[string]holidays.Holiday {
+	holidayCalendar.once.Do(func() {
+		from, to := holidayYearRange()
+		holidayCalendar.years = buildHolidayCalendar(from, to)
+		configslog.SLog.Infof("Resmi tatil takvimi hazırlandı: %d-%d", from, to)
+	})
+	return holidayCalendar.years[year]
+}
+
+// --- Yardımcı Metodlar ---
+
+// AppointmentLocation randevu hizmetinin zaman dilimini döndürür.
+func AppointmentLocation(detail models.AppointmentDetail) *time.Location {
+	name := detail.Timezone
+	if name == "" {
+		name = DefaultAppointmentTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		configslog.Log.Warn("Geçersiz randevu zaman dilimi, varsayılan kullanılıyor", zap.String("timezone", name), zap.Error(err))
+		loc, _ = time.LoadLocation(DefaultAppointmentTimezone)
+		if loc == nil {
+			loc = time.UTC
+		}
+	}
+	return loc
+}
+
+// SeatCapacity bir slotun kabul ettiği en fazla rezervasyon sayısını döndürür (en az 1).
+func SeatCapacity(detail models.AppointmentDetail) int {
+	if detail.Capacity < 1 {
+		return 1
+	}
+	return detail.Capacity
+}
+
+// validMinuteRange gün içi dakika aralığının geçerli olup olmadığını kontrol eder.
+func validMinuteRange(start, end int) bool {
+	return start >= 0 && end <= 24*60 && start < end
+}
+
+// ValidateAvailabilityRule haftalık kuralı doğrular.
+func ValidateAvailabilityRule(rule models.AppointmentAvailabilityRule) error {
+	if rule.Weekday < 0 || rule.Weekday > 6 {
+		return fmt.Errorf("%w: gün 0-6 arasında olmalı", ErrAvailInvalidRule)
+	}
+	if !validMinuteRange(rule.StartMinute, rule.EndMinute) {
+		return fmt.Errorf("%w: başlangıç saati bitişten önce olmalı", ErrAvailInvalidRule)
+	}
+	return nil
+}
+
+// ValidateAvailabilityOverride tarih istisnasını doğrular.
+func ValidateAvailabilityOverride(override models.AppointmentAvailabilityOverride) error {
+	if override.Date.IsZero() {
+		return fmt.Errorf("%w: tarih zorunludur", ErrAvailInvalidOverride)
+	}
+	if !override.IsClosed && !validMinuteRange(override.StartMinute, override.EndMinute) {
+		return fmt.Errorf("%w: kapalı olmayan gün için geçerli bir saat aralığı girilmeli", ErrAvailInvalidOverride)
+	}
+	return nil
+}
+
+// ParseClockMinutes "15:04" biçimindeki saati gün başından itibaren dakikaya çevirir.
+// Gün sonu için "24:00" kabul edilir.
+func ParseClockMinutes(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: saat SS:DD biçiminde olmalı (%s)", ErrAvailInvalidRule, value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// heldBookings bekleme listesi tekliflerinin tuttuğu slotları, slot motorunun rezervasyonlarla
+// aynı şekilde değerlendirebilmesi için kaydedilmemiş rezervasyonlara çevirir.
+func heldBookings(entries []models.BookingWaitlistEntry) []models.AppointmentBooking {
+	held := make([]models.AppointmentBooking, 0, len(entries))
+	for _, e := range entries {
+		if e.OfferStartsAt == nil || e.OfferEndsAt == nil || e.OfferBusyStartsAt == nil || e.OfferBusyEndsAt == nil {
+			continue
+		}
+		held = append(held, models.AppointmentBooking{
+			AppointmentID:  e.AppointmentID,
+			ProviderUserID: e.ProviderUserID,
+			StartsAt:       *e.OfferStartsAt,
+			EndsAt:         *e.OfferEndsAt,
+			BusyStartsAt:   *e.OfferBusyStartsAt,
+			BusyEndsAt:     *e.OfferBusyEndsAt,
+		})
+	}
+	return held
+}
+
+// dateKey takvim gününü "2006-01-02" biçiminde döndürür.
+func dateKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// --- Servis Metodları ---
+
+// GetWeeklyRules randevu hizmetinin haftalık çalışma saatlerini getirir (yetki kontrolü ile).
+func (s *AvailabilityService) GetWeeklyRules(ctx context.Context, appointmentID uint, requestingUserID uint) ([]models.AppointmentAvailabilityRule, error) {
+	if _, err := s.appointmentService.GetAppointmentByID(ctx, appointmentID, requestingUserID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindRulesByAppointmentID(ctx, appointmentID)
+}
+
+// UpdateWeeklyRules randevu hizmetinin haftalık çalışma saatlerini toptan değiştirir.
+func (s *AvailabilityService) UpdateWeeklyRules(ctx context.Context, appointmentID uint, updatingUserID uint, rules []models.AppointmentAvailabilityRule) error {
+	if _, err := s.appointmentService.GetAppointmentByID(ctx, appointmentID, updatingUserID); err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if err := ValidateAvailabilityRule(rule); err != nil {
+			return err
+		}
+	}
+
+	txCtx := contextWithUserID(ctx, updatingUserID)
+	if err := s.repo.ReplaceRules(txCtx, appointmentID, rules); err != nil {
+		configslog.Log.Error("Haftalık çalışma saatleri güncellenemedi", zap.Uint("appointmentID", appointmentID), zap.Error(err))
+		return ErrAvailUpdateFailed
+	}
+	configslog.SLog.Infof("Haftalık çalışma saatleri güncellendi: Appointment ID %d, %d kural (Güncelleyen: %d)", appointmentID, len(rules), updatingUserID)
+	return nil
+}
+
+// GetOverrides sağlayıcının verilen tarih aralığındaki istisnalarını getirir.
+func (s *AvailabilityService) GetOverrides(ctx context.Context, providerUserID uint, from, to time.Time) ([]models.AppointmentAvailabilityOverride, error) {
+	return s.repo.FindOverridesInRange(ctx, providerUserID, from, to)
+}
+
+// CreateOverride sağlayıcı geneli veya hizmete özel bir tarih istisnası ekler.
+func (s *AvailabilityService) CreateOverride(ctx context.Context, providerUserID uint, override models.AppointmentAvailabilityOverride) error {
+	if err := ValidateAvailabilityOverride(override); err != nil {
+		return err
+	}
+	if override.AppointmentID != nil {
+		// Hizmete özel istisna: hizmet bu sağlayıcıya ait olmalı
+		appointment, err := s.appointmentService.GetAppointmentByID(ctx, *override.AppointmentID, providerUserID)
+		if err != nil {
+			return err
+		}
+		if appointment.ProviderUserID != providerUserID {
+			return ErrAvailForbidden
+		}
+	}
+	override.ProviderUserID = providerUserID
+	override.Date = time.Date(override.Date.Year(), override.Date.Month(), override.Date.Day(), 0, 0, 0, 0, time.UTC)
+	if override.IsClosed {
+		override.StartMinute, override.EndMinute = 0, 0
+	}
+
+	if err := s.repo.CreateOverride(contextWithUserID(ctx, providerUserID), &override); err != nil {
+		configslog.Log.Error("Tarih istisnası oluşturulamadı", zap.Uint("providerUserID", providerUserID), zap.Error(err))
+		return ErrAvailUpdateFailed
+	}
+	return nil
+}
+
+// DeleteOverride sağlayıcıya ait bir tarih istisnasını siler.
+func (s *AvailabilityService) DeleteOverride(ctx context.Context, id uint, deletingUserID uint) error {
+	override, err := s.repo.FindOverrideByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrAvailOverrideNotFound
+		}
+		return err
+	}
+	if override.ProviderUserID != deletingUserID {
+		return ErrAvailForbidden
+	}
+	if err := s.repo.DeleteOverride(contextWithUserID(ctx, deletingUserID), override, deletingUserID); err != nil {
+		return ErrAvailUpdateFailed
+	}
+	return nil
+}
+
+// GetProviderSetting sağlayıcı ayarlarını getirir; kayıt yoksa varsayılanları döndürür.
+func (s *AvailabilityService) GetProviderSetting(ctx context.Context, userID uint) (*models.ProviderSetting, error) {
+	setting, err := s.repo.FindProviderSetting(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return &models.ProviderSetting{UserID: userID}, nil
+		}
+		return nil, err
+	}
+	return setting, nil
+}
+
+// UpdateProviderSetting sağlayıcının resmi tatil tercihini kaydeder.
+func (s *AvailabilityService) UpdateProviderSetting(ctx context.Context, userID uint, observePublicHolidays bool) error {
+	setting, err := s.GetProviderSetting(ctx, userID)
+	if err != nil {
+		return err
+	}
+	setting.ObservePublicHolidays = observePublicHolidays
+	if err := s.repo.SaveProviderSetting(contextWithUserID(ctx, userID), setting); err != nil {
+		configslog.Log.Error("Sağlayıcı ayarları kaydedilemedi", zap.Uint("userID", userID), zap.Error(err))
+		return ErrAvailUpdateFailed
+	}
+	return nil
+}
+
+// RegenerateCalendarFeedToken sağlayıcının gizli ICS abonelik anahtarını yeniler.
+// Eski adres hemen geçersiz olur.
+func (s *AvailabilityService) RegenerateCalendarFeedToken(ctx context.Context, userID uint) (string, error) {
+	setting, err := s.GetProviderSetting(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		configslog.Log.Error("Takvim besleme anahtarı üretilemedi", zap.Error(err))
+		return "", ErrAvailUpdateFailed
+	}
+	token := hex.EncodeToString(buf)
+	setting.CalendarFeedToken = &token
+	if err := s.repo.SaveProviderSetting(contextWithUserID(ctx, userID), setting); err != nil {
+		configslog.Log.Error("Takvim besleme anahtarı kaydedilemedi", zap.Uint("userID", userID), zap.Error(err))
+		return "", ErrAvailUpdateFailed
+	}
+	configslog.SLog.Infof("Takvim besleme anahtarı yenilendi: User ID %d", userID)
+	return token, nil
+}
+
+// GetProviderSettingByFeedToken gizli ICS anahtarına ait sağlayıcı ayarını getirir.
+func (s *AvailabilityService) GetProviderSettingByFeedToken(ctx context.Context, token string) (*models.ProviderSetting, error) {
+	setting, err := s.repo.FindProviderSettingByFeedToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrAvailFeedNotFound
+		}
+		return nil, err
+	}
+	return setting, nil
+}
+
+// GetDayWindows randevu hizmetinin verilen gündeki çalışma pencerelerini hesaplar.
+// Öncelik sırası: hizmete özel istisna > sağlayıcı geneli istisna > resmi tatil > haftalık kural.
+func (s *AvailabilityService) GetDayWindows(ctx context.Context, appointment *models.Appointment, day time.Time) ([]slots.Interval, error) {
+	loc := AppointmentLocation(appointment.Detail)
+	y, m, d := day.Date()
+	localDay := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	civilDay := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	overrides, err := s.repo.FindOverridesInRange(ctx, appointment.ProviderUserID, civilDay, civilDay)
+	if err != nil {
+		return nil, err
+	}
+	var ownOverrides, providerOverrides []models.AppointmentAvailabilityOverride
+	for _, o := range overrides {
+		switch {
+		case o.AppointmentID != nil && *o.AppointmentID == appointment.ID:
+			ownOverrides = append(ownOverrides, o)
+		case o.AppointmentID == nil:
+			providerOverrides = append(providerOverrides, o)
+		}
+	}
+	if len(ownOverrides) > 0 {
+		return overrideWindows(localDay, ownOverrides), nil
+	}
+	if len(providerOverrides) > 0 {
+		return overrideWindows(localDay, providerOverrides), nil
+	}
+
+	rules, err := s.repo.FindRulesByAppointmentID(ctx, appointment.ID)
+	if err != nil {
+		return nil, err
+	}
+	var windows []slots.Interval
+	for _, rule := range rules {
+		if time.Weekday(rule.Weekday) == localDay.Weekday() {
+			windows = append(windows, slots.DayWindow(localDay, rule.StartMinute, rule.EndMinute))
+		}
+	}
+
+	setting, err := s.GetProviderSetting(ctx, appointment.ProviderUserID)
+	if err != nil {
+		return nil, err
+	}
+	if setting.ObservePublicHolidays {
+		if holiday, ok := publicHolidays(civilDay.Year())[dateKey(civilDay)]; ok {
+			if !holiday.HalfDay {
+				return nil, nil
+			}
+			// Arife: öğleden sonrası kapalı
+			afternoon := slots.DayWindow(localDay, holidays.HalfDayStartMinute, 24*60)
+			windows = slots.Subtract(windows, []slots.Interval{afternoon})
+		}
+	}
+	return slots.Merge(windows), nil
+}
+
+// overrideWindows istisna kayıtlarından o günün pencerelerini üretir; kapalı kayıt varsa gün kapalıdır.
+func overrideWindows(localDay time.Time, overrides []models.AppointmentAvailabilityOverride) []slots.Interval {
+	var windows []slots.Interval
+	for _, o := range overrides {
+		if o.IsClosed {
+			return nil
+		}
+		windows = append(windows, slots.DayWindow(localDay, o.StartMinute, o.EndMinute))
+	}
+	return slots.Merge(windows)
+}
+
+// GetAvailableSlots verilen gün için rezervasyona açık slotları döndürür.
+// Lead time ve rezervasyon ufku AppointmentDetail'den alınır; sağlayıcının mevcut
+// rezervasyonları meşgul kabul edilir. Grup seanslarında (Capacity > 1) hizmetin kendi
+// rezervasyonları, seans dolana kadar aynı başlangıç saatine yeni katılımcı alınmasına izin verir.
+func (s *AvailabilityService) GetAvailableSlots(ctx context.Context, appointment *models.Appointment, day time.Time) ([]AvailableSlot, error) {
+	return s.availableSlots(ctx, appointment, day, true)
+}
+
+// GetSeriesSlots tekrarlayan seri tekrarları için GetAvailableSlots ile aynı kontrolleri yapar,
+// ancak rezervasyon ufkunu uygulamaz: seriler ufkun aylarca ötesine uzanabilir.
+func (s *AvailabilityService) GetSeriesSlots(ctx context.Context, appointment *models.Appointment, day time.Time) ([]AvailableSlot, error) {
+	return s.availableSlots(ctx, appointment, day, false)
+}
+
+// availableSlots slot üretiminin ortak gövdesi; applyHorizon false ise BookingHorizonDays yok sayılır.
+func (s *AvailabilityService) availableSlots(ctx context.Context, appointment *models.Appointment, day time.Time, applyHorizon bool) ([]AvailableSlot, error) {
+	detail := appointment.Detail
+	capacity := SeatCapacity(detail)
+	now := s.now().UTC()
+	notBefore := now.Add(time.Duration(detail.BookingLeadTime) * time.Minute)
+	var notAfter time.Time
+	if applyHorizon {
+		notAfter = now.AddDate(0, 0, detail.BookingHorizonDays)
+	}
+	bufferBefore := time.Duration(detail.BufferTimeBefore) * time.Minute
+	bufferAfter := time.Duration(detail.BufferTimeAfter) * time.Minute
+
+	windows, err := s.GetDayWindows(ctx, appointment, day)
+	if err != nil {
+		configslog.Log.Error("Çalışma pencereleri hesaplanamadı", zap.Uint("appointmentID", appointment.ID), zap.Error(err))
+		return nil, err
+	}
+	if len(windows) == 0 {
+		return []AvailableSlot{}, nil
+	}
+
+	bookings, err := s.bookingRepo.FindBusyInRange(ctx, appointment.ProviderUserID, appointment.ID, !detail.AllowParallelBookings,
+		windows[0].Start.Add(-bufferBefore), windows[len(windows)-1].End.Add(bufferAfter))
+	if err != nil {
+		configslog.Log.Error("Meşgul aralıklar alınamadı", zap.Uint("appointmentID", appointment.ID), zap.Error(err))
+		return nil, err
+	}
+	// Bekleme listesine teklif edilen slotlar kabul süresi dolana kadar rezervasyon gibi yer kaplar
+	held, err := s.waitlistRepo.FindHeldInRange(ctx, appointment.ProviderUserID, appointment.ID, !detail.AllowParallelBookings,
+		windows[0].Start.Add(-bufferBefore), windows[len(windows)-1].End.Add(bufferAfter), now)
+	if err != nil {
+		configslog.Log.Error("Bekleme listesi tutmaları alınamadı", zap.Uint("appointmentID", appointment.ID), zap.Error(err))
+		return nil, err
+	}
+	bookings = append(bookings, heldBookings(held)...)
+
+	// Diğer hizmetlerin rezervasyonları ve dolu seanslar meşguldür; dolmamış seanslar
+	// yalnızca kendi başlangıç saatlerine katılım için açık kalır.
+	var busy, openSessions []slots.Interval
+	seatsTaken := make(map[int64]int)
+	sessionSpans := make(map[int64]slots.Interval)
+	for _, b := range bookings {
+		span := slots.Interval{Start: b.BusyStartsAt, End: b.BusyEndsAt}
+		if b.AppointmentID != appointment.ID || capacity == 1 {
+			busy = append(busy, span)
+			continue
+		}
+		seatsTaken[b.StartsAt.Unix()]++
+		sessionSpans[b.StartsAt.Unix()] = span
+	}
+	for start, taken := range seatsTaken {
+		if taken >= capacity {
+			busy = append(busy, sessionSpans[start])
+		} else {
+			openSessions = append(openSessions, sessionSpans[start])
+		}
+	}
+
+	// Dış takvimlerden (ICS) içe aktarılan etkinlikler tüm hizmetler için meşguldür
+	external, err := s.externalRepo.FindBlocksInRange(ctx, appointment.ProviderUserID,
+		windows[0].Start.Add(-bufferBefore), windows[len(windows)-1].End.Add(bufferAfter))
+	if err != nil {
+		configslog.Log.Error("Dış takvim blokları alınamadı", zap.Uint("appointmentID", appointment.ID), zap.Error(err))
+		return nil, err
+	}
+	for _, b := range external {
+		busy = append(busy, slots.Interval{Start: b.StartsAt, End: b.EndsAt})
+	}
+
+	candidates := slots.Generate(windows, busy, slots.Options{
+		Duration:     time.Duration(detail.DurationMinutes) * time.Minute,
+		BufferBefore: bufferBefore,
+		BufferAfter:  bufferAfter,
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	})
+
+	available := make([]AvailableSlot, 0, len(candidates))
+	for _, c := range candidates {
+		if taken, ok := seatsTaken[c.Start.Unix()]; ok {
+			available = append(available, AvailableSlot{Interval: c, SeatsLeft: capacity - taken})
+			continue
+		}
+		blocked := slots.Interval{Start: c.Start.Add(-bufferBefore), End: c.End.Add(bufferAfter)}
+		overlapsSession := false
+		for _, session := range openSessions {
+			if blocked.Overlaps(session) {
+				overlapsSession = true
+				break
+			}
+		}
+		if !overlapsSession {
+			available = append(available, AvailableSlot{Interval: c, SeatsLeft: capacity})
+		}
+	}
+	return available, nil
+}
+
+// GetBusyIntervals hizmet için [from, to) aralığında dolu olan zamanları döndürür.
+// Hizmet AllowParallelBookings ile işaretlenmediyse sağlayıcının (ProviderUserID) paralel
+// rezervasyona kapalı tüm hizmetlerindeki rezervasyonlar meşgul sayılır; aksi halde
+// yalnızca hizmetin kendi rezervasyonları dikkate alınır. Bekleme listesi teklifleriyle tutulan
+// slotlar rezervasyonlarla aynı kapsamda, dış takvim blokları her zaman meşguldür.
+func (s *AvailabilityService) GetBusyIntervals(ctx context.Context, appointment *models.Appointment, from, to time.Time) ([]slots.Interval, error) {
+	includeShared := !appointment.Detail.AllowParallelBookings
+	bookings, err := s.bookingRepo.FindBusyInRange(ctx, appointment.ProviderUserID, appointment.ID, includeShared, from, to)
+	if err != nil {
+		return nil, err
+	}
+	held, err := s.waitlistRepo.FindHeldInRange(ctx, appointment.ProviderUserID, appointment.ID, includeShared, from, to, s.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	bookings = append(bookings, heldBookings(held)...)
+	external, err := s.externalRepo.FindBlocksInRange(ctx, appointment.ProviderUserID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	busy := make([]slots.Interval, 0, len(bookings)+len(external))
+	for _, b := range bookings {
+		busy = append(busy, slots.Interval{Start: b.BusyStartsAt, End: b.BusyEndsAt})
+	}
+	for _, b := range external {
+		busy = append(busy, slots.Interval{Start: b.StartsAt, End: b.EndsAt})
+	}
+	return slots.Merge(busy), nil
+}
+
+var _ IAvailabilityService = (*AvailabilityService)(nil)
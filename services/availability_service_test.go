@@ -0,0 +1,67 @@
+package services
+
+import "testing"
+
+// Her yılın takvimi komşu yıllardan taşan bayram günlerini de içerir.
+func TestHolidaysForYear(t *testing.T) {
+	tests := []struct {
+		year    int
+		date    string
+		name    string
+		halfDay bool
+	}{
+		{2026, "2026-10-29", "Cumhuriyet Bayramı", false},
+		{2030, "2030-04-13", "Kurban Bayramı", false},
+		{2030, "2030-04-12", "Kurban Bayramı Arifesi", true},
+		{2035, "2035-01-01", "Yılbaşı", false},
+		// Bir sonraki yıl başlayan bayramın arifesi ve önceki yıl başlayıp Ocak'a taşan bayram günü
+		{2071, "2071-12-31", "Kurban Bayramı Arifesi", true},
+		{2170, "2170-01-01", "Kurban Bayramı", false},
+	}
+	for _, tt := range tests {
+		holiday, ok := holidaysForYear(tt.year)[tt.date]
+		if !ok {
+			t.Errorf("%s tatil olmalı", tt.date)
+			continue
+		}
+		if holiday.Name != tt.name || holiday.HalfDay != tt.halfDay {
+			t.Errorf("%s: beklenen %q/%v, alınan %q/%v", tt.date, tt.name, tt.halfDay, holiday.Name, holiday.HalfDay)
+		}
+	}
+	if _, ok := holidaysForYear(2030)["2030-04-20"]; ok {
+		t.Errorf("2030-04-20 tatil olmamalı")
+	}
+	for date := range holidaysForYear(2071) {
+		if date[:4] != "2071" {
+			t.Errorf("2071 takviminde başka yılın günü var: %s", date)
+		}
+	}
+}
+
+// Takvim yalnızca yapılandırılan yılları içerir; aralık Diyanet tablosuna sıkıştırılır.
+func TestHolidayCalendarRange(t *testing.T) {
+	calendar := buildHolidayCalendar(2026, 2027)
+	if len(calendar) != 2 || calendar[2026] == nil || calendar[2027] == nil {
+		t.Fatalf("2026 ve 2027 beklenirdi, alınan %d yıl", len(calendar))
+	}
+	if _, ok := calendar[2028]; ok {
+		t.Error("aralık dışındaki yıl takvimde olmamalı")
+	}
+
+	tests := []struct {
+		from, to         string
+		wantFrom, wantTo int
+	}{
+		{"2026", "2028", 2026, 2028},
+		{"2015", "2040", 2020, 2030},
+		{"2028", "2026", 2028, 2028},
+		{"2035", "2040", 2030, 2030},
+	}
+	for _, tt := range tests {
+		t.Setenv("HOLIDAYS_YEAR_FROM", tt.from)
+		t.Setenv("HOLIDAYS_YEAR_TO", tt.to)
+		if from, to := holidayYearRange(); from != tt.wantFrom || to != tt.wantTo {
+			t.Errorf("%s-%s: beklenen %d-%d, alınan %d-%d", tt.from, tt.to, tt.wantFrom, tt.wantTo, from, to)
+		}
+	}
+}
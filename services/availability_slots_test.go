@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"davet.link/models"
+	"davet.link/repositories"
+)
+
+// fakeAvailabilityRepo sabit haftalık kuralları döndürür; istisna ve sağlayıcı ayarı yoktur.
+type fakeAvailabilityRepo struct {
+	repositories.IAvailabilityRepository
+	rules []models.AppointmentAvailabilityRule
+}
+
+func (r *fakeAvailabilityRepo) FindRulesByAppointmentID(ctx context.Context, appointmentID uint) ([]models.AppointmentAvailabilityRule, error) {
+	return r.rules, nil
+}
+
+func (r *fakeAvailabilityRepo) FindOverridesInRange(ctx context.Context, providerUserID uint, from, to time.Time) ([]models.AppointmentAvailabilityOverride, error) {
+	return nil, nil
+}
+
+func (r *fakeAvailabilityRepo) FindProviderSetting(ctx context.Context, userID uint) (*models.ProviderSetting, error) {
+	return nil, repositories.ErrNotFound
+}
+
+// fakeBusyRepo FindBusyInRange kapsamını bellekte uygular: hizmetin kendi rezervasyonları
+// her zaman, paralel rezervasyona kapalı diğer hizmetlerinkiler includeShared ile döner.
+type fakeBusyRepo struct {
+	repositories.IAppointmentBookingRepository
+	bookings []models.AppointmentBooking
+	parallel map[uint]bool
+}
+
+func (r *fakeBusyRepo) FindBusyInRange(ctx context.Context, providerUserID uint, appointmentID uint, includeShared bool, from, to time.Time) ([]models.AppointmentBooking, error) {
+	var result []models.AppointmentBooking
+	for _, b := range r.bookings {
+		if b.ProviderUserID != providerUserID || !b.BusyStartsAt.Before(to) || !b.BusyEndsAt.After(from) {
+			continue
+		}
+		if b.AppointmentID == appointmentID || (includeShared && !r.parallel[b.AppointmentID]) {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+
+// fakeHeldRepo bekleme listesi tutması olmayan bir depo.
+type fakeHeldRepo struct {
+	repositories.IBookingWaitlistRepository
+}
+
+func (r *fakeHeldRepo) FindHeldInRange(ctx context.Context, providerUserID uint, appointmentID uint, includeShared bool, from, to, now time.Time) ([]models.BookingWaitlistEntry, error) {
+	return nil, nil
+}
+
+// fakeExternalRepo sabit dış takvim bloklarını döndürür.
+type fakeExternalRepo struct {
+	repositories.IExternalCalendarRepository
+	blocks []models.ExternalBusyBlock
+}
+
+func (r *fakeExternalRepo) FindBlocksInRange(ctx context.Context, userID uint, from, to time.Time) ([]models.ExternalBusyBlock, error) {
+	return r.blocks, nil
+}
+
+// slotDay kurallı bir pazartesidir; saatler UTC'dir.
+var slotDay = time.Date(2030, 3, 4, 0, 0, 0, 0, time.UTC)
+
+func slotAt(hour, minute int) time.Time {
+	return slotDay.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func busyBooking(appointmentID uint, hour int) models.AppointmentBooking {
+	start := slotAt(hour, 0)
+	return models.AppointmentBooking{
+		AppointmentID:  appointmentID,
+		ProviderUserID: 7,
+		StartsAt:       start,
+		BusyStartsAt:   start,
+		BusyEndsAt:     start.Add(time.Hour),
+	}
+}
+
+// Sağlayıcının diğer hizmetlerindeki rezervasyonlar, paralel rezervasyona izin verilmedikçe
+// slotları kapatır; grup seansları yalnızca kendi hizmetinin katılımcılarıyla dolar.
+func TestAvailableSlotsOverlapRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		detail   models.AppointmentDetail
+		bookings []models.AppointmentBooking
+		parallel map[uint]bool
+		blocks   []models.ExternalBusyBlock
+		want     []string
+		seats    []int
+	}{
+		{
+			name:  "boş gün",
+			want:  []string{"09:00", "10:00", "11:00", "12:00"},
+			seats: []int{1, 1, 1, 1},
+		},
+		{
+			name:     "başka hizmetin rezervasyonu",
+			bookings: []models.AppointmentBooking{busyBooking(2, 10)},
+			want:     []string{"09:00", "11:00", "12:00"},
+			seats:    []int{1, 1, 1},
+		},
+		{
+			name:     "tampon başka hizmetin rezervasyonuna taşıyor",
+			detail:   models.AppointmentDetail{BufferTimeAfter: 30},
+			bookings: []models.AppointmentBooking{busyBooking(2, 10)},
+			want:     []string{"11:00", "12:00"},
+			seats:    []int{1, 1},
+		},
+		{
+			name:     "diğer hizmet paralel rezervasyona açık",
+			bookings: []models.AppointmentBooking{busyBooking(2, 10)},
+			parallel: map[uint]bool{2: true},
+			want:     []string{"09:00", "10:00", "11:00", "12:00"},
+			seats:    []int{1, 1, 1, 1},
+		},
+		{
+			name:     "hizmet paralel rezervasyona açık",
+			detail:   models.AppointmentDetail{AllowParallelBookings: true},
+			bookings: []models.AppointmentBooking{busyBooking(2, 10)},
+			want:     []string{"09:00", "10:00", "11:00", "12:00"},
+			seats:    []int{1, 1, 1, 1},
+		},
+		{
+			name:     "dolmamış grup seansı",
+			detail:   models.AppointmentDetail{Capacity: 3},
+			bookings: []models.AppointmentBooking{busyBooking(1, 10), busyBooking(1, 10)},
+			want:     []string{"09:00", "10:00", "11:00", "12:00"},
+			seats:    []int{3, 1, 3, 3},
+		},
+		{
+			name:     "dolu grup seansı",
+			detail:   models.AppointmentDetail{Capacity: 2},
+			bookings: []models.AppointmentBooking{busyBooking(1, 10), busyBooking(1, 10)},
+			want:     []string{"09:00", "11:00", "12:00"},
+			seats:    []int{2, 2, 2},
+		},
+		{
+			name:     "grup seansına başka hizmetin rezervasyonu",
+			detail:   models.AppointmentDetail{Capacity: 3},
+			bookings: []models.AppointmentBooking{busyBooking(2, 10)},
+			want:     []string{"09:00", "11:00", "12:00"},
+			seats:    []int{3, 3, 3},
+		},
+		{
+			name:   "dış takvim bloğu",
+			blocks: []models.ExternalBusyBlock{{UserID: 7, StartsAt: slotAt(12, 0), EndsAt: slotAt(12, 30)}},
+			want:   []string{"09:00", "10:00", "11:00"},
+			seats:  []int{1, 1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail := tt.detail
+			detail.DurationMinutes = 60
+			detail.BookingHorizonDays = 30
+			detail.Timezone = "UTC"
+			appointment := &models.Appointment{ProviderUserID: 7, Detail: detail}
+			appointment.ID = 1
+
+			service := &AvailabilityService{
+				repo: &fakeAvailabilityRepo{rules: []models.AppointmentAvailabilityRule{
+					{Weekday: int(time.Monday), StartMinute: 9 * 60, EndMinute: 13 * 60},
+				}},
+				bookingRepo:  &fakeBusyRepo{bookings: tt.bookings, parallel: tt.parallel},
+				externalRepo: &fakeExternalRepo{blocks: tt.blocks},
+				waitlistRepo: &fakeHeldRepo{},
+				now:          func() time.Time { return slotDay.AddDate(0, 0, -3) },
+			}
+			available, err := service.GetAvailableSlots(context.Background(), appointment, slotDay)
+			if err != nil {
+				t.Fatalf("beklenmeyen hata: %v", err)
+			}
+			starts := make([]string, 0, len(available))
+			seats := make([]int, 0, len(available))
+			for _, slot := range available {
+				starts = append(starts, slot.Start.Format("15:04"))
+				seats = append(seats, slot.SeatsLeft)
+			}
+			if !reflect.DeepEqual(starts, tt.want) || !reflect.DeepEqual(seats, tt.seats) {
+				t.Errorf("beklenen %v %v, alınan %v %v", tt.want, tt.seats, starts, seats)
+			}
+		})
+	}
+}
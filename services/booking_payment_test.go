@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"davet.link/models"
+	"davet.link/pkg/payment"
+	"davet.link/repositories"
+)
+
+// fakePaymentRepo kaydedilen ödeme hareketlerini bellekte tutar; err verilirse kayıt başarısız olur.
+type fakePaymentRepo struct {
+	repositories.IBookingPaymentRepository
+	created []models.BookingPayment
+	err     error
+}
+
+func (r *fakePaymentRepo) Create(ctx context.Context, p *models.BookingPayment) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.created = append(r.created, *p)
+	return nil
+}
+
+// fakeBookingRepo rezervasyon güncellemelerini bellekte tutar.
+type fakeBookingRepo struct {
+	repositories.IAppointmentBookingRepository
+	updates []map[string]interface{}
+}
+
+func (r *fakeBookingRepo) Update(ctx context.Context, booking *models.AppointmentBooking, data map[string]interface{}) error {
+	r.updates = append(r.updates, data)
+	return nil
+}
+
+// refundingProvider FakeProvider üzerinden yapılan iadeleri kaydeder.
+type refundingProvider struct {
+	*payment.FakeProvider
+	refunded []string
+}
+
+func (p *refundingProvider) Refund(ctx context.Context, chargeID string, amountMinor int64) (*payment.Refund, error) {
+	p.refunded = append(p.refunded, chargeID)
+	return p.FakeProvider.Refund(ctx, chargeID, amountMinor)
+}
+
+func paymentBooking(status models.BookingStatus) *models.AppointmentBooking {
+	booking := &models.AppointmentBooking{Status: status, Currency: "TRY", AmountDueMinor: 20000, DepositMinor: 5000}
+	booking.ID = 9
+	return booking
+}
+
+func TestChargeNewBookingChargesDeposit(t *testing.T) {
+	provider := &refundingProvider{FakeProvider: payment.NewFakeProvider()}
+	payments, bookings := &fakePaymentRepo{}, &fakeBookingRepo{}
+	booking := paymentBooking(models.BookingStatusConfirmed)
+
+	charge, err := chargeNewBooking(context.Background(), provider, payments, bookings, booking, "tok_ok")
+	if err != nil {
+		t.Fatalf("beklenmeyen hata: %v", err)
+	}
+	if charge == nil || charge.AmountMinor != 5000 {
+		t.Fatalf("kapora çekilmeli, alınan %+v", charge)
+	}
+	if len(payments.created) != 1 || payments.created[0].ProviderRef != charge.ID {
+		t.Fatalf("ödeme hareketi kaydedilmeli, alınan %+v", payments.created)
+	}
+	if booking.PaidMinor != 5000 || booking.PaymentStatus != models.PaymentStatusPartiallyPaid {
+		t.Errorf("beklenen 5000/%s, alınan %d/%s", models.PaymentStatusPartiallyPaid, booking.PaidMinor, booking.PaymentStatus)
+	}
+	if len(provider.refunded) != 0 {
+		t.Errorf("iade yapılmamalı, alınan %v", provider.refunded)
+	}
+}
+
+func TestChargeNewBookingSkips(t *testing.T) {
+	tests := []struct {
+		name    string
+		booking *models.AppointmentBooking
+		token   string
+	}{
+		{"onay bekleyen", paymentBooking(models.BookingStatusPending), "tok_ok"},
+		{"anahtar yok", paymentBooking(models.BookingStatusConfirmed), ""},
+		{"ücretsiz", &models.AppointmentBooking{Status: models.BookingStatusConfirmed, Currency: "TRY"}, "tok_ok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payments := &fakePaymentRepo{}
+			charge, err := chargeNewBooking(context.Background(), nil, payments, &fakeBookingRepo{}, tt.booking, tt.token)
+			if err != nil || charge != nil {
+				t.Fatalf("ödeme alınmamalı, alınan %+v / %v", charge, err)
+			}
+			if len(payments.created) != 0 {
+				t.Errorf("ödeme hareketi kaydedilmemeli, alınan %+v", payments.created)
+			}
+		})
+	}
+}
+
+// Başarısız ödemede hata döner ve transaction geri alınır; kaydedilemeyen çekim iade edilir.
+func TestChargeNewBookingFailures(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		repoErr    error
+		wantErr    error
+		wantRefund bool
+	}{
+		{"sağlayıcı reddetti", payment.FakeDeclineToken, nil, ErrPaymentDeclined, false},
+		{"ödeme kaydedilemedi", "tok_ok", errors.New("db kapalı"), ErrPaymentSaveFailed, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &refundingProvider{FakeProvider: payment.NewFakeProvider()}
+			payments, bookings := &fakePaymentRepo{err: tt.repoErr}, &fakeBookingRepo{}
+			booking := paymentBooking(models.BookingStatusConfirmed)
+
+			charge, err := chargeNewBooking(context.Background(), provider, payments, bookings, booking, tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("beklenen %v, alınan %v", tt.wantErr, err)
+			}
+			if charge != nil {
+				t.Errorf("başarısız ödemede çekim dönmemeli, alınan %+v", charge)
+			}
+			if got := len(provider.refunded) == 1; got != tt.wantRefund {
+				t.Errorf("iade beklenen %v, alınan %v", tt.wantRefund, provider.refunded)
+			}
+			if len(bookings.updates) != 0 || booking.PaidMinor != 0 {
+				t.Errorf("rezervasyon ödenmiş işaretlenmemeli, alınan %+v", bookings.updates)
+			}
+		})
+	}
+}
+
+// Public rezervasyonda alınan ödeme panelden iade edilebilmeli: iki servis de payment.New ile
+// aynı sağlayıcıyı almalıdır.
+func TestBookingChargeRefundedThroughPaymentService(t *testing.T) {
+	t.Setenv("PAYMENT_PROVIDER", "fake")
+	bookingService := &BookingService{provider: payment.New()}
+	paymentService := &PaymentService{provider: payment.New()}
+	if bookingService.provider == nil {
+		t.Fatal("PAYMENT_PROVIDER=fake iken sağlayıcı dönmeli")
+	}
+
+	payments := &fakePaymentRepo{}
+	booking := paymentBooking(models.BookingStatusConfirmed)
+	charge, err := chargeNewBooking(context.Background(), bookingService.provider, payments, &fakeBookingRepo{}, booking, "tok_ok")
+	if err != nil || charge == nil {
+		t.Fatalf("ödeme alınmalı, alınan %+v / %v", charge, err)
+	}
+
+	original := payments.created[0]
+	refund := &models.BookingPayment{Kind: models.PaymentKindRefund, Method: original.Method, AmountMinor: 2000}
+	if err := refundProviderPayment(context.Background(), paymentService.provider, &original, refund); err != nil {
+		t.Fatalf("rezervasyonda alınan ödeme iade edilebilmeli: %v", err)
+	}
+	if refund.Provider != original.Provider || refund.ProviderRef == "" {
+		t.Errorf("iade referansı yazılmalı, alınan %+v", refund)
+	}
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+// BookingQuestionServiceError özel servis hataları
+type BookingQuestionServiceError string
+
+func (e BookingQuestionServiceError) Error() string { return string(e) }
+
+const (
+	ErrQuestionNotFound      BookingQuestionServiceError = "soru bulunamadı"
+	ErrQuestionForbidden     BookingQuestionServiceError = "bu işlem için yetkiniz yok"
+	ErrQuestionInvalid       BookingQuestionServiceError = "geçersiz soru"
+	ErrQuestionSaveFailed    BookingQuestionServiceError = "soru kaydedilemedi"
+	ErrQuestionDeletionError BookingQuestionServiceError = "soru silinemedi"
+)
+
+// Cevap ve soru uzunluk sınırları (karakter).
+const (
+	maxQuestionLabelLength = 255
+	maxAnswerLength        = 2000
+)
+
+// Onay kutusu cevaplarının kayıtta ve panelde görünen karşılıkları.
+const (
+	checkboxAnswerYes = "Evet"
+	checkboxAnswerNo  = "Hayır"
+)
+
+// IBookingQuestionService randevu hizmetlerinin rezervasyon soruları için arayüz.
+type IBookingQuestionService interface {
+	GetQuestions(ctx context.Context, appointmentID uint, requestingUserID uint) ([]models.AppointmentQuestion, error)
+	GetPublicQuestions(ctx context.Context, appointmentID uint) ([]models.AppointmentQuestion, error)
+	CreateQuestion(ctx context.Context, appointmentID uint, creatingUserID uint, question models.AppointmentQuestion) error
+	DeleteQuestion(ctx context.Context, id uint, deletingUserID uint) error
+}
+
+// BookingQuestionService IBookingQuestionService arayüzünü uygular.
+type BookingQuestionService struct {
+	repo               repositories.IAppointmentQuestionRepository
+	appointmentService IAppointmentService
+}
+
+// NewBookingQuestionService yeni bir BookingQuestionService örneği oluşturur.
+func NewBookingQuestionService() IBookingQuestionService {
+	return &BookingQuestionService{
+		repo:               repositories.NewAppointmentQuestionRepository(),
+		appointmentService: NewAppointmentService(),
+	}
+}
+
+// --- Yardımcı Metodlar ---
+
+// ValidateQuestion soru tanımını doğrular ve boşlukları temizler.
+func ValidateQuestion(question *models.AppointmentQuestion) error {
+	question.Label = strings.TrimSpace(question.Label)
+	if question.Label == "" {
+		return fmt.Errorf("%w: soru metni zorunludur", ErrQuestionInvalid)
+	}
+	if utf8.RuneCountInString(question.Label) > maxQuestionLabelLength {
+		return fmt.Errorf("%w: soru metni en fazla %d karakter olabilir", ErrQuestionInvalid, maxQuestionLabelLength)
+	}
+	switch question.Type {
+	case models.QuestionTypeText, models.QuestionTypeCheckbox:
+		question.Options = ""
+	case models.QuestionTypeChoice:
+		options := question.OptionList()
+		if len(options) < 2 {
+			return fmt.Errorf("%w: seçmeli sorular için en az iki seçenek girilmelidir", ErrQuestionInvalid)
+		}
+		question.Options = strings.Join(options, "\n")
+	default:
+		return fmt.Errorf("%w: bilinmeyen soru tipi", ErrQuestionInvalid)
+	}
+	return nil
+}
+
+// ValidateBookingAnswers public formdan gelen cevapları (soru ID -> değer) hizmetin
+// sorularına göre doğrular ve kaydedilecek cevap listesini döndürür.
+// Tanımlı olmayan sorulara verilen cevaplar yok sayılır.
+func ValidateBookingAnswers(questions []models.AppointmentQuestion, answers map[uint]string) ([]models.AppointmentBookingAnswer, error) {
+	result := make([]models.AppointmentBookingAnswer, 0, len(questions))
+	for _, q := range questions {
+		value := strings.TrimSpace(answers[q.ID])
+		switch q.Type {
+		case models.QuestionTypeCheckbox:
+			checked := value == "true" || value == "on" || value == "1"
+			if q.Required && !checked {
+				return nil, fmt.Errorf("%w: %q işaretlenmelidir", ErrBookingInvalidInput, q.Label)
+			}
+			value = checkboxAnswerNo
+			if checked {
+				value = checkboxAnswerYes
+			}
+		case models.QuestionTypeChoice:
+			if value != "" {
+				valid := false
+				for _, option := range q.OptionList() {
+					if option == value {
+						valid = true
+						break
+					}
+				}
+				if !valid {
+					return nil, fmt.Errorf("%w: %q için geçersiz seçim", ErrBookingInvalidInput, q.Label)
+				}
+			}
+		default:
+			if utf8.RuneCountInString(value) > maxAnswerLength {
+				return nil, fmt.Errorf("%w: %q cevabı en fazla %d karakter olabilir", ErrBookingInvalidInput, q.Label, maxAnswerLength)
+			}
+		}
+		if q.Required && value == "" {
+			return nil, fmt.Errorf("%w: %q sorusu zorunludur", ErrBookingInvalidInput, q.Label)
+		}
+		if value == "" {
+			continue
+		}
+		result = append(result, models.AppointmentBookingAnswer{QuestionID: q.ID, Question: q.Label, Answer: value})
+	}
+	return result, nil
+}
+
+// --- Servis Metodları ---
+
+// GetQuestions hizmetin sorularını getirir (yetki kontrolü ile).
+func (s *BookingQuestionService) GetQuestions(ctx context.Context, appointmentID uint, requestingUserID uint) ([]models.AppointmentQuestion, error) {
+	if _, err := s.appointmentService.GetAppointmentByID(ctx, appointmentID, requestingUserID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByAppointmentID(ctx, appointmentID)
+}
+
+// GetPublicQuestions public rezervasyon sayfasında gösterilecek soruları getirir.
+func (s *BookingQuestionService) GetPublicQuestions(ctx context.Context, appointmentID uint) ([]models.AppointmentQuestion, error) {
+	return s.repo.FindByAppointmentID(ctx, appointmentID)
+}
+
+// CreateQuestion hizmete yeni bir soru ekler. Sıra belirtilmezse soru en sona eklenir.
+func (s *BookingQuestionService) CreateQuestion(ctx context.Context, appointmentID uint, creatingUserID uint, question models.AppointmentQuestion) error {
+	if _, err := s.appointmentService.GetAppointmentByID(ctx, appointmentID, creatingUserID); err != nil {
+		return err
+	}
+	if err := ValidateQuestion(&question); err != nil {
+		return err
+	}
+
+	question.AppointmentID = appointmentID
+	if question.SortOrder == 0 {
+		existing, err := s.repo.FindByAppointmentID(ctx, appointmentID)
+		if err != nil {
+			return ErrQuestionSaveFailed
+		}
+		for _, q := range existing {
+			if q.SortOrder >= question.SortOrder {
+				question.SortOrder = q.SortOrder + 1
+			}
+		}
+	}
+	if err := s.repo.Create(contextWithUserID(ctx, creatingUserID), &question); err != nil {
+		configslog.Log.Error("Rezervasyon sorusu oluşturulamadı", zap.Uint("appointmentID", appointmentID), zap.Error(err))
+		return ErrQuestionSaveFailed
+	}
+	configslog.SLog.Infof("Rezervasyon sorusu eklendi: ID %d, Appointment ID %d (User ID %d)", question.ID, appointmentID, creatingUserID)
+	return nil
+}
+
+// DeleteQuestion bir soruyu siler (yetki kontrolü ile).
+func (s *BookingQuestionService) DeleteQuestion(ctx context.Context, id uint, deletingUserID uint) error {
+	question, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrQuestionNotFound
+		}
+		return err
+	}
+	if _, err := s.appointmentService.GetAppointmentByID(ctx, question.AppointmentID, deletingUserID); err != nil {
+		return ErrQuestionForbidden
+	}
+	if err := s.repo.Delete(contextWithUserID(ctx, deletingUserID), question, deletingUserID); err != nil {
+		configslog.Log.Error("Rezervasyon sorusu silinemedi", zap.Uint("questionID", id), zap.Error(err))
+		return ErrQuestionDeletionError
+	}
+	return nil
+}
+
+var _ IBookingQuestionService = (*BookingQuestionService)(nil)
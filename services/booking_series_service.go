@@ -0,0 +1,495 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"davet.link/configs"
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/ics"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// BookingSeriesServiceError özel servis hataları
+type BookingSeriesServiceError string
+
+func (e BookingSeriesServiceError) Error() string { return string(e) }
+
+const (
+	ErrSeriesNotFound     BookingSeriesServiceError = "tekrarlayan rezervasyon bulunamadı"
+	ErrSeriesInvalidInput BookingSeriesServiceError = "geçersiz tekrarlama bilgisi"
+	ErrSeriesNotAllowed   BookingSeriesServiceError = "bu hizmet için tekrarlayan rezervasyon açık değil"
+	ErrSeriesConflict     BookingSeriesServiceError = "bazı tekrarlar için seçilen saat müsait değil"
+	ErrSeriesForbidden    BookingSeriesServiceError = "bu işlem için yetkiniz yok"
+	ErrSeriesNotInSeries  BookingSeriesServiceError = "rezervasyon bir seriye ait değil"
+)
+
+// maxSeriesOccurrences bir seride oluşturulabilecek en fazla tekrar sayısı (yaklaşık bir yıl).
+const maxSeriesOccurrences = 52
+
+// SeriesCancelScope seri iptalinin kapsamı.
+type SeriesCancelScope string
+
+const (
+	SeriesCancelOne       SeriesCancelScope = "one"       // Yalnızca seçilen tekrar
+	SeriesCancelFollowing SeriesCancelScope = "following" // Seçilen ve sonraki tekrarlar
+	SeriesCancelAll       SeriesCancelScope = "all"       // Başlamamış tüm tekrarlar
+)
+
+// SeriesInput tekrarlayan rezervasyon isteğidir. Booking.StartsAt ilk tekrarın saatidir;
+// seri Count tekrara veya Until gününe (dahil) kadar sürer, hangisi önce gelirse.
+type SeriesInput struct {
+	Booking       BookingInput
+	IntervalWeeks int        // 1: her hafta, 2: iki haftada bir
+	Count         int        // 0 ise yalnızca Until kullanılır
+	Until         *time.Time // Hizmetin zaman diliminde son gün; nil ise yalnızca Count kullanılır
+}
+
+// SeriesOccurrence serinin tek bir tekrarı ve slot motorunun sonucudur.
+type SeriesOccurrence struct {
+	Index     int       `json:"index"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Available bool      `json:"available"`
+}
+
+// IBookingSeriesService tekrarlayan rezervasyon serileri için arayüz.
+type IBookingSeriesService interface {
+	PreviewCustomerSeries(ctx context.Context, key string, input SeriesInput) ([]SeriesOccurrence, error)
+	CreateCustomerSeries(ctx context.Context, key string, input SeriesInput, skipConflicts bool) (*models.BookingSeries, []SeriesOccurrence, error)
+	PreviewProviderSeries(ctx context.Context, appointmentID uint, providerUserID uint, input SeriesInput) ([]SeriesOccurrence, error)
+	CreateProviderSeries(ctx context.Context, appointmentID uint, providerUserID uint, input SeriesInput, skipConflicts bool) (*models.BookingSeries, []SeriesOccurrence, error)
+	GetSeries(ctx context.Context, seriesID uint, providerUserID uint) (*models.BookingSeries, error)
+	CancelSeries(ctx context.Context, bookingID uint, providerUserID uint, scope SeriesCancelScope) (int, error)
+}
+
+// BookingSeriesService IBookingSeriesService arayüzünü uygular.
+type BookingSeriesService struct {
+	repo                repositories.IBookingSeriesRepository
+	bookingRepo         repositories.IAppointmentBookingRepository
+	questionRepo        repositories.IAppointmentQuestionRepository
+	appointmentService  IAppointmentService
+	availabilityService IAvailabilityService
+	customerService     ICustomerService
+	userService         IUserService
+	db                  *gorm.DB // Transaction için
+}
+
+// NewBookingSeriesService yeni bir BookingSeriesService örneği oluşturur.
+func NewBookingSeriesService() IBookingSeriesService {
+	return &BookingSeriesService{
+		repo:                repositories.NewBookingSeriesRepository(),
+		bookingRepo:         repositories.NewAppointmentBookingRepository(),
+		questionRepo:        repositories.NewAppointmentQuestionRepository(),
+		appointmentService:  NewAppointmentService(),
+		availabilityService: NewAvailabilityService(),
+		customerService:     NewCustomerService(),
+		userService:         NewUserService(),
+		db:                  configs.GetDB(),
+	}
+}
+
+// --- Yardımcı Metodlar ---
+
+// SeriesStartTimes ilk tekrarın yerel saatini koruyarak tekrar başlangıçlarını üretir
+// (yaz saati geçişlerinde de aynı duvar saati kullanılır). Sonuçlar UTC'dir.
+func SeriesStartTimes(first time.Time, loc *time.Location, input SeriesInput) ([]time.Time, error) {
+	if input.IntervalWeeks != 1 && input.IntervalWeeks != 2 {
+		return nil, fmt.Errorf("%w: tekrar sıklığı haftalık veya iki haftalık olmalıdır", ErrSeriesInvalidInput)
+	}
+	if input.Count == 0 && input.Until == nil {
+		return nil, fmt.Errorf("%w: tekrar sayısı veya bitiş tarihi girilmelidir", ErrSeriesInvalidInput)
+	}
+	if input.Count < 0 || input.Count > maxSeriesOccurrences {
+		return nil, fmt.Errorf("%w: tekrar sayısı 1 ile %d arasında olmalıdır", ErrSeriesInvalidInput, maxSeriesOccurrences)
+	}
+
+	local := first.In(loc)
+	var untilEnd time.Time
+	if input.Until != nil {
+		u := input.Until.In(loc)
+		untilEnd = time.Date(u.Year(), u.Month(), u.Day()+1, 0, 0, 0, 0, loc)
+		if !untilEnd.After(local) {
+			return nil, fmt.Errorf("%w: bitiş tarihi ilk tekrardan önce olamaz", ErrSeriesInvalidInput)
+		}
+	}
+
+	var starts []time.Time
+	for i := 0; input.Count == 0 || i < input.Count; i++ {
+		t := time.Date(local.Year(), local.Month(), local.Day()+7*input.IntervalWeeks*i, local.Hour(), local.Minute(), 0, 0, loc)
+		if !untilEnd.IsZero() && !t.Before(untilEnd) {
+			break
+		}
+		if len(starts) == maxSeriesOccurrences {
+			return nil, fmt.Errorf("%w: bir seri en fazla %d tekrar içerebilir", ErrSeriesInvalidInput, maxSeriesOccurrences)
+		}
+		starts = append(starts, t.UTC())
+	}
+	return starts, nil
+}
+
+// checkOccurrences her tekrarı slot motoruyla (rezervasyon ufku hariç) kontrol eder.
+func (s *BookingSeriesService) checkOccurrences(ctx context.Context, appointment *models.Appointment, starts []time.Time) ([]SeriesOccurrence, error) {
+	loc := AppointmentLocation(appointment.Detail)
+	duration := time.Duration(appointment.Detail.DurationMinutes) * time.Minute
+	occurrences := make([]SeriesOccurrence, 0, len(starts))
+	for i, start := range starts {
+		available, err := s.availabilityService.GetSeriesSlots(ctx, appointment, start.In(loc))
+		if err != nil {
+			return nil, err
+		}
+		occ := SeriesOccurrence{Index: i + 1, StartsAt: start, EndsAt: start.Add(duration)}
+		for _, slot := range available {
+			if slot.Start.Equal(start) {
+				occ.Available = true
+				break
+			}
+		}
+		occurrences = append(occurrences, occ)
+	}
+	return occurrences, nil
+}
+
+// createSeries seriyi ve müsait tekrarlarını tek bir transaction içinde oluşturur. Tekrarlar,
+// sağlayıcı satırı kilitlendikten sonra yeniden kontrol edilir; skipConflicts false iken tek bir
+// çakışma bile varsa hiçbir kayıt oluşturulmaz ve güncel tekrar listesiyle ErrSeriesConflict döner.
+func (s *BookingSeriesService) createSeries(ctx context.Context, appointment *models.Appointment, input SeriesInput,
+	answers []models.AppointmentBookingAnswer, createdByCustomer bool, skipConflicts bool) (*models.BookingSeries, []SeriesOccurrence, error) {
+	loc := AppointmentLocation(appointment.Detail)
+	starts, err := SeriesStartTimes(input.Booking.StartsAt, loc, input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	series := &models.BookingSeries{
+		AppointmentID:     appointment.ID,
+		ProviderUserID:    appointment.ProviderUserID,
+		IntervalWeeks:     input.IntervalWeeks,
+		Count:             len(starts),
+		Until:             input.Until,
+		FirstStartsAt:     starts[0],
+		Status:            models.BookingSeriesStatusActive,
+		CustomerName:      input.Booking.CustomerName,
+		CustomerEmail:     input.Booking.CustomerEmail,
+		CustomerPhone:     input.Booking.CustomerPhone,
+		CreatedByCustomer: createdByCustomer,
+	}
+	var occurrences []SeriesOccurrence
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, appointment.ProviderUserID), tx)
+		if err := lockProvider(tx, appointment.ProviderUserID); err != nil {
+			configslog.Log.Error("CreateSeries: Sağlayıcı kilitlenemedi", zap.Uint("providerUserID", appointment.ProviderUserID), zap.Error(err))
+			return ErrBookingCreationFailed
+		}
+
+		occurrences, err = s.checkOccurrences(txCtx, appointment, starts)
+		if err != nil {
+			return ErrBookingCreationFailed
+		}
+		free := 0
+		for _, occ := range occurrences {
+			if occ.Available {
+				free++
+			}
+		}
+		if free == 0 || (free < len(occurrences) && !skipConflicts) {
+			return ErrSeriesConflict
+		}
+
+		if err := repositories.NewBookingSeriesRepositoryTx(tx.WithContext(txCtx)).Create(txCtx, series); err != nil {
+			configslog.Log.Error("CreateSeries: Seri kaydedilemedi", zap.Uint("appointmentID", appointment.ID), zap.Error(err))
+			return ErrBookingCreationFailed
+		}
+		bookingRepo := repositories.NewAppointmentBookingRepositoryTx(tx.WithContext(txCtx))
+		webhookRepo := repositories.NewWebhookRepositoryTx(tx.WithContext(txCtx))
+		for _, occ := range occurrences {
+			if !occ.Available {
+				continue
+			}
+			booking := newBooking(appointment, occ.StartsAt, input.Booking, append([]models.AppointmentBookingAnswer(nil), answers...))
+			booking.SeriesID = &series.ID
+			booking.SeriesIndex = occ.Index
+			if createdByCustomer && appointment.Detail.RequiresApproval {
+				booking.Status = models.BookingStatusPending
+			}
+			if err := bookingRepo.Create(txCtx, booking); err != nil {
+				configslog.Log.Error("CreateSeries: Tekrar kaydedilemedi", zap.Uint("appointmentID", appointment.ID), zap.Error(err))
+				return ErrBookingCreationFailed
+			}
+			if err := enqueueBookingWebhook(txCtx, webhookRepo, models.WebhookEventBookingCreated, booking); err != nil {
+				return ErrBookingCreationFailed
+			}
+			series.Bookings = append(series.Bookings, *booking)
+		}
+		if err := enqueueSeriesMail(txCtx, repositories.NewMailOutboxRepositoryTx(tx.WithContext(txCtx)), s.userService, appointment, series.Bookings, ics.MethodRequest); err != nil {
+			configslog.Log.Error("CreateSeries: Seri e-postası kuyruğa eklenemedi", zap.Uint("appointmentID", appointment.ID), zap.Error(err))
+			return ErrBookingCreationFailed
+		}
+		return nil
+	})
+	if txErr != nil {
+		if !errors.Is(txErr, ErrSeriesConflict) {
+			configslog.Log.Error("Seri oluşturma transaction hatası", zap.Uint("appointmentID", appointment.ID), zap.Error(txErr))
+		}
+		return nil, occurrences, txErr
+	}
+
+	configslog.SLog.Infof("Tekrarlayan rezervasyon oluşturuldu: Series ID %d, %d tekrar, Appointment ID %d", series.ID, len(series.Bookings), appointment.ID)
+	return series, occurrences, nil
+}
+
+// MailKindBookingSeries tekrarlayan rezervasyon e-postalarının kuyruk türü (MailOutbox.Kind).
+const MailKindBookingSeries = "booking_series"
+
+// enqueueSeriesMail müşteriye serinin onaylı tekrarları için tek bir e-postayı kuyruğa ekler;
+// her tekrar ayrı bir .ics eki olarak eklenir. Seriyi değiştiren transaction içinde çağrılır.
+func enqueueSeriesMail(ctx context.Context, repo repositories.IMailOutboxRepository, users IUserService, appointment *models.Appointment, bookings []models.AppointmentBooking, method string) error {
+	var confirmed []models.AppointmentBooking
+	for _, b := range bookings {
+		if b.CustomerEmail != "" && (b.Status == models.BookingStatusConfirmed || method == ics.MethodCancel) {
+			confirmed = append(confirmed, b)
+		}
+	}
+	if len(confirmed) == 0 {
+		return nil
+	}
+
+	loc := AppointmentLocation(appointment.Detail)
+	var lines []string
+	var attachments []models.MailOutboxAttachment
+	for _, b := range confirmed {
+		local := b.StartsAt.In(loc)
+		lines = append(lines, "- "+local.Format("02.01.2006 15:04"))
+		calendar := ics.Calendar{Method: method, Events: []ics.Event{inviteEvent(users, appointment, b)}}
+		attachments = append(attachments, models.MailOutboxAttachment{
+			FileName:    fmt.Sprintf("randevu-%d.ics", b.SeriesIndex),
+			ContentType: "text/calendar; method=" + method,
+			Data:        calendar.Bytes(),
+		})
+	}
+
+	subject := "Tekrarlayan randevularınız onaylandı: " + appointment.Detail.Name
+	text := fmt.Sprintf("Merhaba %s,\n\n%s için aşağıdaki randevularınız onaylandı:\n%s\n\nTakvim davetlerini ekte bulabilirsiniz.",
+		confirmed[0].CustomerName, appointment.Detail.Name, strings.Join(lines, "\n"))
+	if method == ics.MethodCancel {
+		subject = "Randevularınız iptal edildi: " + appointment.Detail.Name
+		text = fmt.Sprintf("Merhaba %s,\n\n%s için aşağıdaki randevularınız iptal edildi:\n%s",
+			confirmed[0].CustomerName, appointment.Detail.Name, strings.Join(lines, "\n"))
+	}
+	var sourceID *uint
+	if confirmed[0].SeriesID != nil {
+		id := *confirmed[0].SeriesID
+		sourceID = &id
+	}
+	return enqueueMail(ctx, repo, &models.MailOutbox{
+		OwnerUserID: appointment.ProviderUserID,
+		Kind:        MailKindBookingSeries,
+		SourceID:    sourceID,
+		Recipients:  confirmed[0].CustomerEmail,
+		Subject:     mailSubject(subject),
+		TextBody:    text,
+		Attachments: attachments,
+	})
+}
+
+// customerAppointment public anahtara ait hizmeti getirir ve müşteri serisine açık olduğunu doğrular.
+func (s *BookingSeriesService) customerAppointment(ctx context.Context, key string) (*models.Appointment, error) {
+	appointment, err := s.appointmentService.GetAppointmentByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !appointment.Detail.AllowCustomerRecurring {
+		return nil, ErrSeriesNotAllowed
+	}
+	return appointment, nil
+}
+
+// --- Servis Metodları ---
+
+// PreviewCustomerSeries public sayfada onaydan önce tekrarları ve çakışmaları gösterir.
+func (s *BookingSeriesService) PreviewCustomerSeries(ctx context.Context, key string, input SeriesInput) ([]SeriesOccurrence, error) {
+	appointment, err := s.customerAppointment(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	starts, err := SeriesStartTimes(input.Booking.StartsAt, AppointmentLocation(appointment.Detail), input)
+	if err != nil {
+		return nil, err
+	}
+	return s.checkOccurrences(ctx, appointment, starts)
+}
+
+// CreateCustomerSeries müşterinin public sayfadan gönderdiği seriyi oluşturur. Müşteri bilgileri,
+// rezervasyon soruları ve engel listesi tekil rezervasyonlardaki gibi kontrol edilir.
+func (s *BookingSeriesService) CreateCustomerSeries(ctx context.Context, key string, input SeriesInput, skipConflicts bool) (*models.BookingSeries, []SeriesOccurrence, error) {
+	appointment, err := s.customerAppointment(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ValidateBookingInput(&input.Booking); err != nil {
+		return nil, nil, err
+	}
+	questions, err := s.questionRepo.FindByAppointmentID(ctx, appointment.ID)
+	if err != nil {
+		return nil, nil, ErrBookingCreationFailed
+	}
+	answers, err := ValidateBookingAnswers(questions, input.Booking.Answers)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.customerService.CheckBookingAllowed(ctx, appointment.ProviderUserID, input.Booking.CustomerEmail, input.Booking.CustomerPhone); err != nil {
+		if !errors.Is(err, ErrCustomerBlocked) {
+			return nil, nil, ErrBookingCreationFailed
+		}
+		return nil, nil, err
+	}
+	return s.createSeries(ctx, appointment, input, answers, true, skipConflicts)
+}
+
+// PreviewProviderSeries paneldeki seri formunda onaydan önce tekrarları ve çakışmaları gösterir.
+func (s *BookingSeriesService) PreviewProviderSeries(ctx context.Context, appointmentID uint, providerUserID uint, input SeriesInput) ([]SeriesOccurrence, error) {
+	appointment, err := s.appointmentService.GetAppointmentByID(ctx, appointmentID, providerUserID)
+	if err != nil {
+		return nil, err
+	}
+	starts, err := SeriesStartTimes(input.Booking.StartsAt, AppointmentLocation(appointment.Detail), input)
+	if err != nil {
+		return nil, err
+	}
+	return s.checkOccurrences(ctx, appointment, starts)
+}
+
+// CreateProviderSeries sağlayıcının panelden oluşturduğu seriyi kaydeder. Sağlayıcı serileri
+// onay beklemez ve rezervasyon soruları zorunlu tutulmaz.
+func (s *BookingSeriesService) CreateProviderSeries(ctx context.Context, appointmentID uint, providerUserID uint, input SeriesInput, skipConflicts bool) (*models.BookingSeries, []SeriesOccurrence, error) {
+	appointment, err := s.appointmentService.GetAppointmentByID(ctx, appointmentID, providerUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if appointment.ProviderUserID != providerUserID {
+		return nil, nil, ErrSeriesForbidden
+	}
+	if err := ValidateBookingInput(&input.Booking); err != nil {
+		return nil, nil, err
+	}
+	return s.createSeries(ctx, appointment, input, nil, false, skipConflicts)
+}
+
+// GetSeries seriyi tekrarlarıyla birlikte getirir (yetki kontrolü ile).
+func (s *BookingSeriesService) GetSeries(ctx context.Context, seriesID uint, providerUserID uint) (*models.BookingSeries, error) {
+	series, err := s.repo.FindByID(ctx, seriesID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+	if series.ProviderUserID != providerUserID {
+		return nil, ErrSeriesForbidden
+	}
+	return series, nil
+}
+
+// CancelSeries seriye ait bir rezervasyondan başlayarak kapsam kadar tekrarı iptal eder:
+// yalnızca o tekrar, o ve sonraki tekrarlar ya da başlamamış tüm tekrarlar. Aktif tekrar
+// kalmazsa seri de iptal edilir. İptal edilen tekrar sayısını döndürür.
+func (s *BookingSeriesService) CancelSeries(ctx context.Context, bookingID uint, providerUserID uint, scope SeriesCancelScope) (int, error) {
+	if scope != SeriesCancelOne && scope != SeriesCancelFollowing && scope != SeriesCancelAll {
+		return 0, fmt.Errorf("%w: geçersiz iptal kapsamı", ErrSeriesInvalidInput)
+	}
+	selected, err := s.bookingRepo.FindByID(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return 0, ErrBookingNotFound
+		}
+		return 0, err
+	}
+	if selected.ProviderUserID != providerUserID {
+		return 0, ErrBookingForbidden
+	}
+	if selected.SeriesID == nil {
+		return 0, ErrSeriesNotInSeries
+	}
+
+	now := time.Now().UTC()
+	var series *models.BookingSeries
+	var cancelled int
+	var notify []models.AppointmentBooking // Daha önce onaylanmış tekrarlara METHOD:CANCEL gönderilir
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, providerUserID), tx)
+		if err := lockProvider(tx, providerUserID); err != nil {
+			return ErrBookingUpdateFailed
+		}
+		series, err = repositories.NewBookingSeriesRepositoryTx(tx.WithContext(txCtx)).FindByID(txCtx, *selected.SeriesID)
+		if err != nil {
+			return ErrSeriesNotFound
+		}
+
+		bookingRepo := repositories.NewAppointmentBookingRepositoryTx(tx.WithContext(txCtx))
+		webhookRepo := repositories.NewWebhookRepositoryTx(tx.WithContext(txCtx))
+		freedSlotRepo := repositories.NewWaitlistFreedSlotRepositoryTx(tx.WithContext(txCtx)) // Boşalan slotlar bekleme listelerine teklif edilir
+		remaining := 0
+		for i := range series.Bookings {
+			b := &series.Bookings[i]
+			if b.Status != models.BookingStatusPending && b.Status != models.BookingStatusConfirmed {
+				continue
+			}
+			var match bool
+			switch scope {
+			case SeriesCancelOne:
+				match = b.ID == selected.ID
+			case SeriesCancelFollowing:
+				match = !b.StartsAt.Before(selected.StartsAt)
+			case SeriesCancelAll:
+				match = b.StartsAt.After(now)
+			}
+			if !match {
+				remaining++
+				continue
+			}
+			previous := b.Status
+			b.Status = models.BookingStatusCancelled
+			b.CancelledAt = &now
+			b.Sequence++
+			updateData := map[string]interface{}{"status": b.Status, "cancelled_at": now, "sequence": b.Sequence}
+			if err := bookingRepo.Update(txCtx, b, updateData); err != nil {
+				return ErrBookingUpdateFailed
+			}
+			if err := enqueueBookingWebhook(txCtx, webhookRepo, models.WebhookEventBookingCancelled, b); err != nil {
+				return ErrBookingUpdateFailed
+			}
+			if err := enqueueFreedSlot(txCtx, freedSlotRepo, series.AppointmentID, series.ProviderUserID, b.StartsAt); err != nil {
+				return ErrBookingUpdateFailed
+			}
+			cancelled++
+			if previous == models.BookingStatusConfirmed {
+				notify = append(notify, *b)
+			}
+		}
+		if cancelled == 0 {
+			return ErrBookingInvalidStatus
+		}
+		if err := enqueueSeriesMail(txCtx, repositories.NewMailOutboxRepositoryTx(tx.WithContext(txCtx)), s.userService, &series.Appointment, notify, ics.MethodCancel); err != nil {
+			return ErrBookingUpdateFailed
+		}
+		if remaining == 0 {
+			return repositories.NewBookingSeriesRepositoryTx(tx.WithContext(txCtx)).Update(txCtx, series, map[string]interface{}{"status": models.BookingSeriesStatusCancelled})
+		}
+		return nil
+	})
+	if txErr != nil {
+		return 0, txErr
+	}
+
+	configslog.SLog.Infof("Seri tekrarları iptal edildi: Series ID %d, %d tekrar, kapsam %s (User ID %d)", series.ID, cancelled, scope, providerUserID)
+	return cancelled, nil
+}
+
+var _ IBookingSeriesService = (*BookingSeriesService)(nil)
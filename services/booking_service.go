@@ -0,0 +1,762 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"davet.link/configs"
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/ics"
+	"davet.link/pkg/payment"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause" // Lock için
+)
+
+// BookingServiceError özel servis hataları
+type BookingServiceError string
+
+func (e BookingServiceError) Error() string { return string(e) }
+
+const (
+	ErrBookingNotFound        BookingServiceError = "rezervasyon bulunamadı"
+	ErrBookingInvalidInput    BookingServiceError = "geçersiz rezervasyon bilgisi"
+	ErrBookingSlotUnavailable BookingServiceError = "seçilen saat artık müsait değil"
+	ErrBookingCreationFailed  BookingServiceError = "rezervasyon oluşturulamadı"
+	ErrBookingUpdateFailed    BookingServiceError = "rezervasyon güncellenemedi"
+	ErrBookingForbidden       BookingServiceError = "bu işlem için yetkiniz yok"
+	ErrBookingInvalidStatus   BookingServiceError = "rezervasyon bu durumdayken değiştirilemez"
+)
+
+// Takvim beslemesinde yer alan rezervasyon aralığı (bugüne göre).
+const (
+	calendarFeedPastDays   = 30
+	calendarFeedFutureDays = 365
+)
+
+// maxCalendarRangeDays panel takviminde tek istekte sorgulanabilecek en uzun aralık.
+const maxCalendarRangeDays = 62
+
+// MailKindBookingInvite rezervasyon takvim davetlerinin kuyruk türü (MailOutbox.Kind).
+const MailKindBookingInvite = "booking_invite"
+
+// BookingInput public rezervasyon formundan gelen veriler.
+type BookingInput struct {
+	StartsAt      time.Time
+	CustomerName  string
+	CustomerEmail string
+	CustomerPhone string
+	Notes         string
+	Answers       map[uint]string // Rezervasyon soruları: soru ID -> cevap
+	PaymentToken  string          // Ödeme sağlayıcısının formundan dönen anahtar; boşsa ödeme alınmaz
+}
+
+// BookingSession aynı hizmette aynı saatte başlayan rezervasyonların oluşturduğu seanstır.
+type BookingSession struct {
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Capacity  int
+	Attendees []models.AppointmentBooking
+}
+
+// SeatsLeft seansta kalan boş yer sayısı.
+func (s BookingSession) SeatsLeft() int {
+	if left := s.Capacity - len(s.Attendees); left > 0 {
+		return left
+	}
+	return 0
+}
+
+// Ended seansın bitiş saatinin geçip geçmediğini bildirir (katılım işaretlemesi için).
+func (s BookingSession) Ended() bool {
+	return !s.EndsAt.After(time.Now())
+}
+
+// IBookingService randevu rezervasyonu işlemleri için arayüz.
+type IBookingService interface {
+	CreateBooking(ctx context.Context, key string, input BookingInput) (*models.AppointmentBooking, error)
+	GetBookingDetail(ctx context.Context, bookingID uint, providerUserID uint) (*models.AppointmentBooking, error)
+	GetUpcomingSessions(ctx context.Context, appointmentID uint, requestingUserID uint, days int) (*models.Appointment, []BookingSession, error)
+	GetSessionRoster(ctx context.Context, appointmentID uint, requestingUserID uint, startsAt time.Time) (*models.Appointment, []models.AppointmentBooking, error)
+	ConfirmBooking(ctx context.Context, bookingID uint, providerUserID uint) error
+	CancelBooking(ctx context.Context, bookingID uint, providerUserID uint) error
+	MarkAttendance(ctx context.Context, bookingID uint, providerUserID uint, status models.BookingStatus) error
+	GetCalendarFeed(ctx context.Context, token string) ([]byte, error)
+	GetProviderCalendar(ctx context.Context, providerUserID uint, from, to time.Time) ([]models.AppointmentBooking, error)
+	RescheduleBooking(ctx context.Context, bookingID uint, providerUserID uint, startsAt time.Time) (*models.AppointmentBooking, error)
+}
+
+// BookingService IBookingService arayüzünü uygular.
+type BookingService struct {
+	repo                repositories.IAppointmentBookingRepository
+	questionRepo        repositories.IAppointmentQuestionRepository
+	appointmentService  IAppointmentService
+	availabilityService IAvailabilityService
+	userService         IUserService
+	customerService     ICustomerService
+	provider            payment.Provider // nil ise çevrim içi ödeme kapalıdır
+	db                  *gorm.DB         // Transaction için
+}
+
+// NewBookingService yeni bir BookingService örneği oluşturur.
+func NewBookingService() IBookingService {
+	return &BookingService{
+		repo:                repositories.NewAppointmentBookingRepository(),
+		questionRepo:        repositories.NewAppointmentQuestionRepository(),
+		appointmentService:  NewAppointmentService(),
+		availabilityService: NewAvailabilityService(),
+		userService:         NewUserService(),
+		customerService:     NewCustomerService(),
+		provider:            payment.New(),
+		db:                  configs.GetDB(),
+	}
+}
+
+// --- Yardımcı Metodlar ---
+
+// ValidateBookingInput müşteri bilgilerini doğrular ve boşlukları temizler.
+func ValidateBookingInput(input *BookingInput) error {
+	input.CustomerName = strings.TrimSpace(input.CustomerName)
+	input.CustomerEmail = strings.TrimSpace(input.CustomerEmail)
+	input.CustomerPhone = strings.TrimSpace(input.CustomerPhone)
+	input.Notes = strings.TrimSpace(input.Notes)
+
+	if input.StartsAt.IsZero() {
+		return fmt.Errorf("%w: randevu saati seçilmelidir", ErrBookingInvalidInput)
+	}
+	if input.CustomerName == "" {
+		return fmt.Errorf("%w: ad soyad zorunludur", ErrBookingInvalidInput)
+	}
+	if input.CustomerEmail == "" && input.CustomerPhone == "" {
+		return fmt.Errorf("%w: e-posta veya telefon bilgisi gereklidir", ErrBookingInvalidInput)
+	}
+	if input.CustomerEmail != "" {
+		if _, err := mail.ParseAddress(input.CustomerEmail); err != nil {
+			return fmt.Errorf("%w: geçersiz e-posta adresi", ErrBookingInvalidInput)
+		}
+	}
+	return nil
+}
+
+// newBooking müşteri bilgileri, hizmet süresi, tampon süreler ve fiyatla onaylı bir rezervasyon kaydı hazırlar.
+func newBooking(appointment *models.Appointment, startsAt time.Time, input BookingInput, answers []models.AppointmentBookingAnswer) *models.AppointmentBooking {
+	detail := appointment.Detail
+	endsAt := startsAt.Add(time.Duration(detail.DurationMinutes) * time.Minute)
+	booking := &models.AppointmentBooking{
+		AppointmentID:  appointment.ID,
+		ProviderUserID: appointment.ProviderUserID,
+		StartsAt:       startsAt,
+		EndsAt:         endsAt,
+		BusyStartsAt:   startsAt.Add(-time.Duration(detail.BufferTimeBefore) * time.Minute),
+		BusyEndsAt:     endsAt.Add(time.Duration(detail.BufferTimeAfter) * time.Minute),
+		Status:         models.BookingStatusConfirmed,
+		CustomerName:   input.CustomerName,
+		CustomerEmail:  input.CustomerEmail,
+		CustomerPhone:  input.CustomerPhone,
+		Notes:          input.Notes,
+		Answers:        answers, // Rezervasyonla birlikte oluşturulur
+		Currency:       detail.Currency,
+		AmountDueMinor: detail.PriceMinor,
+		DepositMinor:   detail.DepositMinor,
+		PaymentStatus:  models.PaymentStatusUnpaid,
+	}
+	if booking.Currency == "" {
+		booking.Currency = "TRY"
+	}
+	return booking
+}
+
+// lockProvider sağlayıcının kullanıcı satırını kilitler; aynı sağlayıcının tüm hizmetlerindeki
+// rezervasyon oluşturma ve taşıma işlemleri böylece sıraya girer.
+func lockProvider(tx *gorm.DB, providerUserID uint) error {
+	var provider models.User
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&provider, providerUserID).Error
+}
+
+// withTx transaction'ı, repository'lerin getDB ile kullanacağı şekilde context'e ekler.
+func withTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, "tx", tx.WithContext(ctx))
+}
+
+// --- Servis Metodları ---
+
+// CreateBooking public link üzerinden yeni bir rezervasyon oluşturur.
+// Aynı sağlayıcıya ait eşzamanlı rezervasyonlar sağlayıcının kullanıcı satırı kilitlenerek
+// sıraya sokulur; seçilen saat, kilit altında slot motoruyla yeniden doğrulanır.
+// PaymentToken verilmişse onaylı rezervasyonun kaporası (yoksa ücretin tamamı) aynı
+// transaction içinde çekilir; onay bekleyen rezervasyonlardan ödeme alınmaz.
+func (s *BookingService) CreateBooking(ctx context.Context, key string, input BookingInput) (*models.AppointmentBooking, error) {
+	appointment, err := s.appointmentService.GetAppointmentByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateBookingInput(&input); err != nil {
+		return nil, err
+	}
+	questions, err := s.questionRepo.FindByAppointmentID(ctx, appointment.ID)
+	if err != nil {
+		return nil, ErrBookingCreationFailed
+	}
+	answers, err := ValidateBookingAnswers(questions, input.Answers)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.customerService.CheckBookingAllowed(ctx, appointment.ProviderUserID, input.CustomerEmail, input.CustomerPhone); err != nil {
+		if !errors.Is(err, ErrCustomerBlocked) {
+			return nil, ErrBookingCreationFailed
+		}
+		return nil, err
+	}
+
+	detail := appointment.Detail
+	startsAt := input.StartsAt.UTC()
+	booking := newBooking(appointment, startsAt, input, answers)
+	if detail.RequiresApproval {
+		booking.Status = models.BookingStatusPending
+	}
+
+	var charge *payment.Charge
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		// Public işlem: BaseModel hook'ları için aktör olarak sağlayıcı kullanılır.
+		txCtx := withTx(contextWithUserID(ctx, appointment.ProviderUserID), tx)
+
+		// Sağlayıcı satırını kilitle: aynı sağlayıcının tüm hizmetlerindeki rezervasyonlar sıralanır.
+		if err := lockProvider(tx, appointment.ProviderUserID); err != nil {
+			configslog.Log.Error("CreateBooking: Sağlayıcı kilitlenemedi", zap.Uint("providerUserID", appointment.ProviderUserID), zap.Error(err))
+			return ErrBookingCreationFailed
+		}
+
+		available, err := s.availabilityService.GetAvailableSlots(txCtx, appointment, startsAt.In(AppointmentLocation(detail)))
+		if err != nil {
+			return ErrBookingCreationFailed
+		}
+		slotFound := false
+		for _, slot := range available {
+			if slot.Start.Equal(startsAt) {
+				slotFound = true
+				break
+			}
+		}
+		if !slotFound {
+			return ErrBookingSlotUnavailable
+		}
+
+		bookingRepo := repositories.NewAppointmentBookingRepositoryTx(tx.WithContext(txCtx))
+		if err := bookingRepo.Create(txCtx, booking); err != nil {
+			configslog.Log.Error("CreateBooking: Rezervasyon kaydedilemedi", zap.Uint("appointmentID", appointment.ID), zap.Error(err))
+			return ErrBookingCreationFailed
+		}
+		if err := enqueueBookingWebhook(txCtx, repositories.NewWebhookRepositoryTx(tx.WithContext(txCtx)), models.WebhookEventBookingCreated, booking); err != nil {
+			configslog.Log.Error("CreateBooking: Webhook kuyruğa eklenemedi", zap.Uint("appointmentID", appointment.ID), zap.Error(err))
+			return ErrBookingCreationFailed
+		}
+		if booking.Status == models.BookingStatusConfirmed {
+			if err := enqueueBookingInvite(txCtx, repositories.NewMailOutboxRepositoryTx(tx.WithContext(txCtx)), s.userService, appointment, *booking, ics.MethodRequest); err != nil {
+				configslog.Log.Error("CreateBooking: Takvim daveti kuyruğa eklenemedi", zap.Uint("appointmentID", appointment.ID), zap.Error(err))
+				return ErrBookingCreationFailed
+			}
+		}
+		// Ödeme en son, slot ve kayıtlar kesinleştikten sonra çekilir; ödeme alınamazsa rezervasyon
+		// da geri alınır, böylece ödenmemiş rezervasyon slotu tutmaz.
+		charge, err = chargeNewBooking(txCtx, s.provider, repositories.NewBookingPaymentRepositoryTx(tx.WithContext(txCtx)), bookingRepo, booking, input.PaymentToken)
+		return err
+	})
+	if txErr != nil {
+		if charge != nil {
+			// Çekim yapıldı ama commit başarısız oldu: ödeme iade edilir.
+			refundCharge(ctx, s.provider, charge)
+		}
+		var paymentErr PaymentServiceError
+		if !errors.Is(txErr, ErrBookingSlotUnavailable) && !errors.As(txErr, &paymentErr) {
+			configslog.Log.Error("Rezervasyon oluşturma transaction hatası", zap.Uint("appointmentID", appointment.ID), zap.Error(txErr))
+		}
+		return nil, txErr
+	}
+
+	configslog.SLog.Infof("Rezervasyon oluşturuldu: ID %d, Appointment ID %d, %s", booking.ID, appointment.ID, startsAt.Format(time.RFC3339))
+	return booking, nil
+}
+
+// GetUpcomingSessions hizmetin bugünden itibaren verilen gün sayısı içindeki seanslarını
+// katılımcı listeleriyle birlikte getirir (yetki kontrolü ile).
+func (s *BookingService) GetUpcomingSessions(ctx context.Context, appointmentID uint, requestingUserID uint, days int) (*models.Appointment, []BookingSession, error) {
+	appointment, err := s.appointmentService.GetAppointmentByID(ctx, appointmentID, requestingUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now().UTC()
+	bookings, err := s.repo.FindActiveByAppointmentInRange(ctx, appointmentID, now.Add(-time.Duration(appointment.Detail.DurationMinutes)*time.Minute), now.AddDate(0, 0, days))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	capacity := SeatCapacity(appointment.Detail)
+	var sessions []BookingSession
+	for _, b := range bookings {
+		if n := len(sessions); n > 0 && sessions[n-1].StartsAt.Equal(b.StartsAt) {
+			sessions[n-1].Attendees = append(sessions[n-1].Attendees, b)
+			continue
+		}
+		sessions = append(sessions, BookingSession{
+			StartsAt:  b.StartsAt,
+			EndsAt:    b.EndsAt,
+			Capacity:  capacity,
+			Attendees: []models.AppointmentBooking{b},
+		})
+	}
+	return appointment, sessions, nil
+}
+
+// GetSessionRoster tek bir seansın katılımcı listesini getirir (yetki kontrolü ile).
+func (s *BookingService) GetSessionRoster(ctx context.Context, appointmentID uint, requestingUserID uint, startsAt time.Time) (*models.Appointment, []models.AppointmentBooking, error) {
+	appointment, err := s.appointmentService.GetAppointmentByID(ctx, appointmentID, requestingUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	attendees, err := s.repo.FindActiveBySession(ctx, appointmentID, startsAt.UTC())
+	if err != nil {
+		return nil, nil, err
+	}
+	return appointment, attendees, nil
+}
+
+// findOwnedBooking rezervasyonu bulur ve sağlayıcıya ait olduğunu doğrular.
+func (s *BookingService) findOwnedBooking(ctx context.Context, bookingID uint, providerUserID uint) (*models.AppointmentBooking, error) {
+	booking, err := s.repo.FindByID(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, err
+	}
+	if booking.ProviderUserID != providerUserID {
+		return nil, ErrBookingForbidden
+	}
+	return booking, nil
+}
+
+// GetBookingDetail rezervasyonu soru cevaplarıyla birlikte getirir (yetki kontrolü ile).
+func (s *BookingService) GetBookingDetail(ctx context.Context, bookingID uint, providerUserID uint) (*models.AppointmentBooking, error) {
+	booking, err := s.repo.FindDetailByID(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, err
+	}
+	if booking.ProviderUserID != providerUserID {
+		return nil, ErrBookingForbidden
+	}
+	return booking, nil
+}
+
+// lockOwnedBooking rezervasyonu transaction içinde FOR UPDATE ile yeniden okur ve sağlayıcıya ait
+// olduğunu doğrular. Durum kontrolleri kilitli satır üzerinde yapılır; böylece aynı rezervasyon
+// üzerindeki eşzamanlı işlemler birbirinin değişikliğini görür.
+func lockOwnedBooking(tx *gorm.DB, bookingID uint, providerUserID uint) (*models.AppointmentBooking, error) {
+	var booking models.AppointmentBooking
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&booking, bookingID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, err
+	}
+	if booking.ProviderUserID != providerUserID {
+		return nil, ErrBookingForbidden
+	}
+	return &booking, nil
+}
+
+// ConfirmBooking onay bekleyen bir rezervasyonu onaylar ve müşteriye takvim daveti gönderir.
+func (s *BookingService) ConfirmBooking(ctx context.Context, bookingID uint, providerUserID uint) error {
+	booking, err := s.findOwnedBooking(ctx, bookingID, providerUserID)
+	if err != nil {
+		return err
+	}
+	if booking.Status != models.BookingStatusPending {
+		return ErrBookingInvalidStatus
+	}
+	appointment, err := s.appointmentService.GetAppointmentByID(ctx, booking.AppointmentID, providerUserID)
+	if err != nil {
+		return err
+	}
+
+	// Onay ve takvim daveti birlikte yazılır; davet kuyruğa girmezse onay da geri alınır. Durum
+	// kilitli satırda yeniden kontrol edilir; araya giren bir iptal için davet gönderilmez.
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, providerUserID), tx)
+		locked, err := lockOwnedBooking(tx, bookingID, providerUserID)
+		if err != nil {
+			return err
+		}
+		if locked.Status != models.BookingStatusPending {
+			return ErrBookingInvalidStatus
+		}
+		booking = locked
+		booking.Status = models.BookingStatusConfirmed
+		booking.Sequence++
+		updateData := map[string]interface{}{"status": booking.Status, "sequence": booking.Sequence}
+		if err := repositories.NewAppointmentBookingRepositoryTx(tx.WithContext(txCtx)).Update(txCtx, booking, updateData); err != nil {
+			return err
+		}
+		return enqueueBookingInvite(txCtx, repositories.NewMailOutboxRepositoryTx(tx.WithContext(txCtx)), s.userService, appointment, *booking, ics.MethodRequest)
+	})
+	if txErr != nil {
+		if errors.Is(txErr, ErrBookingInvalidStatus) || errors.Is(txErr, ErrBookingNotFound) || errors.Is(txErr, ErrBookingForbidden) {
+			return txErr
+		}
+		configslog.Log.Error("Rezervasyon onay transaction hatası", zap.Uint("bookingID", bookingID), zap.Error(txErr))
+		return ErrBookingUpdateFailed
+	}
+	configslog.SLog.Infof("Rezervasyon onaylandı: ID %d (Onaylayan: %d)", bookingID, providerUserID)
+	return nil
+}
+
+// CancelBooking rezervasyonu iptal eder. Daha önce onaylanmışsa müşteriye METHOD:CANCEL gönderilir;
+// boşalan slot o günün bekleme listesine teklif edilir.
+func (s *BookingService) CancelBooking(ctx context.Context, bookingID uint, providerUserID uint) error {
+	booking, err := s.findOwnedBooking(ctx, bookingID, providerUserID)
+	if err != nil {
+		return err
+	}
+	if booking.Status != models.BookingStatusPending && booking.Status != models.BookingStatusConfirmed {
+		return ErrBookingInvalidStatus
+	}
+	appointment, err := s.appointmentService.GetAppointmentByID(ctx, booking.AppointmentID, providerUserID)
+	if err != nil {
+		return err
+	}
+
+	// İptal, webhook olayı ve iptal e-postası birlikte yazılır; biri kuyruğa girmezse iptal de geri
+	// alınır. Durum kilitli satırda yeniden kontrol edilir; eşzamanlı iki iptalden yalnızca biri
+	// olayları ve bekleme listesi teklifini üretir.
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, providerUserID), tx)
+		locked, err := lockOwnedBooking(tx, bookingID, providerUserID)
+		if err != nil {
+			return err
+		}
+		if locked.Status != models.BookingStatusPending && locked.Status != models.BookingStatusConfirmed {
+			return ErrBookingInvalidStatus
+		}
+		booking = locked
+		wasConfirmed := booking.Status == models.BookingStatusConfirmed
+		now := time.Now().UTC()
+		booking.Status = models.BookingStatusCancelled
+		booking.CancelledAt = &now
+		booking.Sequence++
+		updateData := map[string]interface{}{"status": booking.Status, "cancelled_at": now, "sequence": booking.Sequence}
+		if err := repositories.NewAppointmentBookingRepositoryTx(tx.WithContext(txCtx)).Update(txCtx, booking, updateData); err != nil {
+			return err
+		}
+		if err := enqueueBookingWebhook(txCtx, repositories.NewWebhookRepositoryTx(tx.WithContext(txCtx)), models.WebhookEventBookingCancelled, booking); err != nil {
+			return err
+		}
+		if err := enqueueFreedSlot(txCtx, repositories.NewWaitlistFreedSlotRepositoryTx(tx.WithContext(txCtx)), appointment.ID, appointment.ProviderUserID, booking.StartsAt); err != nil {
+			return err
+		}
+		if !wasConfirmed {
+			return nil
+		}
+		return enqueueBookingInvite(txCtx, repositories.NewMailOutboxRepositoryTx(tx.WithContext(txCtx)), s.userService, appointment, *booking, ics.MethodCancel)
+	})
+	if txErr != nil {
+		if errors.Is(txErr, ErrBookingInvalidStatus) || errors.Is(txErr, ErrBookingNotFound) || errors.Is(txErr, ErrBookingForbidden) {
+			return txErr
+		}
+		configslog.Log.Error("Rezervasyon iptal transaction hatası", zap.Uint("bookingID", bookingID), zap.Error(txErr))
+		return ErrBookingUpdateFailed
+	}
+	configslog.SLog.Infof("Rezervasyon iptal edildi: ID %d (İptal eden: %d)", bookingID, providerUserID)
+	return nil
+}
+
+// validateAttendanceChange rezervasyonun katılım durumuna geçebileceğini doğrular. Yalnızca onaylanmış
+// veya daha önce işaretlenmiş rezervasyonlar işaretlenir; onaylanmamış bir rezervasyonun gelmedi
+// sayılması müşterinin sayaçlarını ve otomatik engellemeyi haksız yere etkilerdi.
+func validateAttendanceChange(booking *models.AppointmentBooking, status models.BookingStatus, now time.Time) error {
+	switch booking.Status {
+	case models.BookingStatusConfirmed, models.BookingStatusCompleted, models.BookingStatusNoShow:
+	default:
+		return ErrBookingInvalidStatus
+	}
+	if booking.Status == status {
+		return ErrBookingInvalidStatus
+	}
+	if booking.EndsAt.After(now) {
+		return fmt.Errorf("%w: rezervasyon henüz sona ermedi", ErrBookingInvalidStatus)
+	}
+	return nil
+}
+
+// MarkAttendance bitiş saati geçmiş bir rezervasyonu gerçekleşti (completed) veya
+// gelmedi (no_show) olarak işaretler ve müşterinin katılım sayaçlarını günceller. Onay bekleyen
+// ve iptal edilmiş rezervasyonlar işaretlenemez. Yanlış işaretleme, diğer duruma yeniden
+// işaretlenerek düzeltilebilir.
+func (s *BookingService) MarkAttendance(ctx context.Context, bookingID uint, providerUserID uint, status models.BookingStatus) error {
+	if status != models.BookingStatusCompleted && status != models.BookingStatusNoShow {
+		return fmt.Errorf("%w: geçersiz katılım durumu", ErrBookingInvalidInput)
+	}
+
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, providerUserID), tx)
+
+		booking, err := lockOwnedBooking(tx, bookingID, providerUserID)
+		if err != nil {
+			return err
+		}
+		if err := validateAttendanceChange(booking, status, time.Now()); err != nil {
+			return err
+		}
+
+		previous := booking.Status
+		booking.Status = status
+		if err := repositories.NewAppointmentBookingRepositoryTx(tx.WithContext(txCtx)).Update(txCtx, booking, map[string]interface{}{"status": status}); err != nil {
+			return ErrBookingUpdateFailed
+		}
+		if err := recordAttendance(txCtx, repositories.NewCustomerRecordRepositoryTx(tx.WithContext(txCtx)), booking, previous); err != nil {
+			configslog.Log.Error("Müşteri katılım sayaçları güncellenemedi", zap.Uint("bookingID", booking.ID), zap.Error(err))
+			return ErrBookingUpdateFailed
+		}
+		return nil
+	})
+	if txErr != nil {
+		return txErr
+	}
+	configslog.SLog.Infof("Rezervasyon katılımı işaretlendi: ID %d, %s (User ID %d)", bookingID, status, providerUserID)
+	return nil
+}
+
+// GetCalendarFeed gizli anahtara ait sağlayıcının tüm hizmetlerindeki onaylı
+// rezervasyonlarını ICS abonelik beslemesi olarak döndürür.
+func (s *BookingService) GetCalendarFeed(ctx context.Context, token string) ([]byte, error) {
+	setting, err := s.availabilityService.GetProviderSettingByFeedToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	bookings, err := s.repo.FindByProviderInRange(ctx, setting.UserID, []models.BookingStatus{models.BookingStatusConfirmed, models.BookingStatusCompleted},
+		now.AddDate(0, 0, -calendarFeedPastDays), now.AddDate(0, 0, calendarFeedFutureDays))
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := ics.Calendar{Name: "davet.link Randevular", Method: ics.MethodPublish}
+	for _, b := range bookings {
+		calendar.Events = append(calendar.Events, bookingEvent(&b.Appointment, b))
+	}
+	return calendar.Bytes(), nil
+}
+
+// GetProviderCalendar sağlayıcının tüm hizmetlerinde [from, to) aralığında başlayan
+// aktif ve sonuçlanmış rezervasyonlarını panel takvimi için getirir.
+func (s *BookingService) GetProviderCalendar(ctx context.Context, providerUserID uint, from, to time.Time) ([]models.AppointmentBooking, error) {
+	if !to.After(from) || to.Sub(from) > maxCalendarRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: takvim aralığı en fazla %d gün olabilir", ErrBookingInvalidInput, maxCalendarRangeDays)
+	}
+	statuses := []models.BookingStatus{
+		models.BookingStatusPending, models.BookingStatusConfirmed,
+		models.BookingStatusCompleted, models.BookingStatusNoShow,
+	}
+	return s.repo.FindByProviderInRange(ctx, providerUserID, statuses, from.UTC(), to.UTC())
+}
+
+// RescheduleBooking başlamamış bir rezervasyonu yeni saate taşır. Public rezervasyon akışıyla
+// aynı doğrulama uygulanır: sağlayıcı kilitlenir, rezervasyon transaction içinde geçici olarak
+// iptal sayılarak kendi slotunu serbest bırakır ve yeni saat slot motoruyla doğrulanır.
+// Saat uygun değilse transaction geri alınır ve rezervasyon değişmez.
+func (s *BookingService) RescheduleBooking(ctx context.Context, bookingID uint, providerUserID uint, startsAt time.Time) (*models.AppointmentBooking, error) {
+	booking, err := s.findOwnedBooking(ctx, bookingID, providerUserID)
+	if err != nil {
+		return nil, err
+	}
+	appointment, err := s.appointmentService.GetAppointmentByID(ctx, booking.AppointmentID, providerUserID)
+	if err != nil {
+		return nil, err
+	}
+	detail := appointment.Detail
+	startsAt = startsAt.UTC()
+	oldStartsAt := booking.StartsAt
+
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, providerUserID), tx)
+		if err := lockProvider(tx, providerUserID); err != nil {
+			configslog.Log.Error("RescheduleBooking: Sağlayıcı kilitlenemedi", zap.Uint("providerUserID", providerUserID), zap.Error(err))
+			return ErrBookingUpdateFailed
+		}
+
+		// Kilit alındıktan sonra güncel durum okunur.
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(booking, bookingID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrBookingNotFound
+			}
+			return err
+		}
+		if booking.Status != models.BookingStatusPending && booking.Status != models.BookingStatusConfirmed {
+			return ErrBookingInvalidStatus
+		}
+		if !booking.StartsAt.After(time.Now()) {
+			return fmt.Errorf("%w: başlamış rezervasyon taşınamaz", ErrBookingInvalidStatus)
+		}
+		if booking.StartsAt.Equal(startsAt) {
+			return nil
+		}
+
+		// Rezervasyonun kendi slotu slot motorunda meşgul görünmesin; durum güncellemede geri yazılır.
+		status := booking.Status
+		if err := tx.Model(booking).UpdateColumn("status", models.BookingStatusCancelled).Error; err != nil {
+			return ErrBookingUpdateFailed
+		}
+		available, err := s.availabilityService.GetAvailableSlots(txCtx, appointment, startsAt.In(AppointmentLocation(detail)))
+		if err != nil {
+			return ErrBookingUpdateFailed
+		}
+		slotFound := false
+		for _, slot := range available {
+			if slot.Start.Equal(startsAt) {
+				slotFound = true
+				break
+			}
+		}
+		if !slotFound {
+			return ErrBookingSlotUnavailable
+		}
+
+		// Eski slot bekleme listesine teklif edilmek üzere taşımayla birlikte kuyruğa yazılır.
+		if err := enqueueFreedSlot(txCtx, repositories.NewWaitlistFreedSlotRepositoryTx(tx.WithContext(txCtx)), appointment.ID, appointment.ProviderUserID, booking.StartsAt); err != nil {
+			return ErrBookingUpdateFailed
+		}
+
+		endsAt := startsAt.Add(time.Duration(detail.DurationMinutes) * time.Minute)
+		booking.Status = status
+		booking.StartsAt = startsAt
+		booking.EndsAt = endsAt
+		booking.BusyStartsAt = startsAt.Add(-time.Duration(detail.BufferTimeBefore) * time.Minute)
+		booking.BusyEndsAt = endsAt.Add(time.Duration(detail.BufferTimeAfter) * time.Minute)
+		booking.Sequence++
+		updateData := map[string]interface{}{
+			"status":         booking.Status,
+			"starts_at":      booking.StartsAt,
+			"ends_at":        booking.EndsAt,
+			"busy_starts_at": booking.BusyStartsAt,
+			"busy_ends_at":   booking.BusyEndsAt,
+			"sequence":       booking.Sequence,
+		}
+		if err := repositories.NewAppointmentBookingRepositoryTx(tx.WithContext(txCtx)).Update(txCtx, booking, updateData); err != nil {
+			return ErrBookingUpdateFailed
+		}
+		if booking.Status == models.BookingStatusConfirmed {
+			// Aynı UID ve artan SEQUENCE ile müşterinin takvimindeki etkinlik güncellenir.
+			if err := enqueueBookingInvite(txCtx, repositories.NewMailOutboxRepositoryTx(tx.WithContext(txCtx)), s.userService, appointment, *booking, ics.MethodRequest); err != nil {
+				configslog.Log.Error("RescheduleBooking: Takvim daveti kuyruğa eklenemedi", zap.Uint("bookingID", booking.ID), zap.Error(err))
+				return ErrBookingUpdateFailed
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		if !errors.Is(txErr, ErrBookingSlotUnavailable) && !errors.Is(txErr, ErrBookingInvalidStatus) && !errors.Is(txErr, ErrBookingNotFound) {
+			configslog.Log.Error("Rezervasyon taşıma transaction hatası", zap.Uint("bookingID", bookingID), zap.Error(txErr))
+		}
+		return nil, txErr
+	}
+	if booking.StartsAt.Equal(oldStartsAt) {
+		return booking, nil
+	}
+
+	configslog.SLog.Infof("Rezervasyon taşındı: ID %d, %s -> %s (User ID %d)", booking.ID, oldStartsAt.Format(time.RFC3339), booking.StartsAt.Format(time.RFC3339), providerUserID)
+	return booking, nil
+}
+
+// bookingUID rezervasyonun takvimlerde değişmeyen kimliği.
+func bookingUID(bookingID uint) string {
+	return fmt.Sprintf("booking-%d@davet.link", bookingID)
+}
+
+// bookingEvent rezervasyonu bir VEVENT'e çevirir.
+func bookingEvent(appointment *models.Appointment, b models.AppointmentBooking) ics.Event {
+	description := "Müşteri: " + b.CustomerName
+	if b.CustomerEmail != "" {
+		description += "\nE-posta: " + b.CustomerEmail
+	}
+	if b.CustomerPhone != "" {
+		description += "\nTelefon: " + b.CustomerPhone
+	}
+	if b.Notes != "" {
+		description += "\nNot: " + b.Notes
+	}
+	for _, a := range b.Answers {
+		description += "\n" + a.Question + ": " + a.Answer
+	}
+	return ics.Event{
+		UID:          bookingUID(b.ID),
+		Sequence:     b.Sequence,
+		Start:        b.StartsAt,
+		End:          b.EndsAt,
+		Summary:      appointment.Detail.Name + " - " + b.CustomerName,
+		Description:  description,
+		Attendee:     b.CustomerEmail,
+		AttendeeName: b.CustomerName,
+		Cancelled:    b.Status == models.BookingStatusCancelled,
+		Created:      b.CreatedAt,
+		LastModified: b.UpdatedAt,
+	}
+}
+
+// inviteEvent müşteriye gönderilecek davetin VEVENT'i: müşteri bilgileri çıkarılır,
+// e-posta hesabı varsa sağlayıcı ORGANIZER olarak eklenir.
+func inviteEvent(users IUserService, appointment *models.Appointment, b models.AppointmentBooking) ics.Event {
+	event := bookingEvent(appointment, b)
+	event.Summary = appointment.Detail.Name
+	event.Description = ""
+	if provider, err := users.GetUserByID(appointment.ProviderUserID); err == nil && strings.Contains(provider.Account, "@") {
+		event.Organizer = provider.Account
+		event.OrganizerName = provider.Name
+	}
+	return event
+}
+
+// enqueueBookingInvite müşteriye METHOD:REQUEST veya METHOD:CANCEL içeren ICS davetini kuyruğa
+// ekler. Rezervasyonu değiştiren transaction içinde çağrılır; işlem geri alınırsa davet de
+// gönderilmez. Bekleme listesi gibi rezervasyon oluşturan diğer servisler de kullanır.
+func enqueueBookingInvite(ctx context.Context, repo repositories.IMailOutboxRepository, users IUserService, appointment *models.Appointment, b models.AppointmentBooking, method string) error {
+	if b.CustomerEmail == "" {
+		return nil
+	}
+	calendar := ics.Calendar{Method: method, Events: []ics.Event{inviteEvent(users, appointment, b)}}
+
+	local := b.StartsAt.In(AppointmentLocation(appointment.Detail))
+	subject := "Randevunuz onaylandı: " + appointment.Detail.Name
+	text := fmt.Sprintf("Merhaba %s,\n\n%s randevunuz %s tarihinde saat %s için onaylandı.\nTakvim davetini ekte bulabilirsiniz.",
+		b.CustomerName, appointment.Detail.Name, local.Format("02.01.2006"), local.Format("15:04"))
+	if method == ics.MethodCancel {
+		subject = "Randevunuz iptal edildi: " + appointment.Detail.Name
+		text = fmt.Sprintf("Merhaba %s,\n\n%s tarihinde saat %s için alınan %s randevunuz iptal edildi.",
+			b.CustomerName, local.Format("02.01.2006"), local.Format("15:04"), appointment.Detail.Name)
+	}
+
+	sourceID := b.ID
+	return enqueueMail(ctx, repo, &models.MailOutbox{
+		OwnerUserID: appointment.ProviderUserID,
+		Kind:        MailKindBookingInvite,
+		SourceID:    &sourceID,
+		Recipients:  b.CustomerEmail,
+		Subject:     mailSubject(subject),
+		TextBody:    text,
+		Attachments: []models.MailOutboxAttachment{{
+			FileName:    "invite.ics",
+			ContentType: "text/calendar; method=" + method,
+			Data:        calendar.Bytes(),
+			Inline:      true,
+		}},
+	})
+}
+
+var _ IBookingService = (*BookingService)(nil)
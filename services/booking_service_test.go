@@ -0,0 +1,44 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"davet.link/models"
+)
+
+// Yalnızca onaylanmış veya daha önce işaretlenmiş, bitmiş rezervasyonlar katılım durumuna geçer.
+func TestValidateAttendanceChange(t *testing.T) {
+	now := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+	ended, upcoming := now.Add(-time.Hour), now.Add(time.Hour)
+	tests := []struct {
+		name     string
+		previous models.BookingStatus
+		endsAt   time.Time
+		status   models.BookingStatus
+		wantErr  bool
+	}{
+		{"onaylı, gelmedi", models.BookingStatusConfirmed, ended, models.BookingStatusNoShow, false},
+		{"onaylı, gerçekleşti", models.BookingStatusConfirmed, ended, models.BookingStatusCompleted, false},
+		{"gelmedi düzeltmesi", models.BookingStatusNoShow, ended, models.BookingStatusCompleted, false},
+		{"gerçekleşti düzeltmesi", models.BookingStatusCompleted, ended, models.BookingStatusNoShow, false},
+		{"onay bekleyen gelmedi sayılmaz", models.BookingStatusPending, ended, models.BookingStatusNoShow, true},
+		{"onay bekleyen gerçekleşmiş sayılmaz", models.BookingStatusPending, ended, models.BookingStatusCompleted, true},
+		{"iptal edilmiş", models.BookingStatusCancelled, ended, models.BookingStatusNoShow, true},
+		{"aynı durum", models.BookingStatusNoShow, ended, models.BookingStatusNoShow, true},
+		{"henüz bitmemiş", models.BookingStatusConfirmed, upcoming, models.BookingStatusNoShow, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := &models.AppointmentBooking{Status: tt.previous, EndsAt: tt.endsAt}
+			err := validateAttendanceChange(booking, tt.status, now)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("hata beklenen %v, alınan %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrBookingInvalidStatus) {
+				t.Errorf("ErrBookingInvalidStatus beklenirdi, alınan %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+// CustomerServiceError özel servis hataları
+type CustomerServiceError string
+
+func (e CustomerServiceError) Error() string { return string(e) }
+
+const (
+	ErrCustomerNotFound     CustomerServiceError = "müşteri kaydı bulunamadı"
+	ErrCustomerForbidden    CustomerServiceError = "bu işlem için yetkiniz yok"
+	ErrCustomerInvalidInput CustomerServiceError = "geçersiz müşteri bilgisi"
+	ErrCustomerUpdateFailed CustomerServiceError = "müşteri kaydı güncellenemedi"
+	ErrCustomerBlocked      CustomerServiceError = "bu iletişim bilgileriyle çevrim içi rezervasyon yapılamıyor, lütfen doğrudan iletişime geçin"
+)
+
+// maxNoShowBlockLimit otomatik engelleme kuralında girilebilecek en büyük gelmeme sayısı.
+const maxNoShowBlockLimit = 50
+
+// ICustomerService müşteri katılım geçmişi ve engelleme işlemleri için arayüz.
+type ICustomerService interface {
+	GetCustomers(ctx context.Context, providerUserID uint) ([]models.CustomerRecord, error)
+	GetNoShowRule(ctx context.Context, providerUserID uint) (int, error)
+	SetBlocked(ctx context.Context, id uint, providerUserID uint, blocked bool) error
+	UpdateNoShowRule(ctx context.Context, providerUserID uint, limit int) error
+	CheckBookingAllowed(ctx context.Context, providerUserID uint, email, phone string) error
+}
+
+// CustomerService ICustomerService arayüzünü uygular.
+type CustomerService struct {
+	repo        repositories.ICustomerRecordRepository
+	settingRepo repositories.IAvailabilityRepository
+}
+
+// NewCustomerService yeni bir CustomerService örneği oluşturur.
+func NewCustomerService() ICustomerService {
+	return &CustomerService{
+		repo:        repositories.NewCustomerRecordRepository(),
+		settingRepo: repositories.NewAvailabilityRepository(),
+	}
+}
+
+// --- Yardımcı Metodlar ---
+
+// NormalizeEmail e-posta adresini karşılaştırma için küçük harfe çevirir.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone telefon numarasını yalnızca rakamlardan oluşan uluslararası biçime çevirir.
+// Türkiye numaraları "0532 ...", "532 ..." veya "+90 532 ..." yazılsa da aynı anahtarı üretir.
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	digits = strings.TrimPrefix(digits, "00")
+	switch {
+	case len(digits) == 11 && digits[0] == '0':
+		return "90" + digits[1:]
+	case len(digits) == 10 && digits[0] != '0':
+		return "90" + digits
+	}
+	return digits
+}
+
+// CustomerContactKeys rezervasyondaki e-posta ve telefondan müşteri kayıt anahtarlarını üretir.
+func CustomerContactKeys(email, phone string) []string {
+	var keys []string
+	if e := NormalizeEmail(email); e != "" {
+		keys = append(keys, "email:"+e)
+	}
+	if p := NormalizePhone(phone); len(p) >= 7 {
+		keys = append(keys, "phone:"+p)
+	}
+	return keys
+}
+
+// recordAttendance bir rezervasyonun katılım durumu değiştiğinde müşteri sayaçlarını günceller.
+// Önceki durum completed/no_show ise o sayaç geri alınır (yanlış işaretlemenin düzeltilmesi).
+// Transaction içinde, context'e tx eklenmiş olarak çağrılmalıdır.
+func recordAttendance(ctx context.Context, repo repositories.ICustomerRecordRepository, booking *models.AppointmentBooking, previous models.BookingStatus) error {
+	var completedDelta, noShowDelta int
+	switch previous {
+	case models.BookingStatusCompleted:
+		completedDelta--
+	case models.BookingStatusNoShow:
+		noShowDelta--
+	}
+	switch booking.Status {
+	case models.BookingStatusCompleted:
+		completedDelta++
+	case models.BookingStatusNoShow:
+		noShowDelta++
+	}
+
+	now := time.Now().UTC()
+	for _, key := range CustomerContactKeys(booking.CustomerEmail, booking.CustomerPhone) {
+		record, err := repo.FindByContactKeyForUpdate(ctx, booking.ProviderUserID, key)
+		if errors.Is(err, repositories.ErrNotFound) {
+			record = &models.CustomerRecord{
+				ProviderUserID: booking.ProviderUserID,
+				ContactKey:     key,
+				CustomerName:   booking.CustomerName,
+				CompletedCount: max(completedDelta, 0),
+				NoShowCount:    max(noShowDelta, 0),
+			}
+			if noShowDelta > 0 {
+				record.LastNoShowAt = &now
+			}
+			if err := repo.Create(ctx, record); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		updateData := map[string]interface{}{
+			"customer_name":   booking.CustomerName,
+			"completed_count": max(record.CompletedCount+completedDelta, 0),
+			"no_show_count":   max(record.NoShowCount+noShowDelta, 0),
+		}
+		if noShowDelta > 0 {
+			updateData["last_no_show_at"] = now
+		}
+		if err := repo.Update(ctx, record, updateData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- Servis Metodları ---
+
+// GetCustomers sağlayıcının katılım geçmişi olan müşterilerini listeler.
+func (s *CustomerService) GetCustomers(ctx context.Context, providerUserID uint) ([]models.CustomerRecord, error) {
+	return s.repo.FindByProvider(ctx, providerUserID)
+}
+
+// GetNoShowRule sağlayıcının otomatik engelleme sınırını döndürür (0: kapalı).
+func (s *CustomerService) GetNoShowRule(ctx context.Context, providerUserID uint) (int, error) {
+	setting, err := s.settingRepo.FindProviderSetting(ctx, providerUserID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return setting.NoShowBlockLimit, nil
+}
+
+// SetBlocked müşteriyi elle engeller veya engeli kaldırır. Engel kaldırılırken gelmeme sayacı
+// da sıfırlanır; aksi halde otomatik kural müşteriyi hemen yeniden engellerdi.
+func (s *CustomerService) SetBlocked(ctx context.Context, id uint, providerUserID uint, blocked bool) error {
+	record, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrCustomerNotFound
+		}
+		return err
+	}
+	if record.ProviderUserID != providerUserID {
+		return ErrCustomerForbidden
+	}
+	updateData := map[string]interface{}{"blocked": blocked}
+	if !blocked {
+		updateData["no_show_count"] = 0
+	}
+	if err := s.repo.Update(contextWithUserID(ctx, providerUserID), record, updateData); err != nil {
+		return ErrCustomerUpdateFailed
+	}
+	configslog.SLog.Infof("Müşteri engeli güncellendi: ID %d, engelli=%t (User ID %d)", record.ID, blocked, providerUserID)
+	return nil
+}
+
+// UpdateNoShowRule "N kez gelmeyen müşteri çevrim içi rezervasyon yapamasın" kuralını kaydeder.
+// 0 kuralı kapatır.
+func (s *CustomerService) UpdateNoShowRule(ctx context.Context, providerUserID uint, limit int) error {
+	if limit < 0 || limit > maxNoShowBlockLimit {
+		return fmt.Errorf("%w: gelmeme sınırı 0 ile %d arasında olmalıdır", ErrCustomerInvalidInput, maxNoShowBlockLimit)
+	}
+	setting, err := s.settingRepo.FindProviderSetting(ctx, providerUserID)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			return err
+		}
+		setting = &models.ProviderSetting{UserID: providerUserID}
+	}
+	setting.NoShowBlockLimit = limit
+	if err := s.settingRepo.SaveProviderSetting(contextWithUserID(ctx, providerUserID), setting); err != nil {
+		configslog.Log.Error("Gelmeme kuralı kaydedilemedi", zap.Uint("userID", providerUserID), zap.Error(err))
+		return ErrCustomerUpdateFailed
+	}
+	return nil
+}
+
+// CheckBookingAllowed müşterinin e-posta veya telefonundan biri elle engellenmişse ya da
+// sağlayıcının gelmeme sınırına ulaşmışsa ErrCustomerBlocked döner.
+func (s *CustomerService) CheckBookingAllowed(ctx context.Context, providerUserID uint, email, phone string) error {
+	records, err := s.repo.FindByContactKeys(ctx, providerUserID, CustomerContactKeys(email, phone))
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	limit, err := s.GetNoShowRule(ctx, providerUserID)
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if r.Blocked || (limit > 0 && r.NoShowCount >= limit) {
+			return ErrCustomerBlocked
+		}
+	}
+	return nil
+}
+
+var _ ICustomerService = (*CustomerService)(nil)
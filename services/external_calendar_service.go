@@ -0,0 +1,263 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/ics"
+	"davet.link/pkg/safehttp"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+// ExternalCalendarServiceError özel servis hataları
+type ExternalCalendarServiceError string
+
+func (e ExternalCalendarServiceError) Error() string { return string(e) }
+
+const (
+	ErrExtCalNotFound     ExternalCalendarServiceError = "dış takvim bulunamadı"
+	ErrExtCalForbidden    ExternalCalendarServiceError = "bu işlem için yetkiniz yok"
+	ErrExtCalInvalidInput ExternalCalendarServiceError = "geçersiz dış takvim bilgisi"
+	ErrExtCalInvalidFile  ExternalCalendarServiceError = "takvim dosyası okunamadı"
+	ErrExtCalFetchFailed  ExternalCalendarServiceError = "takvim adresinden veri alınamadı"
+	ErrExtCalSaveFailed   ExternalCalendarServiceError = "dış takvim kaydedilemedi"
+)
+
+// Dış takvim etkinliklerinin meşgul bloklara açıldığı pencere (bugüne göre).
+// Rezervasyon ufkundan uzun tutulur; pencere her yenilemede ileri kayar.
+const (
+	externalBusyPastDays   = 1
+	externalBusyFutureDays = 400
+	externalFetchTimeout   = 20 * time.Second
+	externalFetchRedirects = 3 // Takvim adresleri sık sık (http→https gibi) yönlendirilir
+)
+
+// IExternalCalendarService dış takvim içe aktarma işlemleri için arayüz.
+type IExternalCalendarService interface {
+	GetCalendarsForUser(ctx context.Context, userID uint) ([]models.ExternalCalendar, error)
+	ImportFile(ctx context.Context, userID uint, name string, data []byte) (*models.ExternalCalendar, error)
+	AddURL(ctx context.Context, userID uint, name string, rawURL string) (*models.ExternalCalendar, error)
+	Refresh(ctx context.Context, id uint, userID uint) error
+	RefreshAll(ctx context.Context) (synced int, failed int)
+	DeleteCalendar(ctx context.Context, id uint, userID uint) error
+}
+
+// ExternalCalendarService IExternalCalendarService arayüzünü uygular.
+type ExternalCalendarService struct {
+	repo  repositories.IExternalCalendarRepository
+	fetch func(ctx context.Context, feedURL string) ([]byte, error)
+	now   func() time.Time
+}
+
+// NewExternalCalendarService yeni bir ExternalCalendarService örneği oluşturur.
+func NewExternalCalendarService() IExternalCalendarService {
+	client := safehttp.NewClient(externalFetchTimeout, externalFetchRedirects)
+	return &ExternalCalendarService{
+		repo: repositories.NewExternalCalendarRepository(),
+		fetch: func(ctx context.Context, feedURL string) ([]byte, error) {
+			return ics.Fetch(ctx, client, feedURL)
+		},
+		now: time.Now,
+	}
+}
+
+// --- Yardımcı Metodlar ---
+
+// expandBusyBlocks ICS içeriğini okuyup [now-1g, now+400g] penceresindeki meşgul bloklara açar.
+// Zaman dilimi belirtilmemiş (floating) saatler varsayılan randevu zaman diliminde yorumlanır.
+func expandBusyBlocks(data []byte, now time.Time) ([]models.ExternalBusyBlock, error) {
+	loc, err := time.LoadLocation(DefaultAppointmentTimezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	events, err := ics.Parse(bytes.NewReader(data), loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExtCalInvalidFile, err)
+	}
+	periods := ics.BusyPeriods(events, now.AddDate(0, 0, -externalBusyPastDays), now.AddDate(0, 0, externalBusyFutureDays))
+	blocks := make([]models.ExternalBusyBlock, 0, len(periods))
+	for _, p := range periods {
+		blocks = append(blocks, models.ExternalBusyBlock{StartsAt: p.Start, EndsAt: p.End})
+	}
+	return blocks, nil
+}
+
+// storeContent içeriği genişletir, blokları yeniler ve senkronizasyon bilgisini günceller.
+func (s *ExternalCalendarService) storeContent(ctx context.Context, calendar *models.ExternalCalendar, data []byte) error {
+	now := s.now().UTC()
+	blocks, err := expandBusyBlocks(data, now)
+	if err != nil {
+		return err
+	}
+	txCtx := contextWithUserID(ctx, calendar.UserID)
+	if err := s.repo.ReplaceBlocks(txCtx, calendar, blocks); err != nil {
+		configslog.Log.Error("Dış takvim blokları kaydedilemedi", zap.Uint("calendarID", calendar.ID), zap.Error(err))
+		return ErrExtCalSaveFailed
+	}
+	calendar.Content = string(data)
+	calendar.LastSyncedAt = &now
+	calendar.LastSyncError = ""
+	calendar.BlockCount = len(blocks)
+	return s.repo.Update(txCtx, calendar, map[string]interface{}{
+		"content":         calendar.Content,
+		"last_synced_at":  now,
+		"last_sync_error": "",
+		"block_count":     calendar.BlockCount,
+	})
+}
+
+// findOwnedCalendar takvimi bulur ve kullanıcıya ait olduğunu doğrular.
+func (s *ExternalCalendarService) findOwnedCalendar(ctx context.Context, id uint, userID uint) (*models.ExternalCalendar, error) {
+	calendar, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrExtCalNotFound
+		}
+		return nil, err
+	}
+	if calendar.UserID != userID {
+		return nil, ErrExtCalForbidden
+	}
+	return calendar, nil
+}
+
+// --- Servis Metodları ---
+
+// GetCalendarsForUser kullanıcının dış takvimlerini listeler.
+func (s *ExternalCalendarService) GetCalendarsForUser(ctx context.Context, userID uint) ([]models.ExternalCalendar, error) {
+	return s.repo.FindByUserID(ctx, userID)
+}
+
+// ImportFile yüklenen .ics dosyasını dış takvim olarak kaydeder.
+func (s *ExternalCalendarService) ImportFile(ctx context.Context, userID uint, name string, data []byte) (*models.ExternalCalendar, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: takvim adı zorunludur", ErrExtCalInvalidInput)
+	}
+	if len(data) == 0 || len(data) > ics.MaxFeedSize {
+		return nil, fmt.Errorf("%w: dosya boş veya çok büyük", ErrExtCalInvalidFile)
+	}
+	if _, err := expandBusyBlocks(data, s.now().UTC()); err != nil {
+		return nil, err
+	}
+
+	calendar := &models.ExternalCalendar{UserID: userID, Name: name, Source: models.ExternalCalendarSourceFile}
+	if err := s.repo.Create(contextWithUserID(ctx, userID), calendar); err != nil {
+		configslog.Log.Error("Dış takvim oluşturulamadı", zap.Uint("userID", userID), zap.Error(err))
+		return nil, ErrExtCalSaveFailed
+	}
+	if err := s.storeContent(ctx, calendar, data); err != nil {
+		return nil, err
+	}
+	configslog.SLog.Infof("Dış takvim dosyası içe aktarıldı: ID %d, %d blok (User ID %d)", calendar.ID, calendar.BlockCount, userID)
+	return calendar, nil
+}
+
+// AddURL bir ICS adresini kaydeder ve ilk senkronizasyonu hemen yapar.
+func (s *ExternalCalendarService) AddURL(ctx context.Context, userID uint, name string, rawURL string) (*models.ExternalCalendar, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: takvim adı zorunludur", ErrExtCalInvalidInput)
+	}
+	feedURL, err := ics.NormalizeFeedURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExtCalInvalidInput, err)
+	}
+	data, err := s.fetch(ctx, feedURL)
+	if err != nil {
+		configslog.Log.Warn("Dış takvim adresi indirilemedi", zap.Uint("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrExtCalFetchFailed, err)
+	}
+	if _, err := expandBusyBlocks(data, s.now().UTC()); err != nil {
+		return nil, err
+	}
+
+	calendar := &models.ExternalCalendar{UserID: userID, Name: name, Source: models.ExternalCalendarSourceURL, URL: feedURL}
+	if err := s.repo.Create(contextWithUserID(ctx, userID), calendar); err != nil {
+		configslog.Log.Error("Dış takvim oluşturulamadı", zap.Uint("userID", userID), zap.Error(err))
+		return nil, ErrExtCalSaveFailed
+	}
+	if err := s.storeContent(ctx, calendar, data); err != nil {
+		return nil, err
+	}
+	configslog.SLog.Infof("Dış takvim adresi eklendi: ID %d, %d blok (User ID %d)", calendar.ID, calendar.BlockCount, userID)
+	return calendar, nil
+}
+
+// Refresh tek bir dış takvimi kullanıcının isteğiyle hemen yeniler.
+func (s *ExternalCalendarService) Refresh(ctx context.Context, id uint, userID uint) error {
+	calendar, err := s.findOwnedCalendar(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	return s.sync(ctx, calendar)
+}
+
+// sync adres kaynaklı takvimleri yeniden indirir; dosya kaynaklıları (ve indirilemeyen
+// adresleri) saklanan son içerikten yeniden genişleterek pencereyi ileri kaydırır.
+func (s *ExternalCalendarService) sync(ctx context.Context, calendar *models.ExternalCalendar) error {
+	data := []byte(calendar.Content)
+	var fetchErr error
+	if calendar.Source == models.ExternalCalendarSourceURL {
+		fetched, err := s.fetch(ctx, calendar.URL)
+		if err != nil {
+			fetchErr = fmt.Errorf("%w: %v", ErrExtCalFetchFailed, err)
+		} else {
+			data = fetched
+		}
+	}
+
+	if len(data) > 0 {
+		if err := s.storeContent(ctx, calendar, data); err != nil {
+			fetchErr = err
+		}
+	}
+	if fetchErr != nil {
+		_ = s.repo.Update(contextWithUserID(ctx, calendar.UserID), calendar, map[string]interface{}{"last_sync_error": fetchErr.Error()})
+		return fetchErr
+	}
+	return nil
+}
+
+// RefreshAll tüm dış takvimleri senkronize eder (arka plan işi tarafından çağrılır).
+func (s *ExternalCalendarService) RefreshAll(ctx context.Context) (synced int, failed int) {
+	calendars, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return 0, 0
+	}
+	for i := range calendars {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := s.sync(ctx, &calendars[i]); err != nil {
+			configslog.Log.Warn("Dış takvim senkronize edilemedi", zap.Uint("calendarID", calendars[i].ID), zap.Error(err))
+			failed++
+			continue
+		}
+		synced++
+	}
+	return synced, failed
+}
+
+// DeleteCalendar dış takvimi ve meşgul bloklarını siler.
+func (s *ExternalCalendarService) DeleteCalendar(ctx context.Context, id uint, userID uint) error {
+	calendar, err := s.findOwnedCalendar(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(contextWithUserID(ctx, userID), calendar, userID); err != nil {
+		configslog.Log.Error("Dış takvim silinemedi", zap.Uint("calendarID", id), zap.Error(err))
+		return ErrExtCalSaveFailed
+	}
+	return nil
+}
+
+var _ IExternalCalendarService = (*ExternalCalendarService)(nil)
@@ -0,0 +1,383 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+// FormAnalyticsServiceError özel servis hataları
+type FormAnalyticsServiceError string
+
+func (e FormAnalyticsServiceError) Error() string { return string(e) }
+
+const (
+	ErrFormAnalyticsRange FormAnalyticsServiceError = "geçersiz tarih aralığı"
+)
+
+const (
+	defaultAnalyticsDays = 30
+	maxAnalyticsDays     = 366
+)
+
+// FormAnalyticsRange analizin gün aralığıdır (sunucu saat dilimi). From ilk gün, To son
+// günden sonraki günün başlangıcıdır.
+type FormAnalyticsRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Days aralıktaki gün sayısı.
+func (r FormAnalyticsRange) Days() int {
+	return int(r.To.Sub(r.From).Hours()/24 + 0.5)
+}
+
+// LastDay aralığın son günü (panelde gösterim için).
+func (r FormAnalyticsRange) LastDay() time.Time {
+	return r.To.AddDate(0, 0, -1)
+}
+
+// ParseFormAnalyticsRange panelden gelen YYYY-MM-DD tarihlerini aralığa çevirir. Boş
+// bırakılırsa son 30 gün kullanılır; aralık en fazla bir yıl olabilir.
+func ParseFormAnalyticsRange(from string, to string, now time.Time) (FormAnalyticsRange, error) {
+	local := now.In(time.Local)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+	r := FormAnalyticsRange{From: today.AddDate(0, 0, 1-defaultAnalyticsDays), To: today.AddDate(0, 0, 1)}
+	if to = strings.TrimSpace(to); to != "" {
+		day, err := time.ParseInLocation(exportDateLayout, to, time.Local)
+		if err != nil {
+			return r, fmt.Errorf("%w: bitiş tarihi geçersiz", ErrFormAnalyticsRange)
+		}
+		r.To = day.AddDate(0, 0, 1)
+		r.From = day.AddDate(0, 0, 1-defaultAnalyticsDays)
+	}
+	if from = strings.TrimSpace(from); from != "" {
+		day, err := time.ParseInLocation(exportDateLayout, from, time.Local)
+		if err != nil {
+			return r, fmt.Errorf("%w: başlangıç tarihi geçersiz", ErrFormAnalyticsRange)
+		}
+		r.From = day
+	}
+	if !r.From.Before(r.To) {
+		return r, fmt.Errorf("%w: başlangıç tarihi bitiş tarihinden sonra olamaz", ErrFormAnalyticsRange)
+	}
+	if r.Days() > maxAnalyticsDays {
+		return r, fmt.Errorf("%w: en fazla %d günlük aralık seçilebilir", ErrFormAnalyticsRange, maxAnalyticsDays)
+	}
+	return r, nil
+}
+
+// analyticsDay yerel günü, tarih sütunlarında tutulan takvim gününe çevirir.
+func analyticsDay(t time.Time) time.Time {
+	y, m, d := t.In(time.Local).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// percentOf a'nın b içindeki yüzdesini tam sayıya yuvarlar (b sıfırsa 0).
+func percentOf(a int64, b int64) int {
+	if b <= 0 {
+		return 0
+	}
+	return int((a*100 + b/2) / b)
+}
+
+// FormDailyPoint zaman grafiğinde bir gündür. Yüzdeler çubuk yüksekliği içindir (en büyük değer 100).
+type FormDailyPoint struct {
+	Day                time.Time
+	Views              int64
+	Submissions        int64
+	ViewsPercent       int
+	SubmissionsPercent int
+}
+
+// FormChoiceStat bir değerin seçilme sayısı ve alanı cevaplayanlar içindeki yüzdesidir.
+type FormChoiceStat struct {
+	Value   string
+	Count   int64
+	Percent int
+}
+
+// FormFieldDistribution seçim veya puan alanının değer dağılımıdır.
+type FormFieldDistribution struct {
+	Field    models.FormFieldDefinition
+	Answered int64
+	Choices  []FormChoiceStat
+}
+
+// FormNumericSummary sayı veya puan alanının özetidir.
+type FormNumericSummary struct {
+	Field   models.FormFieldDefinition
+	Count   int64
+	Average float64
+	Minimum float64
+	Maximum float64
+}
+
+// FormPageDropOff çok sayfalı formda bir sayfaya ulaşanlar ve sonraki adıma geçenlerdir.
+// İlk sayfaya ulaşanlar görüntülenme sayısıdır; son sayfadan sonraki adım gönderimdir.
+type FormPageDropOff struct {
+	Number      int
+	Title       string
+	Reached     int64
+	Continued   int64
+	DropOffRate int
+}
+
+// FormAnalytics panelde gösterilen form analizidir.
+type FormAnalytics struct {
+	Range          FormAnalyticsRange
+	Daily          []FormDailyPoint
+	Views          int64
+	Submissions    int64
+	ConversionRate int
+	Distributions  []FormFieldDistribution
+	Numeric        []FormNumericSummary
+	Pages          []FormPageDropOff
+}
+
+// IFormAnalyticsService form analizleri ve sayaçları için arayüz.
+type IFormAnalyticsService interface {
+	RecordView(ctx context.Context, form *models.Form)
+	GetAnalytics(ctx context.Context, formID uint, requestingUserID uint, r FormAnalyticsRange) (*models.Form, *FormAnalytics, error)
+}
+
+// FormAnalyticsService IFormAnalyticsService arayüzünü uygular.
+type FormAnalyticsService struct {
+	repo         repositories.IFormAnalyticsRepository
+	fieldService IFormFieldService
+}
+
+// NewFormAnalyticsService yeni bir FormAnalyticsService örneği oluşturur.
+func NewFormAnalyticsService() IFormAnalyticsService {
+	return &FormAnalyticsService{
+		repo:         repositories.NewFormAnalyticsRepository(),
+		fieldService: NewFormFieldService(),
+	}
+}
+
+// RecordView public form sayfasının görüntülenmesini sayar. Sayaç hatası sayfayı engellemez.
+func (s *FormAnalyticsService) RecordView(ctx context.Context, form *models.Form) {
+	// Public işlem: BaseModel hook'ları için aktör olarak form sahibi kullanılır.
+	if err := s.repo.IncrementView(contextWithUserID(ctx, form.CreatorUserID), form.ID, analyticsDay(time.Now())); err != nil {
+		configslog.Log.Warn("Form görüntülenmesi sayılamadı", zap.Uint("formID", form.ID), zap.Error(err))
+	}
+}
+
+// recordPageReached gönderenin çok sayfalı formda yeni bir sayfaya ilk kez ulaştığını sayar.
+func recordPageReached(ctx context.Context, repo repositories.IFormAnalyticsRepository, form *models.Form, page FormPage) {
+	if err := repo.IncrementPageReached(contextWithUserID(ctx, form.CreatorUserID), form.ID, page.BreakID, analyticsDay(time.Now())); err != nil {
+		configslog.Log.Warn("Form sayfa sayacı artırılamadı", zap.Uint("formID", form.ID), zap.Uint("breakID", page.BreakID), zap.Error(err))
+	}
+}
+
+// GetAnalytics formun aralıktaki analizini SQL toplamalarından hesaplar.
+func (s *FormAnalyticsService) GetAnalytics(ctx context.Context, formID uint, requestingUserID uint, r FormAnalyticsRange) (*models.Form, *FormAnalytics, error) {
+	form, fields, err := s.fieldService.GetFields(ctx, formID, requestingUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	analytics := &FormAnalytics{Range: r}
+	if err := s.fillDaily(ctx, form.ID, analytics); err != nil {
+		return nil, nil, err
+	}
+	if err := s.fillFields(ctx, form.ID, fields, analytics); err != nil {
+		return nil, nil, err
+	}
+	if err := s.fillPages(ctx, form.ID, fields, analytics); err != nil {
+		return nil, nil, err
+	}
+	return form, analytics, nil
+}
+
+// fillDaily günlük görüntülenme ve gönderim serisini, toplamları ve dönüşüm oranını doldurur.
+func (s *FormAnalyticsService) fillDaily(ctx context.Context, formID uint, analytics *FormAnalytics) error {
+	r := analytics.Range
+	fromDay, toDay := analyticsDay(r.From), analyticsDay(r.To)
+	views, err := s.repo.DailyViews(ctx, formID, fromDay, toDay)
+	if err != nil {
+		return err
+	}
+	_, offset := r.To.Zone()
+	submissions, err := s.repo.DailySubmissions(ctx, formID, r.From.UTC(), r.To.UTC(), offset)
+	if err != nil {
+		return err
+	}
+
+	byDay := make(map[string]*FormDailyPoint)
+	for day := r.From; day.Before(r.To); day = day.AddDate(0, 0, 1) {
+		analytics.Daily = append(analytics.Daily, FormDailyPoint{Day: day})
+	}
+	for i := range analytics.Daily {
+		byDay[analytics.Daily[i].Day.Format(exportDateLayout)] = &analytics.Daily[i]
+	}
+	for _, count := range views {
+		if point := byDay[count.Day.Format(exportDateLayout)]; point != nil {
+			point.Views = count.Count
+		}
+		analytics.Views += count.Count
+	}
+	for _, count := range submissions {
+		if point := byDay[count.Day.Format(exportDateLayout)]; point != nil {
+			point.Submissions = count.Count
+		}
+		analytics.Submissions += count.Count
+	}
+
+	var peak int64
+	for _, point := range analytics.Daily {
+		if point.Views > peak {
+			peak = point.Views
+		}
+		if point.Submissions > peak {
+			peak = point.Submissions
+		}
+	}
+	for i := range analytics.Daily {
+		analytics.Daily[i].ViewsPercent = percentOf(analytics.Daily[i].Views, peak)
+		analytics.Daily[i].SubmissionsPercent = percentOf(analytics.Daily[i].Submissions, peak)
+	}
+	analytics.ConversionRate = percentOf(analytics.Submissions, analytics.Views)
+	return nil
+}
+
+// fillFields seçim ve puan alanlarının dağılımlarını, sayı ve puan alanlarının özetlerini doldurur.
+func (s *FormAnalyticsService) fillFields(ctx context.Context, formID uint, fields []models.FormFieldDefinition, analytics *FormAnalytics) error {
+	var choiceIDs, numericIDs []uint
+	for _, field := range fields {
+		switch field.Type {
+		case models.FormFieldSelect, models.FormFieldRadio, models.FormFieldCheckbox:
+			choiceIDs = append(choiceIDs, field.ID)
+		case models.FormFieldRating:
+			choiceIDs = append(choiceIDs, field.ID)
+			numericIDs = append(numericIDs, field.ID)
+		case models.FormFieldNumber:
+			numericIDs = append(numericIDs, field.ID)
+		}
+	}
+	r := analytics.Range
+	from, to := r.From.UTC(), r.To.UTC()
+
+	counts, err := s.repo.AnswerCounts(ctx, formID, choiceIDs, from, to)
+	if err != nil {
+		return err
+	}
+	answered, err := s.repo.AnsweredCounts(ctx, formID, choiceIDs, from, to)
+	if err != nil {
+		return err
+	}
+	stats, err := s.repo.NumericStats(ctx, formID, numericIDs, from, to)
+	if err != nil {
+		return err
+	}
+
+	valueCounts := make(map[uint]map[string]int64)
+	valueOrder := make(map[uint][]string) // Değerler çoktan aza
+	for _, count := range counts {
+		if valueCounts[count.FieldID] == nil {
+			valueCounts[count.FieldID] = make(map[string]int64)
+		}
+		valueCounts[count.FieldID][count.Value] = count.Count
+		valueOrder[count.FieldID] = append(valueOrder[count.FieldID], count.Value)
+	}
+	answeredBy := make(map[uint]int64)
+	for _, count := range answered {
+		answeredBy[count.FieldID] = count.Count
+	}
+	statBy := make(map[uint]models.FormNumericStat)
+	for _, stat := range stats {
+		statBy[stat.FieldID] = stat
+	}
+
+	for _, field := range fields {
+		if containsUint(choiceIDs, field.ID) {
+			distribution := FormFieldDistribution{Field: field, Answered: answeredBy[field.ID]}
+			listed := make(map[string]bool)
+			for _, value := range distributionValues(field) {
+				listed[value] = true
+				count := valueCounts[field.ID][value]
+				distribution.Choices = append(distribution.Choices, FormChoiceStat{Value: value, Count: count, Percent: percentOf(count, distribution.Answered)})
+			}
+			// Seçenek sonradan değiştirildiyse eski değerler de gösterilir.
+			for _, value := range valueOrder[field.ID] {
+				if !listed[value] {
+					count := valueCounts[field.ID][value]
+					distribution.Choices = append(distribution.Choices, FormChoiceStat{Value: value, Count: count, Percent: percentOf(count, distribution.Answered)})
+				}
+			}
+			analytics.Distributions = append(analytics.Distributions, distribution)
+		}
+		if containsUint(numericIDs, field.ID) {
+			stat := statBy[field.ID]
+			analytics.Numeric = append(analytics.Numeric, FormNumericSummary{Field: field, Count: stat.Count, Average: stat.Average, Minimum: stat.Minimum, Maximum: stat.Maximum})
+		}
+	}
+	return nil
+}
+
+// distributionValues alanın dağılımda her zaman gösterilecek değerlerini sırasıyla döndürür.
+func distributionValues(field models.FormFieldDefinition) []string {
+	switch {
+	case field.Type == models.FormFieldRating:
+		values := make([]string, field.RatingScale())
+		for i := range values {
+			values[i] = strconv.Itoa(i + 1)
+		}
+		return values
+	case field.Type == models.FormFieldCheckbox && !field.HasOptions():
+		return []string{checkboxAnswerYes, checkboxAnswerNo}
+	}
+	return field.OptionList()
+}
+
+func containsUint(values []uint, value uint) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// fillPages çok sayfalı formda sayfa başına ayrılma oranlarını doldurur.
+func (s *FormAnalyticsService) fillPages(ctx context.Context, formID uint, fields []models.FormFieldDefinition, analytics *FormAnalytics) error {
+	pages := SplitFormPages(fields)
+	if len(pages) < 2 {
+		return nil
+	}
+	r := analytics.Range
+	reached, err := s.repo.PageReached(ctx, formID, analyticsDay(r.From), analyticsDay(r.To))
+	if err != nil {
+		return err
+	}
+	reachedBy := make(map[uint]int64)
+	for _, count := range reached {
+		reachedBy[count.BreakID] = count.Count
+	}
+
+	for i, page := range pages {
+		item := FormPageDropOff{Number: i + 1, Title: page.Title, Reached: reachedBy[page.BreakID]}
+		if i == 0 {
+			item.Reached = analytics.Views
+		}
+		if i+1 < len(pages) {
+			item.Continued = reachedBy[pages[i+1].BreakID]
+		} else {
+			item.Continued = analytics.Submissions
+		}
+		if item.Reached > 0 && item.Continued < item.Reached {
+			item.DropOffRate = 100 - percentOf(item.Continued, item.Reached)
+		}
+		analytics.Pages = append(analytics.Pages, item)
+	}
+	return nil
+}
+
+var _ IFormAnalyticsService = (*FormAnalyticsService)(nil)
@@ -0,0 +1,628 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"davet.link/configs/configsenv"
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// FormDraftServiceError özel servis hataları
+type FormDraftServiceError string
+
+func (e FormDraftServiceError) Error() string { return string(e) }
+
+const (
+	ErrDraftNotFound   FormDraftServiceError = "kayıtlı cevaplar bulunamadı veya saklama süresi doldu"
+	ErrDraftSaveFailed FormDraftServiceError = "cevaplar kaydedilirken bir hata oluştu"
+)
+
+const (
+	defaultDraftRetentionDays = 30
+	draftPurgeBatchSize       = 200
+)
+
+// FormPage sayfa sonu alanlarıyla ayrılmış form sayfasıdır. İlk sayfa dışındaki her sayfa
+// bir sayfa sonuyla başlar; sayfanın başlığı ve görünürlük kuralı o alandan gelir.
+type FormPage struct {
+	Title       string
+	Description string
+	BreakID     uint // Sayfayı başlatan sayfa sonu alanı (başlıksız ilk sayfada 0)
+	Fields      []models.FormFieldDefinition
+}
+
+// SplitFormPages alanları sayfa sonlarından böler. Alan içermeyen sayfalar atlanır; formda
+// hiç sayfa sonu yoksa tüm alanlar tek sayfadadır.
+func SplitFormPages(fields []models.FormFieldDefinition) []FormPage {
+	pages := []FormPage{{}}
+	for _, field := range fields {
+		if field.Type == models.FormFieldPage {
+			pages = append(pages, FormPage{Title: field.Label, Description: field.HelpText, BreakID: field.ID})
+			continue
+		}
+		pages[len(pages)-1].Fields = append(pages[len(pages)-1].Fields, field)
+	}
+	result := pages[:0]
+	for _, page := range pages {
+		if len(page.Fields) > 0 {
+			result = append(result, page)
+		}
+	}
+	if len(result) == 0 {
+		return []FormPage{{}}
+	}
+	return result
+}
+
+type FormPageAction string
+
+const (
+	FormPageNext   FormPageAction = "next"
+	FormPageBack   FormPageAction = "back"
+	FormPageSave   FormPageAction = "save"
+	FormPageSubmit FormPageAction = "submit"
+)
+
+// FormPageInput çok sayfalı formda bir sayfanın gönderimidir.
+type FormPageInput struct {
+	FormSubmissionInput
+	DraftToken string
+	Page       int
+	Action     FormPageAction
+}
+
+// FormPageView çok sayfalı formun gösterilecek durumudur.
+type FormPageView struct {
+	Form          *models.Form
+	Pages         []FormPage
+	Page          int                             // Gösterilecek sayfa
+	DraftToken    string                          // Boşsa henüz taslak kaydedilmedi
+	Values        map[uint][]string               // Tüm sayfalardaki kayıtlı cevaplar
+	Files         map[uint][]models.FormDraftFile // Taslakta saklanan dosyalar
+	ContextValues map[uint][]string               // Diğer sayfalardaki görünür cevaplar (koşullar için)
+	IsLastPage    bool
+	Saved         bool                   // "Kaydet ve sonra devam et" ile kaydedildi
+	Submission    *models.FormSubmission // Form gönderildiyse dolu
+	QuizStartedAt *time.Time             // Sınav modunda sürenin başladığı an
+	Prefill       string                 // Sayfayla taşınan ön doldurma değerleri (sorgu biçiminde)
+}
+
+// MultiPage form birden fazla sayfadan oluşuyorsa true döner.
+func (v *FormPageView) MultiPage() bool {
+	return len(v.Pages) > 1
+}
+
+// CurrentPage gösterilecek sayfayı döner.
+func (v *FormPageView) CurrentPage() FormPage {
+	return v.Pages[v.Page]
+}
+
+// Progress ilerleme çubuğu için yüzdeyi döner.
+func (v *FormPageView) Progress() int {
+	return (v.Page + 1) * 100 / len(v.Pages)
+}
+
+// ResumeURL taslağa geri dönmek için kullanılacak bağlantıdır.
+func (v *FormPageView) ResumeURL() string {
+	if v.DraftToken == "" || v.Form == nil || v.Form.Link.Key == "" {
+		return ""
+	}
+	return PublicURL("/" + v.Form.Link.Key + "?resume=" + v.DraftToken)
+}
+
+type IFormDraftService interface {
+	GetFormPage(ctx context.Context, form *models.Form, respondent FormRespondent, token string, query url.Values, referrer string) (*FormPageView, error)
+	SubmitFormPage(ctx context.Context, key string, input FormPageInput) (*FormPageView, error)
+	PurgeStaleDrafts(ctx context.Context) (int, error)
+}
+
+// FormDraftService IFormDraftService arayüzünü uygular.
+type FormDraftService struct {
+	repo          repositories.IFormDraftRepository
+	fieldRepo     repositories.IFormFieldRepository
+	formService   IFormService
+	submissions   *FormSubmissionService
+	analyticsRepo repositories.IFormAnalyticsRepository // Sayfa bazlı ayrılma oranı sayaçları
+	retentionDays int
+}
+
+// NewFormDraftService yeni bir FormDraftService örneği oluşturur.
+func NewFormDraftService() IFormDraftService {
+	retention := configsenv.GetEnvAsInt("FORM_DRAFT_RETENTION_DAYS", defaultDraftRetentionDays)
+	if retention < 1 {
+		retention = defaultDraftRetentionDays
+	}
+	return &FormDraftService{
+		repo:          repositories.NewFormDraftRepository(),
+		fieldRepo:     repositories.NewFormFieldRepository(),
+		formService:   NewFormService(),
+		submissions:   newFormSubmissionService(),
+		analyticsRepo: repositories.NewFormAnalyticsRepository(),
+		retentionDays: retention,
+	}
+}
+
+// newDraftToken devam bağlantısı için tahmin edilemez bir anahtar üretir.
+func newDraftToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func decodeDraft(draft *models.FormDraft) (map[uint][]string, map[uint][]models.FormDraftFile) {
+	values := make(map[uint][]string)
+	files := make(map[uint][]models.FormDraftFile)
+	if draft.Values != "" {
+		if err := json.Unmarshal([]byte(draft.Values), &values); err != nil {
+			configslog.Log.Warn("Form taslağı çözümlenemedi", zap.Uint("draftID", draft.ID), zap.Error(err))
+			values = make(map[uint][]string)
+		}
+	}
+	if draft.Files != "" {
+		var list []models.FormDraftFile
+		if err := json.Unmarshal([]byte(draft.Files), &list); err != nil {
+			configslog.Log.Warn("Form taslağı dosyaları çözümlenemedi", zap.Uint("draftID", draft.ID), zap.Error(err))
+		}
+		for _, file := range list {
+			files[file.FieldID] = append(files[file.FieldID], file)
+		}
+	}
+	return values, files
+}
+
+func encodeDraft(values map[uint][]string, files map[uint][]models.FormDraftFile) (string, string, error) {
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		return "", "", err
+	}
+	list := make([]models.FormDraftFile, 0)
+	for _, fieldFiles := range files {
+		list = append(list, fieldFiles...)
+	}
+	filesJSON, err := json.Marshal(list)
+	if err != nil {
+		return "", "", err
+	}
+	return string(valuesJSON), string(filesJSON), nil
+}
+
+func draftFileKeys(files map[uint][]models.FormDraftFile) []string {
+	var keys []string
+	for _, fieldFiles := range files {
+		for _, file := range fieldFiles {
+			keys = append(keys, file.StorageKey)
+		}
+	}
+	return keys
+}
+
+func formPageVisible(page FormPage, visible map[uint]bool) bool {
+	return page.BreakID == 0 || visible[page.BreakID]
+}
+
+// adjacentFormPage from sayfasından step yönündeki ilk görünür sayfayı döner; yoksa -1.
+func adjacentFormPage(pages []FormPage, visible map[uint]bool, from int, step int) int {
+	for i := from + step; i >= 0 && i < len(pages); i += step {
+		if formPageVisible(pages[i], visible) {
+			return i
+		}
+	}
+	return -1
+}
+
+// finishView gösterilecek sayfayı görünür bir sayfaya çeker ve şablonun ihtiyaç duyduğu
+// alanları hesaplar.
+func finishView(view *FormPageView, fields []models.FormFieldDefinition) {
+	visible := VisibleFormFields(fields, view.Values)
+	if view.Page < 0 || view.Page >= len(view.Pages) {
+		view.Page = 0
+	}
+	if !formPageVisible(view.Pages[view.Page], visible) {
+		if next := adjacentFormPage(view.Pages, visible, view.Page, 1); next >= 0 {
+			view.Page = next
+		} else if prev := adjacentFormPage(view.Pages, visible, view.Page, -1); prev >= 0 {
+			view.Page = prev
+		}
+	}
+	view.IsLastPage = adjacentFormPage(view.Pages, visible, view.Page, 1) < 0
+
+	onPage := make(map[uint]bool, len(view.Pages[view.Page].Fields))
+	for _, field := range view.Pages[view.Page].Fields {
+		onPage[field.ID] = true
+	}
+	view.ContextValues = make(map[uint][]string)
+	for fieldID, values := range view.Values {
+		if !onPage[fieldID] && visible[fieldID] {
+			view.ContextValues[fieldID] = values
+		}
+	}
+}
+
+// GetFormPage formun gösterilecek sayfasını hazırlar. token verilirse taslaktaki cevaplar ve
+// sayfa yüklenir. Sınavda süre gönderenin (respondent) ilk açılışından itibaren ölçülür. Bağlantıdaki ön doldurma parametreleri (query) ve formu açan sayfanın adresi
+// (referrer) alanlara işlenir ve gönderimde yeniden doğrulanmak üzere sayfayla taşınır.
+func (s *FormDraftService) GetFormPage(ctx context.Context, form *models.Form, respondent FormRespondent, token string, query url.Values, referrer string) (*FormPageView, error) {
+	fields, err := s.fieldRepo.FindByFormID(ctx, form.ID)
+	if err != nil {
+		return nil, err
+	}
+	view := &FormPageView{
+		Form:   form,
+		Pages:  SplitFormPages(fields),
+		Values: make(map[uint][]string),
+		Files:  make(map[uint][]models.FormDraftFile),
+	}
+	var draftErr error
+	var quizStartedAt *time.Time
+	if token = strings.TrimSpace(token); token != "" && view.MultiPage() {
+		draft, err := s.repo.FindByToken(ctx, form.ID, token)
+		switch {
+		case err == nil:
+			view.DraftToken = draft.Token
+			view.Values, view.Files = decodeDraft(draft)
+			view.Page = draft.Page
+			quizStartedAt = draft.QuizStartedAt
+		case errors.Is(err, repositories.ErrNotFound):
+			draftErr = ErrDraftNotFound
+		default:
+			return nil, err
+		}
+	}
+	prefill := FormPrefill(fields, query, referrer)
+	applyFormPrefill(form.ID, fields, view.Values, prefill, view.DraftToken == "", time.Now().UTC())
+	view.Prefill = prefill.Encode()
+	if err := startQuizClock(ctx, s.submissions.quizRepo, view, respondent, quizStartedAt); err != nil {
+		return nil, err
+	}
+	finishView(view, fields)
+	return view, draftErr
+}
+
+// SubmitFormPage bir sayfanın cevaplarını taslağa işler ve istenen işlemi uygular: ileri ve
+// gönder işlemleri sayfayı sunucuda doğrular, geri ve kaydet doğrulamadan kaydeder. Son
+// sayfada ileri veya gönder, tüm formu doğrulayıp gönderimi oluşturur ve taslağı siler.
+func (s *FormDraftService) SubmitFormPage(ctx context.Context, key string, input FormPageInput) (*FormPageView, error) {
+	form, err := s.formService.GetFormByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := s.fieldRepo.FindByFormID(ctx, form.ID)
+	if err != nil {
+		return nil, ErrSubmissionSaveFailed
+	}
+	view := &FormPageView{
+		Form:    form,
+		Pages:   SplitFormPages(fields),
+		Page:    input.Page,
+		Values:  make(map[uint][]string),
+		Files:   make(map[uint][]models.FormDraftFile),
+		Prefill: input.Prefill.Encode(),
+	}
+	if len(fields) == 0 {
+		return view, ErrSubmissionFormNotReady
+	}
+	switch input.Action {
+	case FormPageNext, FormPageBack, FormPageSave, FormPageSubmit:
+	default:
+		input.Action = FormPageNext
+	}
+	if view.Page < 0 || view.Page >= len(view.Pages) {
+		view.Page = 0
+	}
+
+	// Kapalı veya dolu formda taslak da tutulmaz; kesin kontrol gönderimde tekrarlanır.
+	now := time.Now().UTC()
+	state, err := formState(ctx, s.submissions.repo, form, input.Respondent, now)
+	if err != nil {
+		return nil, ErrSubmissionSaveFailed
+	}
+	if state != FormStateOpen {
+		return view, stateErrors[state]
+	}
+
+	var draft *models.FormDraft
+	if token := strings.TrimSpace(input.DraftToken); token != "" {
+		draft, err = s.repo.FindByToken(ctx, form.ID, token)
+		if err != nil {
+			finishView(view, fields)
+			if errors.Is(err, repositories.ErrNotFound) {
+				return view, ErrDraftNotFound
+			}
+			return view, ErrDraftSaveFailed
+		}
+		view.DraftToken = draft.Token
+		view.Values, view.Files = decodeDraft(draft)
+	}
+
+	// Sınavda süre taslak kaydedildiyse taslaktaki başlangıca, değilse gönderenin kayıtlı
+	// denemesine göre denetlenir; devam bağlantısı ve yeniden açılış süreyi sıfırlamaz.
+	if form.Detail.IsQuiz {
+		if draft != nil && draft.QuizStartedAt != nil {
+			view.QuizStartedAt = draft.QuizStartedAt
+			err = checkQuizDeadline(form.Detail, view.QuizStartedAt, now)
+		} else {
+			view.QuizStartedAt, err = quizStartedAt(ctx, s.submissions.quizRepo, form, input.Respondent, now)
+		}
+		if err != nil {
+			if view.QuizStartedAt == nil {
+				if clockErr := startQuizClock(ctx, s.submissions.quizRepo, view, input.Respondent, nil); clockErr != nil {
+					configslog.Log.Error("Sınav başlangıcı kaydedilemedi", zap.Uint("formID", form.ID), zap.Error(clockErr))
+				}
+			}
+			finishView(view, fields)
+			return view, err
+		}
+	}
+
+	// Sayfadaki cevaplar gönderilenlerle değiştirilir. Dosya alanında yeni dosya seçilmediyse
+	// daha önce yüklenen dosyalar korunur. Gizli ve imzalı alanlar gönderilen değeri değil,
+	// bağlantıdaki veya taslakta kayıtlı değeri alır.
+	page := view.Pages[view.Page]
+	for _, field := range page.Fields {
+		if !field.IsInput() || field.IsPrefillLocked() {
+			continue
+		}
+		if field.Type == models.FormFieldFile {
+			if uploads := input.Files[field.ID]; len(uploads) > 0 {
+				names := make([]string, 0, len(uploads))
+				for _, upload := range uploads {
+					names = append(names, upload.FileName)
+				}
+				view.Values[field.ID] = names
+			}
+			continue
+		}
+		delete(view.Values, field.ID)
+		if values := input.Values[field.ID]; len(values) > 0 {
+			view.Values[field.ID] = values
+		}
+	}
+	applyFormPrefill(form.ID, fields, view.Values, input.Prefill, false, now)
+
+	// Gönderimi tamamlayan istekte spam kontrolleri sayfa doğrulaması ve dosya yüklemesinden önce yapılır.
+	complete := input.Action == FormPageSubmit ||
+		(input.Action == FormPageNext && adjacentFormPage(view.Pages, VisibleFormFields(fields, view.Values), view.Page, 1) < 0)
+	if complete {
+		if err := s.submissions.checkSpam(ctx, form, input.FormSubmissionInput, now); err != nil {
+			finishView(view, fields)
+			return view, err
+		}
+	}
+
+	if input.Action == FormPageNext || input.Action == FormPageSubmit {
+		if err := ValidateFormPage(fields, page, view.Values); err != nil {
+			finishView(view, fields)
+			return view, err
+		}
+	}
+
+	stored, replaced, err := s.storePageUploads(ctx, form.ID, page, input.Files, view)
+	if err != nil {
+		finishView(view, fields)
+		if errors.Is(err, ErrSubmissionInvalid) {
+			return view, err
+		}
+		configslog.Log.Error("Form taslağı dosyaları depolanamadı", zap.Uint("formID", form.ID), zap.Error(err))
+		return view, ErrDraftSaveFailed
+	}
+
+	visible := VisibleFormFields(fields, view.Values)
+	switch input.Action {
+	case FormPageBack:
+		if prev := adjacentFormPage(view.Pages, visible, view.Page, -1); prev >= 0 {
+			view.Page = prev
+		}
+	case FormPageNext:
+		if next := adjacentFormPage(view.Pages, visible, view.Page, 1); next >= 0 {
+			view.Page = next
+		}
+	}
+
+	// Yeni yüklenen dosyalar kaybolmasın diye taslak gönderimden önce de kaydedilir.
+	furthest := 0
+	if draft != nil {
+		furthest = draft.MaxPage
+	}
+	if err := s.saveDraft(ctx, form, &draft, view); err != nil {
+		configslog.Log.Error("Form taslağı kaydedilemedi", zap.Uint("formID", form.ID), zap.Error(err))
+		s.submissions.deleteUploads(ctx, stored)
+		finishView(view, fields)
+		return view, ErrDraftSaveFailed
+	}
+	s.submissions.deleteUploads(ctx, replaced)
+	if view.Page > furthest {
+		recordPageReached(ctx, s.analyticsRepo, form, view.Pages[view.Page])
+	}
+
+	if complete {
+		return s.completeDraft(ctx, form, fields, draft, view, input.FormSubmissionInput)
+	}
+	view.Saved = input.Action == FormPageSave
+	finishView(view, fields)
+	return view, nil
+}
+
+// storePageUploads sayfadaki yeni dosyaları depoya yazar ve taslağa işler. Yazılan dosyaların
+// ve yerine geçtikleri eski dosyaların anahtarlarını döner; eskiler taslak kaydedildikten
+// sonra silinir.
+func (s *FormDraftService) storePageUploads(ctx context.Context, formID uint, page FormPage, uploads map[uint][]FormUpload, view *FormPageView) ([]string, []string, error) {
+	var stored, replaced []string
+	for _, field := range page.Fields {
+		if field.Type != models.FormFieldFile || len(uploads[field.ID]) == 0 {
+			continue
+		}
+		if s.submissions.storage == nil {
+			s.submissions.deleteUploads(ctx, stored)
+			return nil, nil, errors.New("dosya deposu yapılandırılmamış")
+		}
+		if len(uploads[field.ID]) > field.FileCountLimit() {
+			s.submissions.deleteUploads(ctx, stored)
+			return nil, nil, fmt.Errorf("%w: %q için en fazla %d dosya yüklenebilir", ErrSubmissionInvalid, field.Label, field.FileCountLimit())
+		}
+		files := make([]models.FormDraftFile, 0, len(uploads[field.ID]))
+		for _, upload := range uploads[field.ID] {
+			file, err := s.submissions.storeUpload(ctx, formID, field, upload)
+			if err != nil {
+				s.submissions.deleteUploads(ctx, stored)
+				return nil, nil, err
+			}
+			stored = append(stored, file.StorageKey)
+			files = append(files, models.FormDraftFile{
+				FieldID:     field.ID,
+				StorageKey:  file.StorageKey,
+				FileName:    file.FileName,
+				ContentType: file.ContentType,
+				Size:        file.Size,
+			})
+		}
+		for _, old := range view.Files[field.ID] {
+			replaced = append(replaced, old.StorageKey)
+		}
+		view.Files[field.ID] = files
+	}
+	return stored, replaced, nil
+}
+
+func (s *FormDraftService) saveDraft(ctx context.Context, form *models.Form, draft **models.FormDraft, view *FormPageView) error {
+	values, files, err := encodeDraft(view.Values, view.Files)
+	if err != nil {
+		return err
+	}
+	// Public işlem: BaseModel hook'ları için aktör olarak form sahibi kullanılır.
+	actorCtx := contextWithUserID(ctx, form.CreatorUserID)
+	now := time.Now().UTC()
+	if *draft == nil {
+		token, err := newDraftToken()
+		if err != nil {
+			return err
+		}
+		created := &models.FormDraft{
+			FormID:      form.ID,
+			Token:       token,
+			Page:        view.Page,
+			MaxPage:     view.Page,
+			Values:      values,
+			Files:       files,
+			LastSavedAt: now,
+
+			QuizStartedAt: view.QuizStartedAt,
+		}
+		if err := s.repo.Create(actorCtx, created); err != nil {
+			return err
+		}
+		*draft = created
+		view.DraftToken = token
+		return nil
+	}
+	data := map[string]interface{}{
+		"page":          view.Page,
+		"values":        values,
+		"files":         files,
+		"last_saved_at": now,
+	}
+	if view.Page > (*draft).MaxPage {
+		data["max_page"] = view.Page
+	}
+	if (*draft).QuizStartedAt == nil && view.QuizStartedAt != nil {
+		data["quiz_started_at"] = view.QuizStartedAt
+	}
+	return s.repo.Update(actorCtx, *draft, data)
+}
+
+// completeDraft taslaktaki tüm cevapları doğrular, dosyaları cevaplara bağlayarak gönderimi
+// oluşturur ve taslağı aynı transaction içinde siler.
+func (s *FormDraftService) completeDraft(ctx context.Context, form *models.Form, fields []models.FormFieldDefinition, draft *models.FormDraft, view *FormPageView, input FormSubmissionInput) (*FormPageView, error) {
+	answers, err := ValidateFormAnswers(fields, view.Values)
+	if err != nil {
+		finishView(view, fields)
+		return view, err
+	}
+	attached := make(map[string]bool)
+	for i := range answers {
+		if answers[i].FieldType != models.FormFieldFile {
+			continue
+		}
+		for _, file := range view.Files[answers[i].FieldID] {
+			answers[i].Files = append(answers[i].Files, models.FormSubmissionFile{
+				FormID:      form.ID,
+				FieldID:     file.FieldID,
+				StorageKey:  file.StorageKey,
+				FileName:    file.FileName,
+				ContentType: file.ContentType,
+				Size:        file.Size,
+			})
+			attached[file.StorageKey] = true
+		}
+	}
+
+	var quiz *quizOutcome
+	if form.Detail.IsQuiz {
+		quiz = scoreQuiz(form.Detail, fields, view.Values, answers, view.QuizStartedAt)
+	}
+
+	submission, err := s.submissions.saveSubmission(ctx, form, fields, answers, quiz, input, func(txCtx context.Context, tx *gorm.DB) error {
+		return repositories.NewFormDraftRepositoryTx(tx.WithContext(txCtx)).Delete(txCtx, draft)
+	})
+	if err != nil {
+		finishView(view, fields)
+		return view, err
+	}
+
+	// Gizlenen alanlara yüklenmiş dosyalar gönderime girmez, depodan silinir.
+	var orphans []string
+	for _, key := range draftFileKeys(view.Files) {
+		if !attached[key] {
+			orphans = append(orphans, key)
+		}
+	}
+	s.submissions.deleteUploads(ctx, orphans)
+
+	view.Submission = submission
+	view.DraftToken = ""
+	return view, nil
+}
+
+// PurgeStaleDrafts saklama süresi dolan taslakları dosyalarıyla birlikte siler. Aynı süreyi
+// aşan, gönderilmemiş sınav denemeleri de temizlenir.
+func (s *FormDraftService) PurgeStaleDrafts(ctx context.Context) (int, error) {
+	savedBefore := time.Now().UTC().AddDate(0, 0, -s.retentionDays)
+	if _, err := s.submissions.quizRepo.DeleteStartedBefore(ctx, savedBefore); err != nil {
+		return 0, err
+	}
+	purged := 0
+	for {
+		drafts, err := s.repo.FindStale(ctx, savedBefore, draftPurgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		for i := range drafts {
+			_, files := decodeDraft(&drafts[i])
+			if keys := draftFileKeys(files); len(keys) > 0 && s.submissions.storage != nil {
+				s.submissions.deleteUploads(ctx, keys)
+			}
+			if err := s.repo.Delete(ctx, &drafts[i]); err != nil {
+				return purged, err
+			}
+			purged++
+		}
+		if len(drafts) < draftPurgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+var _ IFormDraftService = (*FormDraftService)(nil)
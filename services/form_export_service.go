@@ -0,0 +1,804 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"davet.link/configs/configsenv"
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/csvsafe"
+	"davet.link/pkg/xlsx"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+// FormExportServiceError özel servis hataları
+type FormExportServiceError string
+
+func (e FormExportServiceError) Error() string { return string(e) }
+
+const (
+	ErrFormExportInvalid FormExportServiceError = "dışa aktarım ayarları geçersiz"
+	ErrFormExportFailed  FormExportServiceError = "dışa aktarım oluşturulamadı"
+)
+
+// MailKindFormExport zamanlanmış dışa aktarım e-postalarının kuyruk türü (MailOutbox.Kind).
+const MailKindFormExport = "form_export"
+
+const (
+	formExportBatchSize         = 500
+	formExportScheduleBatchSize = 20
+	formExportScheduleLease     = 30 * time.Minute // Dışa aktarım sürerken zamanlamanın tekrar alınmaması için
+	maxFormExportCatchUp        = 31 * 24 * time.Hour
+	defaultExportLinkTTLHours   = 7 * 24
+	defaultExportAttachmentMB   = 10
+	exportDateLayout            = "2006-01-02"
+	exportTimeLayout            = "02.01.2006 15:04"
+	exportsPanelPathFmt         = "/panel/forms/exports/%d"
+)
+
+// FormExportFormat dışa aktarım dosyasının biçimi.
+type FormExportFormat string
+
+const (
+	FormExportCSV  FormExportFormat = "csv"
+	FormExportXLSX FormExportFormat = "xlsx"
+	FormExportJSON FormExportFormat = "json"
+)
+
+// ParseFormExportFormat biçim adını doğrular.
+func ParseFormExportFormat(value string) (FormExportFormat, error) {
+	switch format := FormExportFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case FormExportCSV, FormExportXLSX, FormExportJSON:
+		return format, nil
+	}
+	return "", fmt.Errorf("%w: desteklenmeyen dosya biçimi", ErrFormExportInvalid)
+}
+
+// ContentType biçimin MIME tipi.
+func (f FormExportFormat) ContentType() string {
+	switch f {
+	case FormExportXLSX:
+		return xlsx.ContentType
+	case FormExportJSON:
+		return "application/json; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// FormExportFilter dışa aktarılacak gönderimlerin zaman aralığıdır. From dahil, To hariçtir;
+// nil sınır uygulanmaz.
+type FormExportFilter struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// ParseFormExportFilter panelden gelen YYYY-MM-DD biçimindeki tarihleri sunucu saat
+// dilimine göre aralığa çevirir. Bitiş günü aralığa dahildir.
+func ParseFormExportFilter(from string, to string) (FormExportFilter, error) {
+	var filter FormExportFilter
+	if from = strings.TrimSpace(from); from != "" {
+		day, err := time.ParseInLocation(exportDateLayout, from, time.Local)
+		if err != nil {
+			return filter, fmt.Errorf("%w: başlangıç tarihi geçersiz", ErrFormExportInvalid)
+		}
+		filter.From = &day
+	}
+	if to = strings.TrimSpace(to); to != "" {
+		day, err := time.ParseInLocation(exportDateLayout, to, time.Local)
+		if err != nil {
+			return filter, fmt.Errorf("%w: bitiş tarihi geçersiz", ErrFormExportInvalid)
+		}
+		end := day.AddDate(0, 0, 1)
+		filter.To = &end
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, fmt.Errorf("%w: başlangıç tarihi bitiş tarihinden sonra olamaz", ErrFormExportInvalid)
+	}
+	return filter, nil
+}
+
+// ExportFileName dışa aktarım dosyasının indirme adı.
+func ExportFileName(formID uint, format FormExportFormat, filter FormExportFilter) string {
+	name := fmt.Sprintf("form-%d-yanitlar", formID)
+	if filter.From != nil {
+		name += "-" + filter.From.Format("20060102")
+	}
+	if filter.To != nil {
+		name += "-" + filter.To.AddDate(0, 0, -1).Format("20060102")
+	}
+	return name + "." + string(format)
+}
+
+// FormExportScheduleInput panelde düzenlenen zamanlama ayarlarıdır.
+type FormExportScheduleInput struct {
+	IsEnabled  bool
+	Frequency  models.FormExportFrequency
+	Weekday    int
+	Hour       int
+	Format     string
+	Recipients string
+}
+
+// --- Sütunlar ---
+
+type exportColumnKind int
+
+const (
+	exportColumnValue    exportColumnKind = iota // Cevabın metni (sayı alanlarında sayı)
+	exportColumnOption                           // Çoklu seçimde tek seçenek: seçildiyse 1, seçilmediyse 0
+	exportColumnFile                             // Yüklenen dosyaların bağlantıları
+	exportColumnScore                            // Sınav puanı
+	exportColumnMaxScore                         // Sınavın tam puanı
+	exportColumnPassed                           // Geçti / Kaldı (eşik yoksa boş)
+	exportColumnDuration                         // Sınav süresi (saniye)
+)
+
+// exportColumn tablo biçimlerinde (CSV, XLSX) bir sütundur.
+type exportColumn struct {
+	Header  string
+	FieldID uint
+	Kind    exportColumnKind
+	Option  string
+}
+
+// formExportColumns formun alanlarından sütunları oluşturur. Çoklu seçim alanları, seçilenlerin
+// birleştirildiği sütundan sonra her seçenek için ayrı bir sütuna açılır. Formdan kaldırılmış
+// alanlar (bkz. removedFormFields) son bilinen etiketleriyle eski sütun olarak sona eklenir.
+func formExportColumns(fields []models.FormFieldDefinition, removed []models.FormVersionField) []exportColumn {
+	var columns []exportColumn
+	for _, field := range fields {
+		if !field.IsInput() {
+			continue
+		}
+		if field.Type == models.FormFieldFile {
+			columns = append(columns, exportColumn{Header: field.Label, FieldID: field.ID, Kind: exportColumnFile})
+			continue
+		}
+		columns = append(columns, exportColumn{Header: field.Label, FieldID: field.ID, Kind: exportColumnValue})
+		if field.Type == models.FormFieldCheckbox && field.HasOptions() {
+			for _, option := range field.OptionList() {
+				columns = append(columns, exportColumn{Header: field.Label + ": " + option, FieldID: field.ID, Kind: exportColumnOption, Option: option})
+			}
+		}
+	}
+	for _, field := range removed {
+		kind := exportColumnValue
+		if field.Type == models.FormFieldFile {
+			kind = exportColumnFile
+		}
+		columns = append(columns, exportColumn{Header: field.Label + " (silinmiş alan)", FieldID: field.ID, Kind: kind})
+	}
+	return columns
+}
+
+// quizExportColumns sınav formlarında cevaplardan önce eklenen sonuç sütunları.
+func quizExportColumns() []exportColumn {
+	return []exportColumn{
+		{Header: "Puan", Kind: exportColumnScore},
+		{Header: "Tam Puan", Kind: exportColumnMaxScore},
+		{Header: "Sonuç", Kind: exportColumnPassed},
+		{Header: "Süre (sn)", Kind: exportColumnDuration},
+	}
+}
+
+// optionalNumber boş olabilen tam sayıyı hücreye çevirir.
+func optionalNumber(value *int) xlsx.Cell {
+	if value == nil {
+		return xlsx.Cell{}
+	}
+	return xlsx.Number(float64(*value))
+}
+
+// answerValues çoklu satırlı cevabı boş olmayan değerlere ayırır.
+func answerValues(answer *models.FormSubmissionAnswer) []string {
+	var values []string
+	for _, value := range strings.Split(answer.Value, "\n") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// exportRow gönderimi sütunlara göre hücrelere çevirir. İlk üç hücre gönderim numarası,
+// zamanı ve doldurulduğu form sürümüdür (sürümlemeden önceki gönderimlerde boş).
+func exportRow(columns []exportColumn, submission *models.FormSubmission, fileURL func(uint) string) []xlsx.Cell {
+	cells := make([]xlsx.Cell, 0, len(columns)+3)
+	cells = append(cells,
+		xlsx.Number(float64(submission.ID)),
+		xlsx.Text(submission.SubmittedAt.Local().Format(exportTimeLayout)),
+	)
+	if number := submission.VersionNumber(); number > 0 {
+		cells = append(cells, xlsx.Number(float64(number)))
+	} else {
+		cells = append(cells, xlsx.Cell{})
+	}
+	for _, column := range columns {
+		switch column.Kind {
+		case exportColumnScore:
+			cells = append(cells, optionalNumber(submission.Score))
+			continue
+		case exportColumnMaxScore:
+			cells = append(cells, optionalNumber(submission.MaxScore))
+			continue
+		case exportColumnDuration:
+			cells = append(cells, optionalNumber(submission.DurationSeconds))
+			continue
+		case exportColumnPassed:
+			switch {
+			case submission.Passed == nil:
+				cells = append(cells, xlsx.Cell{})
+			case *submission.Passed:
+				cells = append(cells, xlsx.Text("Geçti"))
+			default:
+				cells = append(cells, xlsx.Text("Kaldı"))
+			}
+			continue
+		}
+		answer := submission.AnswerFor(column.FieldID)
+		if answer == nil {
+			cells = append(cells, xlsx.Cell{})
+			continue
+		}
+		switch column.Kind {
+		case exportColumnOption:
+			selected := 0.0
+			if containsOption(answerValues(answer), column.Option) {
+				selected = 1
+			}
+			cells = append(cells, xlsx.Number(selected))
+		case exportColumnFile:
+			switch len(answer.Files) {
+			case 0:
+				cells = append(cells, xlsx.Cell{})
+			case 1:
+				cells = append(cells, xlsx.Link(fileURL(answer.Files[0].ID), answer.Files[0].FileName))
+			default:
+				lines := make([]string, len(answer.Files))
+				for i, file := range answer.Files {
+					lines[i] = file.FileName + " " + fileURL(file.ID)
+				}
+				cells = append(cells, xlsx.Text(strings.Join(lines, "\n")))
+			}
+		default:
+			if answer.NumberValue != nil {
+				cells = append(cells, xlsx.Number(*answer.NumberValue))
+			} else {
+				cells = append(cells, xlsx.Text(answerText(*answer)))
+			}
+		}
+	}
+	return cells
+}
+
+// exportHeader tablo biçimlerinin başlık satırı.
+func exportHeader(columns []exportColumn) []xlsx.Cell {
+	header := []xlsx.Cell{xlsx.Text("Gönderim No"), xlsx.Text("Gönderim Zamanı"), xlsx.Text("Form Sürümü")}
+	for _, column := range columns {
+		header = append(header, xlsx.Text(column.Header))
+	}
+	return header
+}
+
+// --- Yazıcılar ---
+
+// formExportSink gönderimleri seçilen biçimde yazar.
+type formExportSink interface {
+	Write(submission *models.FormSubmission) error
+	Close() error
+}
+
+type csvExportSink struct {
+	w       *csv.Writer
+	columns []exportColumn
+	fileURL func(uint) string
+}
+
+func newCSVExportSink(w io.Writer, columns []exportColumn, fileURL func(uint) string) (*csvExportSink, error) {
+	// Excel'in UTF-8 Türkçe karakterleri doğru açması için BOM
+	if _, err := w.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return nil, err
+	}
+	sink := &csvExportSink{w: csv.NewWriter(w), columns: columns, fileURL: fileURL}
+	return sink, sink.writeCells(exportHeader(columns))
+}
+
+func (s *csvExportSink) Write(submission *models.FormSubmission) error {
+	return s.writeCells(exportRow(s.columns, submission, s.fileURL))
+}
+
+func (s *csvExportSink) writeCells(cells []xlsx.Cell) error {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		switch {
+		case cell.Number != nil:
+			record[i] = strconv.FormatFloat(*cell.Number, 'f', -1, 64)
+		case cell.Link != "":
+			record[i] = cell.Link
+		default:
+			record[i] = csvsafe.Cell(cell.Text)
+		}
+	}
+	return s.w.Write(record)
+}
+
+func (s *csvExportSink) Close() error {
+	s.w.Flush()
+	return s.w.Error()
+}
+
+type xlsxExportSink struct {
+	w       *xlsx.Writer
+	columns []exportColumn
+	fileURL func(uint) string
+}
+
+func newXLSXExportSink(w io.Writer, title string, columns []exportColumn, fileURL func(uint) string) (*xlsxExportSink, error) {
+	writer, err := xlsx.NewWriter(w, title, true)
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxExportSink{w: writer, columns: columns, fileURL: fileURL}, writer.WriteRow(exportHeader(columns))
+}
+
+func (s *xlsxExportSink) Write(submission *models.FormSubmission) error {
+	return s.w.WriteRow(exportRow(s.columns, submission, s.fileURL))
+}
+
+func (s *xlsxExportSink) Close() error {
+	return s.w.Close()
+}
+
+// formExportRecord JSON dışa aktarımında bir gönderimdir. Cevaplar tiplerine göre değer taşır:
+// çoklu seçimde dizi, sayı ve puanda sayı, tek onay kutusunda true/false.
+type formExportRecord struct {
+	ID          uint               `json:"id"`
+	SubmittedAt time.Time          `json:"submitted_at"`
+	Version     int                `json:"version,omitempty"` // Doldurulan form sürümü
+	Quiz        *formExportQuiz    `json:"quiz,omitempty"`
+	Answers     []formExportAnswer `json:"answers"`
+}
+
+// formExportQuiz sınav formlarında gönderimin sonucudur.
+type formExportQuiz struct {
+	Score           int   `json:"score"`
+	MaxScore        int   `json:"max_score"`
+	Passed          *bool `json:"passed"`
+	DurationSeconds *int  `json:"duration_seconds"`
+}
+
+type formExportAnswer struct {
+	FieldID    uint             `json:"field_id"`
+	Label      string           `json:"label"`
+	AskedLabel string           `json:"asked_label,omitempty"` // Gönderim anındaki etiket güncelinden farklıysa
+	Type       string           `json:"type"`
+	Removed    bool             `json:"removed,omitempty"` // Alan formdan kaldırılmış
+	Value      interface{}      `json:"value"`
+	Correct    *bool            `json:"correct,omitempty"`
+	Points     *int             `json:"points,omitempty"`
+	Files      []formExportFile `json:"files,omitempty"`
+}
+
+type formExportFile struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+type jsonExportSink struct {
+	w       io.Writer
+	count   int
+	fileURL func(uint) string
+	labels  map[uint]string // Güncel alanların etiketleri; cevaplar alan kimliğiyle eşlenir
+}
+
+func newJSONExportSink(w io.Writer, fields []models.FormFieldDefinition, fileURL func(uint) string) (*jsonExportSink, error) {
+	labels := make(map[uint]string, len(fields))
+	for _, field := range fields {
+		labels[field.ID] = field.Label
+	}
+	_, err := io.WriteString(w, "[")
+	return &jsonExportSink{w: w, fileURL: fileURL, labels: labels}, err
+}
+
+func (s *jsonExportSink) Write(submission *models.FormSubmission) error {
+	record := formExportRecord{ID: submission.ID, SubmittedAt: submission.SubmittedAt, Version: submission.VersionNumber(), Answers: make([]formExportAnswer, 0, len(submission.Answers))}
+	if submission.Score != nil && submission.MaxScore != nil {
+		record.Quiz = &formExportQuiz{Score: *submission.Score, MaxScore: *submission.MaxScore, Passed: submission.Passed, DurationSeconds: submission.DurationSeconds}
+	}
+	for i := range submission.Answers {
+		answer := &submission.Answers[i]
+		item := formExportAnswer{FieldID: answer.FieldID, Label: answer.FieldLabel, Type: string(answer.FieldType), Value: answer.Value, Correct: answer.IsCorrect, Points: answer.Points}
+		if label, ok := s.labels[answer.FieldID]; !ok {
+			item.Removed = true
+		} else if label != answer.FieldLabel {
+			item.Label, item.AskedLabel = label, answer.FieldLabel
+		}
+		switch {
+		case answer.FieldType == models.FormFieldCheckbox && answer.BoolValue != nil:
+			item.Value = *answer.BoolValue
+		case answer.FieldType == models.FormFieldCheckbox:
+			item.Value = answerValues(answer)
+		case answer.NumberValue != nil:
+			item.Value = *answer.NumberValue
+		case answer.FieldType == models.FormFieldFile:
+			item.Value = answerValues(answer)
+			for _, file := range answer.Files {
+				item.Files = append(item.Files, formExportFile{Name: file.FileName, URL: s.fileURL(file.ID), ContentType: file.ContentType, Size: file.Size})
+			}
+		}
+		record.Answers = append(record.Answers, item)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	separator := ",\n"
+	if s.count == 0 {
+		separator = "\n"
+	}
+	s.count++
+	if _, err := io.WriteString(s.w, separator); err != nil {
+		return err
+	}
+	_, err = s.w.Write(data)
+	return err
+}
+
+func (s *jsonExportSink) Close() error {
+	_, err := io.WriteString(s.w, "\n]\n")
+	return err
+}
+
+// --- Zamanlama ---
+
+// nextExportRun zamanlamanın after'dan sonraki ilk çalıştırma zamanını sunucu saat dilimine
+// göre hesaplar.
+func nextExportRun(frequency models.FormExportFrequency, weekday int, hour int, after time.Time) time.Time {
+	local := after.In(time.Local)
+	next := time.Date(local.Year(), local.Month(), local.Day(), hour, 0, 0, 0, time.Local)
+	step := 1
+	if frequency == models.FormExportWeekly {
+		step = 7
+		next = next.AddDate(0, 0, (weekday-int(next.Weekday())+7)%7)
+	}
+	for !next.After(local) {
+		next = next.AddDate(0, 0, step)
+	}
+	return next.UTC()
+}
+
+// exportScheduleWindow çalıştırmada gönderilecek aralığı döndürür: son gönderilen dönemin
+// sonundan çalıştırma zamanına kadar; ilk çalıştırmada bir dönem. Uzun kesintilerden sonra
+// en fazla maxFormExportCatchUp kadar geriye gidilir.
+func exportScheduleWindow(schedule *models.FormExportSchedule, runAt time.Time) (time.Time, time.Time) {
+	days := 1
+	if schedule.Frequency == models.FormExportWeekly {
+		days = 7
+	}
+	from := runAt.In(time.Local).AddDate(0, 0, -days).UTC()
+	if schedule.CoveredUntil != nil && schedule.CoveredUntil.Before(runAt) {
+		from = *schedule.CoveredUntil
+	}
+	if runAt.Sub(from) > maxFormExportCatchUp {
+		from = runAt.Add(-maxFormExportCatchUp)
+	}
+	return from, runAt
+}
+
+// ValidateFormExportSchedule zamanlama ayarlarını doğrular ve adres listesini normalleştirir.
+func ValidateFormExportSchedule(input *FormExportScheduleInput) error {
+	if input.Frequency != models.FormExportDaily && input.Frequency != models.FormExportWeekly {
+		return fmt.Errorf("%w: sıklık günlük veya haftalık olmalıdır", ErrFormExportInvalid)
+	}
+	if input.Weekday < 0 || input.Weekday > 6 {
+		return fmt.Errorf("%w: geçersiz gün", ErrFormExportInvalid)
+	}
+	if input.Hour < 0 || input.Hour > 23 {
+		return fmt.Errorf("%w: saat 0 ile 23 arasında olmalıdır", ErrFormExportInvalid)
+	}
+	format, err := ParseFormExportFormat(input.Format)
+	if err != nil {
+		return err
+	}
+	input.Format = string(format)
+	recipients, err := parseAddressList(input.Recipients, maxNotifyRecipients)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrFormExportInvalid, err)
+	}
+	if input.IsEnabled && len(recipients) == 0 {
+		return fmt.Errorf("%w: en az bir alıcı adresi girilmelidir", ErrFormExportInvalid)
+	}
+	input.Recipients = strings.Join(recipients, ", ")
+	return nil
+}
+
+// IFormExportService gönderimlerin dışa aktarımı ve zamanlanmış e-posta gönderimi için arayüz.
+type IFormExportService interface {
+	GetExportForm(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, []models.FormFieldDefinition, error)
+	GetSchedule(ctx context.Context, formID uint) (*models.FormExportSchedule, error)
+	WriteExport(ctx context.Context, form *models.Form, fields []models.FormFieldDefinition, format FormExportFormat, filter FormExportFilter, w io.Writer) (int, error)
+	SaveSchedule(ctx context.Context, formID uint, updatingUserID uint, input FormExportScheduleInput) error
+	RunDueSchedules(ctx context.Context) (sent int, failed int, err error)
+}
+
+// FormExportService IFormExportService arayüzünü uygular.
+type FormExportService struct {
+	submissionRepo repositories.IFormSubmissionRepository
+	versionRepo    repositories.IFormVersionRepository
+	scheduleRepo   repositories.IFormExportScheduleRepository
+	outboxRepo     repositories.IMailOutboxRepository
+	fieldService   IFormFieldService
+	linkTTL        time.Duration // Dosya bağlantılarının geçerlilik süresi
+	maxAttachment  int           // Zamanlanmış e-postada eklenebilecek en büyük dosya (bayt)
+}
+
+// NewFormExportService yeni bir FormExportService örneği oluşturur.
+func NewFormExportService() IFormExportService {
+	initUploadBackend()
+	linkHours := configsenv.GetEnvAsInt("FORM_EXPORT_LINK_TTL_HOURS", defaultExportLinkTTLHours)
+	if linkHours < 1 {
+		linkHours = defaultExportLinkTTLHours
+	}
+	attachmentMB := configsenv.GetEnvAsInt("FORM_EXPORT_MAX_ATTACHMENT_MB", defaultExportAttachmentMB)
+	if attachmentMB < 1 {
+		attachmentMB = defaultExportAttachmentMB
+	}
+	return &FormExportService{
+		submissionRepo: repositories.NewFormSubmissionRepository(),
+		versionRepo:    repositories.NewFormVersionRepository(),
+		scheduleRepo:   repositories.NewFormExportScheduleRepository(),
+		outboxRepo:     repositories.NewMailOutboxRepository(),
+		fieldService:   NewFormFieldService(),
+		linkTTL:        time.Duration(linkHours) * time.Hour,
+		maxAttachment:  attachmentMB << 20,
+	}
+}
+
+// fileURL dosya için dışa aktarım dosyasında kullanılacak mutlak ve uzun süreli indirme adresi.
+func (s *FormExportService) fileURL(fileID uint) string {
+	return PublicURL(uploadBackend.signer.Sign(fmt.Sprintf(fileDownloadPathFormat, fileID), time.Now().Add(s.linkTTL)))
+}
+
+// GetExportForm formu (yetki kontrolüyle) ve sütunları için alanlarını getirir.
+func (s *FormExportService) GetExportForm(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, []models.FormFieldDefinition, error) {
+	return s.fieldService.GetFields(ctx, formID, requestingUserID)
+}
+
+// GetSchedule formun zamanlamasını getirir; zamanlama yoksa nil döner.
+// Yetki kontrolü formu getiren çağıranda yapılır.
+func (s *FormExportService) GetSchedule(ctx context.Context, formID uint) (*models.FormExportSchedule, error) {
+	schedule, err := s.scheduleRepo.FindByFormID(ctx, formID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, nil
+	}
+	return schedule, err
+}
+
+// WriteExport filtreye uyan gönderimleri eskiden yeniye w'ye yazar ve yazılan gönderim
+// sayısını döndürür. Gönderimler parça parça okunur; tüm liste belleğe alınmaz.
+func (s *FormExportService) WriteExport(ctx context.Context, form *models.Form, fields []models.FormFieldDefinition, format FormExportFormat, filter FormExportFilter, w io.Writer) (int, error) {
+	var sink formExportSink
+	if format == FormExportJSON {
+		jsonSink, err := newJSONExportSink(w, fields, s.fileURL)
+		if err != nil {
+			return 0, err
+		}
+		sink = jsonSink
+	} else {
+		versions, err := s.versionRepo.FindByFormID(ctx, form.ID)
+		if err != nil {
+			return 0, ErrFormExportFailed
+		}
+		answered, err := s.submissionRepo.FindAnsweredFields(ctx, form.ID)
+		if err != nil {
+			return 0, ErrFormExportFailed
+		}
+		columns := formExportColumns(fields, removedFormFields(fields, versions, answered))
+		if form.Detail.IsQuiz {
+			columns = append(quizExportColumns(), columns...)
+		}
+		if format == FormExportXLSX {
+			xlsxSink, err := newXLSXExportSink(w, form.Detail.Title, columns, s.fileURL)
+			if err != nil {
+				return 0, err
+			}
+			sink = xlsxSink
+		} else {
+			csvSink, err := newCSVExportSink(w, columns, s.fileURL)
+			if err != nil {
+				return 0, err
+			}
+			sink = csvSink
+		}
+	}
+
+	count := 0
+	var afterID uint
+	for {
+		submissions, err := s.submissionRepo.FindForExport(ctx, form.ID, filter.From, filter.To, afterID, formExportBatchSize)
+		if err != nil {
+			return count, ErrFormExportFailed
+		}
+		for i := range submissions {
+			if err := sink.Write(&submissions[i]); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if len(submissions) < formExportBatchSize {
+			break
+		}
+		afterID = submissions[len(submissions)-1].ID
+	}
+	return count, sink.Close()
+}
+
+// SaveSchedule formun dışa aktarım zamanlamasını oluşturur veya günceller. Sonraki çalıştırma
+// zamanı ayarlara göre yeniden hesaplanır.
+func (s *FormExportService) SaveSchedule(ctx context.Context, formID uint, updatingUserID uint, input FormExportScheduleInput) error {
+	if _, _, err := s.fieldService.GetFields(ctx, formID, updatingUserID); err != nil {
+		return err
+	}
+	if err := ValidateFormExportSchedule(&input); err != nil {
+		return err
+	}
+	nextRunAt := nextExportRun(input.Frequency, input.Weekday, input.Hour, time.Now())
+	ctx = contextWithUserID(ctx, updatingUserID)
+
+	existing, err := s.GetSchedule(ctx, formID)
+	if err != nil {
+		return ErrFormUpdateFailed
+	}
+	if existing == nil {
+		err = s.scheduleRepo.Create(ctx, &models.FormExportSchedule{
+			FormID:      formID,
+			OwnerUserID: updatingUserID,
+			Frequency:   input.Frequency,
+			Weekday:     input.Weekday,
+			Hour:        input.Hour,
+			Format:      input.Format,
+			Recipients:  input.Recipients,
+			IsEnabled:   input.IsEnabled,
+			NextRunAt:   nextRunAt,
+		})
+	} else {
+		data := map[string]interface{}{
+			"owner_user_id": updatingUserID,
+			"frequency":     input.Frequency,
+			"weekday":       input.Weekday,
+			"hour":          input.Hour,
+			"format":        input.Format,
+			"recipients":    input.Recipients,
+			"is_enabled":    input.IsEnabled,
+			"next_run_at":   nextRunAt,
+		}
+		if input.IsEnabled && !existing.IsEnabled {
+			// Yeniden açılan zamanlama kapalı kaldığı dönemi toplu göndermez.
+			data["covered_until"] = nil
+			data["last_error"] = ""
+		}
+		err = s.scheduleRepo.Update(ctx, existing, data)
+	}
+	if err != nil {
+		configslog.Log.Error("Dışa aktarım zamanlaması kaydedilemedi", zap.Uint("formID", formID), zap.Error(err))
+		return ErrFormUpdateFailed
+	}
+	configslog.SLog.Infof("Dışa aktarım zamanlaması güncellendi: Form ID %d (Güncelleyen: %d)", formID, updatingUserID)
+	return nil
+}
+
+// RunDueSchedules zamanı gelen dışa aktarımları hazırlar ve e-posta kuyruğuna ekler.
+// Başarısız çalıştırma, lease süresi dolunca yeniden denenir.
+func (s *FormExportService) RunDueSchedules(ctx context.Context) (int, int, error) {
+	sent, failed := 0, 0
+	for {
+		now := time.Now().UTC()
+		schedules, err := s.scheduleRepo.ClaimDue(ctx, now, formExportScheduleLease, formExportScheduleBatchSize)
+		if err != nil {
+			return sent, failed, err
+		}
+		for i := range schedules {
+			if s.runSchedule(ctx, &schedules[i], now) {
+				sent++
+			} else {
+				failed++
+			}
+		}
+		if len(schedules) < formExportScheduleBatchSize || ctx.Err() != nil {
+			return sent, failed, nil
+		}
+	}
+}
+
+// runSchedule tek bir zamanlamanın dönemini dışa aktarır ve sonucu kaydeder.
+func (s *FormExportService) runSchedule(ctx context.Context, schedule *models.FormExportSchedule, now time.Time) bool {
+	runAt := schedule.NextRunAt
+	from, to := exportScheduleWindow(schedule, runAt)
+	actorCtx := contextWithUserID(ctx, schedule.OwnerUserID)
+
+	form, fields, err := s.fieldService.GetFields(ctx, schedule.FormID, schedule.OwnerUserID)
+	if err != nil {
+		data := map[string]interface{}{"last_error": err.Error()}
+		if errors.Is(err, ErrFormNotFound) || errors.Is(err, ErrFormForbidden) {
+			// Form silinmiş veya sahibi değişmiş; zamanlama kapatılır.
+			data["is_enabled"] = false
+		}
+		s.updateSchedule(actorCtx, schedule, data)
+		return false
+	}
+
+	var buf bytes.Buffer
+	format := FormExportFormat(schedule.Format)
+	count, err := s.WriteExport(ctx, form, fields, format, FormExportFilter{From: &from, To: &to}, &buf)
+	if err == nil {
+		err = enqueueMail(ctx, s.outboxRepo, s.scheduleMail(form, schedule, format, from, to, count, &buf))
+	}
+	if err != nil {
+		configslog.Log.Error("Zamanlanmış dışa aktarım hazırlanamadı", zap.Uint("scheduleID", schedule.ID), zap.Uint("formID", schedule.FormID), zap.Error(err))
+		s.updateSchedule(actorCtx, schedule, map[string]interface{}{"last_error": err.Error()})
+		return false
+	}
+
+	after := now
+	if runAt.After(after) {
+		after = runAt
+	}
+	s.updateSchedule(actorCtx, schedule, map[string]interface{}{
+		"next_run_at":   nextExportRun(schedule.Frequency, schedule.Weekday, schedule.Hour, after),
+		"covered_until": to,
+		"last_run_at":   now,
+		"last_error":    "",
+	})
+	return true
+}
+
+// scheduleMail dönemin dışa aktarım e-postasını hazırlar. Yanıt yoksa veya dosya ek sınırını
+// aşıyorsa ek konmaz; panelden indirme adresi verilir.
+func (s *FormExportService) scheduleMail(form *models.Form, schedule *models.FormExportSchedule, format FormExportFormat, from, to time.Time, count int, file *bytes.Buffer) *models.MailOutbox {
+	period := fmt.Sprintf("%s - %s", from.Local().Format(exportTimeLayout), to.Local().Format(exportTimeLayout))
+	sourceID := form.ID
+	mail := &models.MailOutbox{
+		OwnerUserID: schedule.OwnerUserID,
+		Kind:        MailKindFormExport,
+		SourceID:    &sourceID,
+		Recipients:  strings.ReplaceAll(schedule.Recipients, ", ", ","),
+		Subject:     mailSubject(fmt.Sprintf("Yanıt raporu: %s (%s)", form.Detail.Title, period)),
+	}
+	exportsURL := PublicURL(fmt.Sprintf(exportsPanelPathFmt, form.ID))
+	switch {
+	case count == 0:
+		mail.TextBody = fmt.Sprintf("%s formuna %s döneminde yeni yanıt gelmedi.\n\nDışa aktarım ayarları: %s", form.Detail.Title, period, exportsURL)
+	case file.Len() > s.maxAttachment:
+		mail.TextBody = fmt.Sprintf("%s formuna %s döneminde %d yeni yanıt geldi. Dosya e-postaya eklenemeyecek kadar büyük olduğundan panelden indirebilirsiniz:\n%s", form.Detail.Title, period, count, exportsURL)
+	default:
+		filter := FormExportFilter{From: &from, To: &to}
+		mail.TextBody = fmt.Sprintf("%s formuna %s döneminde gelen %d yanıt ektedir.\n\nDışa aktarım ayarları: %s", form.Detail.Title, period, count, exportsURL)
+		mail.Attachments = []models.MailOutboxAttachment{{
+			FileName:    ExportFileName(form.ID, format, filter),
+			ContentType: format.ContentType(),
+			Data:        file.Bytes(),
+		}}
+	}
+	return mail
+}
+
+func (s *FormExportService) updateSchedule(ctx context.Context, schedule *models.FormExportSchedule, data map[string]interface{}) {
+	if err := s.scheduleRepo.Update(ctx, schedule, data); err != nil {
+		configslog.Log.Error("Dışa aktarım zamanlaması güncellenemedi", zap.Uint("scheduleID", schedule.ID), zap.Error(err))
+	}
+}
+
+var _ IFormExportService = (*FormExportService)(nil)
@@ -0,0 +1,793 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"davet.link/configs"
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// FormFieldServiceError özel servis hataları
+type FormFieldServiceError string
+
+func (e FormFieldServiceError) Error() string { return string(e) }
+
+const (
+	ErrFormFieldNotFound      FormFieldServiceError = "form alanı bulunamadı"
+	ErrFormFieldForbidden     FormFieldServiceError = "bu işlem için yetkiniz yok"
+	ErrFormFieldInvalid       FormFieldServiceError = "geçersiz form alanı"
+	ErrFormFieldSaveFailed    FormFieldServiceError = "form alanı kaydedilemedi"
+	ErrFormFieldDeletionError FormFieldServiceError = "form alanı silinemedi"
+	ErrFormFieldInUse         FormFieldServiceError = "form alanı başka alanların koşullarında kullanılıyor"
+)
+
+// Form alanı tanım sınırları.
+const (
+	maxFormFieldLabelLength = 255
+	maxFormFieldHelpLength  = 1000
+	maxFormFieldPattern     = 255
+	maxFormFieldsPerForm    = 100
+	maxRatingScale          = 10
+	maxUploadSizeMB         = 25 // Dosya alanında dosya başına izin verilebilecek en büyük boyut
+	maxUploadFiles          = 10 // Dosya alanında izin verilebilecek en fazla dosya sayısı
+	maxLogicConditions      = 20 // Bir kuraldaki toplam karşılaştırma sayısı
+	maxLogicDepth           = 2  // Kural > grup > karşılaştırma
+	maxLogicValueLength     = 255
+)
+
+// prefillParamPattern ön doldurma sorgu parametresi adı deseni (örn. "email", "utm_source").
+var prefillParamPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,50}$`)
+
+// reservedPrefillParams public form bağlantısının kendi kullandığı, ön doldurmaya verilemeyen
+// parametrelerdir.
+var reservedPrefillParams = map[string]bool{"resume": true, "expires": true, "signature": true}
+
+// mimeTypePattern dosya alanında kabul edilen MIME tipi deseni (örn. "application/pdf", "image/*").
+var mimeTypePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9!#$&^_.+-]*/(\*|[a-z0-9][a-z0-9!#$&^_.+-]*)$`)
+
+// FormFieldMove alanın sıradaki yönü.
+type FormFieldMove string
+
+const (
+	FormFieldMoveUp   FormFieldMove = "up"
+	FormFieldMoveDown FormFieldMove = "down"
+)
+
+// IFormFieldService form alan tanımları (form oluşturucu) için arayüz.
+type IFormFieldService interface {
+	GetFields(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, []models.FormFieldDefinition, error)
+	GetPublicFields(ctx context.Context, formID uint) ([]models.FormFieldDefinition, error)
+	CreateField(ctx context.Context, formID uint, creatingUserID uint, field models.FormFieldDefinition) error
+	UpdateField(ctx context.Context, id uint, updatingUserID uint, field models.FormFieldDefinition) (*models.FormFieldDefinition, error)
+	MoveField(ctx context.Context, id uint, updatingUserID uint, direction FormFieldMove) (*models.FormFieldDefinition, error)
+	DeleteField(ctx context.Context, id uint, deletingUserID uint) (*models.FormFieldDefinition, error)
+}
+
+// FormFieldService IFormFieldService arayüzünü uygular.
+type FormFieldService struct {
+	repo        repositories.IFormFieldRepository
+	formService IFormService
+	db          *gorm.DB // Transaction için
+}
+
+// NewFormFieldService yeni bir FormFieldService örneği oluşturur.
+func NewFormFieldService() IFormFieldService {
+	return &FormFieldService{
+		repo:        repositories.NewFormFieldRepository(),
+		formService: NewFormService(),
+		db:          configs.GetDB(),
+	}
+}
+
+// --- Yardımcı Metodlar ---
+
+// ValidateFormField alan tanımını doğrular, boşlukları temizler ve tipe uymayan
+// kuralları sıfırlar (örn. metin alanındaki MinValue).
+func ValidateFormField(field *models.FormFieldDefinition) error {
+	field.Label = strings.TrimSpace(field.Label)
+	field.HelpText = strings.TrimSpace(field.HelpText)
+	field.Placeholder = strings.TrimSpace(field.Placeholder)
+	field.Pattern = strings.TrimSpace(field.Pattern)
+	if field.Label == "" {
+		return fmt.Errorf("%w: alan etiketi zorunludur", ErrFormFieldInvalid)
+	}
+	if utf8.RuneCountInString(field.Label) > maxFormFieldLabelLength {
+		return fmt.Errorf("%w: alan etiketi en fazla %d karakter olabilir", ErrFormFieldInvalid, maxFormFieldLabelLength)
+	}
+	if utf8.RuneCountInString(field.HelpText) > maxFormFieldHelpLength {
+		return fmt.Errorf("%w: yardım metni en fazla %d karakter olabilir", ErrFormFieldInvalid, maxFormFieldHelpLength)
+	}
+
+	textual, numeric := false, false
+	switch field.Type {
+	case models.FormFieldText, models.FormFieldTextarea, models.FormFieldEmail, models.FormFieldPhone:
+		textual = true
+	case models.FormFieldHidden:
+		// Gizli alan gösterilmez ve koşula bağlanamaz; değeri yalnızca bağlantıdan gelir.
+		textual = true
+		field.Required = false
+		field.Placeholder = ""
+		field.Logic = ""
+		if field.PrefillParam == "" {
+			return fmt.Errorf("%w: gizli alan için ön doldurma parametresi girilmelidir", ErrFormFieldInvalid)
+		}
+	case models.FormFieldNumber, models.FormFieldRating:
+		numeric = true
+	case models.FormFieldDate, models.FormFieldFile:
+		// Dosya sınırları aşağıda ayrıca doğrulanır.
+	case models.FormFieldSelect, models.FormFieldRadio:
+		options := field.OptionList()
+		if len(options) < 2 {
+			return fmt.Errorf("%w: seçmeli alanlar için en az iki seçenek girilmelidir", ErrFormFieldInvalid)
+		}
+		field.Options = strings.Join(options, "\n")
+	case models.FormFieldCheckbox:
+		// Seçeneksiz onay kutusu tek bir evet/hayır alanıdır.
+		field.Options = strings.Join(field.OptionList(), "\n")
+	case models.FormFieldSection, models.FormFieldPage:
+		field.Required = false
+		field.Placeholder = ""
+	default:
+		return fmt.Errorf("%w: bilinmeyen alan tipi", ErrFormFieldInvalid)
+	}
+	if !field.HasOptions() {
+		field.Options = ""
+	}
+
+	if textual {
+		if field.MinLength != nil && *field.MinLength < 0 || field.MaxLength != nil && *field.MaxLength <= 0 {
+			return fmt.Errorf("%w: uzunluk sınırları pozitif olmalıdır", ErrFormFieldInvalid)
+		}
+		if field.MinLength != nil && field.MaxLength != nil && *field.MinLength > *field.MaxLength {
+			return fmt.Errorf("%w: en az uzunluk en fazla uzunluktan büyük olamaz", ErrFormFieldInvalid)
+		}
+		if field.Pattern != "" {
+			if len(field.Pattern) > maxFormFieldPattern {
+				return fmt.Errorf("%w: desen en fazla %d karakter olabilir", ErrFormFieldInvalid, maxFormFieldPattern)
+			}
+			if _, err := regexp.Compile(field.Pattern); err != nil {
+				return fmt.Errorf("%w: desen geçerli bir düzenli ifade değil", ErrFormFieldInvalid)
+			}
+		}
+	} else {
+		field.MinLength, field.MaxLength, field.Pattern = nil, nil, ""
+	}
+
+	if numeric {
+		if field.MinValue != nil && field.MaxValue != nil && *field.MinValue > *field.MaxValue {
+			return fmt.Errorf("%w: en küçük değer en büyük değerden büyük olamaz", ErrFormFieldInvalid)
+		}
+		if field.Type == models.FormFieldRating {
+			// Puan her zaman 1'den başlar; MaxValue ölçeğin üst sınırıdır.
+			field.MinValue = nil
+			if field.MaxValue != nil && (*field.MaxValue < 2 || *field.MaxValue > maxRatingScale || *field.MaxValue != float64(int(*field.MaxValue))) {
+				return fmt.Errorf("%w: puan ölçeği 2 ile %d arasında bir tam sayı olmalıdır", ErrFormFieldInvalid, maxRatingScale)
+			}
+		}
+	} else {
+		field.MinValue, field.MaxValue = nil, nil
+	}
+
+	if field.Type == models.FormFieldFile {
+		accept := make([]string, 0, len(field.AcceptList()))
+		for _, t := range field.AcceptList() {
+			t = strings.ToLower(t)
+			if !mimeTypePattern.MatchString(t) {
+				return fmt.Errorf("%w: %q geçerli bir MIME tipi değil (örn. application/pdf, image/*)", ErrFormFieldInvalid, t)
+			}
+			accept = append(accept, t)
+		}
+		field.AcceptTypes = strings.Join(accept, ", ")
+		if len(field.AcceptTypes) > 255 {
+			return fmt.Errorf("%w: kabul edilen tipler en fazla 255 karakter olabilir", ErrFormFieldInvalid)
+		}
+		if field.MaxFileSizeMB != nil && (*field.MaxFileSizeMB < 1 || *field.MaxFileSizeMB > maxUploadSizeMB) {
+			return fmt.Errorf("%w: dosya boyutu sınırı 1 ile %d MB arasında olmalıdır", ErrFormFieldInvalid, maxUploadSizeMB)
+		}
+		if field.MaxFiles != nil && (*field.MaxFiles < 1 || *field.MaxFiles > maxUploadFiles) {
+			return fmt.Errorf("%w: dosya sayısı sınırı 1 ile %d arasında olmalıdır", ErrFormFieldInvalid, maxUploadFiles)
+		}
+	} else {
+		field.AcceptTypes, field.MaxFileSizeMB, field.MaxFiles = "", nil, nil
+	}
+	if err := validateFieldPrefill(field); err != nil {
+		return err
+	}
+	return validateFieldQuiz(field)
+}
+
+// validateFieldPrefill ön doldurma parametresini doğrular. "referrer" parametresi formu açan
+// sayfanın adresini alır; bu değer bağlantıda taşınmadığından imzalanamaz.
+func validateFieldPrefill(field *models.FormFieldDefinition) error {
+	field.PrefillParam = strings.TrimSpace(field.PrefillParam)
+	if field.PrefillParam == "" || !field.IsInput() || field.Type == models.FormFieldFile {
+		field.PrefillParam, field.PrefillSigned = "", false
+		return nil
+	}
+	if !prefillParamPattern.MatchString(field.PrefillParam) {
+		return fmt.Errorf("%w: ön doldurma parametresi yalnızca harf, rakam, nokta, tire ve alt çizgiden oluşmalı ve en fazla 50 karakter olmalıdır", ErrFormFieldInvalid)
+	}
+	if reservedPrefillParams[strings.ToLower(field.PrefillParam)] {
+		return fmt.Errorf("%w: %q parametresi form bağlantısında kullanıldığından ön doldurmaya verilemez", ErrFormFieldInvalid, field.PrefillParam)
+	}
+	if field.PrefillSigned && field.PrefillParam == models.FormPrefillReferrer {
+		return fmt.Errorf("%w: yönlendiren adres imzalı bağlantıyla doldurulamaz", ErrFormFieldInvalid)
+	}
+	return nil
+}
+
+// validateFieldQuiz sınav modundaki doğru cevabı alan tipine göre doğrular ve normalleştirir.
+// Puan girilmeden verilen doğru cevap 1 puan sayılır; cevap alınmayan alanlar ve dosya
+// alanları puanlanmaz.
+func validateFieldQuiz(field *models.FormFieldDefinition) error {
+	if !field.IsInput() || field.IsHidden() || field.Type == models.FormFieldFile {
+		field.CorrectAnswer, field.Points = "", 0
+		return nil
+	}
+	if field.Points < 0 || field.Points > maxQuizPoints {
+		return fmt.Errorf("%w: soru puanı 0 ile %d arasında olmalıdır", ErrFormFieldInvalid, maxQuizPoints)
+	}
+	answers := field.CorrectAnswerList()
+	if len(answers) == 0 {
+		if field.Points > 0 {
+			return fmt.Errorf("%w: puan verilen soruya doğru cevap girilmelidir", ErrFormFieldInvalid)
+		}
+		field.CorrectAnswer = ""
+		return nil
+	}
+	for i, answer := range answers {
+		switch {
+		case field.HasOptions():
+			if !containsOption(field.OptionList(), answer) {
+				return fmt.Errorf("%w: doğru cevap %q seçeneklerden biri olmalıdır", ErrFormFieldInvalid, answer)
+			}
+		case field.Type == models.FormFieldCheckbox:
+			switch quizNormalize(answer) {
+			case "evet", "true":
+				answers[i] = checkboxAnswerYes
+			case "hayır", "hayir", "false":
+				answers[i] = checkboxAnswerNo
+			default:
+				return fmt.Errorf("%w: onay kutusunun doğru cevabı Evet veya Hayır olmalıdır", ErrFormFieldInvalid)
+			}
+		case field.Type == models.FormFieldNumber || field.Type == models.FormFieldRating:
+			n, ok := ParseFormNumber(answer)
+			if !ok {
+				return fmt.Errorf("%w: doğru cevap bir sayı olmalıdır", ErrFormFieldInvalid)
+			}
+			answers[i] = strconv.FormatFloat(n, 'f', -1, 64)
+		case field.Type == models.FormFieldDate:
+			if _, err := time.Parse("2006-01-02", answer); err != nil {
+				return fmt.Errorf("%w: doğru cevap YYYY-AA-GG biçiminde bir tarih olmalıdır", ErrFormFieldInvalid)
+			}
+		}
+	}
+	if field.Type == models.FormFieldCheckbox && !field.HasOptions() && len(answers) > 1 {
+		return fmt.Errorf("%w: onay kutusunun tek bir doğru cevabı olabilir", ErrFormFieldInvalid)
+	}
+	field.CorrectAnswer = strings.Join(answers, "\n")
+	if utf8.RuneCountInString(field.CorrectAnswer) > maxLongAnswerLength {
+		return fmt.Errorf("%w: doğru cevap en fazla %d karakter olabilir", ErrFormFieldInvalid, maxLongAnswerLength)
+	}
+	if field.Points == 0 {
+		field.Points = 1
+	}
+	return nil
+}
+
+// ValidateFormFieldLogic alanın koşul kuralını formun diğer alanlarına göre doğrular ve
+// Logic sütununu normalize edilmiş JSON ile yeniden yazar. Karşılaştırmalar yalnızca
+// cevap alan (bölüm ve dosya dışı) diğer alanlara başvurabilir.
+func ValidateFormFieldLogic(field *models.FormFieldDefinition, fields []models.FormFieldDefinition) error {
+	logic, err := field.ParsedLogic()
+	if err != nil {
+		return fmt.Errorf("%w: koşul kuralı okunamadı", ErrFormFieldInvalid)
+	}
+	if logic == nil {
+		field.Logic = ""
+		return nil
+	}
+	if logic.Action != models.FormLogicShow && logic.Action != models.FormLogicHide {
+		return fmt.Errorf("%w: geçersiz koşul işlemi", ErrFormFieldInvalid)
+	}
+	if !logic.When.IsGroup() && logic.When.FieldID == 0 {
+		// Boş kural: koşul kaldırılmış demektir.
+		field.Logic = ""
+		return nil
+	}
+
+	targets := make(map[uint]models.FormFieldDefinition, len(fields))
+	for _, f := range fields {
+		if f.ID != field.ID && f.IsInput() && f.Type != models.FormFieldFile {
+			targets[f.ID] = f
+		}
+	}
+	count := 0
+	var validate func(cond *models.FormCondition, depth int) error
+	validate = func(cond *models.FormCondition, depth int) error {
+		if cond.IsGroup() {
+			if depth >= maxLogicDepth {
+				return fmt.Errorf("%w: koşul grupları en fazla %d seviye olabilir", ErrFormFieldInvalid, maxLogicDepth)
+			}
+			if cond.Match == "" {
+				cond.Match = models.FormLogicMatchAll
+			}
+			if cond.Match != models.FormLogicMatchAll && cond.Match != models.FormLogicMatchAny {
+				return fmt.Errorf("%w: geçersiz koşul birleştirme", ErrFormFieldInvalid)
+			}
+			cond.FieldID, cond.Operator, cond.Value = 0, "", ""
+			for i := range cond.Conditions {
+				if err := validate(&cond.Conditions[i], depth+1); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		count++
+		if count > maxLogicConditions {
+			return fmt.Errorf("%w: bir kuralda en fazla %d koşul olabilir", ErrFormFieldInvalid, maxLogicConditions)
+		}
+		if _, ok := targets[cond.FieldID]; !ok {
+			return fmt.Errorf("%w: koşuldaki alan bu formda bulunamadı", ErrFormFieldInvalid)
+		}
+		cond.Match = ""
+		cond.Value = strings.TrimSpace(cond.Value)
+		if cond.Value == "" {
+			return fmt.Errorf("%w: koşul değeri boş olamaz", ErrFormFieldInvalid)
+		}
+		if utf8.RuneCountInString(cond.Value) > maxLogicValueLength {
+			return fmt.Errorf("%w: koşul değeri en fazla %d karakter olabilir", ErrFormFieldInvalid, maxLogicValueLength)
+		}
+		switch cond.Operator {
+		case models.FormConditionEquals, models.FormConditionContains:
+		case models.FormConditionGreaterThan:
+			if _, ok := ParseFormNumber(cond.Value); !ok {
+				if _, ok := logicDate(cond.Value); !ok {
+					return fmt.Errorf("%w: büyüktür koşulu için sayı veya YYYY-AA-GG tarihi girilmelidir", ErrFormFieldInvalid)
+				}
+			}
+		default:
+			return fmt.Errorf("%w: geçersiz koşul karşılaştırması", ErrFormFieldInvalid)
+		}
+		return nil
+	}
+	if err := validate(&logic.When, 0); err != nil {
+		return err
+	}
+
+	normalized, err := json.Marshal(logic)
+	if err != nil {
+		return fmt.Errorf("%w: koşul kuralı kaydedilemedi", ErrFormFieldInvalid)
+	}
+	field.Logic = string(normalized)
+	return nil
+}
+
+// maxFormNumber sayı alanlarında kabul edilen en büyük mutlak değer. float64'ün tam sayıları
+// kayıpsız tuttuğu aralıkla sınırlıdır; böylece raporlardaki toplam ve ortalamalar taşmaz.
+const maxFormNumber = 1e15
+
+// ParseFormNumber form değerini sayıya çevirir (virgül ondalık ayırıcı kabul edilir).
+// NaN, sonsuz ve mutlak değeri maxFormNumber'ı aşan sayılar reddedilir.
+func ParseFormNumber(value string) (float64, bool) {
+	n, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(value), ",", ".", 1), 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) || math.Abs(n) > maxFormNumber {
+		return 0, false
+	}
+	return n, true
+}
+
+// logicDate karşılaştırma için YYYY-AA-GG biçimindeki tarihi çözümler.
+func logicDate(value string) (time.Time, bool) {
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	return t, err == nil
+}
+
+// evaluateFormCondition koşulu verilen cevaplarla değerlendirir. Çoklu cevapta
+// (çoklu seçim) herhangi bir cevabın karşılaştırmayı sağlaması yeterlidir.
+// Tarayıcıdaki değerlendirme (public/form_fill.html) aynı kuralları uygular.
+func evaluateFormCondition(cond models.FormCondition, valuesOf func(fieldID uint) []string) bool {
+	if cond.IsGroup() {
+		anyMatch := cond.Match == models.FormLogicMatchAny
+		for _, sub := range cond.Conditions {
+			ok := evaluateFormCondition(sub, valuesOf)
+			if anyMatch && ok {
+				return true
+			}
+			if !anyMatch && !ok {
+				return false
+			}
+		}
+		return !anyMatch
+	}
+
+	target := strings.TrimSpace(cond.Value)
+	for _, value := range valuesOf(cond.FieldID) {
+		value = strings.TrimSpace(value)
+		switch cond.Operator {
+		case models.FormConditionEquals:
+			if strings.EqualFold(value, target) {
+				return true
+			}
+		case models.FormConditionContains:
+			if target != "" && strings.Contains(strings.ToLower(value), strings.ToLower(target)) {
+				return true
+			}
+		case models.FormConditionGreaterThan:
+			if a, ok := ParseFormNumber(value); ok {
+				if b, ok := ParseFormNumber(target); ok && a > b {
+					return true
+				}
+				continue
+			}
+			if a, ok := logicDate(value); ok {
+				if b, ok := logicDate(target); ok && a.After(b) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// formConditionReferences koşulun (veya alt koşullarından birinin) verilen alana başvurup
+// başvurmadığını bildirir.
+func formConditionReferences(cond models.FormCondition, fieldID uint) bool {
+	if cond.IsGroup() {
+		for _, sub := range cond.Conditions {
+			if formConditionReferences(sub, fieldID) {
+				return true
+			}
+		}
+		return false
+	}
+	return cond.FieldID == fieldID
+}
+
+// formFieldDependents koşul kuralı verilen alana başvuran alanları, bölümleri ve sayfaları
+// form sırasıyla döndürür.
+func formFieldDependents(fieldID uint, fields []models.FormFieldDefinition) []models.FormFieldDefinition {
+	var dependents []models.FormFieldDefinition
+	for _, field := range fields {
+		if field.ID == fieldID {
+			continue
+		}
+		logic, err := field.ParsedLogic()
+		if err != nil || logic == nil {
+			continue
+		}
+		if formConditionReferences(logic.When, fieldID) {
+			dependents = append(dependents, field)
+		}
+	}
+	return dependents
+}
+
+// VisibleFormFields gönderilen cevaplara göre hangi alanların görünür olduğunu hesaplar.
+// Alanlar sırayla değerlendirilir; gizlenen bir alanın cevabı sonraki koşullarda boş sayılır.
+// Bölüm başlığının kuralı bir sonraki bölüme veya sayfaya, sayfa sonunun kuralı ise bir
+// sonraki sayfaya kadar olan tüm alanlara uygulanır (gizli sayfa atlanır). Gizli alanlar her
+// zaman görünür sayılır.
+func VisibleFormFields(fields []models.FormFieldDefinition, values map[uint][]string) map[uint]bool {
+	visible := make(map[uint]bool, len(fields))
+	known := make(map[uint]bool, len(fields))
+	for _, field := range fields {
+		known[field.ID] = true
+	}
+	valuesOf := func(fieldID uint) []string {
+		if !known[fieldID] {
+			return nil
+		}
+		if shown, evaluated := visible[fieldID]; evaluated && !shown {
+			return nil
+		}
+		return values[fieldID]
+	}
+
+	pageVisible, sectionVisible := true, true
+	for _, field := range fields {
+		shown := true
+		logic, err := field.ParsedLogic()
+		if err != nil {
+			configslog.Log.Warn("Form alanı koşulu okunamadı", zap.Uint("fieldID", field.ID), zap.Error(err))
+		} else if logic != nil {
+			matched := evaluateFormCondition(logic.When, valuesOf)
+			shown = matched == (logic.Action == models.FormLogicShow)
+		}
+		switch field.Type {
+		case models.FormFieldPage:
+			pageVisible, sectionVisible = shown, true
+			visible[field.ID] = shown
+		case models.FormFieldSection:
+			sectionVisible = shown
+			visible[field.ID] = pageVisible && shown
+		case models.FormFieldHidden:
+			// Gizli alanın değeri bağlantıdan gelir; bulunduğu sayfa atlansa da saklanır.
+			visible[field.ID] = true
+		default:
+			visible[field.ID] = pageVisible && sectionVisible && shown
+		}
+	}
+	return visible
+}
+
+// formFieldUpdateData alan tanımının düzenlenebilir sütunlarını günceller haritasına çevirir.
+// Nil kurallar da yazılır, böylece panelde temizlenen sınırlar kaldırılır.
+func formFieldUpdateData(field models.FormFieldDefinition) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        field.Type,
+		"label":       field.Label,
+		"help_text":   field.HelpText,
+		"placeholder": field.Placeholder,
+		"required":    field.Required,
+		"options":     field.Options,
+		"min_length":  field.MinLength,
+		"max_length":  field.MaxLength,
+		"min_value":   field.MinValue,
+		"max_value":   field.MaxValue,
+		"pattern":     field.Pattern,
+		"logic":       field.Logic,
+
+		"correct_answer": field.CorrectAnswer,
+		"points":         field.Points,
+
+		"accept_types":     field.AcceptTypes,
+		"max_file_size_mb": field.MaxFileSizeMB,
+		"max_files":        field.MaxFiles,
+
+		"prefill_param":  field.PrefillParam,
+		"prefill_signed": field.PrefillSigned,
+	}
+}
+
+// findOwnedField alanı bulur ve formun kullanıcıya ait olduğunu doğrular.
+func (s *FormFieldService) findOwnedField(ctx context.Context, id uint, userID uint) (*models.FormFieldDefinition, error) {
+	field, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrFormFieldNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.formService.GetFormByID(ctx, field.FormID, userID); err != nil {
+		return nil, ErrFormFieldForbidden
+	}
+	return field, nil
+}
+
+// publishVersion alan değişikliğinden sonra formun alanlarını yeni sürüm olarak yayınlar.
+// Değişiklik zaten kaydedildiği için hata yalnızca loglanır; eksik sürüm ilk gönderimde
+// oluşturulur.
+func (s *FormFieldService) publishVersion(ctx context.Context, formID uint, userID uint) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, userID), tx)
+		if err := lockFormRow(tx, formID); err != nil {
+			return err
+		}
+		fields, err := repositories.NewFormFieldRepositoryTx(tx.WithContext(txCtx)).FindByFormID(txCtx, formID)
+		if err != nil {
+			return err
+		}
+		_, err = publishFormVersion(txCtx, repositories.NewFormVersionRepositoryTx(tx.WithContext(txCtx)), formID, fields, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		configslog.Log.Warn("Form sürümü yayınlanamadı", zap.Uint("formID", formID), zap.Error(err))
+	}
+}
+
+// --- Servis Metodları ---
+
+// GetFields formun alanlarını getirir (yetki kontrolü ile).
+func (s *FormFieldService) GetFields(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, []models.FormFieldDefinition, error) {
+	form, err := s.formService.GetFormByID(ctx, formID, requestingUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields, err := s.repo.FindByFormID(ctx, formID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return form, fields, nil
+}
+
+// GetPublicFields public form sayfasında gösterilecek alanları getirir.
+func (s *FormFieldService) GetPublicFields(ctx context.Context, formID uint) ([]models.FormFieldDefinition, error) {
+	return s.repo.FindByFormID(ctx, formID)
+}
+
+// CreateField forma yeni bir alan ekler. Alan en sona eklenir. Koşul kuralı form satırı
+// kilitliyken okunan alanlara göre doğrulanır; böylece aynı anda silinen bir alana bağlanamaz.
+func (s *FormFieldService) CreateField(ctx context.Context, formID uint, creatingUserID uint, field models.FormFieldDefinition) error {
+	if _, err := s.formService.GetFormByID(ctx, formID, creatingUserID); err != nil {
+		return err
+	}
+	if err := ValidateFormField(&field); err != nil {
+		return err
+	}
+
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, creatingUserID), tx)
+		if err := lockFormRow(tx, formID); err != nil {
+			return err
+		}
+		repoTx := repositories.NewFormFieldRepositoryTx(tx.WithContext(txCtx))
+		existing, err := repoTx.FindByFormID(txCtx, formID)
+		if err != nil {
+			return err
+		}
+		if err := ValidateFormFieldLogic(&field, existing); err != nil {
+			return err
+		}
+		if len(existing) >= maxFormFieldsPerForm {
+			return fmt.Errorf("%w: bir formda en fazla %d alan olabilir", ErrFormFieldInvalid, maxFormFieldsPerForm)
+		}
+
+		field.FormID = formID
+		field.SortOrder = 1
+		if n := len(existing); n > 0 {
+			field.SortOrder = existing[n-1].SortOrder + 1
+		}
+		return repoTx.Create(txCtx, &field)
+	})
+	if txErr != nil {
+		if errors.Is(txErr, ErrFormFieldInvalid) {
+			return txErr
+		}
+		configslog.Log.Error("Form alanı oluşturulamadı", zap.Uint("formID", formID), zap.Error(txErr))
+		return ErrFormFieldSaveFailed
+	}
+	configslog.SLog.Infof("Form alanı eklendi: ID %d, Form ID %d (User ID %d)", field.ID, formID, creatingUserID)
+	s.publishVersion(ctx, formID, creatingUserID)
+	return nil
+}
+
+// UpdateField alanın tanımını ve doğrulama kurallarını günceller. Sıra MoveField ile değişir.
+// Koşul kuralı CreateField'daki gibi form satırı kilitliyken doğrulanır.
+func (s *FormFieldService) UpdateField(ctx context.Context, id uint, updatingUserID uint, field models.FormFieldDefinition) (*models.FormFieldDefinition, error) {
+	existing, err := s.findOwnedField(ctx, id, updatingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateFormField(&field); err != nil {
+		return existing, err
+	}
+	field.ID = existing.ID
+
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, updatingUserID), tx)
+		if err := lockFormRow(tx, existing.FormID); err != nil {
+			return err
+		}
+		repoTx := repositories.NewFormFieldRepositoryTx(tx.WithContext(txCtx))
+		siblings, err := repoTx.FindByFormID(txCtx, existing.FormID)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, sibling := range siblings {
+			found = found || sibling.ID == existing.ID
+		}
+		if !found {
+			return ErrFormFieldNotFound // Kilit beklenirken silindi
+		}
+		if err := ValidateFormFieldLogic(&field, siblings); err != nil {
+			return err
+		}
+		return repoTx.Update(txCtx, existing, formFieldUpdateData(field))
+	})
+	if txErr != nil {
+		if errors.Is(txErr, ErrFormFieldInvalid) || errors.Is(txErr, ErrFormFieldNotFound) {
+			return existing, txErr
+		}
+		configslog.Log.Error("Form alanı güncellenemedi", zap.Uint("fieldID", id), zap.Error(txErr))
+		return existing, ErrFormFieldSaveFailed
+	}
+	s.publishVersion(ctx, existing.FormID, updatingUserID)
+	return existing, nil
+}
+
+// MoveField alanı bir üst veya alt sıradaki alanla yer değiştirir.
+func (s *FormFieldService) MoveField(ctx context.Context, id uint, updatingUserID uint, direction FormFieldMove) (*models.FormFieldDefinition, error) {
+	field, err := s.findOwnedField(ctx, id, updatingUserID)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := s.repo.FindByFormID(ctx, field.FormID)
+	if err != nil {
+		return field, ErrFormFieldSaveFailed
+	}
+	index := -1
+	for i := range fields {
+		if fields[i].ID == field.ID {
+			index = i
+			break
+		}
+	}
+	target := index - 1
+	if direction == FormFieldMoveDown {
+		target = index + 1
+	} else if direction != FormFieldMoveUp {
+		return field, fmt.Errorf("%w: geçersiz yön", ErrFormFieldInvalid)
+	}
+	if index < 0 || target < 0 || target >= len(fields) {
+		return field, nil // Zaten başta veya sonda
+	}
+
+	// Eski kayıtlarda sıralar eşit olabilir; liste sırası yeniden numaralanarak yer değiştirilir.
+	fields[index], fields[target] = fields[target], fields[index]
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, updatingUserID), tx)
+		repoTx := repositories.NewFormFieldRepositoryTx(tx.WithContext(txCtx))
+		for i := range fields {
+			if fields[i].SortOrder == i+1 {
+				continue
+			}
+			if err := repoTx.Update(txCtx, &fields[i], map[string]interface{}{"sort_order": i + 1}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		configslog.Log.Error("Form alanı sırası güncellenemedi", zap.Uint("fieldID", id), zap.Error(txErr))
+		return field, ErrFormFieldSaveFailed
+	}
+	s.publishVersion(ctx, field.FormID, updatingUserID)
+	return field, nil
+}
+
+// DeleteField bir alanı siler (yetki kontrolü ile). Başka alanların koşullarında kullanılan
+// alan silinmez; koşul hiç oluşmayacak bir değere bağlı kalıp bağımlı alanları kalıcı olarak
+// gizlerdi. Kontrol form satırı kilitliyken yapılır, böylece araya yeni bir koşul giremez.
+func (s *FormFieldService) DeleteField(ctx context.Context, id uint, deletingUserID uint) (*models.FormFieldDefinition, error) {
+	field, err := s.findOwnedField(ctx, id, deletingUserID)
+	if err != nil {
+		return nil, err
+	}
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, deletingUserID), tx)
+		if err := lockFormRow(tx, field.FormID); err != nil {
+			return err
+		}
+		repoTx := repositories.NewFormFieldRepositoryTx(tx.WithContext(txCtx))
+		fields, err := repoTx.FindByFormID(txCtx, field.FormID)
+		if err != nil {
+			return err
+		}
+		if dependents := formFieldDependents(field.ID, fields); len(dependents) > 0 {
+			labels := make([]string, len(dependents))
+			for i, dependent := range dependents {
+				labels[i] = dependent.Label
+			}
+			return fmt.Errorf("%w: %s", ErrFormFieldInUse, strings.Join(labels, ", "))
+		}
+		return repoTx.Delete(txCtx, field, deletingUserID)
+	})
+	if txErr != nil {
+		if errors.Is(txErr, ErrFormFieldInUse) {
+			return field, txErr
+		}
+		configslog.Log.Error("Form alanı silinemedi", zap.Uint("fieldID", id), zap.Error(txErr))
+		return field, ErrFormFieldDeletionError
+	}
+	s.publishVersion(ctx, field.FormID, deletingUserID)
+	return field, nil
+}
+
+var _ IFormFieldService = (*FormFieldService)(nil)
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"davet.link/models"
+)
+
+func TestParseFormNumber(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   float64
+		wantOK bool
+	}{
+		{"42", 42, true},
+		{" 3,5 ", 3.5, true},
+		{"-0.25", -0.25, true},
+		{"1e15", 1e15, true},
+		{"", 0, false},
+		{"abc", 0, false},
+		{"NaN", 0, false},
+		{"nan", 0, false},
+		{"Inf", 0, false},
+		{"-Infinity", 0, false},
+		{"1e308", 0, false},
+		{"1e400", 0, false},
+		{"1,5,0", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseFormNumber(tt.value)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("ParseFormNumber(%q): beklenen %v/%v, alınan %v/%v", tt.value, tt.want, tt.wantOK, got, ok)
+		}
+	}
+}
+
+func logicField(id uint, fieldType models.FormFieldType, logic string) models.FormFieldDefinition {
+	field := models.FormFieldDefinition{Type: fieldType, Label: "Alan", Logic: logic}
+	field.ID = id
+	return field
+}
+
+// Koşullar sırayla değerlendirilir; gizlenen alanın cevabı boş sayılır, bölüm ve sayfa
+// kuralları sonraki alanlara uygulanır.
+func TestVisibleFormFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []models.FormFieldDefinition
+		values map[uint][]string
+		want   map[uint]bool
+	}{
+		{
+			name: "eşittir büyük/küçük harf duyarsız",
+			fields: []models.FormFieldDefinition{
+				logicField(1, models.FormFieldRadio, ""),
+				logicField(2, models.FormFieldText, `{"action":"show","when":{"field":1,"op":"equals","value":"evet"}}`),
+			},
+			values: map[uint][]string{1: {" Evet "}},
+			want:   map[uint]bool{1: true, 2: true},
+		},
+		{
+			name: "koşul sağlanmazsa gösterme kuralı gizler",
+			fields: []models.FormFieldDefinition{
+				logicField(1, models.FormFieldRadio, ""),
+				logicField(2, models.FormFieldText, `{"action":"show","when":{"field":1,"op":"equals","value":"evet"}}`),
+			},
+			values: map[uint][]string{1: {"Hayır"}},
+			want:   map[uint]bool{1: true, 2: false},
+		},
+		{
+			name: "gizleme kuralı",
+			fields: []models.FormFieldDefinition{
+				logicField(1, models.FormFieldRadio, ""),
+				logicField(2, models.FormFieldText, `{"action":"hide","when":{"field":1,"op":"equals","value":"evet"}}`),
+			},
+			values: map[uint][]string{1: {"evet"}},
+			want:   map[uint]bool{1: true, 2: false},
+		},
+		{
+			name: "çoklu seçimde içerir",
+			fields: []models.FormFieldDefinition{
+				logicField(1, models.FormFieldCheckbox, ""),
+				logicField(2, models.FormFieldText, `{"action":"show","when":{"field":1,"op":"contains","value":"vegan"}}`),
+			},
+			values: map[uint][]string{1: {"Et", "Vegan menü"}},
+			want:   map[uint]bool{1: true, 2: true},
+		},
+		{
+			name: "büyüktür virgüllü sayı",
+			fields: []models.FormFieldDefinition{
+				logicField(1, models.FormFieldNumber, ""),
+				logicField(2, models.FormFieldText, `{"action":"show","when":{"field":1,"op":"greater_than","value":"3"}}`),
+				logicField(3, models.FormFieldText, `{"action":"show","when":{"field":1,"op":"greater_than","value":"3,5"}}`),
+			},
+			values: map[uint][]string{1: {"3,5"}},
+			want:   map[uint]bool{1: true, 2: true, 3: false},
+		},
+		{
+			name: "büyüktür tarih",
+			fields: []models.FormFieldDefinition{
+				logicField(1, models.FormFieldDate, ""),
+				logicField(2, models.FormFieldText, `{"action":"show","when":{"field":1,"op":"greater_than","value":"2030-01-01"}}`),
+			},
+			values: map[uint][]string{1: {"2030-01-02"}},
+			want:   map[uint]bool{1: true, 2: true},
+		},
+		{
+			name: "büyüktür sayı olmayan cevap",
+			fields: []models.FormFieldDefinition{
+				logicField(1, models.FormFieldText, ""),
+				logicField(2, models.FormFieldText, `{"action":"show","when":{"field":1,"op":"greater_than","value":"3"}}`),
+			},
+			values: map[uint][]string{1: {"NaN"}},
+			want:   map[uint]bool{1: true, 2: false},
+		},
+		{
+			name: "hepsi grubu",
+			fields: []models.FormFieldDefinition{
+				logicField(1, models.FormFieldRadio, ""),
+				logicField(2, models.FormFieldNumber, ""),
+				logicField(3, models.FormFieldText, `{"action":"show","when":{"match":"all","conditions":[{"field":1,"op":"equals","value":"evet"},{"field":2,"op":"greater_than","value":"17"}]}}`),
+			},
+			values: map[uint][]string{1: {"evet"}, 2: {"16"}},
+			want:   map[uint]bool{1: true, 2: true, 3: false},
+		},
+		{
+			name: "herhangi biri grubu",
+			fields: []models.FormFieldDefinition{
+				logicField(1, models.FormFieldRadio, ""),
+				logicField(2, models.FormFieldNumber, ""),
+				logicField(3, models.FormFieldText, `{"action":"show","when":{"match":"any","conditions":[{"field":1,"op":"equals","value":"evet"},{"field":2,"op":"greater_than","value":"17"}]}}`),
+			},
+			values: map[uint][]string{1: {"hayır"}, 2: {"18"}},
+			want:   map[uint]bool{1: true, 2: true, 3: true},
+		},
+		{
+			name: "gizlenen alanın cevabı boş sayılır",
+			fields: []models.FormFieldDefinition{
+				logicField(1, models.FormFieldRadio, ""),
+				logicField(2, models.FormFieldRadio, `{"action":"show","when":{"field":1,"op":"equals","value":"evet"}}`),
+				logicField(3, models.FormFieldText, `{"action":"show","when":{"field":2,"op":"equals","value":"evet"}}`),
+			},
+			values: map[uint][]string{1: {"hayır"}, 2: {"evet"}},
+			want:   map[uint]bool{1: true, 2: false, 3: false},
+		},
+		{
+			name: "formda olmayan alana koşul",
+			fields: []models.FormFieldDefinition{
+				logicField(2, models.FormFieldText, `{"action":"show","when":{"field":9,"op":"equals","value":"evet"}}`),
+			},
+			values: map[uint][]string{9: {"evet"}},
+			want:   map[uint]bool{2: false},
+		},
+		{
+			name: "bölüm kuralı sonraki bölüme kadar uygulanır",
+			fields: []models.FormFieldDefinition{
+				logicField(1, models.FormFieldRadio, ""),
+				logicField(2, models.FormFieldSection, `{"action":"hide","when":{"field":1,"op":"equals","value":"evet"}}`),
+				logicField(3, models.FormFieldText, ""),
+				logicField(4, models.FormFieldSection, ""),
+				logicField(5, models.FormFieldText, ""),
+			},
+			values: map[uint][]string{1: {"evet"}},
+			want:   map[uint]bool{1: true, 2: false, 3: false, 4: true, 5: true},
+		},
+		{
+			name: "atlanan sayfa gizli alanı gizlemez",
+			fields: []models.FormFieldDefinition{
+				logicField(1, models.FormFieldRadio, ""),
+				logicField(2, models.FormFieldPage, `{"action":"show","when":{"field":1,"op":"equals","value":"evet"}}`),
+				logicField(3, models.FormFieldText, ""),
+				logicField(4, models.FormFieldSection, ""),
+				logicField(5, models.FormFieldHidden, ""),
+				logicField(6, models.FormFieldPage, ""),
+				logicField(7, models.FormFieldText, ""),
+			},
+			values: map[uint][]string{1: {"hayır"}},
+			want:   map[uint]bool{1: true, 2: false, 3: false, 4: false, 5: true, 6: true, 7: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VisibleFormFields(tt.fields, tt.values); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("beklenen %v, alınan %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// Koşulunda silinecek alana (grup içinde de olsa) başvuran alanlar bağımlı sayılır.
+func TestFormFieldDependents(t *testing.T) {
+	fields := []models.FormFieldDefinition{
+		logicField(1, models.FormFieldRadio, ""),
+		logicField(2, models.FormFieldText, `{"action":"show","when":{"field":1,"op":"equals","value":"evet"}}`),
+		logicField(3, models.FormFieldSection, `{"action":"hide","when":{"match":"any","conditions":[{"field":2,"op":"contains","value":"x"},{"match":"all","conditions":[{"field":1,"op":"equals","value":"hayır"}]}]}}`),
+		logicField(4, models.FormFieldText, `{"action":"show","when":{"field":2,"op":"equals","value":"a"}}`),
+		logicField(5, models.FormFieldPage, "bozuk"),
+	}
+	tests := []struct {
+		fieldID uint
+		want    []uint
+	}{
+		{1, []uint{2, 3}},
+		{2, []uint{3, 4}},
+		{4, nil},
+	}
+	for _, tt := range tests {
+		var got []uint
+		for _, field := range formFieldDependents(tt.fieldID, fields) {
+			got = append(got, field.ID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("alan %d: beklenen %v, alınan %v", tt.fieldID, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+// FormNotificationServiceError özel servis hataları
+type FormNotificationServiceError string
+
+func (e FormNotificationServiceError) Error() string { return string(e) }
+
+const (
+	ErrFormNotificationInvalid FormNotificationServiceError = "bildirim ayarları geçersiz"
+)
+
+// Kuyruk kayıtlarında form e-postalarının türleri (MailOutbox.Kind).
+const (
+	MailKindFormNotification = "form_notification"
+	MailKindFormAutoresponse = "form_autoresponse"
+)
+
+const (
+	maxNotifyRecipients      = 10
+	maxMailSubjectLength     = 255
+	maxMailTemplateLength    = 10000
+	defaultNotifySubject     = "Yeni yanıt: {{form}}"
+	defaultNotifyBody        = "{{form}} formuna yeni bir yanıt geldi.\n\n{{answers}}\n\nGönderim zamanı: {{submitted_at}}\nPanelde görüntüle: {{panel_url}}"
+	defaultAutoreplySubject  = "Yanıtınız alındı: {{form}}"
+	defaultAutoreplyBody     = "Merhaba,\n\n{{form}} formuna verdiğiniz yanıtlar bize ulaştı. Yanıtlarınızın bir kopyası aşağıdadır.\n\n{{answers}}"
+	submissionPanelPathFmt   = "/panel/forms/submissions/view/%d"
+	submissionTimeLayoutMail = "02.01.2006 15:04"
+)
+
+// mailPlaceholderPattern şablonlardaki {{ad}} yer tutucularını bulur.
+var mailPlaceholderPattern = regexp.MustCompile(`\{\{\s*([a-z0-9_]+)\s*\}\}`)
+
+// FormMailPlaceholder panelde şablon düzenlerken gösterilen yer tutucudur.
+type FormMailPlaceholder struct {
+	Key   string
+	Label string
+}
+
+// FormMailPlaceholders formun alanlarıyla birlikte kullanılabilecek yer tutucuları döner.
+func FormMailPlaceholders(fields []models.FormFieldDefinition) []FormMailPlaceholder {
+	placeholders := []FormMailPlaceholder{
+		{"{{form}}", "Form başlığı"},
+		{"{{answers}}", "Tüm cevaplar (alan: cevap)"},
+		{"{{submitted_at}}", "Gönderim zamanı"},
+		{"{{submission_id}}", "Gönderim numarası"},
+		{"{{panel_url}}", "Gönderimin paneldeki adresi"},
+	}
+	for _, field := range fields {
+		if field.IsInput() {
+			placeholders = append(placeholders, FormMailPlaceholder{fmt.Sprintf("{{field_%d}}", field.ID), field.Label})
+		}
+	}
+	return placeholders
+}
+
+// FormNotificationSettings panelde düzenlenen gönderim e-postası ayarlarıdır.
+type FormNotificationSettings struct {
+	NotifyOnSubmitEmail  string
+	NotifySubject        string
+	NotifyBody           string
+	AutoresponderEnabled bool
+	AutoresponderFieldID *uint
+	AutoresponderSubject string
+	AutoresponderBody    string
+}
+
+// ParseRecipients virgül, noktalı virgül veya satır sonuyla ayrılmış adresleri ayrıştırır.
+func ParseRecipients(value string) ([]string, error) {
+	recipients, err := parseAddressList(value, maxNotifyRecipients)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFormNotificationInvalid, err)
+	}
+	return recipients, nil
+}
+
+// parseAddressList adres listesini ayrıştırır, tekrarları atar ve sayısını sınırlar.
+func parseAddressList(value string, limit int) ([]string, error) {
+	parts := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' || r == '\n' || r == '\r' })
+	var recipients []string
+	seen := make(map[string]bool)
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		addr, err := mail.ParseAddress(part)
+		if err != nil {
+			return nil, fmt.Errorf("%q geçerli bir e-posta adresi değil", part)
+		}
+		address := strings.ToLower(addr.Address)
+		if !seen[address] {
+			seen[address] = true
+			recipients = append(recipients, addr.Address)
+		}
+	}
+	if len(recipients) > limit {
+		return nil, fmt.Errorf("en fazla %d adres girilebilir", limit)
+	}
+	return recipients, nil
+}
+
+// ValidateFormNotificationSettings ayarları doğrular ve adres listesini normalleştirir.
+func ValidateFormNotificationSettings(settings *FormNotificationSettings, fields []models.FormFieldDefinition) error {
+	recipients, err := ParseRecipients(settings.NotifyOnSubmitEmail)
+	if err != nil {
+		return err
+	}
+	settings.NotifyOnSubmitEmail = strings.Join(recipients, ", ")
+
+	for _, subject := range []*string{&settings.NotifySubject, &settings.AutoresponderSubject} {
+		*subject = strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(*subject, "\r", " "), "\n", " "))
+		if utf8.RuneCountInString(*subject) > maxMailSubjectLength {
+			return fmt.Errorf("%w: konu en fazla %d karakter olabilir", ErrFormNotificationInvalid, maxMailSubjectLength)
+		}
+	}
+	for _, body := range []*string{&settings.NotifyBody, &settings.AutoresponderBody} {
+		*body = strings.TrimSpace(strings.ReplaceAll(*body, "\r\n", "\n"))
+		if utf8.RuneCountInString(*body) > maxMailTemplateLength {
+			return fmt.Errorf("%w: e-posta metni en fazla %d karakter olabilir", ErrFormNotificationInvalid, maxMailTemplateLength)
+		}
+	}
+
+	if settings.AutoresponderFieldID != nil && *settings.AutoresponderFieldID == 0 {
+		settings.AutoresponderFieldID = nil
+	}
+	if settings.AutoresponderFieldID != nil {
+		found := false
+		for _, field := range fields {
+			if field.ID == *settings.AutoresponderFieldID && field.Type == models.FormFieldEmail {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: otomatik yanıt için formdaki bir e-posta alanı seçilmelidir", ErrFormNotificationInvalid)
+		}
+	}
+	if settings.AutoresponderEnabled && settings.AutoresponderFieldID == nil {
+		hasEmail := false
+		for _, field := range fields {
+			if field.Type == models.FormFieldEmail {
+				hasEmail = true
+				break
+			}
+		}
+		if !hasEmail {
+			return fmt.Errorf("%w: otomatik yanıt için formda bir e-posta alanı olmalıdır", ErrFormNotificationInvalid)
+		}
+	}
+	return nil
+}
+
+// renderMailTemplate şablondaki yer tutucuları verilen değerlerle değiştirir. Bilinmeyen
+// yer tutucular boş bırakılır.
+func renderMailTemplate(template string, data map[string]string) string {
+	return mailPlaceholderPattern.ReplaceAllStringFunc(template, func(match string) string {
+		key := mailPlaceholderPattern.FindStringSubmatch(match)[1]
+		return data[key]
+	})
+}
+
+// answerText cevabın e-postadaki metni; çoklu değerler virgülle birleştirilir.
+func answerText(answer models.FormSubmissionAnswer) string {
+	if answer.FieldType == models.FormFieldCheckbox && answer.BoolValue != nil {
+		if *answer.BoolValue {
+			return "Evet"
+		}
+		return "Hayır"
+	}
+	return strings.Join(strings.Split(answer.Value, "\n"), ", ")
+}
+
+// submissionMailData gönderimin şablon değerlerini hazırlar.
+func submissionMailData(form *models.Form, submission *models.FormSubmission) map[string]string {
+	data := map[string]string{
+		"form":          form.Detail.Title,
+		"submitted_at":  submission.SubmittedAt.Local().Format(submissionTimeLayoutMail),
+		"submission_id": strconv.FormatUint(uint64(submission.ID), 10),
+		"panel_url":     PublicURL(fmt.Sprintf(submissionPanelPathFmt, submission.ID)),
+	}
+	var summary strings.Builder
+	for _, answer := range submission.Answers {
+		text := answerText(answer)
+		data["field_"+strconv.FormatUint(uint64(answer.FieldID), 10)] = text
+		if text == "" {
+			continue
+		}
+		fmt.Fprintf(&summary, "%s: %s\n", answer.FieldLabel, text)
+	}
+	data["answers"] = strings.TrimRight(summary.String(), "\n")
+	return data
+}
+
+// respondentEmail otomatik yanıtın gideceği adresi gönderimden bulur.
+func respondentEmail(form *models.Form, submission *models.FormSubmission) string {
+	for _, answer := range submission.Answers {
+		if answer.FieldType != models.FormFieldEmail || answer.Value == "" {
+			continue
+		}
+		if fieldID := form.Detail.AutoresponderFieldID; fieldID != nil && *fieldID != answer.FieldID {
+			continue
+		}
+		if addr, err := mail.ParseAddress(answer.Value); err == nil {
+			return addr.Address
+		}
+	}
+	return ""
+}
+
+// mailSubject işlenmiş konuyu tek satıra indirger ve kısaltır.
+func mailSubject(subject string) string {
+	subject = strings.Join(strings.Fields(subject), " ")
+	if utf8.RuneCountInString(subject) > maxMailSubjectLength {
+		subject = string([]rune(subject)[:maxMailSubjectLength])
+	}
+	return subject
+}
+
+func templateOrDefault(template string, def string) string {
+	if strings.TrimSpace(template) == "" {
+		return def
+	}
+	return template
+}
+
+// enqueueSubmissionMails gönderimin bildirim ve otomatik yanıt e-postalarını kuyruğa ekler.
+// Gönderimle aynı transaction içinde çağrılır; SMTP'ye istek sırasında bağlanılmaz.
+func enqueueSubmissionMails(ctx context.Context, repo repositories.IMailOutboxRepository, form *models.Form, submission *models.FormSubmission) error {
+	data := submissionMailData(form, submission)
+	replyTo := respondentEmail(form, submission)
+	sourceID := submission.ID
+
+	recipients, err := ParseRecipients(form.Detail.NotifyOnSubmitEmail)
+	if err != nil {
+		// Eski kayıtlarda doğrulanmamış adresler olabilir; gönderimi engellemez.
+		configslog.Log.Warn("Form bildirim adresleri geçersiz", zap.Uint("formID", form.ID), zap.Error(err))
+	}
+	if len(recipients) > 0 {
+		err := enqueueMail(ctx, repo, &models.MailOutbox{
+			OwnerUserID: form.CreatorUserID,
+			Kind:        MailKindFormNotification,
+			SourceID:    &sourceID,
+			Recipients:  strings.Join(recipients, ","),
+			ReplyTo:     replyTo,
+			Subject:     mailSubject(renderMailTemplate(templateOrDefault(form.Detail.NotifySubject, defaultNotifySubject), data)),
+			TextBody:    renderMailTemplate(templateOrDefault(form.Detail.NotifyBody, defaultNotifyBody), data),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if form.Detail.AutoresponderEnabled && replyTo != "" {
+		return enqueueMail(ctx, repo, &models.MailOutbox{
+			OwnerUserID: form.CreatorUserID,
+			Kind:        MailKindFormAutoresponse,
+			SourceID:    &sourceID,
+			Recipients:  replyTo,
+			Subject:     mailSubject(renderMailTemplate(templateOrDefault(form.Detail.AutoresponderSubject, defaultAutoreplySubject), data)),
+			TextBody:    renderMailTemplate(templateOrDefault(form.Detail.AutoresponderBody, defaultAutoreplyBody), data),
+		})
+	}
+	return nil
+}
+
+// IFormNotificationService formun gönderim e-postası ayarlarını yönetir.
+type IFormNotificationService interface {
+	GetSettings(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, []models.FormFieldDefinition, error)
+	UpdateSettings(ctx context.Context, formID uint, updatingUserID uint, settings FormNotificationSettings) error
+	GetSubmissionMails(ctx context.Context, submissionID uint) ([]models.MailOutbox, error)
+}
+
+// FormNotificationService IFormNotificationService arayüzünü uygular.
+type FormNotificationService struct {
+	formRepo     repositories.IFormRepository
+	outboxRepo   repositories.IMailOutboxRepository
+	fieldService IFormFieldService
+}
+
+// NewFormNotificationService yeni bir FormNotificationService örneği oluşturur.
+func NewFormNotificationService() IFormNotificationService {
+	return &FormNotificationService{
+		formRepo:     repositories.NewFormRepository(),
+		outboxRepo:   repositories.NewMailOutboxRepository(),
+		fieldService: NewFormFieldService(),
+	}
+}
+
+// GetSettings formu (yetki kontrolüyle) ve şablon yer tutucuları için alanlarını getirir.
+func (s *FormNotificationService) GetSettings(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, []models.FormFieldDefinition, error) {
+	return s.fieldService.GetFields(ctx, formID, requestingUserID)
+}
+
+func (s *FormNotificationService) UpdateSettings(ctx context.Context, formID uint, updatingUserID uint, settings FormNotificationSettings) error {
+	form, fields, err := s.fieldService.GetFields(ctx, formID, updatingUserID)
+	if err != nil {
+		return err
+	}
+	if err := ValidateFormNotificationSettings(&settings, fields); err != nil {
+		return err
+	}
+
+	detail := form.Detail
+	detail.NotifyOnSubmitEmail = settings.NotifyOnSubmitEmail
+	detail.NotifySubject = settings.NotifySubject
+	detail.NotifyBody = settings.NotifyBody
+	detail.AutoresponderEnabled = settings.AutoresponderEnabled
+	detail.AutoresponderFieldID = settings.AutoresponderFieldID
+	detail.AutoresponderSubject = settings.AutoresponderSubject
+	detail.AutoresponderBody = settings.AutoresponderBody
+	if err := s.formRepo.UpdateDetail(contextWithUserID(ctx, updatingUserID), &detail); err != nil {
+		configslog.Log.Error("Form bildirim ayarları kaydedilemedi", zap.Uint("formID", formID), zap.Error(err))
+		return ErrFormUpdateFailed
+	}
+	configslog.SLog.Infof("Form bildirim ayarları güncellendi: Form ID %d (Güncelleyen: %d)", formID, updatingUserID)
+	return nil
+}
+
+// GetSubmissionMails gönderim için kuyruğa alınan e-postaları (durumlarıyla) getirir.
+// Yetki kontrolü gönderimi getiren çağıranda yapılır.
+func (s *FormNotificationService) GetSubmissionMails(ctx context.Context, submissionID uint) ([]models.MailOutbox, error) {
+	return s.outboxRepo.FindBySource(ctx, []string{MailKindFormNotification, MailKindFormAutoresponse}, submissionID)
+}
+
+var _ IFormNotificationService = (*FormNotificationService)(nil)
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"davet.link/models"
+)
+
+// FormPrefillServiceError özel servis hataları
+type FormPrefillServiceError string
+
+func (e FormPrefillServiceError) Error() string { return string(e) }
+
+const (
+	ErrFormPrefillInvalid FormPrefillServiceError = "geçersiz ön doldurma bağlantısı"
+	ErrFormPrefillNoField FormPrefillServiceError = "formda ön doldurmaya açık alan yok"
+)
+
+// Ön doldurma bağlantısı sınırları.
+const (
+	defaultPrefillLinkDays = 30
+	maxPrefillLinkDays     = 365
+)
+
+// formPrefillSignPath imzalı alanların parametrelerinin imzalandığı yoldur. Form ID'si imzaya
+// dahildir; bir formun bağlantısındaki imza başka bir formda geçmez.
+func formPrefillSignPath(formID uint, signed url.Values) string {
+	return fmt.Sprintf("/form-prefill/%d/%s", formID, signed.Encode())
+}
+
+// formPrefillSignedQuery bağlantıdaki imzalı alan parametrelerini döndürür.
+func formPrefillSignedQuery(fields []models.FormFieldDefinition, prefill url.Values) url.Values {
+	signed := url.Values{}
+	for _, field := range fields {
+		if field.PrefillSigned && field.PrefillParam != "" {
+			if values, ok := prefill[field.PrefillParam]; ok {
+				signed[field.PrefillParam] = values
+			}
+		}
+	}
+	return signed
+}
+
+// verifyFormPrefill bağlantıdaki imzalı parametrelerin değiştirilmediğini ve bağlantının
+// süresinin dolmadığını doğrular. İmzalı alan parametresi yoksa false döner.
+func verifyFormPrefill(formID uint, fields []models.FormFieldDefinition, prefill url.Values, now time.Time) bool {
+	signed := formPrefillSignedQuery(fields, prefill)
+	if len(signed) == 0 {
+		return false
+	}
+	return formSigner().Verify(formPrefillSignPath(formID, signed), prefill.Get("expires"), prefill.Get("signature"), now) == nil
+}
+
+// FormPrefill public form bağlantısından ön doldurma değerlerini çıkarır: alanların
+// kullandığı sorgu parametreleri, imza parametreleri ve formu açan sayfanın adresi. Sonuç
+// sayfayla birlikte taşınır, böylece gönderimde aynı değerler yeniden doğrulanır.
+func FormPrefill(fields []models.FormFieldDefinition, query url.Values, referrer string) url.Values {
+	prefill := url.Values{}
+	for _, field := range fields {
+		if field.PrefillParam == "" || field.PrefillParam == models.FormPrefillReferrer {
+			continue
+		}
+		if values, ok := query[field.PrefillParam]; ok {
+			prefill[field.PrefillParam] = values
+		}
+	}
+	if len(formPrefillSignedQuery(fields, prefill)) > 0 {
+		prefill.Set("expires", query.Get("expires"))
+		prefill.Set("signature", query.Get("signature"))
+	}
+	if referrer = strings.TrimSpace(referrer); referrer != "" {
+		for _, field := range fields {
+			if field.PrefillParam == models.FormPrefillReferrer {
+				prefill.Set(models.FormPrefillReferrer, truncateRunes(referrer, maxShortAnswerLength))
+				break
+			}
+		}
+	}
+	return prefill
+}
+
+// truncateRunes metni en fazla max karaktere kısaltır.
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max])
+}
+
+// validPrefillValue değerin alanın gönderimdeki doğrulamasından geçip geçmediğini bildirir.
+// Alanın koşulu değerlendirilmez; gizlenen alanın değeri gönderimde zaten saklanmaz.
+func validPrefillValue(field models.FormFieldDefinition, values []string) error {
+	field.Logic = ""
+	_, err := validateFormAnswers([]models.FormFieldDefinition{field}, map[uint][]string{field.ID: values}, nil)
+	return err
+}
+
+// stripPrefillLocked gönderenin değiştiremeyeceği alanların gönderilen değerlerini siler;
+// bu alanlar yalnızca applyFormPrefill ile doldurulur.
+func stripPrefillLocked(fields []models.FormFieldDefinition, values map[uint][]string) {
+	for _, field := range fields {
+		if field.IsPrefillLocked() {
+			delete(values, field.ID)
+		}
+	}
+}
+
+// applyFormPrefill ön doldurma değerlerini cevaplara işler. Gizli alanlar ve imzası geçerli
+// imzalı alanlar her seferinde bağlantıdaki değeri alır; diğer alanlar yalnızca form ilk
+// açılırken (initial) ve boşsa doldurulur, gönderen sonradan değiştirebilir. Alanın
+// doğrulamasından geçmeyen değerler yok sayılır.
+func applyFormPrefill(formID uint, fields []models.FormFieldDefinition, values map[uint][]string, prefill url.Values, initial bool, now time.Time) {
+	if len(prefill) == 0 {
+		return
+	}
+	signed := verifyFormPrefill(formID, fields, prefill, now)
+	for _, field := range fields {
+		if field.PrefillParam == "" {
+			continue
+		}
+		if field.PrefillSigned && !signed {
+			continue
+		}
+		if !field.IsPrefillLocked() && (!initial || len(values[field.ID]) > 0) {
+			continue
+		}
+		raw := prefill[field.PrefillParam]
+		if len(raw) == 0 || validPrefillValue(field, raw) != nil {
+			continue
+		}
+		values[field.ID] = raw
+	}
+}
+
+// FormPrefillLink panelde oluşturulan ön doldurma bağlantısıdır.
+type FormPrefillLink struct {
+	URL       string
+	Signed    bool       // Bağlantıda imzalı alan değeri var
+	ExpiresAt *time.Time // İmzalı bağlantının son geçerlilik anı
+}
+
+// IFormPrefillService ön doldurma bağlantılarının panelde oluşturulması için arayüz.
+type IFormPrefillService interface {
+	GetPrefillFields(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, []models.FormFieldDefinition, error)
+	BuildPrefillLink(ctx context.Context, formID uint, requestingUserID uint, values map[uint][]string, validDays int) (*models.Form, *FormPrefillLink, error)
+}
+
+// FormPrefillService IFormPrefillService arayüzünü uygular.
+type FormPrefillService struct {
+	fieldService IFormFieldService
+}
+
+// NewFormPrefillService yeni bir FormPrefillService örneği oluşturur.
+func NewFormPrefillService() IFormPrefillService {
+	return &FormPrefillService{
+		fieldService: NewFormFieldService(),
+	}
+}
+
+// GetPrefillFields formun bağlantıyla doldurulabilen alanlarını getirir (yetki kontrolü ile).
+// Yönlendiren adresi alan alanlar bağlantıda taşınmadığından listelenmez.
+func (s *FormPrefillService) GetPrefillFields(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, []models.FormFieldDefinition, error) {
+	form, fields, err := s.fieldService.GetFields(ctx, formID, requestingUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var prefillable []models.FormFieldDefinition
+	for _, field := range fields {
+		if field.PrefillParam != "" && field.PrefillParam != models.FormPrefillReferrer {
+			prefillable = append(prefillable, field)
+		}
+	}
+	return form, prefillable, nil
+}
+
+// BuildPrefillLink verilen değerlerle formun public bağlantısını oluşturur. Değerler
+// gönderimdeki doğrulamadan geçmelidir. İmzalı alanlara değer verildiyse bağlantı validDays
+// gün geçerli olacak şekilde imzalanır; alıcı imzalı değerleri değiştirirse bu alanlar
+// doldurulmaz.
+func (s *FormPrefillService) BuildPrefillLink(ctx context.Context, formID uint, requestingUserID uint, values map[uint][]string, validDays int) (*models.Form, *FormPrefillLink, error) {
+	form, fields, err := s.GetPrefillFields(ctx, formID, requestingUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(fields) == 0 {
+		return form, nil, ErrFormPrefillNoField
+	}
+	if form.Link.Key == "" {
+		return form, nil, fmt.Errorf("%w: formun bağlantısı yok", ErrFormPrefillInvalid)
+	}
+	if validDays == 0 {
+		validDays = defaultPrefillLinkDays
+	}
+	if validDays < 1 || validDays > maxPrefillLinkDays {
+		return form, nil, fmt.Errorf("%w: geçerlilik süresi 1 ile %d gün arasında olmalıdır", ErrFormPrefillInvalid, maxPrefillLinkDays)
+	}
+
+	query := url.Values{}
+	for _, field := range fields {
+		var raw []string
+		for _, v := range values[field.ID] {
+			if v = strings.TrimSpace(v); v != "" {
+				raw = append(raw, v)
+			}
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		if err := validPrefillValue(field, raw); err != nil {
+			return form, nil, fmt.Errorf("%w: %s", ErrFormPrefillInvalid, strings.TrimPrefix(err.Error(), ErrSubmissionInvalid.Error()+": "))
+		}
+		if existing, ok := query[field.PrefillParam]; ok && strings.Join(existing, "\n") != strings.Join(raw, "\n") {
+			return form, nil, fmt.Errorf("%w: aynı parametreyi kullanan alanlara farklı değer verildi (%s)", ErrFormPrefillInvalid, field.PrefillParam)
+		}
+		query[field.PrefillParam] = raw
+	}
+	if len(query) == 0 {
+		return form, nil, fmt.Errorf("%w: en az bir alana değer girilmelidir", ErrFormPrefillInvalid)
+	}
+
+	link := &FormPrefillLink{}
+	if signed := formPrefillSignedQuery(fields, query); len(signed) > 0 {
+		expiresAt := time.Now().UTC().AddDate(0, 0, validDays)
+		path := formPrefillSignPath(form.ID, signed)
+		params, err := url.ParseQuery(strings.TrimPrefix(formSigner().Sign(path, expiresAt), path+"?"))
+		if err != nil {
+			return form, nil, ErrFormPrefillInvalid
+		}
+		query.Set("expires", params.Get("expires"))
+		query.Set("signature", params.Get("signature"))
+		link.Signed, link.ExpiresAt = true, &expiresAt
+	}
+	link.URL = PublicURL("/" + form.Link.Key + "?" + query.Encode())
+	return form, link, nil
+}
+
+var _ IFormPrefillService = (*FormPrefillService)(nil)
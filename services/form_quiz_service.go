@@ -0,0 +1,445 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+// FormQuizServiceError özel servis hataları
+type FormQuizServiceError string
+
+func (e FormQuizServiceError) Error() string { return string(e) }
+
+const (
+	ErrQuizInvalid      FormQuizServiceError = "sınav ayarları geçersiz"
+	ErrQuizTimeUp       FormQuizServiceError = "sınav süresi doldu; cevaplarınız kabul edilmedi"
+	ErrQuizStartInvalid FormQuizServiceError = "sınavın başlangıç zamanı doğrulanamadı; lütfen formu yeniden açın"
+)
+
+const (
+	maxQuizPoints           = 1000
+	maxQuizTimeLimitMinutes = 24 * 60
+	quizTimeGrace           = 30 * time.Second // Ağ gecikmesi ve yavaş yüklemeler için tolerans
+	quizLeaderboardSize     = 50
+)
+
+// --- Süre ---
+
+// quizStartedAt gönderenin kayıtlı sınav başlangıcını okur ve süreyi denetler. Süresiz
+// sınavda kayıt yoksa gönderim engellenmez, yalnızca süre ölçülmez.
+func quizStartedAt(ctx context.Context, repo repositories.IFormQuizAttemptRepository, form *models.Form, respondent FormRespondent, now time.Time) (*time.Time, error) {
+	attempt, err := repo.FindByRespondent(ctx, form.ID, respondent.Key)
+	switch {
+	case errors.Is(err, repositories.ErrNotFound):
+		if form.Detail.QuizTimeLimit() > 0 {
+			return nil, ErrQuizStartInvalid
+		}
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return &attempt.StartedAt, checkQuizDeadline(form.Detail, &attempt.StartedAt, now)
+}
+
+// checkQuizDeadline süreli sınavda sürenin (toleransla) dolup dolmadığını denetler. Süre
+// sınırı sınav başladıktan sonra değiştiyse güncel sınır uygulanır.
+func checkQuizDeadline(detail models.FormDetail, startedAt *time.Time, now time.Time) error {
+	limit := detail.QuizTimeLimit()
+	if limit == 0 {
+		return nil
+	}
+	if startedAt == nil {
+		return ErrQuizStartInvalid
+	}
+	if now.After(startedAt.Add(limit + quizTimeGrace)) {
+		return ErrQuizTimeUp
+	}
+	return nil
+}
+
+// startQuizClock sınav formunda gösterilecek sayfanın başlangıç zamanını belirler: taslakta
+// kayıtlıysa o, değilse gönderenin kayıtlı denemesi. Deneme yoksa şimdi başlatılıp kaydedilir;
+// formu yeniden açmak süreyi sıfırlamaz.
+func startQuizClock(ctx context.Context, repo repositories.IFormQuizAttemptRepository, view *FormPageView, respondent FormRespondent, draftStartedAt *time.Time) error {
+	if view.Form == nil || !view.Form.Detail.IsQuiz {
+		return nil
+	}
+	if draftStartedAt != nil {
+		view.QuizStartedAt = draftStartedAt
+		return nil
+	}
+	// Public işlem: BaseModel hook'ları için aktör olarak form sahibi kullanılır.
+	attempt, err := repo.Start(contextWithUserID(ctx, view.Form.CreatorUserID), view.Form.ID, respondent.Key, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	view.QuizStartedAt = &attempt.StartedAt
+	return nil
+}
+
+// QuizDeadline süreli sınavın bitiş zamanı; süre sınırı yoksa nil.
+func (v *FormPageView) QuizDeadline() *time.Time {
+	if v.QuizStartedAt == nil || v.Form == nil {
+		return nil
+	}
+	limit := v.Form.Detail.QuizTimeLimit()
+	if limit == 0 {
+		return nil
+	}
+	deadline := v.QuizStartedAt.Add(limit)
+	return &deadline
+}
+
+// --- Puanlama ---
+
+// quizNormalize cevapları büyük/küçük harf ve fazla boşluklardan bağımsız karşılaştırmak için sadeleştirir.
+func quizNormalize(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+func quizContains(accepted []string, value string) bool {
+	value = quizNormalize(value)
+	for _, a := range accepted {
+		if quizNormalize(a) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// quizAnswerCorrect cevabın alanın doğru cevaplarından birine uyup uymadığını bildirir.
+// Çoklu seçimde işaretlenen seçenekler doğru seçeneklerle birebir aynı olmalıdır; sayı ve
+// puan alanları sayısal, tarih alanları YYYY-MM-DD biçiminde karşılaştırılır.
+func quizAnswerCorrect(field models.FormFieldDefinition, answer *models.FormSubmissionAnswer) bool {
+	accepted := field.CorrectAnswerList()
+	switch {
+	case field.Type == models.FormFieldCheckbox && field.HasOptions():
+		selected := answerValues(answer)
+		if len(selected) != len(accepted) {
+			return false
+		}
+		for _, value := range selected {
+			if !quizContains(accepted, value) {
+				return false
+			}
+		}
+		return true
+	case answer.NumberValue != nil:
+		for _, value := range accepted {
+			if n, ok := ParseFormNumber(value); ok && n == *answer.NumberValue {
+				return true
+			}
+		}
+		return false
+	case answer.DateValue != nil:
+		return quizContains(accepted, answer.DateValue.Format("2006-01-02"))
+	}
+	return quizContains(accepted, answer.Value)
+}
+
+// quizOutcome gönderimin sınav sonucudur; saveSubmission kayıttan hemen önce süreyi yeniden denetler.
+type quizOutcome struct {
+	score     int
+	maxScore  int
+	passed    *bool
+	startedAt *time.Time
+}
+
+// scoreQuiz görünür ve puanlanan alanların cevaplarını değerlendirir; cevaplara doğruluk ve
+// kazanılan puan işlenir. Koşulla gizlenen sorular tam puana katılmaz, boş bırakılanlar
+// yanlış sayılır.
+func scoreQuiz(detail models.FormDetail, fields []models.FormFieldDefinition, values map[uint][]string, answers []models.FormSubmissionAnswer, startedAt *time.Time) *quizOutcome {
+	visible := VisibleFormFields(fields, values)
+	byField := make(map[uint]*models.FormSubmissionAnswer, len(answers))
+	for i := range answers {
+		byField[answers[i].FieldID] = &answers[i]
+	}
+	outcome := &quizOutcome{startedAt: startedAt}
+	for _, field := range fields {
+		if !field.IsScored() || !visible[field.ID] {
+			continue
+		}
+		outcome.maxScore += field.Points
+		answer := byField[field.ID]
+		if answer == nil {
+			continue
+		}
+		correct := quizAnswerCorrect(field, answer)
+		points := 0
+		if correct {
+			points = field.Points
+			outcome.score += points
+		}
+		answer.IsCorrect, answer.Points = &correct, &points
+	}
+	if detail.QuizPassPercent != nil && outcome.maxScore > 0 {
+		passed := outcome.score*100 >= *detail.QuizPassPercent*outcome.maxScore
+		outcome.passed = &passed
+	}
+	return outcome
+}
+
+// submissionValues kayıtlı cevaplardan, koşulların değerlendirildiği ham değerleri geri üretir.
+func submissionValues(submission *models.FormSubmission) map[uint][]string {
+	values := make(map[uint][]string, len(submission.Answers))
+	for i := range submission.Answers {
+		answer := &submission.Answers[i]
+		switch {
+		case answer.BoolValue != nil:
+			if *answer.BoolValue {
+				values[answer.FieldID] = []string{"true"}
+			}
+		case answer.DateValue != nil:
+			values[answer.FieldID] = []string{answer.DateValue.Format("2006-01-02")}
+		default:
+			values[answer.FieldID] = answerValues(answer)
+		}
+	}
+	return values
+}
+
+// --- Sonuç ve ayarlar ---
+
+// QuizResultItem sonuç sayfasında tek bir sorudur.
+type QuizResultItem struct {
+	Label         string
+	Answer        string // Boşsa soru cevaplanmadı
+	CorrectAnswer string
+	IsCorrect     bool
+	Points        int
+	MaxPoints     int
+}
+
+// QuizResult gönderene gösterilen sınav sonucudur. Items yalnızca doğru cevapların
+// gösterilmesi açıksa doludur.
+type QuizResult struct {
+	Score       int
+	MaxScore    int
+	Percent     int
+	HasPassMark bool // Geçme eşiği tanımlı
+	PassPercent int
+	Passed      bool
+	Duration    string // Süre ölçülmediyse boş
+	Items       []QuizResultItem
+}
+
+// BuildQuizResult gönderimin sonucunu formun güncel alanlarıyla hazırlar.
+func BuildQuizResult(form *models.Form, fields []models.FormFieldDefinition, submission *models.FormSubmission) *QuizResult {
+	if submission.Score == nil || submission.MaxScore == nil {
+		return nil
+	}
+	result := &QuizResult{
+		Score:       *submission.Score,
+		MaxScore:    *submission.MaxScore,
+		Percent:     submission.ScorePercent(),
+		HasPassMark: submission.Passed != nil,
+		Passed:      submission.IsPassed(),
+		Duration:    submission.DurationText(),
+	}
+	if form.Detail.QuizPassPercent != nil {
+		result.PassPercent = *form.Detail.QuizPassPercent
+	}
+	if !form.Detail.QuizShowAnswers {
+		return result
+	}
+	visible := VisibleFormFields(fields, submissionValues(submission))
+	for _, field := range fields {
+		if !field.IsScored() || !visible[field.ID] {
+			continue
+		}
+		item := QuizResultItem{Label: field.Label, MaxPoints: field.Points, CorrectAnswer: strings.Join(field.CorrectAnswerList(), ", ")}
+		if answer := submission.AnswerFor(field.ID); answer != nil {
+			item.Answer = answerText(*answer)
+			item.IsCorrect = answer.IsCorrect != nil && *answer.IsCorrect
+			if answer.Points != nil {
+				item.Points = *answer.Points
+			}
+		}
+		result.Items = append(result.Items, item)
+	}
+	return result
+}
+
+// FormQuizSettings panelde düzenlenen sınav ayarlarıdır.
+type FormQuizSettings struct {
+	IsQuiz           bool
+	PassPercent      *int
+	TimeLimitMinutes *int
+	ShowAnswers      bool
+}
+
+// ValidateFormQuizSettings ayarları doğrular; sıfır eşik ve süre "yok" olarak kaydedilir.
+func ValidateFormQuizSettings(settings *FormQuizSettings) error {
+	if settings.PassPercent != nil {
+		if *settings.PassPercent < 0 || *settings.PassPercent > 100 {
+			return fmt.Errorf("%w: geçme eşiği 0 ile 100 arasında olmalıdır", ErrQuizInvalid)
+		}
+		if *settings.PassPercent == 0 {
+			settings.PassPercent = nil
+		}
+	}
+	if settings.TimeLimitMinutes != nil {
+		if *settings.TimeLimitMinutes < 0 || *settings.TimeLimitMinutes > maxQuizTimeLimitMinutes {
+			return fmt.Errorf("%w: süre sınırı en fazla %d dakika olabilir", ErrQuizInvalid, maxQuizTimeLimitMinutes)
+		}
+		if *settings.TimeLimitMinutes == 0 {
+			settings.TimeLimitMinutes = nil
+		}
+	}
+	return nil
+}
+
+// QuizLeaderboardEntry panelde sıralamadaki bir gönderimdir.
+type QuizLeaderboardEntry struct {
+	Rank        int
+	Submission  models.FormSubmission
+	Participant string // Puanlanmayan ilk metin veya e-posta cevabı (ad, e-posta vb.)
+}
+
+// QuizQuestionSummary panelde bir sorunun doğru cevaplanma oranıdır.
+type QuizQuestionSummary struct {
+	Field          models.FormFieldDefinition
+	Answered       int64
+	Correct        int64
+	CorrectPercent int
+}
+
+// QuizLeaderboard sınav sayfasının verisidir.
+type QuizLeaderboard struct {
+	Stats     models.FormQuizStats
+	PassRate  int
+	Entries   []QuizLeaderboardEntry
+	Questions []QuizQuestionSummary
+}
+
+// quizParticipant sıralamada gönderimi tanıtan cevabı seçer.
+func quizParticipant(submission *models.FormSubmission) string {
+	for _, answer := range submission.Answers {
+		if answer.IsCorrect != nil || answer.Value == "" {
+			continue
+		}
+		if answer.FieldType == models.FormFieldText || answer.FieldType == models.FormFieldEmail {
+			return answer.Value
+		}
+	}
+	return ""
+}
+
+type IFormQuizService interface {
+	GetResult(ctx context.Context, form *models.Form, submission *models.FormSubmission) *QuizResult
+	GetQuiz(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, []models.FormFieldDefinition, error)
+	GetLeaderboard(ctx context.Context, formID uint, fields []models.FormFieldDefinition) (*QuizLeaderboard, error)
+	UpdateSettings(ctx context.Context, formID uint, updatingUserID uint, settings FormQuizSettings) error
+}
+
+// FormQuizService IFormQuizService arayüzünü uygular.
+type FormQuizService struct {
+	formRepo       repositories.IFormRepository
+	fieldRepo      repositories.IFormFieldRepository
+	submissionRepo repositories.IFormSubmissionRepository
+	fieldService   IFormFieldService
+}
+
+// NewFormQuizService yeni bir FormQuizService örneği oluşturur.
+func NewFormQuizService() IFormQuizService {
+	return &FormQuizService{
+		formRepo:       repositories.NewFormRepository(),
+		fieldRepo:      repositories.NewFormFieldRepository(),
+		submissionRepo: repositories.NewFormSubmissionRepository(),
+		fieldService:   NewFormFieldService(),
+	}
+}
+
+// GetResult gönderene gösterilecek sonucu hazırlar; sınav değilse nil döner. Alanlar
+// okunamazsa soru dökümü olmadan yalnızca puan gösterilir.
+func (s *FormQuizService) GetResult(ctx context.Context, form *models.Form, submission *models.FormSubmission) *QuizResult {
+	if !form.Detail.IsQuiz || submission == nil {
+		return nil
+	}
+	var fields []models.FormFieldDefinition
+	if form.Detail.QuizShowAnswers {
+		var err error
+		if fields, err = s.fieldRepo.FindByFormID(ctx, form.ID); err != nil {
+			configslog.Log.Error("Sınav sonucu için alanlar okunamadı", zap.Uint("formID", form.ID), zap.Error(err))
+		}
+	}
+	return BuildQuizResult(form, fields, submission)
+}
+
+// GetQuiz formu (yetki kontrolüyle) ve soruları getirir.
+func (s *FormQuizService) GetQuiz(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, []models.FormFieldDefinition, error) {
+	return s.fieldService.GetFields(ctx, formID, requestingUserID)
+}
+
+// GetLeaderboard puan sıralamasını, toplu sonuçları ve soru bazında doğru oranlarını getirir.
+// Yetki kontrolü formu getiren GetQuiz'de yapılır.
+func (s *FormQuizService) GetLeaderboard(ctx context.Context, formID uint, fields []models.FormFieldDefinition) (*QuizLeaderboard, error) {
+	stats, err := s.submissionRepo.QuizStats(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+	submissions, err := s.submissionRepo.FindQuizLeaderboard(ctx, formID, quizLeaderboardSize)
+	if err != nil {
+		return nil, err
+	}
+	questionStats, err := s.submissionRepo.QuizQuestionStats(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &QuizLeaderboard{Stats: *stats, PassRate: percentOf(stats.PassedCount, stats.Count)}
+	for i := range submissions {
+		board.Entries = append(board.Entries, QuizLeaderboardEntry{Rank: i + 1, Submission: submissions[i], Participant: quizParticipant(&submissions[i])})
+	}
+	byField := make(map[uint]models.FormQuizQuestionStat, len(questionStats))
+	for _, stat := range questionStats {
+		byField[stat.FieldID] = stat
+	}
+	for _, field := range fields {
+		if !field.IsScored() {
+			continue
+		}
+		stat := byField[field.ID]
+		board.Questions = append(board.Questions, QuizQuestionSummary{
+			Field:          field,
+			Answered:       stat.Answered,
+			Correct:        stat.Correct,
+			CorrectPercent: percentOf(stat.Correct, stat.Answered),
+		})
+	}
+	return board, nil
+}
+
+// UpdateSettings sınav modunu ve ayarlarını kaydeder.
+func (s *FormQuizService) UpdateSettings(ctx context.Context, formID uint, updatingUserID uint, settings FormQuizSettings) error {
+	form, _, err := s.fieldService.GetFields(ctx, formID, updatingUserID)
+	if err != nil {
+		return err
+	}
+	if err := ValidateFormQuizSettings(&settings); err != nil {
+		return err
+	}
+
+	detail := form.Detail
+	detail.IsQuiz = settings.IsQuiz
+	detail.QuizPassPercent = settings.PassPercent
+	detail.QuizTimeLimitMinutes = settings.TimeLimitMinutes
+	detail.QuizShowAnswers = settings.ShowAnswers
+	if err := s.formRepo.UpdateDetail(contextWithUserID(ctx, updatingUserID), &detail); err != nil {
+		configslog.Log.Error("Sınav ayarları kaydedilemedi", zap.Uint("formID", formID), zap.Error(err))
+		return ErrFormUpdateFailed
+	}
+	configslog.SLog.Infof("Sınav ayarları güncellendi: Form ID %d (Güncelleyen: %d)", formID, updatingUserID)
+	return nil
+}
+
+var _ IFormQuizService = (*FormQuizService)(nil)
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"davet.link/models"
+)
+
+func quizField(id uint, fieldType models.FormFieldType, options, correct string, points int) models.FormFieldDefinition {
+	field := models.FormFieldDefinition{Type: fieldType, Label: "Soru", Options: options, CorrectAnswer: correct, Points: points}
+	field.ID = id
+	return field
+}
+
+func TestQuizAnswerCorrect(t *testing.T) {
+	number := func(n float64) *float64 { return &n }
+	date := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	multi := quizField(1, models.FormFieldCheckbox, "A\nB\nC", "A\nC", 5)
+	tests := []struct {
+		name   string
+		field  models.FormFieldDefinition
+		answer models.FormSubmissionAnswer
+		want   bool
+	}{
+		{"çoklu seçim birebir", multi, models.FormSubmissionAnswer{Value: "C\nA"}, true},
+		{"çoklu seçim harf ve boşluk", multi, models.FormSubmissionAnswer{Value: " a \n  c"}, true},
+		{"çoklu seçim eksik", multi, models.FormSubmissionAnswer{Value: "A"}, false},
+		{"çoklu seçim fazla", multi, models.FormSubmissionAnswer{Value: "A\nB\nC"}, false},
+		{"çoklu seçim yanlış", multi, models.FormSubmissionAnswer{Value: "A\nB"}, false},
+		{"onay kutusu", quizField(1, models.FormFieldCheckbox, "", "Evet", 5), models.FormSubmissionAnswer{Value: checkboxAnswerYes}, true},
+		{"onay kutusu boş", quizField(1, models.FormFieldCheckbox, "", "Evet", 5), models.FormSubmissionAnswer{Value: checkboxAnswerNo}, false},
+		{"virgüllü doğru cevap", quizField(1, models.FormFieldNumber, "", "3,5", 5), models.FormSubmissionAnswer{NumberValue: number(3.5)}, true},
+		{"sayısal eşitlik", quizField(1, models.FormFieldNumber, "", "3.50", 5), models.FormSubmissionAnswer{NumberValue: number(3.5)}, true},
+		{"yanlış sayı", quizField(1, models.FormFieldNumber, "", "3,5", 5), models.FormSubmissionAnswer{NumberValue: number(3.4)}, false},
+		{"sayı olmayan doğru cevap", quizField(1, models.FormFieldNumber, "", "NaN", 5), models.FormSubmissionAnswer{NumberValue: number(0)}, false},
+		{"tarih", quizField(1, models.FormFieldDate, "", "2030-01-02", 5), models.FormSubmissionAnswer{DateValue: &date}, true},
+		{"metin alternatifleri", quizField(1, models.FormFieldText, "", "İstanbul\nAnkara", 5), models.FormSubmissionAnswer{Value: "  ankara "}, true},
+		{"yanlış metin", quizField(1, models.FormFieldText, "", "Ankara", 5), models.FormSubmissionAnswer{Value: "İzmir"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quizAnswerCorrect(tt.field, &tt.answer); got != tt.want {
+				t.Errorf("beklenen %v, alınan %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// Koşulla gizlenen sorular tam puana katılmaz, cevapsız sorular yanlış sayılır ve geçme
+// eşiği yüzdeye eşit puanı kapsar.
+func TestScoreQuiz(t *testing.T) {
+	number := func(n float64) *float64 { return &n }
+	percent := func(p int) *int { return &p }
+	boolean := func(b bool) *bool { return &b }
+	hidden := quizField(3, models.FormFieldNumber, "", "3,5", 5)
+	hidden.Logic = `{"action":"hide","when":{"field":1,"op":"equals","value":"A"}}`
+	fields := []models.FormFieldDefinition{
+		quizField(1, models.FormFieldRadio, "A\nB", "B", 10),
+		quizField(2, models.FormFieldCheckbox, "X\nY\nZ", "X\nY", 5),
+		hidden,
+		quizField(4, models.FormFieldText, "", "", 0), // Puanlanmayan soru
+	}
+	tests := []struct {
+		name         string
+		values       map[uint][]string
+		answers      []models.FormSubmissionAnswer
+		passPercent  *int
+		wantScore    int
+		wantMax      int
+		wantPassed   *bool
+		wantUnscored []uint
+	}{
+		{
+			name:        "tam puan",
+			values:      map[uint][]string{1: {"B"}, 2: {"X", "Y"}, 3: {"3,5"}},
+			answers:     []models.FormSubmissionAnswer{{FieldID: 1, Value: "B"}, {FieldID: 2, Value: "X\nY"}, {FieldID: 3, NumberValue: number(3.5)}, {FieldID: 4, Value: "not"}},
+			passPercent: percent(100),
+			wantScore:   20, wantMax: 20, wantPassed: boolean(true),
+			wantUnscored: []uint{4},
+		},
+		{
+			name:        "gizlenen soru tam puana katılmaz",
+			values:      map[uint][]string{1: {"A"}, 2: {"X", "Y"}, 3: {"7"}},
+			answers:     []models.FormSubmissionAnswer{{FieldID: 1, Value: "A"}, {FieldID: 2, Value: "X\nY"}, {FieldID: 3, NumberValue: number(7)}},
+			passPercent: percent(50),
+			wantScore:   5, wantMax: 15, wantPassed: boolean(false),
+			wantUnscored: []uint{3},
+		},
+		{
+			name:        "cevapsız soru yanlış, eşik sınırda geçer",
+			values:      map[uint][]string{1: {"B"}, 3: {"3,5"}},
+			answers:     []models.FormSubmissionAnswer{{FieldID: 1, Value: "B"}, {FieldID: 3, NumberValue: number(3.5)}},
+			passPercent: percent(75),
+			wantScore:   15, wantMax: 20, wantPassed: boolean(true),
+		},
+		{
+			name:        "eşiğin altında",
+			values:      map[uint][]string{1: {"B"}, 3: {"3,5"}},
+			answers:     []models.FormSubmissionAnswer{{FieldID: 1, Value: "B"}, {FieldID: 3, NumberValue: number(3.5)}},
+			passPercent: percent(76),
+			wantScore:   15, wantMax: 20, wantPassed: boolean(false),
+		},
+		{
+			name:      "eşik yok",
+			values:    map[uint][]string{1: {"B"}},
+			answers:   []models.FormSubmissionAnswer{{FieldID: 1, Value: "B"}},
+			wantScore: 10, wantMax: 20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail := models.FormDetail{IsQuiz: true, QuizPassPercent: tt.passPercent}
+			outcome := scoreQuiz(detail, fields, tt.values, tt.answers, nil)
+			if outcome.score != tt.wantScore || outcome.maxScore != tt.wantMax {
+				t.Errorf("beklenen %d/%d, alınan %d/%d", tt.wantScore, tt.wantMax, outcome.score, outcome.maxScore)
+			}
+			if (outcome.passed == nil) != (tt.wantPassed == nil) || outcome.passed != nil && *outcome.passed != *tt.wantPassed {
+				t.Errorf("geçme durumu beklenen %v, alınan %v", tt.wantPassed, outcome.passed)
+			}
+			for _, answer := range tt.answers {
+				unscored := answer.IsCorrect == nil
+				want := false
+				for _, id := range tt.wantUnscored {
+					want = want || id == answer.FieldID
+				}
+				if unscored != want {
+					t.Errorf("alan %d: puanlanmamış olması beklenen %v, alınan %v", answer.FieldID, want, unscored)
+				}
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"davet.link/configs"
 	"davet.link/configs/configslog"
@@ -85,7 +84,10 @@ func ValidateFormDetail(detail models.FormDetail) error {
 	if detail.LimitPerUser != nil && *detail.LimitPerUser < 0 {
 		return fmt.Errorf("%w: kullanıcı başına limit negatif olamaz", ErrFrmInvalidInput)
 	}
-	// TODO: RedirectURL, NotifyOnSubmitEmail formatları
+	if _, err := ParseRecipients(detail.NotifyOnSubmitEmail); err != nil {
+		return fmt.Errorf("%w: %v", ErrFrmInvalidInput, err)
+	}
+	// TODO: RedirectURL formatı
 	return nil
 }
 
@@ -209,9 +211,8 @@ func (s *FormService) GetFormByKey(ctx context.Context, key string) (*models.For
 	if !form.IsEnabled {
 		return nil, ErrFormNotFound
 	}
-	if form.Detail.ClosesAt != nil && time.Now().UTC().After(*form.Detail.ClosesAt) {
-		return nil, ErrFormNotFound
-	} // Veya ErrFormClosed
+	// Kapanış tarihi, gönderim sınırları ve giriş zorunluluğu burada değil,
+	// FormSubmissionService.GetFormState ile değerlendirilir; sayfa kapalı/dolu durumunu gösterir.
 
 	// TODO: Şifre kontrolü handler'da
 	// TODO: FieldDefinitions Preload edilmeli (repo'da veya burada)
@@ -303,7 +304,13 @@ func (s *FormService) UpdateForm(ctx context.Context, id uint, updatingUserID ui
 		// ... (detailData'dan existingDetail'e tüm alanları kopyala) ...
 		existingDetail.Title = detailData.Title
 		existingDetail.Description = detailData.Description
-		// ... (SubmissionLimit, ClosesAt, vb.) ...
+		existingDetail.SubmissionLimit = detailData.SubmissionLimit
+		existingDetail.LimitPerUser = detailData.LimitPerUser
+		existingDetail.ClosesAt = detailData.ClosesAt
+		existingDetail.RequiresLogin = detailData.RequiresLogin
+		existingDetail.ConfirmationMessage = detailData.ConfirmationMessage
+		existingDetail.RedirectURLOnSubmit = detailData.RedirectURLOnSubmit
+		existingDetail.NotifyOnSubmitEmail = detailData.NotifyOnSubmitEmail
 		// Şifre: Eğer detailData'da hashlenmiş varsa onu kullan, yoksa mevcutu koru.
 		if detailData.PasswordHash != "" {
 			existingDetail.PasswordHash = detailData.PasswordHash
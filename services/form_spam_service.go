@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"davet.link/configs/configsenv"
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/captcha"
+	"davet.link/pkg/ratelimit"
+	"davet.link/pkg/storage"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+// FormSpamServiceError özel servis hataları
+type FormSpamServiceError string
+
+func (e FormSpamServiceError) Error() string { return string(e) }
+
+const (
+	ErrSpamBlocked         FormSpamServiceError = "gönderiminiz otomatik olarak engellendi"
+	ErrSpamTooFast         FormSpamServiceError = "form çok hızlı gönderildi; lütfen birkaç saniye bekleyip tekrar gönderin"
+	ErrSpamPageExpired     FormSpamServiceError = "sayfa doğrulanamadı veya süresi doldu; lütfen formu tekrar gönderin"
+	ErrSpamCaptcha         FormSpamServiceError = "doğrulama cevabı hatalı veya süresi dolmuş; lütfen yeni soruyu cevaplayın"
+	ErrSpamRateLimited     FormSpamServiceError = "çok fazla gönderim yapıldı; lütfen daha sonra tekrar deneyin"
+	ErrSpamSettingsInvalid FormSpamServiceError = "spam koruması ayarları geçersiz"
+)
+
+// FormHoneypotField public formdaki gizli tuzak alanının adı. Alan kullanıcıdan gizlenir;
+// formu otomatik dolduran botlar genellikle bu adı taşıyan alanı doldurur.
+const FormHoneypotField = "website"
+
+const (
+	spamRenderPathFormat  = "/form-render/%d/"
+	spamRenderTokenTTL    = 24 * time.Hour
+	formCaptchaTTL        = 30 * time.Minute
+	formRateWindow        = time.Hour
+	maxSpamMinFillSeconds = 120
+	maxFormRateLimit      = 10000
+	spamStatsRecentDays   = 30
+)
+
+// FormSpamInput public gönderimle gelen spam koruması alanları.
+type FormSpamInput struct {
+	Honeypot      string // Gizli tuzak alanının değeri; insanlar için her zaman boş
+	RenderToken   string // Sayfanın gösterildiği anın imzalı jetonu (süre tuzağı)
+	CaptchaToken  string
+	CaptchaAnswer string
+}
+
+// spamBackend tüm servis örneklerinin paylaştığı gönderim sayaçları ve doğrulayıcılardır.
+// Doğrulama anahtarı FORM_CAPTCHA_SECRET'tan okunur (bkz. envSecret).
+var spamBackend = struct {
+	once     sync.Once
+	limiter  *ratelimit.Limiter
+	captchas map[string]captcha.Captcha
+}{}
+
+func initSpamBackend() {
+	spamBackend.once.Do(func() {
+		secret := envSecret("FORM_CAPTCHA_SECRET", "açık formlardaki doğrulamalar yeniden başlatmada geçersiz olacak")
+		difficulty := configsenv.GetEnvAsInt("FORM_CAPTCHA_POW_DIFFICULTY", captcha.DefaultDifficulty)
+		spamBackend.limiter = ratelimit.New()
+		spamBackend.captchas = map[string]captcha.Captcha{
+			captcha.KindMath:        captcha.NewMath(secret, formCaptchaTTL),
+			captcha.KindProofOfWork: captcha.NewProofOfWork(secret, formCaptchaTTL, difficulty),
+		}
+	})
+}
+
+// formCaptcha formda seçili doğrulayıcıyı döndürür; doğrulama kapalıysa nil.
+func formCaptcha(detail models.FormDetail) captcha.Captcha {
+	if detail.CaptchaType == "" {
+		return nil
+	}
+	initSpamBackend()
+	return spamBackend.captchas[detail.CaptchaType]
+}
+
+// SpamToken sayfadaki gizli alana konan, sayfanın gösterildiği anın imzalı jetonu.
+func (v *FormPageView) SpamToken() string {
+	if v.Form == nil {
+		return ""
+	}
+	now := time.Now().UTC()
+	return signTimeToken(fmt.Sprintf(spamRenderPathFormat, v.Form.ID), now, now.Add(spamRenderTokenTTL))
+}
+
+// CaptchaChallenge formun son sayfasında gösterilecek doğrulama; doğrulama kapalıysa nil.
+func (v *FormPageView) CaptchaChallenge() *captcha.Challenge {
+	if v.Form == nil || !v.IsLastPage {
+		return nil
+	}
+	verifier := formCaptcha(v.Form.Detail)
+	if verifier == nil {
+		return nil
+	}
+	challenge, err := verifier.Challenge(time.Now())
+	if err != nil {
+		configslog.Log.Error("Form doğrulaması üretilemedi", zap.Uint("formID", v.Form.ID), zap.Error(err))
+		return nil
+	}
+	return &challenge
+}
+
+// checkSpam gönderimi sırayla tuzak alanı, süre tuzağı, doğrulama ve saatlik sınırlarla
+// denetler. Sınır sayaçları yalnızca diğer kontrolleri geçen gönderimlerde artar; böylece
+// botların reddedilen denemeleri gerçek kullanıcıların hakkını tüketmez.
+func (s *FormSubmissionService) checkSpam(ctx context.Context, form *models.Form, input FormSubmissionInput, now time.Time) error {
+	detail := form.Detail
+	if strings.TrimSpace(input.Spam.Honeypot) != "" {
+		return s.blockSpam(ctx, form, models.FormSpamHoneypot, ErrSpamBlocked, now)
+	}
+	if detail.SpamMinFillSeconds > 0 {
+		renderedAt, err := parseTimeToken(fmt.Sprintf(spamRenderPathFormat, form.ID), input.Spam.RenderToken, now)
+		if err != nil {
+			if errors.Is(err, storage.ErrURLExpired) {
+				return ErrSpamPageExpired // Uzun süre açık kalan sayfa; sayılmaz
+			}
+			return s.blockSpam(ctx, form, models.FormSpamTimeTrap, ErrSpamPageExpired, now)
+		}
+		if now.Sub(renderedAt) < time.Duration(detail.SpamMinFillSeconds)*time.Second {
+			return s.blockSpam(ctx, form, models.FormSpamTimeTrap, ErrSpamTooFast, now)
+		}
+	}
+	if verifier := formCaptcha(detail); verifier != nil {
+		if err := verifier.Verify(input.Spam.CaptchaToken, input.Spam.CaptchaAnswer, now); err != nil {
+			return s.blockSpam(ctx, form, models.FormSpamCaptcha, ErrSpamCaptcha, now)
+		}
+	}
+
+	initSpamBackend()
+	ipRule := ratelimit.Rule{Key: fmt.Sprintf("form:%d:ip:%s", form.ID, input.IPAddress), Limit: detail.RateLimitPerIP, Window: formRateWindow}
+	formRule := ratelimit.Rule{Key: fmt.Sprintf("form:%d", form.ID), Limit: detail.RateLimitPerForm, Window: formRateWindow}
+	if blocked, ok := spamBackend.limiter.Allow(now, ipRule, formRule); !ok {
+		reason := models.FormSpamRateIP
+		if blocked.Key == formRule.Key {
+			reason = models.FormSpamRateForm
+		}
+		return s.blockSpam(ctx, form, reason, ErrSpamRateLimited, now)
+	}
+	return nil
+}
+
+// blockSpam engellenen denemeyi sayar ve verilen hatayı döndürür. Sayaç hatası engeli kaldırmaz.
+func (s *FormSubmissionService) blockSpam(ctx context.Context, form *models.Form, reason models.FormSpamReason, blockErr error, now time.Time) error {
+	configslog.Log.Info("Form gönderimi engellendi", zap.Uint("formID", form.ID), zap.String("reason", string(reason)))
+	// Public işlem: BaseModel hook'ları için aktör olarak form sahibi kullanılır.
+	if err := s.spamRepo.IncrementBlocked(contextWithUserID(ctx, form.CreatorUserID), form.ID, reason, analyticsDay(now)); err != nil {
+		configslog.Log.Warn("Engellenen gönderim sayılamadı", zap.Uint("formID", form.ID), zap.Error(err))
+	}
+	return blockErr
+}
+
+// --- Panel ---
+
+// FormSpamSettings panelden gelen spam koruması ayarları.
+type FormSpamSettings struct {
+	MinFillSeconds   int
+	RateLimitPerIP   int
+	RateLimitPerForm int
+	CaptchaType      string
+}
+
+// ValidateFormSpamSettings ayarların sınırlarını denetler.
+func ValidateFormSpamSettings(settings *FormSpamSettings) error {
+	if settings.MinFillSeconds < 0 || settings.MinFillSeconds > maxSpamMinFillSeconds {
+		return fmt.Errorf("%w: en kısa doldurma süresi 0 ile %d saniye arasında olmalıdır", ErrSpamSettingsInvalid, maxSpamMinFillSeconds)
+	}
+	if settings.RateLimitPerIP < 0 || settings.RateLimitPerIP > maxFormRateLimit ||
+		settings.RateLimitPerForm < 0 || settings.RateLimitPerForm > maxFormRateLimit {
+		return fmt.Errorf("%w: gönderim sınırları 0 ile %d arasında olmalıdır", ErrSpamSettingsInvalid, maxFormRateLimit)
+	}
+	switch settings.CaptchaType {
+	case "", captcha.KindMath, captcha.KindProofOfWork:
+	default:
+		return fmt.Errorf("%w: doğrulama türü tanınmıyor", ErrSpamSettingsInvalid)
+	}
+	return nil
+}
+
+// FormSpamReportRow bir engelleme sebebinin son günlerdeki ve toplam sayısı.
+type FormSpamReportRow struct {
+	Reason models.FormSpamReason
+	Recent int64
+	Total  int64
+}
+
+// FormSpamReport formda engellenen denemelerin özeti.
+type FormSpamReport struct {
+	RecentDays  int
+	Rows        []FormSpamReportRow
+	RecentTotal int64
+	Total       int64
+}
+
+// IFormSpamService panelde spam koruması ayarları ve engelleme sayıları için arayüz.
+type IFormSpamService interface {
+	GetReport(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, *FormSpamReport, error)
+	UpdateSettings(ctx context.Context, formID uint, updatingUserID uint, settings FormSpamSettings) error
+}
+
+// FormSpamService IFormSpamService arayüzünü uygular.
+type FormSpamService struct {
+	repo         repositories.IFormSpamRepository
+	formRepo     repositories.IFormRepository
+	fieldService IFormFieldService
+}
+
+// NewFormSpamService yeni bir FormSpamService örneği oluşturur.
+func NewFormSpamService() IFormSpamService {
+	return &FormSpamService{
+		repo:         repositories.NewFormSpamRepository(),
+		formRepo:     repositories.NewFormRepository(),
+		fieldService: NewFormFieldService(),
+	}
+}
+
+// GetReport formu yetkiyle getirir ve engellenen denemeleri sebebe göre özetler.
+func (s *FormSpamService) GetReport(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, *FormSpamReport, error) {
+	form, _, err := s.fieldService.GetFields(ctx, formID, requestingUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	total, err := s.repo.BlockedCounts(ctx, form.ID, time.Time{})
+	if err != nil {
+		return nil, nil, err
+	}
+	recent, err := s.repo.BlockedCounts(ctx, form.ID, analyticsDay(time.Now().AddDate(0, 0, -(spamStatsRecentDays-1))))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &FormSpamReport{RecentDays: spamStatsRecentDays}
+	rows := make(map[models.FormSpamReason]*FormSpamReportRow, len(models.FormSpamReasons))
+	for _, reason := range models.FormSpamReasons {
+		report.Rows = append(report.Rows, FormSpamReportRow{Reason: reason})
+	}
+	for i := range report.Rows {
+		rows[report.Rows[i].Reason] = &report.Rows[i]
+	}
+	for _, count := range total {
+		if row, ok := rows[count.Reason]; ok {
+			row.Total = count.Count
+			report.Total += count.Count
+		}
+	}
+	for _, count := range recent {
+		if row, ok := rows[count.Reason]; ok {
+			row.Recent = count.Count
+			report.RecentTotal += count.Count
+		}
+	}
+	return form, report, nil
+}
+
+// UpdateSettings formun spam koruması ayarlarını kaydeder.
+func (s *FormSpamService) UpdateSettings(ctx context.Context, formID uint, updatingUserID uint, settings FormSpamSettings) error {
+	form, _, err := s.fieldService.GetFields(ctx, formID, updatingUserID)
+	if err != nil {
+		return err
+	}
+	if err := ValidateFormSpamSettings(&settings); err != nil {
+		return err
+	}
+
+	detail := form.Detail
+	detail.SpamMinFillSeconds = settings.MinFillSeconds
+	detail.RateLimitPerIP = settings.RateLimitPerIP
+	detail.RateLimitPerForm = settings.RateLimitPerForm
+	detail.CaptchaType = settings.CaptchaType
+	if err := s.formRepo.UpdateDetail(contextWithUserID(ctx, updatingUserID), &detail); err != nil {
+		configslog.Log.Error("Spam koruması ayarları kaydedilemedi", zap.Uint("formID", formID), zap.Error(err))
+		return ErrFormUpdateFailed
+	}
+	configslog.SLog.Infof("Spam koruması ayarları güncellendi: Form ID %d (Güncelleyen: %d)", formID, updatingUserID)
+	return nil
+}
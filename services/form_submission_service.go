@@ -0,0 +1,964 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"davet.link/configs"
+	"davet.link/configs/configsenv"
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/queryparams"
+	"davet.link/pkg/storage"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// FormSubmissionServiceError özel servis hataları
+type FormSubmissionServiceError string
+
+func (e FormSubmissionServiceError) Error() string { return string(e) }
+
+const (
+	ErrSubmissionNotFound     FormSubmissionServiceError = "form gönderimi bulunamadı"
+	ErrSubmissionInvalid      FormSubmissionServiceError = "form eksik veya hatalı dolduruldu"
+	ErrSubmissionSaveFailed   FormSubmissionServiceError = "form gönderilemedi"
+	ErrSubmissionForbidden    FormSubmissionServiceError = "bu işlem için yetkiniz yok"
+	ErrSubmissionFormNotReady FormSubmissionServiceError = "bu formda henüz doldurulacak alan yok"
+	ErrSubmissionClosed       FormSubmissionServiceError = "bu form yanıtlara kapandı"
+	ErrSubmissionFull         FormSubmissionServiceError = "bu form yanıt sınırına ulaştı"
+	ErrSubmissionUserLimit    FormSubmissionServiceError = "bu formu doldurma hakkınızı kullandınız"
+	ErrSubmissionLoginNeeded  FormSubmissionServiceError = "bu formu doldurmak için giriş yapmalısınız"
+	ErrSubmissionFileNotFound FormSubmissionServiceError = "dosya bulunamadı"
+	ErrSubmissionFileLink     FormSubmissionServiceError = "indirme bağlantısı geçersiz veya süresi dolmuş"
+	ErrSubmissionFormChanged  FormSubmissionServiceError = "form siz doldururken değiştirildi; lütfen cevaplarınızı kontrol edip tekrar gönderin"
+)
+
+// FormState public form sayfasının gönderen için durumudur.
+type FormState string
+
+const (
+	FormStateOpen          FormState = "open"           // Gönderime açık
+	FormStateClosed        FormState = "closed"         // ClosesAt geçti
+	FormStateFull          FormState = "full"           // SubmissionLimit doldu
+	FormStateUserLimit     FormState = "user_limit"     // Gönderen LimitPerUser hakkını kullandı
+	FormStateLoginRequired FormState = "login_required" // RequiresLogin ve oturum yok
+	FormStateQuizTimeUp    FormState = "quiz_time_up"   // Sınav süresi doldu (yalnızca gönderim sonucunda)
+)
+
+// stateErrors kapalı durumların gönderimde döndürülen hataları.
+var stateErrors = map[FormState]error{
+	FormStateClosed:        ErrSubmissionClosed,
+	FormStateFull:          ErrSubmissionFull,
+	FormStateUserLimit:     ErrSubmissionUserLimit,
+	FormStateLoginRequired: ErrSubmissionLoginNeeded,
+}
+
+// FormRespondent formu dolduran kişidir. UserID giriş yapmış kullanıcıyı, Key ise kişi başı
+// sınırın uygulandığı anahtarı tutar (kullanıcı veya anonim çerez / parmak izi özeti).
+// Fingerprint anonim gönderenin IP ve tarayıcı özetidir; kişi başı sınır Key ve Fingerprint
+// için birlikte uygulanır. Giriş yapmış kullanıcılarda boştur.
+type FormRespondent struct {
+	UserID      uint
+	Key         string
+	Fingerprint string
+}
+
+// Cevap uzunluk sınırları (karakter); alanda MaxLength tanımlıysa o kullanılır.
+const (
+	maxShortAnswerLength = 500
+	maxLongAnswerLength  = 5000
+	maxUserAgentLength   = 500
+)
+
+// Telefon cevabında kabul edilen rakam sayısı aralığı.
+const (
+	minPhoneDigits = 7
+	maxPhoneDigits = 15
+)
+
+// FormUpload dosya alanına yüklenen tek bir dosyadır. İçerik yalnızca doğrulama ve
+// depolama sırasında Open ile okunur.
+type FormUpload struct {
+	FileName string
+	Size     int64
+	Open     func() (io.ReadCloser, error)
+}
+
+// FormSubmissionInput public form gönderiminden gelen veriler.
+type FormSubmissionInput struct {
+	Values     map[uint][]string     // Alan ID -> değerler (çoklu seçimde birden fazla)
+	Files      map[uint][]FormUpload // Dosya alanı ID -> yüklenen dosyalar
+	Respondent FormRespondent
+	IPAddress  string
+	UserAgent  string
+	Spam       FormSpamInput
+	Prefill    url.Values // Bağlantıdan gelen ön doldurma değerleri (bkz. FormPrefill)
+}
+
+// Dosya indirme bağlantıları.
+const (
+	fileDownloadPathFormat = "/files/forms/%d"
+	defaultFileURLTTL      = 15 * time.Minute
+)
+
+// uploadContainerTypes içerikten yalnızca genel tipi (zip, OLE, düz metin) tespit edilebilen
+// belge biçimlerinde uzantıya göre kesin tipi verir. Uzantı, tespit edilen kap tipiyle
+// uyuşmuyorsa kullanılmaz; böylece adı değiştirilmiş çalıştırılabilir dosyalar belge sayılmaz.
+var uploadContainerTypes = map[string][2]string{
+	".docx": {"application/zip", "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+	".xlsx": {"application/zip", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+	".pptx": {"application/zip", "application/vnd.openxmlformats-officedocument.presentationml.presentation"},
+	".odt":  {"application/zip", "application/vnd.oasis.opendocument.text"},
+	".ods":  {"application/zip", "application/vnd.oasis.opendocument.spreadsheet"},
+	".doc":  {"application/octet-stream", "application/msword"},
+	".xls":  {"application/octet-stream", "application/vnd.ms-excel"},
+	".csv":  {"text/plain", "text/csv"},
+}
+
+// envSecret imza anahtarını ortam değişkeninden okur. Tanımlı değilse süreç başına rastgele
+// bir anahtar üretilir ve uyarı loglanır; yeniden başlatmada o anahtarla verilen imzalar
+// geçersiz olur. Tüm imza anahtarları (FILE_URL_SECRET, FORM_SIGNING_SECRET,
+// FORM_CAPTCHA_SECRET) aynı kuralla okunur.
+func envSecret(key string, effect string) []byte {
+	if secret := configsenv.GetEnvWithDefault(key, ""); secret != "" {
+		return []byte(secret)
+	}
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	configslog.SLog.Warnf("%s tanımlı değil; %s", key, effect)
+	return secret
+}
+
+// uploadBackend tüm servis örneklerinin paylaştığı depo ve bağlantı imzalayıcısıdır.
+// İmza anahtarı FILE_URL_SECRET'tan okunur (bkz. envSecret).
+var uploadBackend = struct {
+	once    sync.Once
+	store   storage.Storage
+	signer  *storage.URLSigner
+	linkTTL time.Duration
+}{}
+
+func initUploadBackend() {
+	uploadBackend.once.Do(func() {
+		store, err := storage.New()
+		if err != nil {
+			configslog.Log.Error("Dosya deposu başlatılamadı; dosya yüklemeleri kapalı", zap.Error(err))
+		} else {
+			uploadBackend.store = store
+		}
+		uploadBackend.signer = storage.NewURLSigner(envSecret("FILE_URL_SECRET", "indirme bağlantıları yeniden başlatmada geçersiz olacak"))
+		uploadBackend.linkTTL = time.Duration(configsenv.GetEnvAsInt("FILE_URL_TTL_MINUTES", int(defaultFileURLTTL/time.Minute))) * time.Minute
+	})
+}
+
+// formSigning ön doldurma bağlantılarını ve süre tuzağı jetonlarını imzalayan, dosya
+// bağlantılarından ayrı anahtardır. Anahtar FORM_SIGNING_SECRET'tan okunur (bkz. envSecret).
+var formSigning = struct {
+	once   sync.Once
+	signer *storage.URLSigner
+}{}
+
+// formSigner form imzalayıcısını döndürür.
+func formSigner() *storage.URLSigner {
+	formSigning.once.Do(func() {
+		formSigning.signer = storage.NewURLSigner(envSecret("FORM_SIGNING_SECRET", "ön doldurma bağlantıları ve açık form sayfaları yeniden başlatmada geçersiz olacak"))
+	})
+	return formSigning.signer
+}
+
+// signTimeToken bir anı form imzalayıcısıyla imzalar. Jeton "unix?expires=..&signature=.."
+// biçimindedir; prefix (örn. "/form-render/12/") imzaya dahildir, böylece bir amaçla verilen
+// jeton başka bir amaçta veya başka bir formda geçmez.
+func signTimeToken(prefix string, at time.Time, expiresAt time.Time) string {
+	return strings.TrimPrefix(formSigner().Sign(prefix+strconv.FormatInt(at.Unix(), 10), expiresAt), prefix)
+}
+
+// parseTimeToken signTimeToken ile üretilen jetonu doğrular ve imzalanan anı döndürür. İmzası
+// geçerli ama süresi dolmuş jetonda an storage.ErrURLExpired ile birlikte döner.
+func parseTimeToken(prefix string, token string, now time.Time) (time.Time, error) {
+	unixText, query, ok := strings.Cut(token, "?")
+	unix, err := strconv.ParseInt(unixText, 10, 64)
+	if !ok || err != nil {
+		return time.Time{}, storage.ErrInvalidSignature
+	}
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return time.Time{}, storage.ErrInvalidSignature
+	}
+	at := time.Unix(unix, 0).UTC()
+	if at.After(now.Add(time.Minute)) {
+		return time.Time{}, storage.ErrInvalidSignature
+	}
+	err = formSigner().Verify(prefix+unixText, params.Get("expires"), params.Get("signature"), now)
+	if err != nil && !errors.Is(err, storage.ErrURLExpired) {
+		return time.Time{}, err
+	}
+	return at, err
+}
+
+// IFormSubmissionService form gönderimleri için arayüz.
+type IFormSubmissionService interface {
+	GetFormState(ctx context.Context, form *models.Form, respondent FormRespondent) (FormState, error)
+	SubmitForm(ctx context.Context, key string, input FormSubmissionInput) (*models.Form, *models.FormSubmission, error)
+	GetSubmissions(ctx context.Context, formID uint, requestingUserID uint, params queryparams.ListParams) (*models.Form, []models.FormFieldDefinition, *queryparams.PaginatedResult, error)
+	GetSubmission(ctx context.Context, id uint, requestingUserID uint) (*models.Form, *models.FormSubmission, error)
+	GetSubmissionAnswers(ctx context.Context, submission *models.FormSubmission) ([]FormSubmissionAnswerRow, error)
+	FileURL(fileID uint) string
+	OpenFile(ctx context.Context, fileID uint, expires string, signature string) (*models.FormSubmissionFile, io.ReadCloser, error)
+}
+
+// FormSubmissionService IFormSubmissionService arayüzünü uygular.
+type FormSubmissionService struct {
+	repo        repositories.IFormSubmissionRepository
+	fieldRepo   repositories.IFormFieldRepository
+	formService IFormService
+	db          *gorm.DB           // Transaction ve sınır kilidi için
+	storage     storage.Storage    // nil ise dosya yüklemeleri kabul edilmez
+	signer      *storage.URLSigner // Dosya indirme bağlantıları için
+	fileURLTTL  time.Duration
+	spamRepo    repositories.IFormSpamRepository        // Engellenen deneme sayaçları
+	quizRepo    repositories.IFormQuizAttemptRepository // Sınav başlangıç kayıtları
+}
+
+// NewFormSubmissionService yeni bir FormSubmissionService örneği oluşturur.
+func NewFormSubmissionService() IFormSubmissionService {
+	return newFormSubmissionService()
+}
+
+// newFormSubmissionService taslak servisinin de kullandığı somut örneği oluşturur.
+func newFormSubmissionService() *FormSubmissionService {
+	initUploadBackend()
+	formSigner() // Anahtar eksikse açılışta uyarı loglanır
+	return &FormSubmissionService{
+		repo:        repositories.NewFormSubmissionRepository(),
+		fieldRepo:   repositories.NewFormFieldRepository(),
+		formService: NewFormService(),
+		db:          configs.GetDB(),
+		storage:     uploadBackend.store,
+		signer:      uploadBackend.signer,
+		fileURLTTL:  uploadBackend.linkTTL,
+		spamRepo:    repositories.NewFormSpamRepository(),
+		quizRepo:    repositories.NewFormQuizAttemptRepository(),
+	}
+}
+
+// --- Yardımcı Metodlar ---
+
+// normalizeSubmissionParams sayfalama parametrelerini varsayılanlara ve sınırlara çeker.
+func normalizeSubmissionParams(params *queryparams.ListParams) {
+	if params.Page <= 0 {
+		params.Page = queryparams.DefaultPage
+	}
+	if params.PerPage <= 0 || params.PerPage > queryparams.MaxPerPage {
+		params.PerPage = queryparams.DefaultPerPage
+	}
+	if params.SortBy == "" {
+		params.SortBy = "submitted_at"
+	}
+	if params.OrderBy != "asc" && params.OrderBy != "desc" {
+		params.OrderBy = queryparams.DefaultOrderBy
+	}
+}
+
+// containsOption değerin alanın seçeneklerinden biri olup olmadığını bildirir.
+func containsOption(options []string, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePhone telefon cevabında yalnızca rakam ve yaygın ayırıcıların bulunduğunu doğrular.
+func validatePhone(value string) bool {
+	digits := 0
+	for _, r := range value {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+		case strings.ContainsRune("+ ()-.", r):
+		default:
+			return false
+		}
+	}
+	return digits >= minPhoneDigits && digits <= maxPhoneDigits
+}
+
+// validateTextAnswer metin cevaplarını tipe ve alanın uzunluk/desen kurallarına göre doğrular.
+func validateTextAnswer(field models.FormFieldDefinition, value string) error {
+	length := utf8.RuneCountInString(value)
+	maxLength := maxShortAnswerLength
+	if field.Type == models.FormFieldTextarea {
+		maxLength = maxLongAnswerLength
+	}
+	if field.MaxLength != nil && *field.MaxLength < maxLength {
+		maxLength = *field.MaxLength
+	}
+	if length > maxLength {
+		return fmt.Errorf("%w: %q en fazla %d karakter olabilir", ErrSubmissionInvalid, field.Label, maxLength)
+	}
+	if field.MinLength != nil && length < *field.MinLength {
+		return fmt.Errorf("%w: %q en az %d karakter olmalıdır", ErrSubmissionInvalid, field.Label, *field.MinLength)
+	}
+	switch field.Type {
+	case models.FormFieldEmail:
+		if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+			return fmt.Errorf("%w: %q geçerli bir e-posta adresi olmalıdır", ErrSubmissionInvalid, field.Label)
+		}
+	case models.FormFieldPhone:
+		if !validatePhone(value) {
+			return fmt.Errorf("%w: %q geçerli bir telefon numarası olmalıdır", ErrSubmissionInvalid, field.Label)
+		}
+	}
+	if field.Pattern != "" {
+		// Tarayıcıdaki pattern özniteliği gibi değerin tamamı eşleşmelidir.
+		re, err := regexp.Compile("^(?:" + field.Pattern + ")$")
+		if err == nil && !re.MatchString(value) {
+			return fmt.Errorf("%w: %q istenen biçimde değil", ErrSubmissionInvalid, field.Label)
+		}
+	}
+	return nil
+}
+
+// ValidateFormAnswers public formdan gelen değerleri (alan ID -> değerler) formun alan
+// tanımlarına göre doğrular ve kaydedilecek tipli cevap listesini döndürür.
+// Tanımlı olmayan alanlara gönderilen değerler yok sayılır.
+func ValidateFormAnswers(fields []models.FormFieldDefinition, values map[uint][]string) ([]models.FormSubmissionAnswer, error) {
+	return validateFormAnswers(fields, values, nil)
+}
+
+// ValidateFormPage çok sayfalı formda yalnızca verilen sayfanın alanlarını doğrular.
+// Görünürlük tüm formun değerleriyle hesaplanır; önceki sayfalardaki cevaplar koşulları etkiler.
+func ValidateFormPage(fields []models.FormFieldDefinition, page FormPage, values map[uint][]string) error {
+	only := make(map[uint]bool, len(page.Fields))
+	for _, field := range page.Fields {
+		only[field.ID] = true
+	}
+	_, err := validateFormAnswers(fields, values, only)
+	return err
+}
+
+// validateFormAnswers ValidateFormAnswers'ın gövdesidir; only nil değilse yalnızca oradaki alanlar doğrulanır.
+func validateFormAnswers(fields []models.FormFieldDefinition, values map[uint][]string, only map[uint]bool) ([]models.FormSubmissionAnswer, error) {
+	result := make([]models.FormSubmissionAnswer, 0, len(fields))
+	// Koşulla gizlenen alanlar zorunlu olsa da kontrol edilmez ve cevapları saklanmaz.
+	visible := VisibleFormFields(fields, values)
+	for _, field := range fields {
+		if !field.IsInput() || !visible[field.ID] || only != nil && !only[field.ID] {
+			continue
+		}
+		var raw []string
+		for _, v := range values[field.ID] {
+			if v = strings.TrimSpace(v); v != "" {
+				raw = append(raw, v)
+			}
+		}
+		answer := models.FormSubmissionAnswer{FieldID: field.ID, FieldLabel: field.Label, FieldType: field.Type, SortOrder: field.SortOrder}
+
+		// Seçeneksiz onay kutusu her zaman evet/hayır olarak kaydedilir.
+		if field.Type == models.FormFieldCheckbox && !field.HasOptions() {
+			checked := len(raw) > 0 && (raw[0] == "true" || raw[0] == "on" || raw[0] == "1")
+			if field.Required && !checked {
+				return nil, fmt.Errorf("%w: %q işaretlenmelidir", ErrSubmissionInvalid, field.Label)
+			}
+			answer.BoolValue = &checked
+			answer.Value = checkboxAnswerNo
+			if checked {
+				answer.Value = checkboxAnswerYes
+			}
+			result = append(result, answer)
+			continue
+		}
+
+		if len(raw) == 0 {
+			if field.Required {
+				return nil, fmt.Errorf("%w: %q alanı zorunludur", ErrSubmissionInvalid, field.Label)
+			}
+			continue
+		}
+		if field.Type == models.FormFieldFile && len(raw) > field.FileCountLimit() {
+			return nil, fmt.Errorf("%w: %q için en fazla %d dosya yüklenebilir", ErrSubmissionInvalid, field.Label, field.FileCountLimit())
+		}
+		if field.Type != models.FormFieldCheckbox && field.Type != models.FormFieldFile && len(raw) > 1 {
+			return nil, fmt.Errorf("%w: %q için tek bir değer girilmelidir", ErrSubmissionInvalid, field.Label)
+		}
+		value := raw[0]
+
+		switch field.Type {
+		case models.FormFieldText, models.FormFieldTextarea, models.FormFieldEmail, models.FormFieldPhone, models.FormFieldHidden:
+			if err := validateTextAnswer(field, value); err != nil {
+				return nil, err
+			}
+			answer.Value = value
+		case models.FormFieldNumber:
+			n, ok := ParseFormNumber(value)
+			if !ok {
+				return nil, fmt.Errorf("%w: %q bir sayı olmalıdır", ErrSubmissionInvalid, field.Label)
+			}
+			if field.MinValue != nil && n < *field.MinValue || field.MaxValue != nil && n > *field.MaxValue {
+				return nil, fmt.Errorf("%w: %q izin verilen aralığın dışında", ErrSubmissionInvalid, field.Label)
+			}
+			answer.NumberValue = &n
+			answer.Value = strconv.FormatFloat(n, 'f', -1, 64)
+		case models.FormFieldRating:
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > field.RatingScale() {
+				return nil, fmt.Errorf("%w: %q için 1 ile %d arasında puan verilmelidir", ErrSubmissionInvalid, field.Label, field.RatingScale())
+			}
+			score := float64(n)
+			answer.NumberValue = &score
+			answer.Value = strconv.Itoa(n)
+		case models.FormFieldDate:
+			d, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q geçerli bir tarih olmalıdır", ErrSubmissionInvalid, field.Label)
+			}
+			answer.DateValue = &d
+			answer.Value = d.Format("02.01.2006")
+		case models.FormFieldSelect, models.FormFieldRadio:
+			if !containsOption(field.OptionList(), value) {
+				return nil, fmt.Errorf("%w: %q için geçersiz seçim", ErrSubmissionInvalid, field.Label)
+			}
+			answer.Value = value
+		case models.FormFieldCheckbox:
+			options := field.OptionList()
+			var selected []string
+			for _, v := range raw {
+				if !containsOption(options, v) {
+					return nil, fmt.Errorf("%w: %q için geçersiz seçim", ErrSubmissionInvalid, field.Label)
+				}
+				if !containsOption(selected, v) {
+					selected = append(selected, v)
+				}
+			}
+			answer.Value = strings.Join(selected, "\n")
+		case models.FormFieldFile:
+			// Dosya adları okunabilir cevap olarak saklanır; içerik SubmitForm'da depoya yazılır.
+			answer.Value = strings.Join(raw, "\n")
+		default:
+			continue
+		}
+		result = append(result, answer)
+	}
+	return result, nil
+}
+
+// uploadContentType dosyanın tipini ilk baytlarından tespit eder; gönderenin bildirdiği
+// tipe güvenilmez. Genel kap tiplerinde uploadContainerTypes ile uzantıya bakılır.
+func uploadContentType(head []byte, fileName string) string {
+	detected := http.DetectContentType(head)
+	if i := strings.IndexByte(detected, ';'); i >= 0 {
+		detected = detected[:i]
+	}
+	if mapped, ok := uploadContainerTypes[strings.ToLower(filepath.Ext(fileName))]; ok && mapped[0] == detected {
+		return mapped[1]
+	}
+	return detected
+}
+
+// uploadTypeAllowed tipin alanın kabul listesinde olup olmadığını bildirir ("image/*" gibi
+// joker tipler desteklenir). Liste boşsa her tip kabul edilir.
+func uploadTypeAllowed(accept []string, contentType string) bool {
+	if len(accept) == 0 {
+		return true
+	}
+	for _, allowed := range accept {
+		if allowed == contentType || strings.HasSuffix(allowed, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// safeFileName depo anahtarında kullanılacak dosya adını üretir (harf, rakam, nokta, tire).
+func safeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+		if b.Len() >= 100 {
+			break
+		}
+	}
+	safe := strings.Trim(b.String(), "._")
+	if safe == "" {
+		return "dosya"
+	}
+	return safe
+}
+
+// storeUpload tek bir dosyayı doğrulayıp depoya yazar ve kaydını döndürür.
+func (s *FormSubmissionService) storeUpload(ctx context.Context, formID uint, field models.FormFieldDefinition, upload FormUpload) (*models.FormSubmissionFile, error) {
+	if upload.Size <= 0 {
+		return nil, fmt.Errorf("%w: %q için boş dosya yüklenemez", ErrSubmissionInvalid, field.Label)
+	}
+	if upload.Size > field.FileSizeLimit() {
+		return nil, fmt.Errorf("%w: %q için dosya en fazla %d MB olabilir", ErrSubmissionInvalid, field.Label, field.FileSizeLimit()>>20)
+	}
+	f, err := upload.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+	contentType := uploadContentType(head, upload.FileName)
+	if !uploadTypeAllowed(field.AcceptList(), contentType) {
+		return nil, fmt.Errorf("%w: %q için bu dosya türü kabul edilmiyor (%s)", ErrSubmissionInvalid, field.Label, field.AcceptTypes)
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("forms/%d/%s/%s", formID, hex.EncodeToString(token), safeFileName(upload.FileName))
+	if err := s.storage.Put(ctx, key, io.MultiReader(bytes.NewReader(head), f), upload.Size, contentType); err != nil {
+		return nil, err
+	}
+
+	fileName := strings.TrimSpace(filepath.Base(strings.ReplaceAll(upload.FileName, "\\", "/")))
+	if utf8.RuneCountInString(fileName) > 255 {
+		fileName = string([]rune(fileName)[:255])
+	}
+	return &models.FormSubmissionFile{
+		FormID:      formID,
+		FieldID:     field.ID,
+		StorageKey:  key,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        upload.Size,
+	}, nil
+}
+
+// storeUploads görünür dosya alanlarının dosyalarını depoya yazar ve cevaplara bağlar.
+// Hata olursa o ana kadar yazılan dosyalar silinir; başarıda yazılan anahtarlar döner
+// (kayıt başarısız olursa temizlik için).
+func (s *FormSubmissionService) storeUploads(ctx context.Context, formID uint, fields []models.FormFieldDefinition, answers []models.FormSubmissionAnswer, files map[uint][]FormUpload) ([]string, error) {
+	byID := make(map[uint]models.FormFieldDefinition, len(fields))
+	for _, field := range fields {
+		byID[field.ID] = field
+	}
+	var keys []string
+	for i := range answers {
+		if answers[i].FieldType != models.FormFieldFile {
+			continue
+		}
+		field := byID[answers[i].FieldID]
+		uploads := files[field.ID]
+		if len(uploads) == 0 {
+			s.deleteUploads(ctx, keys)
+			return nil, fmt.Errorf("%w: %q için dosya yüklenmelidir", ErrSubmissionInvalid, field.Label)
+		}
+		if s.storage == nil {
+			s.deleteUploads(ctx, keys)
+			return nil, errors.New("dosya deposu yapılandırılmamış")
+		}
+		for _, upload := range uploads {
+			file, err := s.storeUpload(ctx, formID, field, upload)
+			if err != nil {
+				s.deleteUploads(ctx, keys)
+				return nil, err
+			}
+			keys = append(keys, file.StorageKey)
+			answers[i].Files = append(answers[i].Files, *file)
+		}
+	}
+	return keys, nil
+}
+
+// deleteUploads kaydı tamamlanamayan gönderimin dosyalarını depodan siler.
+func (s *FormSubmissionService) deleteUploads(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.storage.Delete(context.WithoutCancel(ctx), key); err != nil {
+			configslog.Log.Warn("Yarım kalan gönderimin dosyası silinemedi", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+// positiveLimit nil veya sıfır olmayan sınırı döndürür; sıfır sınırsız anlamına gelir.
+func positiveLimit(limit *int) (int, bool) {
+	if limit == nil || *limit <= 0 {
+		return 0, false
+	}
+	return *limit, true
+}
+
+// formState formun gönderen için durumunu hesaplar. Sayımlar verilen repository ile yapılır;
+// gönderim sırasında transaction içindeki repository kullanılarak kilit altında tekrarlanır.
+func formState(ctx context.Context, repo repositories.IFormSubmissionRepository, form *models.Form, respondent FormRespondent, now time.Time) (FormState, error) {
+	detail := form.Detail
+	if detail.ClosesAt != nil && !now.Before(*detail.ClosesAt) {
+		return FormStateClosed, nil
+	}
+	if limit, ok := positiveLimit(detail.SubmissionLimit); ok {
+		count, err := repo.CountByFormID(ctx, form.ID)
+		if err != nil {
+			return "", err
+		}
+		if count >= int64(limit) {
+			return FormStateFull, nil
+		}
+	}
+	if detail.RequiresLogin && respondent.UserID == 0 {
+		return FormStateLoginRequired, nil
+	}
+	if limit, ok := positiveLimit(detail.LimitPerUser); ok && respondent.Key != "" {
+		count, err := repo.CountByRespondent(ctx, form.ID, respondent.Key, respondent.Fingerprint)
+		if err != nil {
+			return "", err
+		}
+		if count >= int64(limit) {
+			return FormStateUserLimit, nil
+		}
+	}
+	return FormStateOpen, nil
+}
+
+// FormSubmissionAnswerRow gönderim sayfasında bir alanın satırıdır. Cevaplar sürümler arasında
+// değişmeyen alan kimliğiyle eşlenir; satırlar formun güncel alan sırasını izler.
+type FormSubmissionAnswerRow struct {
+	FieldID       uint
+	Label         string                       // Alanın güncel etiketi (kaldırılmışsa gönderimdeki etiket)
+	OriginalLabel string                       // Gönderim anındaki etiket farklıysa
+	Answer        *models.FormSubmissionAnswer // Cevap yoksa nil
+	Removed       bool                         // Alan formdan kaldırılmış (eski alan)
+	AddedLater    bool                         // Alan, gönderimin doldurulduğu sürümden sonra eklenmiş
+}
+
+// submissionAnswerRows cevapları güncel alanlara eşler; formdan kaldırılmış alanların cevapları
+// gönderimdeki etiketleriyle sona eklenir.
+func submissionAnswerRows(fields []models.FormFieldDefinition, submission *models.FormSubmission) []FormSubmissionAnswerRow {
+	inVersion := make(map[uint]bool)
+	if submission.FormVersion != nil {
+		for _, field := range submission.FormVersion.FieldList() {
+			inVersion[field.ID] = true
+		}
+	}
+	current := make(map[uint]bool, len(fields))
+	var rows []FormSubmissionAnswerRow
+	for _, field := range fields {
+		current[field.ID] = true
+		if !field.IsInput() {
+			continue
+		}
+		row := FormSubmissionAnswerRow{FieldID: field.ID, Label: field.Label, Answer: submission.AnswerFor(field.ID)}
+		if row.Answer != nil && row.Answer.FieldLabel != field.Label {
+			row.OriginalLabel = row.Answer.FieldLabel
+		}
+		row.AddedLater = row.Answer == nil && submission.FormVersion != nil && !inVersion[field.ID]
+		rows = append(rows, row)
+	}
+	for i := range submission.Answers {
+		answer := &submission.Answers[i]
+		if !current[answer.FieldID] {
+			rows = append(rows, FormSubmissionAnswerRow{FieldID: answer.FieldID, Label: answer.FieldLabel, Answer: answer, Removed: true})
+		}
+	}
+	return rows
+}
+
+// --- Servis Metodları ---
+
+// GetFormState public sayfada gösterilecek durumu (açık, kapalı, dolu...) döndürür.
+func (s *FormSubmissionService) GetFormState(ctx context.Context, form *models.Form, respondent FormRespondent) (FormState, error) {
+	return formState(ctx, s.repo, form, respondent, time.Now().UTC())
+}
+
+// SubmitForm public link üzerinden gelen cevapları doğrular ve gönderimi kaydeder.
+// Kapanış tarihi, giriş zorunluluğu ve sınırlar form satırı kilitlenerek kontrol edilir;
+// böylece eşzamanlı gönderimler toplam veya kişi başı sınırı aşamaz.
+func (s *FormSubmissionService) SubmitForm(ctx context.Context, key string, input FormSubmissionInput) (*models.Form, *models.FormSubmission, error) {
+	form, err := s.formService.GetFormByKey(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Spam kontrolleri alan doğrulaması ve dosya yüklemesinden önce yapılır; botlar sunucuya iş yaptıramaz.
+	if err := s.checkSpam(ctx, form, input, time.Now().UTC()); err != nil {
+		return form, nil, err
+	}
+	fields, err := s.fieldRepo.FindByFormID(ctx, form.ID)
+	if err != nil {
+		return form, nil, ErrSubmissionSaveFailed
+	}
+	if len(fields) == 0 {
+		return form, nil, ErrSubmissionFormNotReady
+	}
+	var startedAt *time.Time
+	if form.Detail.IsQuiz {
+		if startedAt, err = quizStartedAt(ctx, s.quizRepo, form, input.Respondent, time.Now().UTC()); err != nil {
+			return form, nil, err
+		}
+	}
+	// Gizli ve imzalı alanlar gönderilen değeri değil, bağlantıdaki değeri alır.
+	if input.Values == nil {
+		input.Values = make(map[uint][]string)
+	}
+	stripPrefillLocked(fields, input.Values)
+	applyFormPrefill(form.ID, fields, input.Values, input.Prefill, false, time.Now().UTC())
+	answers, err := ValidateFormAnswers(fields, input.Values)
+	if err != nil {
+		return form, nil, err
+	}
+	var quiz *quizOutcome
+	if form.Detail.IsQuiz {
+		quiz = scoreQuiz(form.Detail, fields, input.Values, answers, startedAt)
+	}
+
+	// Dosyalar kayıttan önce depoya yazılır. Kapalı veya dolu bir form için boşuna yükleme
+	// yapılmasın diye durum önce kilitsiz kontrol edilir; kesin kontrol kayıtta tekrarlanır.
+	var storedKeys []string
+	for _, answer := range answers {
+		if answer.FieldType != models.FormFieldFile {
+			continue
+		}
+		state, err := formState(ctx, s.repo, form, input.Respondent, time.Now().UTC())
+		if err != nil {
+			return form, nil, ErrSubmissionSaveFailed
+		}
+		if state != FormStateOpen {
+			return form, nil, stateErrors[state]
+		}
+		storedKeys, err = s.storeUploads(ctx, form.ID, fields, answers, input.Files)
+		if err != nil {
+			if errors.Is(err, ErrSubmissionInvalid) {
+				return form, nil, err
+			}
+			configslog.Log.Error("Form dosyaları depolanamadı", zap.Uint("formID", form.ID), zap.Error(err))
+			return form, nil, ErrSubmissionSaveFailed
+		}
+		break
+	}
+
+	submission, err := s.saveSubmission(ctx, form, fields, answers, quiz, input, nil)
+	if err != nil {
+		s.deleteUploads(ctx, storedKeys)
+		return form, nil, err
+	}
+	return form, submission, nil
+}
+
+// saveSubmission gönderimi form satırı kilitli iken durum ve sınırları yeniden kontrol ederek
+// kaydeder. Gönderim, cevapların doğrulandığı alanların sürümüne bağlanır; alanlar son
+// sürümden farklıysa yeni sürüm yayınlanır. inTx verilirse aynı transaction içinde
+// çalıştırılır (örn. taslağın silinmesi).
+func (s *FormSubmissionService) saveSubmission(ctx context.Context, form *models.Form, fields []models.FormFieldDefinition, answers []models.FormSubmissionAnswer, quiz *quizOutcome, input FormSubmissionInput, inTx func(txCtx context.Context, tx *gorm.DB) error) (*models.FormSubmission, error) {
+	userAgent := input.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+	submission := &models.FormSubmission{
+		FormID:                form.ID,
+		IPAddress:             input.IPAddress,
+		UserAgent:             userAgent,
+		RespondentKey:         input.Respondent.Key,
+		RespondentFingerprint: input.Respondent.Fingerprint,
+		Answers:               answers,
+	}
+	if input.Respondent.UserID != 0 {
+		userID := input.Respondent.UserID
+		submission.UserID = &userID
+	}
+	if quiz != nil {
+		score, maxScore := quiz.score, quiz.maxScore
+		submission.Score, submission.MaxScore = &score, &maxScore
+		submission.Passed, submission.StartedAt = quiz.passed, quiz.startedAt
+	}
+
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		// Public işlem: BaseModel hook'ları için aktör olarak form sahibi kullanılır.
+		txCtx := withTx(contextWithUserID(ctx, form.CreatorUserID), tx)
+
+		// Form satırını kilitle: aynı formun gönderimleri sıraya girer ve sayımlar tutarlı kalır.
+		if err := lockFormRow(tx, form.ID); err != nil {
+			return err
+		}
+		repoTx := repositories.NewFormSubmissionRepositoryTx(tx.WithContext(txCtx))
+		now := time.Now().UTC()
+		state, err := formState(txCtx, repoTx, form, input.Respondent, now)
+		if err != nil {
+			return err
+		}
+		if state != FormStateOpen {
+			return stateErrors[state]
+		}
+		if quiz != nil {
+			// Dosya yüklemeleri uzun sürebilir; süre kayıt anına göre yeniden denetlenir.
+			if err := checkQuizDeadline(form.Detail, quiz.startedAt, now); err != nil {
+				return err
+			}
+			if quiz.startedAt != nil {
+				duration := int(now.Sub(*quiz.startedAt) / time.Second)
+				submission.DurationSeconds = &duration
+			}
+			// Deneme gönderimle kapanır; kişi başı sınır izin veriyorsa yeniden açılış yeni süre başlatır.
+			if err := repositories.NewFormQuizAttemptRepositoryTx(tx.WithContext(txCtx)).DeleteByRespondent(txCtx, form.ID, input.Respondent.Key); err != nil {
+				return err
+			}
+		}
+
+		// Cevaplar kilitten önce okunan alanlarla doğrulandı; bu arada alanlar değiştiyse
+		// gönderim reddedilir, sürüm kilit altında okunan alanlardan yayınlanır.
+		current, err := repositories.NewFormFieldRepositoryTx(tx.WithContext(txCtx)).FindByFormID(txCtx, form.ID)
+		if err != nil {
+			return err
+		}
+		same, err := sameFormFields(fields, current)
+		if err != nil {
+			return err
+		}
+		if !same {
+			return ErrSubmissionFormChanged
+		}
+		version, err := publishFormVersion(txCtx, repositories.NewFormVersionRepositoryTx(tx.WithContext(txCtx)), form.ID, current, now)
+		if err != nil {
+			return err
+		}
+		submission.FormVersionID = &version.ID
+		submission.SubmittedAt = now
+		if err := repoTx.Create(txCtx, submission); err != nil {
+			return err
+		}
+		// Bildirim ve otomatik yanıt gönderimle birlikte kuyruğa yazılır; SMTP beklenmez.
+		if err := enqueueSubmissionMails(txCtx, repositories.NewMailOutboxRepositoryTx(tx.WithContext(txCtx)), form, submission); err != nil {
+			return err
+		}
+		if err := enqueueSubmissionWebhook(txCtx, repositories.NewWebhookRepositoryTx(tx.WithContext(txCtx)), form, submission); err != nil {
+			return err
+		}
+		if inTx != nil {
+			return inTx(txCtx, tx)
+		}
+		return nil
+	})
+	if txErr != nil {
+		if errors.Is(txErr, ErrQuizTimeUp) || errors.Is(txErr, ErrQuizStartInvalid) || errors.Is(txErr, ErrSubmissionFormChanged) {
+			return nil, txErr
+		}
+		for _, stateErr := range stateErrors {
+			if errors.Is(txErr, stateErr) {
+				return nil, txErr
+			}
+		}
+		configslog.Log.Error("Form gönderimi kaydedilemedi", zap.Uint("formID", form.ID), zap.Error(txErr))
+		return nil, ErrSubmissionSaveFailed
+	}
+	configslog.SLog.Infof("Form gönderimi alındı: ID %d, Form ID %d", submission.ID, form.ID)
+	return submission, nil
+}
+
+// GetSubmissions formun gönderimlerini sayfalayarak getirir (yetki kontrolü ile).
+// Alan tanımları, listede cevap sütunlarını oluşturmak için birlikte döndürülür.
+func (s *FormSubmissionService) GetSubmissions(ctx context.Context, formID uint, requestingUserID uint, params queryparams.ListParams) (*models.Form, []models.FormFieldDefinition, *queryparams.PaginatedResult, error) {
+	form, err := s.formService.GetFormByID(ctx, formID, requestingUserID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	fields, err := s.fieldRepo.FindByFormID(ctx, formID)
+	if err != nil {
+		return form, nil, nil, err
+	}
+	normalizeSubmissionParams(&params)
+	submissions, totalCount, err := s.repo.FindByFormIDPaginated(ctx, formID, params)
+	if err != nil {
+		return form, fields, nil, err
+	}
+	result := &queryparams.PaginatedResult{
+		Data: submissions,
+		Meta: queryparams.PaginationMeta{
+			CurrentPage: params.Page,
+			PerPage:     params.PerPage,
+			TotalItems:  totalCount,
+			TotalPages:  queryparams.CalculateTotalPages(totalCount, params.PerPage),
+		},
+	}
+	return form, fields, result, nil
+}
+
+// GetSubmission tek bir gönderimi cevaplarıyla getirir (yetki kontrolü ile).
+func (s *FormSubmissionService) GetSubmission(ctx context.Context, id uint, requestingUserID uint) (*models.Form, *models.FormSubmission, error) {
+	submission, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, nil, ErrSubmissionNotFound
+		}
+		return nil, nil, err
+	}
+	form, err := s.formService.GetFormByID(ctx, submission.FormID, requestingUserID)
+	if err != nil {
+		return nil, nil, ErrSubmissionForbidden
+	}
+	return form, submission, nil
+}
+
+// GetSubmissionAnswers gönderimin cevaplarını formun güncel alanlarıyla eşler. Yetki kontrolü
+// gönderimi getiren GetSubmission'da yapılır.
+func (s *FormSubmissionService) GetSubmissionAnswers(ctx context.Context, submission *models.FormSubmission) ([]FormSubmissionAnswerRow, error) {
+	fields, err := s.fieldRepo.FindByFormID(ctx, submission.FormID)
+	if err != nil {
+		return nil, err
+	}
+	return submissionAnswerRows(fields, submission), nil
+}
+
+// FileURL yüklenen dosya için imzalı ve süreli indirme adresi üretir.
+func (s *FormSubmissionService) FileURL(fileID uint) string {
+	return s.signer.Sign(fmt.Sprintf(fileDownloadPathFormat, fileID), time.Now().Add(s.fileURLTTL))
+}
+
+// OpenFile imzalı indirme adresini doğrular ve dosyayı depodan okumak için açar.
+// Okuyucuyu kapatmak çağırana aittir.
+func (s *FormSubmissionService) OpenFile(ctx context.Context, fileID uint, expires string, signature string) (*models.FormSubmissionFile, io.ReadCloser, error) {
+	if err := s.signer.Verify(fmt.Sprintf(fileDownloadPathFormat, fileID), expires, signature, time.Now()); err != nil {
+		return nil, nil, ErrSubmissionFileLink
+	}
+	file, err := s.repo.FindFileByID(ctx, fileID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, nil, ErrSubmissionFileNotFound
+		}
+		return nil, nil, err
+	}
+	if s.storage == nil {
+		return file, nil, errors.New("dosya deposu yapılandırılmamış")
+	}
+	rc, _, err := s.storage.Get(ctx, file.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return file, nil, ErrSubmissionFileNotFound
+		}
+		return file, nil, err
+	}
+	return file, rc, nil
+}
+
+var _ IFormSubmissionService = (*FormSubmissionService)(nil)
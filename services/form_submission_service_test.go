@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"davet.link/models"
+	"davet.link/repositories"
+)
+
+// fakeSubmissionRepo gönderimleri bellekte tutar; sayımlar repository sorgusuyla aynı kuralı izler.
+type fakeSubmissionRepo struct {
+	repositories.IFormSubmissionRepository
+	submissions []models.FormSubmission
+}
+
+func (r *fakeSubmissionRepo) CountByFormID(ctx context.Context, formID uint) (int64, error) {
+	return int64(len(r.submissions)), nil
+}
+
+func (r *fakeSubmissionRepo) CountByRespondent(ctx context.Context, formID uint, respondentKey string, fingerprint string) (int64, error) {
+	var count int64
+	for _, s := range r.submissions {
+		if s.RespondentKey == respondentKey || fingerprint != "" && (s.RespondentKey == fingerprint || s.RespondentFingerprint == fingerprint) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Kişi başı sınır çerez anahtarına ve IP/tarayıcı parmak izine birlikte uygulanır.
+func TestFormStateRespondentLimit(t *testing.T) {
+	limit := 1
+	form := &models.Form{Detail: models.FormDetail{LimitPerUser: &limit}}
+	repo := &fakeSubmissionRepo{submissions: []models.FormSubmission{
+		{RespondentKey: "c:cerez-1", RespondentFingerprint: "f:ev"},
+		{RespondentKey: "f:kafe", RespondentFingerprint: "f:kafe"}, // Çerezsiz gönderim
+		{RespondentKey: "u:5"},
+	}}
+
+	tests := []struct {
+		name       string
+		respondent FormRespondent
+		want       FormState
+	}{
+		{"aynı çerez, farklı ağ", FormRespondent{Key: "c:cerez-1", Fingerprint: "f:is"}, FormStateUserLimit},
+		{"çerez silinmiş, aynı ağ", FormRespondent{Key: "c:cerez-2", Fingerprint: "f:ev"}, FormStateUserLimit},
+		{"çerezsiz gönderimden sonra çerezli", FormRespondent{Key: "c:cerez-3", Fingerprint: "f:kafe"}, FormStateUserLimit},
+		{"çerez ve ağ yeni", FormRespondent{Key: "c:cerez-4", Fingerprint: "f:is"}, FormStateOpen},
+		{"giriş yapmış kullanıcı", FormRespondent{UserID: 5, Key: "u:5"}, FormStateUserLimit},
+		{"başka kullanıcı aynı ağda", FormRespondent{UserID: 6, Key: "u:6"}, FormStateOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formState(context.Background(), repo, form, tt.respondent, time.Now())
+			if err != nil {
+				t.Fatalf("beklenmeyen hata: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("beklenen %s, alınan %s", tt.want, got)
+			}
+		})
+	}
+}
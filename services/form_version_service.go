@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"davet.link/models"
+	"davet.link/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FormVersionServiceError özel servis hataları
+type FormVersionServiceError string
+
+func (e FormVersionServiceError) Error() string { return string(e) }
+
+const (
+	ErrFormVersionLoadFailed FormVersionServiceError = "form sürümleri yüklenemedi"
+)
+
+// publishFormVersion formun güncel alanlarını yeni bir sürüm olarak yayınlar. Alanlar son
+// sürümle aynıysa yeni sürüm açılmaz, son sürüm döndürülür. Sürüm numaraları çakışmasın
+// diye çağıran form satırını kilitlemiş olmalıdır (bkz. lockFormRow).
+func publishFormVersion(ctx context.Context, repo repositories.IFormVersionRepository, formID uint, fields []models.FormFieldDefinition, now time.Time) (*models.FormVersion, error) {
+	data, checksum, err := formVersionSnapshot(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	number := 1
+	latest, err := repo.FindLatest(ctx, formID)
+	switch {
+	case err == nil:
+		if latest.Checksum == checksum {
+			return latest, nil
+		}
+		number = latest.Number + 1
+	case !errors.Is(err, repositories.ErrNotFound):
+		return nil, err
+	}
+	version := &models.FormVersion{FormID: formID, Number: number, Fields: string(data), Checksum: checksum, PublishedAt: now}
+	if err := repo.Create(ctx, version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// formVersionSnapshot alanların sürümde saklanan JSON görüntüsünü ve sağlama toplamını döndürür.
+func formVersionSnapshot(fields []models.FormFieldDefinition) ([]byte, string, error) {
+	snapshot := make([]models.FormVersionField, len(fields))
+	for i := range fields {
+		snapshot[i] = models.NewFormVersionField(fields[i])
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(data)
+	return data, hex.EncodeToString(sum[:]), nil
+}
+
+// sameFormFields iki alan listesinin aynı sürüme karşılık gelip gelmediğini bildirir.
+func sameFormFields(a, b []models.FormFieldDefinition) (bool, error) {
+	_, sumA, err := formVersionSnapshot(a)
+	if err != nil {
+		return false, err
+	}
+	_, sumB, err := formVersionSnapshot(b)
+	if err != nil {
+		return false, err
+	}
+	return sumA == sumB, nil
+}
+
+// lockFormRow form satırını transaction sonuna kadar kilitler; aynı formun gönderim ve
+// sürüm işlemleri sıraya girer.
+func lockFormRow(tx *gorm.DB, formID uint) error {
+	var locked models.Form
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&locked, formID).Error
+}
+
+// removedFormFields formdan kaldırılmış ama sürümlerde veya gönderimlerde bulunan alanları
+// döndürür. Etiket, alanın yer aldığı en son sürümden alınır; sürümlemeden önce kaldırılan
+// alanlar için gönderimde saklanan etiket kullanılır. versions yeniden eskiye sıralıdır.
+func removedFormFields(fields []models.FormFieldDefinition, versions []models.FormVersion, answered []models.FormSubmissionAnswer) []models.FormVersionField {
+	current := make(map[uint]bool, len(fields))
+	for _, field := range fields {
+		current[field.ID] = true
+	}
+	seen := make(map[uint]bool)
+	var removed []models.FormVersionField
+	for _, version := range versions {
+		for _, field := range version.FieldList() {
+			if current[field.ID] || seen[field.ID] || !field.IsInput() {
+				continue
+			}
+			seen[field.ID] = true
+			removed = append(removed, field)
+		}
+	}
+	for _, answer := range answered {
+		if current[answer.FieldID] || seen[answer.FieldID] {
+			continue
+		}
+		seen[answer.FieldID] = true
+		removed = append(removed, models.FormVersionField{ID: answer.FieldID, Type: answer.FieldType, Label: answer.FieldLabel, SortOrder: answer.SortOrder})
+	}
+	sort.SliceStable(removed, func(i, j int) bool {
+		if removed[i].SortOrder != removed[j].SortOrder {
+			return removed[i].SortOrder < removed[j].SortOrder
+		}
+		return removed[i].ID < removed[j].ID
+	})
+	return removed
+}
+
+// FormVersionSummary panelde listelenen bir sürüm ve bir önceki sürüme göre değişiklikleridir.
+type FormVersionSummary struct {
+	Version     models.FormVersion
+	Fields      []models.FormVersionField
+	Submissions int64
+	Added       []string // Eklenen alanların etiketleri
+	Removed     []string // Kaldırılan alanların etiketleri
+	Renamed     []string // "Eski etiket → Yeni etiket"
+}
+
+// formVersionSummaries sürümleri (yeniden eskiye) bir öncekiyle karşılaştırarak özetler.
+func formVersionSummaries(versions []models.FormVersion, counts []models.FormVersionCount) []FormVersionSummary {
+	submissions := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		submissions[count.FormVersionID] = count.Count
+	}
+	summaries := make([]FormVersionSummary, len(versions))
+	for i := range versions {
+		summaries[i] = FormVersionSummary{Version: versions[i], Fields: versions[i].FieldList(), Submissions: submissions[versions[i].ID]}
+	}
+	for i := range summaries {
+		if i+1 >= len(summaries) {
+			break // İlk sürüm
+		}
+		previous := make(map[uint]models.FormVersionField)
+		for _, field := range summaries[i+1].Fields {
+			previous[field.ID] = field
+		}
+		for _, field := range summaries[i].Fields {
+			old, ok := previous[field.ID]
+			switch {
+			case !ok:
+				summaries[i].Added = append(summaries[i].Added, field.Label)
+			case old.Label != field.Label:
+				summaries[i].Renamed = append(summaries[i].Renamed, old.Label+" → "+field.Label)
+			}
+			delete(previous, field.ID)
+		}
+		for _, field := range summaries[i+1].Fields {
+			if _, ok := previous[field.ID]; ok {
+				summaries[i].Removed = append(summaries[i].Removed, field.Label)
+			}
+		}
+	}
+	return summaries
+}
+
+// IFormVersionService form sürümlerinin panelde listelenmesi için arayüz.
+type IFormVersionService interface {
+	GetVersions(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, []FormVersionSummary, error)
+}
+
+// FormVersionService IFormVersionService arayüzünü uygular.
+type FormVersionService struct {
+	repo         repositories.IFormVersionRepository
+	fieldService IFormFieldService
+}
+
+// NewFormVersionService yeni bir FormVersionService örneği oluşturur.
+func NewFormVersionService() IFormVersionService {
+	return &FormVersionService{
+		repo:         repositories.NewFormVersionRepository(),
+		fieldService: NewFormFieldService(),
+	}
+}
+
+// GetVersions formun sürümlerini yeniden eskiye, gönderim sayıları ve değişiklikleriyle
+// getirir (yetki kontrolü ile).
+func (s *FormVersionService) GetVersions(ctx context.Context, formID uint, requestingUserID uint) (*models.Form, []FormVersionSummary, error) {
+	form, _, err := s.fieldService.GetFields(ctx, formID, requestingUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	versions, err := s.repo.FindByFormID(ctx, formID)
+	if err != nil {
+		return form, nil, ErrFormVersionLoadFailed
+	}
+	counts, err := s.repo.CountSubmissions(ctx, formID)
+	if err != nil {
+		return form, nil, ErrFormVersionLoadFailed
+	}
+	return form, formVersionSummaries(versions, counts), nil
+}
+
+var _ IFormVersionService = (*FormVersionService)(nil)
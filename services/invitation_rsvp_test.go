@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"davet.link/models"
+	"davet.link/repositories"
+)
+
+// fakeGuestRepo anahtarları bellekte tutulan davetliler.
+type fakeGuestRepo struct {
+	repositories.IInvitationGuestRepository
+	guests []models.InvitationGuest
+}
+
+func (r *fakeGuestRepo) FindByRSVPToken(ctx context.Context, invitationID uint, token string) (*models.InvitationGuest, error) {
+	for i := range r.guests {
+		if r.guests[i].InvitationID == invitationID && r.guests[i].RSVPToken == token {
+			return &r.guests[i], nil
+		}
+	}
+	return nil, repositories.ErrNotFound
+}
+
+// fakeRSVPRepo davetli başına tek yanıt tutar.
+type fakeRSVPRepo struct {
+	repositories.IInvitationRSVPRepository
+	saved map[uint]models.InvitationRSVP
+}
+
+func (r *fakeRSVPRepo) CreateOrUpdate(ctx context.Context, rsvp *models.InvitationRSVP) error {
+	if r.saved == nil {
+		r.saved = map[uint]models.InvitationRSVP{}
+	}
+	rsvp.ID = *rsvp.InvitationGuestID
+	r.saved[*rsvp.InvitationGuestID] = *rsvp
+	return nil
+}
+
+// fakeWebhookRepo uç noktaları ve kuyruğa eklenen teslimatları bellekte tutar.
+type fakeWebhookRepo struct {
+	repositories.IWebhookRepository
+	endpoints  []models.WebhookEndpoint
+	deliveries []models.WebhookDelivery
+}
+
+func (r *fakeWebhookRepo) FindActiveEndpoints(ctx context.Context, userID uint) ([]models.WebhookEndpoint, error) {
+	var active []models.WebhookEndpoint
+	for _, endpoint := range r.endpoints {
+		if endpoint.UserID == userID && endpoint.IsActive {
+			active = append(active, endpoint)
+		}
+	}
+	return active, nil
+}
+
+func (r *fakeWebhookRepo) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	r.deliveries = append(r.deliveries, *delivery)
+	return nil
+}
+
+func rsvpFixture() (*models.Invitation, *fakeGuestRepo, *fakeRSVPRepo, *fakeWebhookRepo) {
+	invitation := &models.Invitation{CreatorUserID: 7, Detail: models.InvitationDetail{AllowPlusOnes: true, MaxPlusOnes: 2}}
+	invitation.ID = 3
+	guests := &fakeGuestRepo{guests: []models.InvitationGuest{
+		{InvitationID: 3, Name: "Ayşe", RSVPToken: "gizli-ayse"},
+		{InvitationID: 3, Name: "Mehmet", RSVPToken: "gizli-mehmet"},
+	}}
+	guests.guests[0].ID, guests.guests[1].ID = 11, 12
+	webhooks := &fakeWebhookRepo{endpoints: []models.WebhookEndpoint{
+		{UserID: 7, IsActive: true, Events: string(models.WebhookEventRSVPUpdated)},
+	}}
+	return invitation, guests, &fakeRSVPRepo{}, webhooks
+}
+
+func TestSaveRSVPEnqueuesWebhook(t *testing.T) {
+	invitation, guests, rsvps, webhooks := rsvpFixture()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	rsvp, err := saveRSVP(context.Background(), guests, rsvps, webhooks, invitation, "gizli-mehmet",
+		models.InvitationRSVP{Status: models.RSVPStatusAttending, PlusOnes: 1, Notes: "  geliyoruz "}, now)
+	if err != nil {
+		t.Fatalf("saveRSVP: %v", err)
+	}
+	if *rsvp.InvitationGuestID != 12 || rsvps.saved[12].Notes != "geliyoruz" {
+		t.Errorf("yanıt yanlış davetliye veya yanlış notla kaydedildi: %+v", rsvps.saved)
+	}
+	if len(webhooks.deliveries) != 1 {
+		t.Fatalf("beklenen 1 teslimat, alınan %d", len(webhooks.deliveries))
+	}
+	delivery := webhooks.deliveries[0]
+	if delivery.Event != models.WebhookEventRSVPUpdated || delivery.Status != models.WebhookDeliveryPending {
+		t.Errorf("beklenen bekleyen rsvp.updated teslimatı, alınan %s/%s", delivery.Event, delivery.Status)
+	}
+	var payload struct {
+		Data webhookRSVP `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(delivery.Payload), &payload); err != nil {
+		t.Fatalf("teslimat gövdesi çözümlenemedi: %v", err)
+	}
+	if payload.Data.GuestID == nil || *payload.Data.GuestID != 12 || payload.Data.PlusOnes != 1 {
+		t.Errorf("teslimat gövdesi yanlış: %+v", payload.Data)
+	}
+}
+
+func TestSaveRSVPRejects(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	tests := []struct {
+		name     string
+		token    string
+		data     models.InvitationRSVP
+		deadline *time.Time
+		want     error
+	}{
+		{"bilinmeyen anahtar", "11", models.InvitationRSVP{Status: models.RSVPStatusAttending}, nil, ErrGuestNotFoundForRSVP},
+		{"boş anahtar", "", models.InvitationRSVP{Status: models.RSVPStatusAttending}, nil, ErrGuestNotFoundForRSVP},
+		{"son tarih geçti", "gizli-ayse", models.InvitationRSVP{Status: models.RSVPStatusAttending}, &past, ErrRSVPDeadlinePassed},
+		{"geçersiz durum", "gizli-ayse", models.InvitationRSVP{Status: models.RSVPStatusPending}, nil, ErrInvalidRSVPStatus},
+		{"ek kişi sınırı", "gizli-ayse", models.InvitationRSVP{Status: models.RSVPStatusAttending, PlusOnes: 3}, nil, ErrMaxPlusOnesExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitation, guests, rsvps, webhooks := rsvpFixture()
+			invitation.Detail.RSVPDeadline = tt.deadline
+			_, err := saveRSVP(context.Background(), guests, rsvps, webhooks, invitation, tt.token, tt.data, now)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("beklenen %v, alınan %v", tt.want, err)
+			}
+			if len(rsvps.saved) != 0 || len(webhooks.deliveries) != 0 {
+				t.Errorf("reddedilen yanıt kaydedilmemeli: %d yanıt, %d teslimat", len(rsvps.saved), len(webhooks.deliveries))
+			}
+		})
+	}
+}
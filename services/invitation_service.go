@@ -2,8 +2,11 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time" // Zaman validasyonu için
 
 	"davet.link/configs"
@@ -38,6 +41,12 @@ const (
 	ErrInvLinkUpdateFailed         InvitationServiceError = "davetiye linki güncellenemedi"
 	ErrInvLinkDeletionFailed       InvitationServiceError = "davetiye linki silinemedi"
 	ErrInvPasswordHashingFailed    InvitationServiceError = "davetiye şifresi oluşturulamadı"
+	ErrGuestNotFoundForRSVP        InvitationServiceError = "davetli bulunamadı"
+	ErrRSVPDeadlinePassed          InvitationServiceError = "LCV için son tarih geçti"
+	ErrInvalidRSVPStatus           InvitationServiceError = "geçersiz LCV yanıtı"
+	ErrPlusOnesNotAllowed          InvitationServiceError = "bu davetiyede ek kişi getirilemez"
+	ErrMaxPlusOnesExceeded         InvitationServiceError = "ek kişi sayısı izin verilen sınırı aşıyor"
+	ErrRSVPSaveFailed              InvitationServiceError = "LCV yanıtı kaydedilemedi"
 )
 
 // IInvitationService davetiye işlemleri için arayüz.
@@ -51,11 +60,14 @@ type IInvitationService interface {
 	DeleteInvitation(ctx context.Context, id uint, deletingUserID uint) error
 	GetInvitationCountForUser(ctx context.Context, creatorUserID uint) (int64, error)
 	GetAllInvitationsCount(ctx context.Context) (int64, error) // Admin için
+	AddGuest(ctx context.Context, invitationID uint, requestingUserID uint, guest models.InvitationGuest) (*models.InvitationGuest, error)
+	SubmitRSVP(ctx context.Context, key string, token string, data models.InvitationRSVP) error
 }
 
 // InvitationService IInvitationService arayüzünü uygular.
 type InvitationService struct {
 	repo        repositories.IInvitationRepository
+	guestRepo   repositories.IInvitationGuestRepository
 	linkService ILinkService // Bağımlılıklar
 	typeService ITypeService
 	userService IUserService
@@ -67,6 +79,7 @@ func NewInvitationService() IInvitationService {
 	// Gerçek uygulamada DI kullanın
 	return &InvitationService{
 		repo:        repositories.NewInvitationRepository(),
+		guestRepo:   repositories.NewInvitationGuestRepository(),
 		linkService: NewLinkService(),
 		typeService: NewTypeService(),
 		userService: NewUserService(),
@@ -430,6 +443,130 @@ func (s *InvitationService) GetAllInvitationsCount(ctx context.Context) (int64,
 	return count, nil
 }
 
+// newRSVPToken davetliye özel LCV bağlantısı için tahmin edilemez bir anahtar üretir.
+func newRSVPToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// GuestRSVPURL davetlinin LCV bağlantısını döndürür (POST /{key}/rsvp/{token}).
+func GuestRSVPURL(key string, guest *models.InvitationGuest) string {
+	return PublicURL("/" + key + "/rsvp/" + guest.RSVPToken)
+}
+
+// AddGuest davetiyeye davetli ekler ve davetliye özel LCV anahtarını üretir.
+func (s *InvitationService) AddGuest(ctx context.Context, invitationID uint, requestingUserID uint, guest models.InvitationGuest) (*models.InvitationGuest, error) {
+	invitation, err := s.GetInvitationByID(ctx, invitationID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	guest.Name = strings.TrimSpace(guest.Name)
+	if guest.Name == "" {
+		return nil, fmt.Errorf("%w: davetli adı zorunludur", ErrInvInvalidInput)
+	}
+	guest.InvitationID = invitation.ID
+	if guest.RSVPToken, err = newRSVPToken(); err != nil {
+		return nil, ErrInvitationUpdateFailed
+	}
+	if err := s.guestRepo.Create(contextWithUserID(ctx, requestingUserID), &guest); err != nil {
+		return nil, ErrInvitationUpdateFailed
+	}
+	return &guest, nil
+}
+
+// validateRSVP LCV yanıtını davetiyenin kurallarına göre denetler ve normalleştirir.
+func validateRSVP(detail models.InvitationDetail, data *models.InvitationRSVP, now time.Time) error {
+	if detail.RSVPDeadline != nil && now.After(*detail.RSVPDeadline) {
+		return ErrRSVPDeadlinePassed
+	}
+	switch data.Status {
+	case models.RSVPStatusAttending, models.RSVPStatusNotAttending, models.RSVPStatusMaybe:
+	default:
+		return ErrInvalidRSVPStatus
+	}
+	if data.Status == models.RSVPStatusNotAttending {
+		data.PlusOnes = 0
+	}
+	if data.PlusOnes < 0 {
+		return fmt.Errorf("%w: ek kişi sayısı negatif olamaz", ErrInvInvalidInput)
+	}
+	if data.PlusOnes > 0 && !detail.AllowPlusOnes {
+		return ErrPlusOnesNotAllowed
+	}
+	if data.PlusOnes > detail.MaxPlusOnes {
+		return ErrMaxPlusOnesExceeded
+	}
+	data.Notes = strings.TrimSpace(data.Notes)
+	return nil
+}
+
+// saveRSVP anahtarı verilen davetlinin yanıtını kaydeder ve rsvp.updated olayını kuyruğa
+// ekler. Çağıran, depoları aynı transaction'a bağlamalıdır.
+func saveRSVP(ctx context.Context, guests repositories.IInvitationGuestRepository, rsvps repositories.IInvitationRSVPRepository, webhooks repositories.IWebhookRepository, invitation *models.Invitation, token string, data models.InvitationRSVP, now time.Time) (*models.InvitationRSVP, error) {
+	if err := validateRSVP(invitation.Detail, &data, now); err != nil {
+		return nil, err
+	}
+	guest, err := guests.FindByRSVPToken(ctx, invitation.ID, strings.TrimSpace(token))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrGuestNotFoundForRSVP
+		}
+		return nil, err
+	}
+	rsvp := &models.InvitationRSVP{
+		InvitationID:      invitation.ID,
+		InvitationGuestID: &guest.ID,
+		Status:            data.Status,
+		PlusOnes:          data.PlusOnes,
+		Notes:             data.Notes,
+		RespondedAt:       &now,
+	}
+	if err := rsvps.CreateOrUpdate(ctx, rsvp); err != nil {
+		configslog.Log.Error("SubmitRSVP: LCV kaydedilemedi", zap.Uint("invitationID", invitation.ID), zap.Uint("guestID", guest.ID), zap.Error(err))
+		return nil, ErrRSVPSaveFailed
+	}
+	if err := enqueueRSVPWebhook(ctx, webhooks, invitation, rsvp); err != nil {
+		configslog.Log.Error("SubmitRSVP: Webhook kuyruğa eklenemedi", zap.Uint("invitationID", invitation.ID), zap.Error(err))
+		return nil, ErrRSVPSaveFailed
+	}
+	return rsvp, nil
+}
+
+// SubmitRSVP davetlinin LCV yanıtını kaydeder; daha önce yanıt verdiyse günceller. Davetli,
+// LCV bağlantısındaki gizli anahtarla bulunur. rsvp.updated webhook olayı aynı transaction'da
+// kuyruğa eklenir.
+func (s *InvitationService) SubmitRSVP(ctx context.Context, key string, token string, data models.InvitationRSVP) error {
+	invitation, err := s.GetInvitationByKey(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	var rsvp *models.InvitationRSVP
+	// Public işlem: BaseModel hook'ları için aktör olarak davetiye sahibi kullanılır.
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, invitation.CreatorUserID), tx)
+		rsvp, err = saveRSVP(txCtx,
+			repositories.NewInvitationGuestRepositoryTx(tx.WithContext(txCtx)),
+			repositories.NewInvitationRSVPRepositoryTx(tx.WithContext(txCtx)),
+			repositories.NewWebhookRepositoryTx(tx.WithContext(txCtx)),
+			invitation, token, data, time.Now().UTC())
+		return err
+	})
+	if txErr != nil {
+		var invErr InvitationServiceError
+		if !errors.As(txErr, &invErr) {
+			configslog.Log.Error("SubmitRSVP transaction failed", zap.Uint("invitationID", invitation.ID), zap.Error(txErr))
+			return ErrRSVPSaveFailed
+		}
+		return txErr
+	}
+	configslog.SLog.Infof("LCV yanıtı kaydedildi: Invitation ID %d, Guest ID %d, %s", invitation.ID, *rsvp.InvitationGuestID, rsvp.Status)
+	return nil
+}
+
 var _ IInvitationService = (*InvitationService)(nil)
 
 // Transaction'lı Repository için yardımcı constructor
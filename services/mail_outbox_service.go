@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"davet.link/configs/configsenv"
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/mailer"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMailMaxAttempts = 6
+	mailOutboxBatchSize    = 50
+	mailOutboxLease        = 10 * time.Minute // Gönderim sürerken kaydın tekrar alınmaması için
+	maxMailRetryDelay      = 6 * time.Hour
+	maxMailErrorLength     = 1000
+)
+
+// IMailOutboxService kuyruktaki e-postaları gönderir.
+type IMailOutboxService interface {
+	ProcessDue(ctx context.Context) (sent int, failed int, err error)
+}
+
+// MailOutboxService IMailOutboxService arayüzünü uygular.
+type MailOutboxService struct {
+	repo        repositories.IMailOutboxRepository
+	mailer      mailer.Mailer
+	maxAttempts int
+}
+
+// NewMailOutboxService yeni bir MailOutboxService örneği oluşturur.
+func NewMailOutboxService() IMailOutboxService {
+	maxAttempts := configsenv.GetEnvAsInt("MAIL_OUTBOX_MAX_ATTEMPTS", defaultMailMaxAttempts)
+	if maxAttempts < 1 {
+		maxAttempts = defaultMailMaxAttempts
+	}
+	return &MailOutboxService{
+		repo:        repositories.NewMailOutboxRepository(),
+		mailer:      mailer.New(),
+		maxAttempts: maxAttempts,
+	}
+}
+
+// enqueueMail e-postayı hemen gönderilmek üzere kuyruğa ekler. ctx içinde transaction varsa
+// kayıt onunla birlikte yazılır; böylece geri alınan işlemin e-postası da gönderilmez.
+func enqueueMail(ctx context.Context, repo repositories.IMailOutboxRepository, mail *models.MailOutbox) error {
+	mail.Status = models.MailOutboxPending
+	mail.NextAttemptAt = time.Now().UTC()
+	return repo.Create(contextWithUserID(ctx, mail.OwnerUserID), mail)
+}
+
+// mailRetryDelay n. başarısız denemeden sonra beklenecek süre: 1, 2, 4... dakika (en fazla 6 saat).
+func mailRetryDelay(attempts int) time.Duration {
+	delay := time.Minute
+	for i := 1; i < attempts && delay < maxMailRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxMailRetryDelay {
+		return maxMailRetryDelay
+	}
+	return delay
+}
+
+// ProcessDue zamanı gelen e-postaları gönderir. Başarısız gönderimler artan aralıklarla
+// yeniden denenir; deneme hakkı biten e-posta failed olarak işaretlenir.
+func (s *MailOutboxService) ProcessDue(ctx context.Context) (int, int, error) {
+	sent, failed := 0, 0
+	for {
+		now := time.Now().UTC()
+		mails, err := s.repo.ClaimDue(ctx, now, mailOutboxLease, mailOutboxBatchSize)
+		if err != nil {
+			return sent, failed, err
+		}
+		for i := range mails {
+			if s.deliver(ctx, &mails[i]) {
+				sent++
+			} else {
+				failed++
+			}
+		}
+		if len(mails) < mailOutboxBatchSize || ctx.Err() != nil {
+			return sent, failed, nil
+		}
+	}
+}
+
+// deliver tek bir e-postayı gönderir ve sonucunu kaydeder.
+func (s *MailOutboxService) deliver(ctx context.Context, mail *models.MailOutbox) bool {
+	attachments := make([]mailer.Attachment, 0, len(mail.Attachments))
+	for _, attachment := range mail.Attachments {
+		attachments = append(attachments, mailer.Attachment{
+			Filename:    attachment.FileName,
+			ContentType: attachment.ContentType,
+			Data:        attachment.Data,
+			Inline:      attachment.Inline,
+		})
+	}
+	sendErr := s.mailer.Send(mailer.Message{
+		To:          strings.Split(mail.Recipients, ","),
+		Subject:     mail.Subject,
+		TextBody:    mail.TextBody,
+		ReplyTo:     mail.ReplyTo,
+		Attachments: attachments,
+	})
+
+	now := time.Now().UTC()
+	attempts := mail.Attempts + 1
+	data := map[string]interface{}{"attempts": attempts}
+	if sendErr == nil {
+		data["status"] = models.MailOutboxSent
+		data["sent_at"] = now
+		data["last_error"] = ""
+	} else {
+		message := sendErr.Error()
+		if len(message) > maxMailErrorLength {
+			message = strings.ToValidUTF8(message[:maxMailErrorLength], "")
+		}
+		data["last_error"] = message
+		if attempts >= s.maxAttempts {
+			data["status"] = models.MailOutboxFailed
+		} else {
+			data["next_attempt_at"] = now.Add(mailRetryDelay(attempts))
+		}
+		configslog.Log.Warn("Kuyruktaki e-posta gönderilemedi", zap.Uint("mailID", mail.ID), zap.Int("attempt", attempts), zap.Error(sendErr))
+	}
+	if err := s.repo.Update(contextWithUserID(ctx, mail.OwnerUserID), mail, data); err != nil {
+		configslog.Log.Error("Kuyruk e-postası güncellenemedi", zap.Uint("mailID", mail.ID), zap.Error(err))
+	}
+	return sendErr == nil
+}
+
+var _ IMailOutboxService = (*MailOutboxService)(nil)
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"os"
+	"testing"
+
+	"davet.link/configs/configslog"
+
+	"go.uber.org/zap"
+)
+
+// TestMain servislerin logladığı hata yollarının testlerde panik üretmemesi için sessiz logger kurar.
+func TestMain(m *testing.M) {
+	configslog.Log = zap.NewNop()
+	configslog.SLog = configslog.Log.Sugar()
+	os.Exit(m.Run())
+}
@@ -0,0 +1,368 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"davet.link/configs"
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/payment"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause" // Lock için
+)
+
+// PaymentServiceError özel servis hataları
+type PaymentServiceError string
+
+func (e PaymentServiceError) Error() string { return string(e) }
+
+const (
+	ErrPaymentInvalidInput     PaymentServiceError = "geçersiz ödeme bilgisi"
+	ErrPaymentNotFound         PaymentServiceError = "ödeme kaydı bulunamadı"
+	ErrPaymentForbidden        PaymentServiceError = "bu işlem için yetkiniz yok"
+	ErrPaymentSaveFailed       PaymentServiceError = "ödeme kaydedilemedi"
+	ErrPaymentProviderDisabled PaymentServiceError = "çevrim içi ödeme etkin değil"
+	ErrPaymentDeclined         PaymentServiceError = "ödeme reddedildi"
+	ErrPaymentRefundTooLarge   PaymentServiceError = "iade tutarı ödenen tutarı aşamaz"
+)
+
+// ManualPaymentInput panelden elle girilen ödeme veya iade.
+type ManualPaymentInput struct {
+	Kind        models.PaymentKind
+	Method      models.PaymentMethod
+	AmountMinor int64
+	PaidAt      time.Time
+	Note        string
+}
+
+// IPaymentService rezervasyon ödemeleri için arayüz.
+type IPaymentService interface {
+	OnlinePaymentsEnabled() bool
+	RecordManualPayment(ctx context.Context, bookingID uint, providerUserID uint, input ManualPaymentInput) error
+	RefundPayment(ctx context.Context, paymentID uint, providerUserID uint, amountMinor int64, note string) error
+}
+
+// PaymentService IPaymentService arayüzünü uygular.
+type PaymentService struct {
+	repo        repositories.IBookingPaymentRepository
+	bookingRepo repositories.IAppointmentBookingRepository
+	provider    payment.Provider // nil ise çevrim içi ödeme kapalıdır
+	db          *gorm.DB         // Transaction için
+}
+
+// NewPaymentService yeni bir PaymentService örneği oluşturur.
+func NewPaymentService() IPaymentService {
+	return &PaymentService{
+		repo:        repositories.NewBookingPaymentRepository(),
+		bookingRepo: repositories.NewAppointmentBookingRepository(),
+		provider:    payment.New(),
+		db:          configs.GetDB(),
+	}
+}
+
+// --- Yardımcı Metodlar ---
+
+// ComputePaymentStatus ödeme hareketlerinden net tahsil edilen tutarı ve ödeme durumunu hesaplar.
+func ComputePaymentStatus(amountDueMinor int64, payments []models.BookingPayment) (int64, models.PaymentStatus) {
+	var paid, refunded int64
+	for _, p := range payments {
+		if p.Kind == models.PaymentKindRefund {
+			refunded += p.AmountMinor
+		} else {
+			paid += p.AmountMinor
+		}
+	}
+	net := paid - refunded
+	switch {
+	case refunded > 0 && net <= 0:
+		return net, models.PaymentStatusRefunded
+	case amountDueMinor > 0 && net >= amountDueMinor:
+		return net, models.PaymentStatusPaid
+	case net > 0:
+		return net, models.PaymentStatusPartiallyPaid
+	default:
+		return net, models.PaymentStatusUnpaid
+	}
+}
+
+// refundedAmount bir ödemeye karşılık daha önce yapılmış iadelerin toplamı.
+func refundedAmount(payments []models.BookingPayment, paymentID uint) int64 {
+	var total int64
+	for _, p := range payments {
+		if p.Kind == models.PaymentKindRefund && p.RefundOfID != nil && *p.RefundOfID == paymentID {
+			total += p.AmountMinor
+		}
+	}
+	return total
+}
+
+// recordPayment rezervasyon satırını kilitleyip build ile üretilen hareketi kaydeder ve
+// rezervasyonun PaidMinor / PaymentStatus alanlarını yeniden hesaplar.
+// build, kilit altında okunan rezervasyon ve mevcut hareketlerle çağrılır.
+func (s *PaymentService) recordPayment(ctx context.Context, bookingID uint, actorUserID uint,
+	build func(booking *models.AppointmentBooking, payments []models.BookingPayment) (*models.BookingPayment, error)) (*models.BookingPayment, error) {
+	var created *models.BookingPayment
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, actorUserID), tx)
+
+		var booking models.AppointmentBooking
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&booking, bookingID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrBookingNotFound
+			}
+			return err
+		}
+		paymentRepo := repositories.NewBookingPaymentRepositoryTx(tx.WithContext(txCtx))
+		payments, err := paymentRepo.FindByBookingID(txCtx, booking.ID)
+		if err != nil {
+			return err
+		}
+
+		p, err := build(&booking, payments)
+		if err != nil {
+			return err
+		}
+		p.BookingID = booking.ID
+		p.Currency = booking.Currency
+		if p.PaidAt.IsZero() {
+			p.PaidAt = time.Now().UTC()
+		}
+		if err := paymentRepo.Create(txCtx, p); err != nil {
+			configslog.Log.Error("Ödeme hareketi kaydedilemedi", zap.Uint("bookingID", booking.ID), zap.Error(err))
+			return ErrPaymentSaveFailed
+		}
+
+		paid, status := ComputePaymentStatus(booking.AmountDueMinor, append(payments, *p))
+		updateData := map[string]interface{}{"paid_minor": paid, "payment_status": status}
+		if err := repositories.NewAppointmentBookingRepositoryTx(tx.WithContext(txCtx)).Update(txCtx, &booking, updateData); err != nil {
+			return ErrPaymentSaveFailed
+		}
+		created = p
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return created, nil
+}
+
+// bookingChargeAmount public rezervasyonda çevrim içi çekilecek tutar: kapora tanımlıysa kapora,
+// yoksa ücretin tamamı.
+func bookingChargeAmount(booking *models.AppointmentBooking) int64 {
+	if booking.DepositMinor > 0 && booking.DepositMinor < booking.AmountDueMinor {
+		return booking.DepositMinor
+	}
+	return booking.AmountDueMinor
+}
+
+// chargeNewBooking yeni rezervasyonun ödemesini rezervasyonu yazan transaction içinde çeker ve
+// kaydeder. Onay bekleyen veya ücretsiz rezervasyonlardan ödeme alınmaz (nil döner). Çekim yapılıp
+// kayıt yazılamazsa çekim iade edilir; dönen hata transaction'ı geri alır ve rezervasyon oluşmaz.
+func chargeNewBooking(ctx context.Context, provider payment.Provider, payments repositories.IBookingPaymentRepository,
+	bookings repositories.IAppointmentBookingRepository, booking *models.AppointmentBooking, sourceToken string) (*payment.Charge, error) {
+	amountMinor := bookingChargeAmount(booking)
+	if sourceToken == "" || booking.Status != models.BookingStatusConfirmed || amountMinor <= 0 {
+		return nil, nil
+	}
+	if provider == nil {
+		return nil, ErrPaymentProviderDisabled
+	}
+
+	charge, err := provider.Charge(ctx, payment.ChargeRequest{
+		AmountMinor:    amountMinor,
+		Currency:       booking.Currency,
+		Description:    fmt.Sprintf("Rezervasyon #%d", booking.ID),
+		CustomerEmail:  booking.CustomerEmail,
+		SourceToken:    sourceToken,
+		IdempotencyKey: fmt.Sprintf("booking-%d-%d-%d", booking.ID, booking.PaidMinor, amountMinor),
+	})
+	if err != nil {
+		if errors.Is(err, payment.ErrDeclined) {
+			return nil, ErrPaymentDeclined
+		}
+		configslog.Log.Error("Sağlayıcı ödemesi başarısız", zap.Uint("bookingID", booking.ID), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrPaymentSaveFailed, err)
+	}
+
+	p := &models.BookingPayment{
+		BookingID:   booking.ID,
+		Kind:        models.PaymentKindPayment,
+		Method:      models.PaymentMethodOnline,
+		AmountMinor: charge.AmountMinor,
+		Currency:    booking.Currency,
+		PaidAt:      charge.CreatedAt,
+		Provider:    provider.Name(),
+		ProviderRef: charge.ID,
+	}
+	paid, status := ComputePaymentStatus(booking.AmountDueMinor, []models.BookingPayment{*p})
+	err = payments.Create(ctx, p)
+	if err == nil {
+		err = bookings.Update(ctx, booking, map[string]interface{}{"paid_minor": paid, "payment_status": status})
+	}
+	if err != nil {
+		configslog.Log.Error("Sağlayıcı ödemesi kaydedilemedi", zap.Uint("bookingID", booking.ID), zap.String("providerRef", charge.ID), zap.Error(err))
+		refundCharge(ctx, provider, charge)
+		return nil, ErrPaymentSaveFailed
+	}
+	booking.PaidMinor = paid
+	booking.PaymentStatus = status
+	configslog.SLog.Infof("Çevrim içi ödeme alındı: Booking ID %d, %d (%s)", booking.ID, charge.AmountMinor, charge.ID)
+	return charge, nil
+}
+
+// refundCharge kaydı tutulamayan bir çekimi iade eder. İade de başarısız olursa elle
+// düzeltilebilmesi için sağlayıcı referansıyla loglanır.
+func refundCharge(ctx context.Context, provider payment.Provider, charge *payment.Charge) {
+	if _, err := provider.Refund(context.WithoutCancel(ctx), charge.ID, charge.AmountMinor); err != nil {
+		configslog.Log.Error("Kaydedilemeyen ödeme iade edilemedi", zap.String("providerRef", charge.ID), zap.Int64("amountMinor", charge.AmountMinor), zap.Error(err))
+		return
+	}
+	configslog.SLog.Infof("Kaydedilemeyen ödeme iade edildi: %s, %d", charge.ID, charge.AmountMinor)
+}
+
+// refundProviderPayment çevrim içi alınmış ödemenin refund.AmountMinor kadarını ödemeyi alan
+// sağlayıcı üzerinden iade eder ve iade referansını refund'a yazar.
+func refundProviderPayment(ctx context.Context, provider payment.Provider, original *models.BookingPayment, refund *models.BookingPayment) error {
+	if provider == nil || provider.Name() != original.Provider {
+		return ErrPaymentProviderDisabled
+	}
+	result, err := provider.Refund(ctx, original.ProviderRef, refund.AmountMinor)
+	if err != nil {
+		configslog.Log.Error("Sağlayıcı iadesi başarısız", zap.Uint("paymentID", original.ID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrPaymentSaveFailed, err)
+	}
+	refund.Provider = provider.Name()
+	refund.ProviderRef = result.ID
+	return nil
+}
+
+// findOwnedBooking rezervasyonu bulur ve sağlayıcıya ait olduğunu doğrular.
+func (s *PaymentService) findOwnedBooking(ctx context.Context, bookingID uint, providerUserID uint) (*models.AppointmentBooking, error) {
+	booking, err := s.bookingRepo.FindByID(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, err
+	}
+	if booking.ProviderUserID != providerUserID {
+		return nil, ErrPaymentForbidden
+	}
+	return booking, nil
+}
+
+// --- Servis Metodları ---
+
+// OnlinePaymentsEnabled bir ödeme sağlayıcısı yapılandırılmışsa true döner.
+func (s *PaymentService) OnlinePaymentsEnabled() bool {
+	return s.provider != nil
+}
+
+// RecordManualPayment panelden nakit, havale veya POS ile alınan bir ödemeyi ya da
+// elle yapılan bir iadeyi kaydeder. İade tutarı net tahsil edilen tutarı aşamaz.
+func (s *PaymentService) RecordManualPayment(ctx context.Context, bookingID uint, providerUserID uint, input ManualPaymentInput) error {
+	if _, err := s.findOwnedBooking(ctx, bookingID, providerUserID); err != nil {
+		return err
+	}
+	if input.AmountMinor <= 0 {
+		return fmt.Errorf("%w: tutar sıfırdan büyük olmalı", ErrPaymentInvalidInput)
+	}
+	switch input.Method {
+	case models.PaymentMethodCash, models.PaymentMethodBankTransfer, models.PaymentMethodCard:
+	default:
+		return fmt.Errorf("%w: geçersiz ödeme yöntemi", ErrPaymentInvalidInput)
+	}
+	if input.Kind != models.PaymentKindPayment && input.Kind != models.PaymentKindRefund {
+		return fmt.Errorf("%w: geçersiz hareket tipi", ErrPaymentInvalidInput)
+	}
+	if !input.PaidAt.IsZero() && input.PaidAt.After(time.Now().Add(time.Minute)) {
+		return fmt.Errorf("%w: ödeme tarihi ileri bir tarih olamaz", ErrPaymentInvalidInput)
+	}
+
+	_, err := s.recordPayment(ctx, bookingID, providerUserID, func(booking *models.AppointmentBooking, payments []models.BookingPayment) (*models.BookingPayment, error) {
+		if input.Kind == models.PaymentKindRefund {
+			if paid, _ := ComputePaymentStatus(booking.AmountDueMinor, payments); input.AmountMinor > paid {
+				return nil, ErrPaymentRefundTooLarge
+			}
+		}
+		return &models.BookingPayment{
+			Kind:        input.Kind,
+			Method:      input.Method,
+			AmountMinor: input.AmountMinor,
+			PaidAt:      input.PaidAt.UTC(),
+			Note:        strings.TrimSpace(input.Note),
+		}, nil
+	})
+	if err != nil {
+		return err
+	}
+	configslog.SLog.Infof("Elle ödeme hareketi kaydedildi: Booking ID %d, %s %d (User ID %d)", bookingID, input.Kind, input.AmountMinor, providerUserID)
+	return nil
+}
+
+// RefundPayment belirli bir ödemenin tamamını veya bir kısmını iade eder. Çevrim içi alınmış
+// ödemeler sağlayıcı üzerinden iade edilir; diğerleri için yalnızca iade hareketi kaydedilir.
+func (s *PaymentService) RefundPayment(ctx context.Context, paymentID uint, providerUserID uint, amountMinor int64, note string) error {
+	original, err := s.repo.FindByID(ctx, paymentID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrPaymentNotFound
+		}
+		return err
+	}
+	if original.Kind != models.PaymentKindPayment {
+		return fmt.Errorf("%w: yalnızca tahsilatlar iade edilebilir", ErrPaymentInvalidInput)
+	}
+	if _, err := s.findOwnedBooking(ctx, original.BookingID, providerUserID); err != nil {
+		return err
+	}
+	payments, err := s.repo.FindByBookingID(ctx, original.BookingID)
+	if err != nil {
+		return err
+	}
+	remaining := original.AmountMinor - refundedAmount(payments, original.ID)
+	if amountMinor == 0 {
+		amountMinor = remaining
+	}
+	if amountMinor <= 0 || amountMinor > remaining {
+		return ErrPaymentRefundTooLarge
+	}
+
+	refund := &models.BookingPayment{
+		Kind:        models.PaymentKindRefund,
+		Method:      original.Method,
+		AmountMinor: amountMinor,
+		Note:        strings.TrimSpace(note),
+		RefundOfID:  &original.ID,
+	}
+	if original.Method == models.PaymentMethodOnline {
+		if err := refundProviderPayment(ctx, s.provider, original, refund); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.recordPayment(ctx, original.BookingID, providerUserID, func(booking *models.AppointmentBooking, current []models.BookingPayment) (*models.BookingPayment, error) {
+		if amountMinor > original.AmountMinor-refundedAmount(current, original.ID) {
+			return nil, ErrPaymentRefundTooLarge
+		}
+		return refund, nil
+	})
+	if err != nil {
+		if refund.ProviderRef != "" {
+			// Sağlayıcıda iade yapıldı ama kaydedilemedi: elle mutabakat için referans loglanır
+			configslog.Log.Error("Sağlayıcı iadesi kaydedilemedi", zap.Uint("paymentID", original.ID), zap.String("providerRef", refund.ProviderRef), zap.Error(err))
+		}
+		return err
+	}
+	configslog.SLog.Infof("Ödeme iade edildi: Payment ID %d, %d (User ID %d)", original.ID, amountMinor, providerUserID)
+	return nil
+}
+
+var _ IPaymentService = (*PaymentService)(nil)
@@ -0,0 +1,647 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"davet.link/configs"
+	"davet.link/configs/configsenv"
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/ics"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// WaitlistServiceError özel servis hataları
+type WaitlistServiceError string
+
+func (e WaitlistServiceError) Error() string { return string(e) }
+
+const (
+	ErrWaitlistNotFound      WaitlistServiceError = "bekleme listesi kaydı bulunamadı"
+	ErrWaitlistInvalidInput  WaitlistServiceError = "geçersiz bekleme listesi bilgisi"
+	ErrWaitlistNotOpen       WaitlistServiceError = "bu tarih için bekleme listesi açık değil"
+	ErrWaitlistDuplicate     WaitlistServiceError = "bu tarih için zaten bekleme listesindesiniz"
+	ErrWaitlistForbidden     WaitlistServiceError = "bu işlem için yetkiniz yok"
+	ErrWaitlistUpdateFailed  WaitlistServiceError = "bekleme listesi güncellenemedi"
+	ErrWaitlistOfferNotFound WaitlistServiceError = "teklif bulunamadı"
+	ErrWaitlistOfferExpired  WaitlistServiceError = "teklif artık geçerli değil"
+)
+
+const (
+	defaultWaitlistClaimMinutes = 120              // Hizmette kabul süresi tanımlı değilse kullanılır
+	freedSlotBatchSize          = 50               // Boşalan slot kuyruğundan tek seferde alınan kayıt
+	freedSlotLease              = 10 * time.Minute // Teklif yapılırken kaydın tekrar alınmaması için
+)
+
+// MailKindWaitlistOffer bekleme listesi teklif e-postalarının kuyruk türü (MailOutbox.Kind).
+const MailKindWaitlistOffer = "waitlist_offer"
+
+// WaitlistInput public sayfadan bekleme listesine katılma isteğidir.
+type WaitlistInput struct {
+	Date          time.Time // Hizmetin zaman dilimindeki gün
+	CustomerName  string
+	CustomerEmail string
+	CustomerPhone string
+	Notes         string
+}
+
+// IWaitlistService dolu günler için bekleme listesi ve boşalan slot teklifleri için arayüz.
+type IWaitlistService interface {
+	IsWaitlistOpen(ctx context.Context, appointment *models.Appointment, day time.Time) (bool, error)
+	JoinWaitlist(ctx context.Context, key string, input WaitlistInput) (*models.BookingWaitlistEntry, int, error)
+	ProcessFreedSlots(ctx context.Context) (int, error)
+	ProcessExpiredOffers(ctx context.Context) (int, error)
+	GetOffer(ctx context.Context, token string) (*models.BookingWaitlistEntry, error)
+	ClaimOffer(ctx context.Context, token string, answers map[uint]string) (*models.AppointmentBooking, error)
+	DeclineOffer(ctx context.Context, token string) error
+	GetWaitlist(ctx context.Context, appointmentID uint, providerUserID uint) (*models.Appointment, []models.BookingWaitlistEntry, error)
+	RemoveEntry(ctx context.Context, id uint, providerUserID uint) error
+}
+
+// WaitlistService IWaitlistService arayüzünü uygular.
+type WaitlistService struct {
+	repo                repositories.IBookingWaitlistRepository
+	freedSlotRepo       repositories.IWaitlistFreedSlotRepository
+	questionRepo        repositories.IAppointmentQuestionRepository
+	appointmentService  IAppointmentService
+	availabilityService IAvailabilityService
+	customerService     ICustomerService
+	userService         IUserService
+	db                  *gorm.DB // Transaction için
+}
+
+// NewWaitlistService yeni bir WaitlistService örneği oluşturur.
+func NewWaitlistService() IWaitlistService {
+	return &WaitlistService{
+		repo:                repositories.NewBookingWaitlistRepository(),
+		freedSlotRepo:       repositories.NewWaitlistFreedSlotRepository(),
+		questionRepo:        repositories.NewAppointmentQuestionRepository(),
+		appointmentService:  NewAppointmentService(),
+		availabilityService: NewAvailabilityService(),
+		customerService:     NewCustomerService(),
+		userService:         NewUserService(),
+		db:                  configs.GetDB(),
+	}
+}
+
+// --- Yardımcı Metodlar ---
+
+// PublicURL e-postalarda kullanılacak mutlak adresi APP_BASE_URL üzerinden üretir.
+func PublicURL(path string) string {
+	base := strings.TrimRight(configsenv.GetEnvWithDefault("APP_BASE_URL", "https://davet.link"), "/")
+	return base + path
+}
+
+// waitlistDate hizmetin zaman dilimindeki günü veritabanında tutulan takvim gününe çevirir.
+func waitlistDate(localDay time.Time) time.Time {
+	y, m, d := localDay.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// waitlistLocalDay kaydın gününü hizmetin zaman diliminde gün başına çevirir.
+func waitlistLocalDay(date time.Time, loc *time.Location) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// claimWindow teklifin kabul süresini döndürür.
+func claimWindow(detail models.AppointmentDetail) time.Duration {
+	minutes := detail.WaitlistClaimMinutes
+	if minutes <= 0 {
+		minutes = defaultWaitlistClaimMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// newOfferToken teklif bağlantısı için tahmin edilemez bir anahtar üretir.
+func newOfferToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// offerUsable teklifin hâlâ kabul edilebilir olup olmadığını bildirir.
+func offerUsable(entry *models.BookingWaitlistEntry, now time.Time) bool {
+	return entry.Status == models.WaitlistStatusOffered && entry.OfferExpiresAt != nil && entry.OfferExpiresAt.After(now) && entry.OfferStartsAt != nil
+}
+
+// enqueueFreedSlot boşalan slotu, slotu boşaltan transaction içinde teklif kuyruğuna yazar.
+// Teklifler ProcessFreedSlots ile arka plan işinden yapılır.
+func enqueueFreedSlot(ctx context.Context, repo repositories.IWaitlistFreedSlotRepository, appointmentID uint, providerUserID uint, startsAt time.Time) error {
+	return repo.Create(ctx, &models.WaitlistFreedSlot{
+		AppointmentID:  appointmentID,
+		ProviderUserID: providerUserID,
+		StartsAt:       startsAt.UTC(),
+		NextAttemptAt:  time.Now().UTC(),
+	})
+}
+
+// offerDay günün bekleyen müşterilerine sırayla müsait slot teklif eder; preferred saat
+// müsaitse (boşalan slot) önce o teklif edilir. Her teklif slotu tuttuğu için müsait slotlar
+// her adımda yeniden hesaplanır. Sağlayıcı kilidi altında çalışır; freed kuyruk kaydı
+// tekliflerle aynı transaction'da silinir.
+func (s *WaitlistService) offerDay(ctx context.Context, appointment *models.Appointment, localDay time.Time, preferred time.Time, freed *models.WaitlistFreedSlot) ([]models.BookingWaitlistEntry, error) {
+	detail := appointment.Detail
+	var offered []models.BookingWaitlistEntry
+
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, appointment.ProviderUserID), tx)
+		if err := lockProvider(tx, appointment.ProviderUserID); err != nil {
+			return err
+		}
+		repo := repositories.NewBookingWaitlistRepositoryTx(tx.WithContext(txCtx))
+		entries, err := repo.FindOpenForDay(txCtx, appointment.ID, waitlistDate(localDay))
+		if err != nil {
+			return err
+		}
+		outboxRepo := repositories.NewMailOutboxRepositoryTx(tx.WithContext(txCtx))
+
+		for i := range entries {
+			entry := &entries[i]
+			if entry.Status != models.WaitlistStatusWaiting {
+				continue
+			}
+			available, err := s.availabilityService.GetAvailableSlots(txCtx, appointment, localDay)
+			if err != nil {
+				return err
+			}
+			if len(available) == 0 {
+				break
+			}
+			slot := available[0]
+			for _, a := range available {
+				if a.Start.Equal(preferred) {
+					slot = a
+					break
+				}
+			}
+
+			token, err := newOfferToken()
+			if err != nil {
+				return err
+			}
+			// Teklif, slotun rezervasyona kapandığı andan (lead time) sonra geçerli kalmaz
+			now := time.Now().UTC()
+			expiresAt := now.Add(claimWindow(detail))
+			if latest := slot.Start.Add(-time.Duration(detail.BookingLeadTime) * time.Minute); expiresAt.After(latest) {
+				expiresAt = latest
+			}
+			busyStartsAt := slot.Start.Add(-time.Duration(detail.BufferTimeBefore) * time.Minute)
+			busyEndsAt := slot.End.Add(time.Duration(detail.BufferTimeAfter) * time.Minute)
+
+			entry.Status = models.WaitlistStatusOffered
+			entry.OfferToken = &token
+			entry.OfferStartsAt = &slot.Start
+			entry.OfferEndsAt = &slot.End
+			entry.OfferBusyStartsAt = &busyStartsAt
+			entry.OfferBusyEndsAt = &busyEndsAt
+			entry.OfferExpiresAt = &expiresAt
+			updateData := map[string]interface{}{
+				"status":               entry.Status,
+				"offer_token":          token,
+				"offer_starts_at":      slot.Start,
+				"offer_ends_at":        slot.End,
+				"offer_busy_starts_at": busyStartsAt,
+				"offer_busy_ends_at":   busyEndsAt,
+				"offer_expires_at":     expiresAt,
+			}
+			if err := repo.Update(txCtx, entry, updateData); err != nil {
+				return err
+			}
+			// Teklif e-postası teklifle birlikte yazılır; kuyruğa girmezse teklif de geri alınır.
+			if err := enqueueOfferMail(txCtx, outboxRepo, appointment, *entry); err != nil {
+				return err
+			}
+			offered = append(offered, *entry)
+		}
+		return repositories.NewWaitlistFreedSlotRepositoryTx(tx.WithContext(txCtx)).Delete(txCtx, freed.ID)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	for _, entry := range offered {
+		configslog.SLog.Infof("Bekleme listesi teklifi yapıldı: Waitlist ID %d, Appointment ID %d, %s", entry.ID, appointment.ID, entry.OfferStartsAt.Format(time.RFC3339))
+	}
+	return offered, nil
+}
+
+// enqueueOfferMail müşteriye boşalan slotu ve kabul bağlantısını bildiren e-postayı
+// teklifi yazan transaction içinde kuyruğa ekler.
+func enqueueOfferMail(ctx context.Context, repo repositories.IMailOutboxRepository, appointment *models.Appointment, entry models.BookingWaitlistEntry) error {
+	loc := AppointmentLocation(appointment.Detail)
+	startsAt := entry.OfferStartsAt.In(loc)
+	expiresAt := entry.OfferExpiresAt.In(loc)
+	text := fmt.Sprintf("Merhaba %s,\n\nBekleme listesinde olduğunuz %s için %s tarihinde saat %s boşaldı.\n"+
+		"Bu saat %s tarihinde %s saatine kadar sizin için ayrıldı. Randevuyu almak için:\n%s\n\n"+
+		"Bu sürede kabul edilmeyen teklif sıradaki kişiye aktarılır.",
+		entry.CustomerName, appointment.Detail.Name, startsAt.Format("02.01.2006"), startsAt.Format("15:04"),
+		expiresAt.Format("02.01.2006"), expiresAt.Format("15:04"), PublicURL("/waitlist/claim/"+*entry.OfferToken))
+
+	sourceID := entry.ID
+	return enqueueMail(ctx, repo, &models.MailOutbox{
+		OwnerUserID: appointment.ProviderUserID,
+		Kind:        MailKindWaitlistOffer,
+		SourceID:    &sourceID,
+		Recipients:  entry.CustomerEmail,
+		Subject:     mailSubject("Randevu için yer açıldı: " + appointment.Detail.Name),
+		TextBody:    text,
+	})
+}
+
+// releaseOffer teklifi kapanan kaydın tuttuğu slotu, aynı transaction içinde teklif kuyruğuna yazar.
+func releaseOffer(ctx context.Context, tx *gorm.DB, entry *models.BookingWaitlistEntry) error {
+	if entry.OfferStartsAt == nil {
+		return nil
+	}
+	return enqueueFreedSlot(ctx, repositories.NewWaitlistFreedSlotRepositoryTx(tx.WithContext(ctx)), entry.AppointmentID, entry.ProviderUserID, *entry.OfferStartsAt)
+}
+
+// offerFreedSlot kuyruktaki boşalan slotu, slotun günündeki bekleme listesine teklif eder.
+// Geçmişte kalan veya hizmeti silinmiş slotlar teklif yapılmadan kuyruktan düşülür.
+func (s *WaitlistService) offerFreedSlot(ctx context.Context, slot *models.WaitlistFreedSlot, now time.Time) (int, error) {
+	ctx = contextWithUserID(ctx, slot.ProviderUserID)
+	if !slot.StartsAt.After(now) {
+		return 0, s.freedSlotRepo.Delete(ctx, slot.ID)
+	}
+	appointment, err := s.appointmentService.GetAppointmentByID(ctx, slot.AppointmentID, slot.ProviderUserID)
+	if err != nil {
+		if errors.Is(err, ErrAppointmentNotFound) {
+			return 0, s.freedSlotRepo.Delete(ctx, slot.ID)
+		}
+		return 0, err
+	}
+	localDay := slot.StartsAt.In(AppointmentLocation(appointment.Detail))
+	offered, err := s.offerDay(ctx, appointment, localDay, slot.StartsAt.UTC(), slot)
+	return len(offered), err
+}
+
+// --- Servis Metodları ---
+
+// IsWaitlistOpen gün çalışma günüyse, rezervasyon aralığındaysa ve hiç müsait slot kalmadıysa true döner.
+func (s *WaitlistService) IsWaitlistOpen(ctx context.Context, appointment *models.Appointment, day time.Time) (bool, error) {
+	detail := appointment.Detail
+	loc := AppointmentLocation(detail)
+	localDay := waitlistLocalDay(day, loc)
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if localDay.Before(today) || localDay.After(today.AddDate(0, 0, detail.BookingHorizonDays)) {
+		return false, nil
+	}
+
+	windows, err := s.availabilityService.GetDayWindows(ctx, appointment, localDay)
+	if err != nil {
+		return false, err
+	}
+	if len(windows) == 0 {
+		return false, nil
+	}
+	available, err := s.availabilityService.GetAvailableSlots(ctx, appointment, localDay)
+	if err != nil {
+		return false, err
+	}
+	return len(available) == 0, nil
+}
+
+// JoinWaitlist müşteriyi dolu gün için bekleme listesine ekler ve sıradaki yerini döndürür.
+// Teklif bağlantısı e-postayla gönderildiği için e-posta adresi zorunludur.
+func (s *WaitlistService) JoinWaitlist(ctx context.Context, key string, input WaitlistInput) (*models.BookingWaitlistEntry, int, error) {
+	appointment, err := s.appointmentService.GetAppointmentByKey(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	input.CustomerName = strings.TrimSpace(input.CustomerName)
+	input.CustomerEmail = strings.TrimSpace(input.CustomerEmail)
+	input.CustomerPhone = strings.TrimSpace(input.CustomerPhone)
+	input.Notes = strings.TrimSpace(input.Notes)
+	if input.CustomerName == "" {
+		return nil, 0, fmt.Errorf("%w: ad soyad zorunludur", ErrWaitlistInvalidInput)
+	}
+	if _, err := mail.ParseAddress(input.CustomerEmail); err != nil {
+		return nil, 0, fmt.Errorf("%w: geçerli bir e-posta adresi gereklidir", ErrWaitlistInvalidInput)
+	}
+
+	open, err := s.IsWaitlistOpen(ctx, appointment, input.Date)
+	if err != nil {
+		return nil, 0, ErrWaitlistUpdateFailed
+	}
+	if !open {
+		return nil, 0, ErrWaitlistNotOpen
+	}
+	if err := s.customerService.CheckBookingAllowed(ctx, appointment.ProviderUserID, input.CustomerEmail, input.CustomerPhone); err != nil {
+		if !errors.Is(err, ErrCustomerBlocked) {
+			return nil, 0, ErrWaitlistUpdateFailed
+		}
+		return nil, 0, err
+	}
+
+	date := waitlistDate(input.Date)
+	existing, err := s.repo.FindOpenForDay(ctx, appointment.ID, date)
+	if err != nil {
+		return nil, 0, ErrWaitlistUpdateFailed
+	}
+	email := NormalizeEmail(input.CustomerEmail)
+	for _, e := range existing {
+		if NormalizeEmail(e.CustomerEmail) == email {
+			return nil, 0, ErrWaitlistDuplicate
+		}
+	}
+
+	entry := &models.BookingWaitlistEntry{
+		AppointmentID:  appointment.ID,
+		ProviderUserID: appointment.ProviderUserID,
+		Date:           date,
+		Status:         models.WaitlistStatusWaiting,
+		CustomerName:   input.CustomerName,
+		CustomerEmail:  input.CustomerEmail,
+		CustomerPhone:  input.CustomerPhone,
+		Notes:          input.Notes,
+	}
+	// Public işlem: BaseModel hook'ları için aktör olarak sağlayıcı kullanılır.
+	if err := s.repo.Create(contextWithUserID(ctx, appointment.ProviderUserID), entry); err != nil {
+		configslog.Log.Error("Bekleme listesi kaydı oluşturulamadı", zap.Uint("appointmentID", appointment.ID), zap.Error(err))
+		return nil, 0, ErrWaitlistUpdateFailed
+	}
+	configslog.SLog.Infof("Bekleme listesine eklendi: Waitlist ID %d, Appointment ID %d, %s", entry.ID, appointment.ID, date.Format("2006-01-02"))
+	return entry, len(existing) + 1, nil
+}
+
+// ProcessFreedSlots iptal, taşıma veya serbest kalan tekliflerle kuyruğa yazılan slotları bekleme
+// listesine teklif eder ve yapılan teklif sayısını döndürür. Başarısız kayıtlar artan aralıklarla
+// yeniden denenir. Arka plan işinden periyodik olarak çağrılır.
+func (s *WaitlistService) ProcessFreedSlots(ctx context.Context) (int, error) {
+	offered := 0
+	for {
+		now := time.Now().UTC()
+		slots, err := s.freedSlotRepo.ClaimDue(ctx, now, freedSlotLease, freedSlotBatchSize)
+		if err != nil {
+			return offered, err
+		}
+		for i := range slots {
+			slot := &slots[i]
+			count, err := s.offerFreedSlot(ctx, slot, now)
+			if err == nil {
+				offered += count
+				continue
+			}
+			attempts := slot.Attempts + 1
+			message := err.Error()
+			if len(message) > maxMailErrorLength {
+				message = strings.ToValidUTF8(message[:maxMailErrorLength], "")
+			}
+			configslog.Log.Warn("Boşalan slot teklif edilemedi", zap.Uint("freedSlotID", slot.ID), zap.Int("attempt", attempts), zap.Error(err))
+			data := map[string]interface{}{
+				"attempts":        attempts,
+				"next_attempt_at": now.Add(mailRetryDelay(attempts)),
+				"last_error":      message,
+			}
+			if err := s.freedSlotRepo.Update(contextWithUserID(ctx, slot.ProviderUserID), slot, data); err != nil {
+				configslog.Log.Error("Boşalan slot kaydı güncellenemedi", zap.Uint("freedSlotID", slot.ID), zap.Error(err))
+			}
+		}
+		if len(slots) < freedSlotBatchSize || ctx.Err() != nil {
+			return offered, nil
+		}
+	}
+}
+
+// ProcessExpiredOffers kabul süresi dolan teklifleri kapatıp slotu yeniden teklif için kuyruğa yazar;
+// günü geçmiş bekleyen kayıtları da kapatır. Arka plan işinden periyodik olarak çağrılır.
+func (s *WaitlistService) ProcessExpiredOffers(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	if _, err := s.repo.ExpirePastDays(ctx, waitlistDate(now.AddDate(0, 0, -1))); err != nil {
+		return 0, err
+	}
+	expired, err := s.repo.FindExpiredOffers(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := range expired {
+		entry := &expired[i]
+		released := false
+		txErr := s.db.Transaction(func(tx *gorm.DB) error {
+			txCtx := withTx(contextWithUserID(ctx, entry.ProviderUserID), tx)
+			repo := repositories.NewBookingWaitlistRepositoryTx(tx.WithContext(txCtx))
+			// Aynı anda kabul edilmiş olabilir: kilit altında yeniden kontrol edilir
+			locked, err := repo.FindByIDForUpdate(txCtx, entry.ID)
+			if err != nil {
+				return err
+			}
+			if locked.Status != models.WaitlistStatusOffered || locked.OfferExpiresAt == nil || locked.OfferExpiresAt.After(now) {
+				return nil
+			}
+			released = true
+			if err := repo.Update(txCtx, locked, map[string]interface{}{"status": models.WaitlistStatusExpired}); err != nil {
+				return err
+			}
+			return releaseOffer(txCtx, tx, locked)
+		})
+		if txErr != nil {
+			configslog.Log.Error("Süresi dolan teklif kapatılamadı", zap.Uint("waitlistID", entry.ID), zap.Error(txErr))
+			continue
+		}
+		if released {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetOffer gizli anahtara ait teklifi hizmet detaylarıyla getirir; durum kontrolü çağırana bırakılır.
+func (s *WaitlistService) GetOffer(ctx context.Context, token string) (*models.BookingWaitlistEntry, error) {
+	entry, err := s.repo.FindByOfferToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrWaitlistOfferNotFound
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ClaimOffer teklif edilen slot için rezervasyon oluşturur. Teklif kilit altında kapatılır,
+// böylece tutma kalkar ve slot motoru saati diğer rezervasyonlara karşı yeniden doğrular.
+func (s *WaitlistService) ClaimOffer(ctx context.Context, token string, answers map[uint]string) (*models.AppointmentBooking, error) {
+	entry, err := s.GetOffer(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !offerUsable(entry, time.Now()) {
+		return nil, ErrWaitlistOfferExpired
+	}
+	appointment := &entry.Appointment
+	questions, err := s.questionRepo.FindByAppointmentID(ctx, appointment.ID)
+	if err != nil {
+		return nil, ErrBookingCreationFailed
+	}
+	validAnswers, err := ValidateBookingAnswers(questions, answers)
+	if err != nil {
+		return nil, err
+	}
+
+	startsAt := entry.OfferStartsAt.UTC()
+	booking := newBooking(appointment, startsAt, BookingInput{
+		CustomerName:  entry.CustomerName,
+		CustomerEmail: entry.CustomerEmail,
+		CustomerPhone: entry.CustomerPhone,
+		Notes:         entry.Notes,
+	}, validAnswers)
+	if appointment.Detail.RequiresApproval {
+		booking.Status = models.BookingStatusPending
+	}
+
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, appointment.ProviderUserID), tx)
+		if err := lockProvider(tx, appointment.ProviderUserID); err != nil {
+			return ErrBookingCreationFailed
+		}
+		repo := repositories.NewBookingWaitlistRepositoryTx(tx.WithContext(txCtx))
+		locked, err := repo.FindByIDForUpdate(txCtx, entry.ID)
+		if err != nil {
+			return ErrBookingCreationFailed
+		}
+		if !offerUsable(locked, time.Now()) {
+			return ErrWaitlistOfferExpired
+		}
+		if err := repo.Update(txCtx, locked, map[string]interface{}{"status": models.WaitlistStatusClaimed}); err != nil {
+			return ErrBookingCreationFailed
+		}
+
+		available, err := s.availabilityService.GetAvailableSlots(txCtx, appointment, startsAt.In(AppointmentLocation(appointment.Detail)))
+		if err != nil {
+			return ErrBookingCreationFailed
+		}
+		slotFound := false
+		for _, slot := range available {
+			if slot.Start.Equal(startsAt) {
+				slotFound = true
+				break
+			}
+		}
+		if !slotFound {
+			return ErrBookingSlotUnavailable
+		}
+
+		if err := repositories.NewAppointmentBookingRepositoryTx(tx.WithContext(txCtx)).Create(txCtx, booking); err != nil {
+			configslog.Log.Error("ClaimOffer: Rezervasyon kaydedilemedi", zap.Uint("waitlistID", entry.ID), zap.Error(err))
+			return ErrBookingCreationFailed
+		}
+		if err := enqueueBookingWebhook(txCtx, repositories.NewWebhookRepositoryTx(tx.WithContext(txCtx)), models.WebhookEventBookingCreated, booking); err != nil {
+			return ErrBookingCreationFailed
+		}
+		if booking.Status == models.BookingStatusConfirmed {
+			if err := enqueueBookingInvite(txCtx, repositories.NewMailOutboxRepositoryTx(tx.WithContext(txCtx)), s.userService, appointment, *booking, ics.MethodRequest); err != nil {
+				return ErrBookingCreationFailed
+			}
+		}
+		return repo.Update(txCtx, locked, map[string]interface{}{"booking_id": booking.ID})
+	})
+	if txErr != nil {
+		if !errors.Is(txErr, ErrWaitlistOfferExpired) && !errors.Is(txErr, ErrBookingSlotUnavailable) {
+			configslog.Log.Error("Bekleme listesi teklifi kabul edilemedi", zap.Uint("waitlistID", entry.ID), zap.Error(txErr))
+		}
+		return nil, txErr
+	}
+
+	configslog.SLog.Infof("Bekleme listesi teklifi kabul edildi: Waitlist ID %d, Booking ID %d", entry.ID, booking.ID)
+	return booking, nil
+}
+
+// DeclineOffer müşterinin teklifi reddetmesini kaydeder; slot sıradaki müşteriye teklif edilmek
+// üzere aynı transaction içinde kuyruğa yazılır.
+func (s *WaitlistService) DeclineOffer(ctx context.Context, token string) error {
+	entry, err := s.GetOffer(ctx, token)
+	if err != nil {
+		return err
+	}
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, entry.ProviderUserID), tx)
+		repo := repositories.NewBookingWaitlistRepositoryTx(tx.WithContext(txCtx))
+		locked, err := repo.FindByIDForUpdate(txCtx, entry.ID)
+		if err != nil {
+			return ErrWaitlistUpdateFailed
+		}
+		if !offerUsable(locked, time.Now()) {
+			return ErrWaitlistOfferExpired
+		}
+		if err := repo.Update(txCtx, locked, map[string]interface{}{"status": models.WaitlistStatusDeclined}); err != nil {
+			return err
+		}
+		return releaseOffer(txCtx, tx, locked)
+	})
+	if txErr != nil {
+		return txErr
+	}
+	configslog.SLog.Infof("Bekleme listesi teklifi reddedildi: Waitlist ID %d", entry.ID)
+	return nil
+}
+
+// GetWaitlist hizmetin bugünden itibaren bekleme listesini getirir (yetki kontrolü ile).
+func (s *WaitlistService) GetWaitlist(ctx context.Context, appointmentID uint, providerUserID uint) (*models.Appointment, []models.BookingWaitlistEntry, error) {
+	appointment, err := s.appointmentService.GetAppointmentByID(ctx, appointmentID, providerUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	today := waitlistDate(time.Now().In(AppointmentLocation(appointment.Detail)))
+	entries, err := s.repo.FindByAppointmentFrom(ctx, appointmentID, today)
+	if err != nil {
+		return nil, nil, err
+	}
+	return appointment, entries, nil
+}
+
+// RemoveEntry sağlayıcının bir müşteriyi bekleme listesinden çıkarmasını sağlar.
+// Müşteriye teklif yapılmışsa tutulan slot sıradaki müşteriye teklif edilir.
+func (s *WaitlistService) RemoveEntry(ctx context.Context, id uint, providerUserID uint) error {
+	entry, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrWaitlistNotFound
+		}
+		return err
+	}
+	if entry.ProviderUserID != providerUserID {
+		return ErrWaitlistForbidden
+	}
+
+	txErr := s.db.Transaction(func(tx *gorm.DB) error {
+		txCtx := withTx(contextWithUserID(ctx, providerUserID), tx)
+		repo := repositories.NewBookingWaitlistRepositoryTx(tx.WithContext(txCtx))
+		locked, err := repo.FindByIDForUpdate(txCtx, id)
+		if err != nil {
+			return ErrWaitlistUpdateFailed
+		}
+		if locked.Status != models.WaitlistStatusWaiting && locked.Status != models.WaitlistStatusOffered {
+			return fmt.Errorf("%w: kayıt artık listede değil", ErrWaitlistInvalidInput)
+		}
+		wasOffered := offerUsable(locked, time.Now())
+		if err := repo.Update(txCtx, locked, map[string]interface{}{"status": models.WaitlistStatusRemoved}); err != nil {
+			return err
+		}
+		if !wasOffered {
+			return nil
+		}
+		return releaseOffer(txCtx, tx, locked)
+	})
+	if txErr != nil {
+		return txErr
+	}
+	configslog.SLog.Infof("Bekleme listesinden çıkarıldı: Waitlist ID %d (User ID %d)", id, providerUserID)
+	return nil
+}
+
+var _ IWaitlistService = (*WaitlistService)(nil)
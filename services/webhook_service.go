@@ -0,0 +1,557 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"davet.link/configs/configsenv"
+	"davet.link/configs/configslog"
+	"davet.link/models"
+	"davet.link/pkg/safehttp"
+	"davet.link/pkg/webhook"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+// WebhookServiceError özel servis hataları
+type WebhookServiceError string
+
+func (e WebhookServiceError) Error() string { return string(e) }
+
+const (
+	ErrWebhookNotFound         WebhookServiceError = "webhook bulunamadı"
+	ErrWebhookForbidden        WebhookServiceError = "bu webhook üzerinde yetkiniz yok"
+	ErrWebhookInvalid          WebhookServiceError = "webhook bilgileri geçersiz"
+	ErrWebhookDeliveryNotFound WebhookServiceError = "teslimat kaydı bulunamadı"
+	ErrWebhookEndpointInactive WebhookServiceError = "webhook silinmiş veya devre dışı; teslimat yeniden gönderilemez"
+)
+
+const (
+	defaultWebhookMaxAttempts    = 8
+	defaultWebhookTimeoutSeconds = 10
+	webhookBatchSize             = 50
+	webhookLease                 = 5 * time.Minute // Gönderim sürerken kaydın tekrar alınmaması için
+	maxWebhookRetryDelay         = 6 * time.Hour
+	maxWebhookErrorLength        = 1000
+	maxWebhookEndpointsPerUser   = 10
+	maxWebhookNameLength         = 100
+	maxWebhookURLLength          = 2048
+	webhookDeliveryLogLimit      = 100
+)
+
+// WebhookEndpointInput panelden gelen uç nokta bilgileridir.
+type WebhookEndpointInput struct {
+	Name     string
+	URL      string
+	Events   []string
+	IsActive bool
+}
+
+// WebhookPayload alıcıya gönderilen JSON gövdesidir. ID olayın kimliğidir; aynı olayın
+// yeniden denemelerinde ve elle yeniden gönderimlerinde değişmez, alıcı tekrarları bununla ayıklayabilir.
+type WebhookPayload struct {
+	ID        string              `json:"id"`
+	Event     models.WebhookEvent `json:"event"`
+	CreatedAt time.Time           `json:"created_at"`
+	Data      interface{}         `json:"data"`
+}
+
+// webhookFormSubmission form.submitted olayının verisidir.
+type webhookFormSubmission struct {
+	FormID       uint                `json:"form_id"`
+	FormTitle    string              `json:"form_title"`
+	SubmissionID uint                `json:"submission_id"`
+	SubmittedAt  time.Time           `json:"submitted_at"`
+	Score        *int                `json:"score,omitempty"`
+	MaxScore     *int                `json:"max_score,omitempty"`
+	Passed       *bool               `json:"passed,omitempty"`
+	Answers      []webhookFormAnswer `json:"answers"`
+}
+
+type webhookFormAnswer struct {
+	FieldID uint                 `json:"field_id"`
+	Label   string               `json:"label"`
+	Type    models.FormFieldType `json:"type"`
+	Value   string               `json:"value"`
+	Files   []string             `json:"files,omitempty"` // Dosya adları; içerik panelden indirilir
+}
+
+// webhookBooking booking.created ve booking.cancelled olaylarının verisidir.
+type webhookBooking struct {
+	BookingID      uint                   `json:"booking_id"`
+	AppointmentID  uint                   `json:"appointment_id"`
+	Status         models.BookingStatus   `json:"status"`
+	StartsAt       time.Time              `json:"starts_at"`
+	EndsAt         time.Time              `json:"ends_at"`
+	CustomerName   string                 `json:"customer_name"`
+	CustomerEmail  string                 `json:"customer_email,omitempty"`
+	CustomerPhone  string                 `json:"customer_phone,omitempty"`
+	Notes          string                 `json:"notes,omitempty"`
+	SeriesID       *uint                  `json:"series_id,omitempty"`
+	SeriesIndex    int                    `json:"series_index,omitempty"`
+	Currency       string                 `json:"currency"`
+	AmountDueMinor int64                  `json:"amount_due_minor"`
+	PaymentStatus  models.PaymentStatus   `json:"payment_status"`
+	CancelledAt    *time.Time             `json:"cancelled_at,omitempty"`
+	Answers        []webhookBookingAnswer `json:"answers,omitempty"`
+}
+
+type webhookBookingAnswer struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// webhookRSVP rsvp.updated olayının verisidir.
+type webhookRSVP struct {
+	InvitationID uint              `json:"invitation_id"`
+	RSVPID       uint              `json:"rsvp_id"`
+	GuestID      *uint             `json:"guest_id,omitempty"`
+	Status       models.RSVPStatus `json:"status"`
+	PlusOnes     int               `json:"plus_ones"`
+	Notes        string            `json:"notes,omitempty"`
+	RespondedAt  *time.Time        `json:"responded_at,omitempty"`
+}
+
+// newWebhookEventID olay için tahmin edilemez bir kimlik üretir.
+func newWebhookEventID() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "evt_" + hex.EncodeToString(buf), nil
+}
+
+// newWebhookSecret uç noktanın imza anahtarını üretir.
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// enqueueWebhookEvent olayı kullanıcının abone olan her etkin uç noktası için kuyruğa ekler.
+// ctx içinde transaction varsa teslimatlar onunla birlikte yazılır; geri alınan işlemin olayı
+// gönderilmez. Abone uç nokta yoksa hiçbir şey yapmaz.
+func enqueueWebhookEvent(ctx context.Context, repo repositories.IWebhookRepository, userID uint, event models.WebhookEvent, data interface{}) error {
+	endpoints, err := repo.FindActiveEndpoints(ctx, userID)
+	if err != nil {
+		return err
+	}
+	var subscribed []models.WebhookEndpoint
+	for _, endpoint := range endpoints {
+		if endpoint.Subscribes(event) {
+			subscribed = append(subscribed, endpoint)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	eventID, err := newWebhookEventID()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	body, err := json.Marshal(WebhookPayload{ID: eventID, Event: event, CreatedAt: now, Data: data})
+	if err != nil {
+		return err
+	}
+	ctx = contextWithUserID(ctx, userID)
+	for _, endpoint := range subscribed {
+		delivery := &models.WebhookDelivery{
+			EndpointID:    endpoint.ID,
+			Event:         event,
+			EventID:       eventID,
+			Payload:       string(body),
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: now,
+		}
+		if err := repo.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueueSubmissionWebhook form.submitted olayını form sahibinin uç noktalarına kuyruğa ekler.
+func enqueueSubmissionWebhook(ctx context.Context, repo repositories.IWebhookRepository, form *models.Form, submission *models.FormSubmission) error {
+	data := webhookFormSubmission{
+		FormID:       form.ID,
+		FormTitle:    form.Detail.Title,
+		SubmissionID: submission.ID,
+		SubmittedAt:  submission.SubmittedAt,
+		Score:        submission.Score,
+		MaxScore:     submission.MaxScore,
+		Passed:       submission.Passed,
+		Answers:      make([]webhookFormAnswer, 0, len(submission.Answers)),
+	}
+	for _, answer := range submission.Answers {
+		item := webhookFormAnswer{FieldID: answer.FieldID, Label: answer.FieldLabel, Type: answer.FieldType, Value: answer.Value}
+		for _, file := range answer.Files {
+			item.Files = append(item.Files, file.FileName)
+		}
+		data.Answers = append(data.Answers, item)
+	}
+	return enqueueWebhookEvent(ctx, repo, form.CreatorUserID, models.WebhookEventFormSubmitted, data)
+}
+
+// enqueueBookingWebhook rezervasyon olayını sağlayıcının uç noktalarına kuyruğa ekler.
+func enqueueBookingWebhook(ctx context.Context, repo repositories.IWebhookRepository, event models.WebhookEvent, booking *models.AppointmentBooking) error {
+	data := webhookBooking{
+		BookingID:      booking.ID,
+		AppointmentID:  booking.AppointmentID,
+		Status:         booking.Status,
+		StartsAt:       booking.StartsAt,
+		EndsAt:         booking.EndsAt,
+		CustomerName:   booking.CustomerName,
+		CustomerEmail:  booking.CustomerEmail,
+		CustomerPhone:  booking.CustomerPhone,
+		Notes:          booking.Notes,
+		SeriesID:       booking.SeriesID,
+		SeriesIndex:    booking.SeriesIndex,
+		Currency:       booking.Currency,
+		AmountDueMinor: booking.AmountDueMinor,
+		PaymentStatus:  booking.PaymentStatus,
+		CancelledAt:    booking.CancelledAt,
+	}
+	for _, answer := range booking.Answers {
+		data.Answers = append(data.Answers, webhookBookingAnswer{Question: answer.Question, Answer: answer.Answer})
+	}
+	return enqueueWebhookEvent(ctx, repo, booking.ProviderUserID, event, data)
+}
+
+// enqueueRSVPWebhook rsvp.updated olayını davetiye sahibinin uç noktalarına kuyruğa ekler.
+// InvitationService.SubmitRSVP tarafından LCV kaydıyla aynı transaction'da çağrılır.
+func enqueueRSVPWebhook(ctx context.Context, repo repositories.IWebhookRepository, invitation *models.Invitation, rsvp *models.InvitationRSVP) error {
+	data := webhookRSVP{
+		InvitationID: invitation.ID,
+		RSVPID:       rsvp.ID,
+		GuestID:      rsvp.InvitationGuestID,
+		Status:       rsvp.Status,
+		PlusOnes:     rsvp.PlusOnes,
+		Notes:        rsvp.Notes,
+		RespondedAt:  rsvp.RespondedAt,
+	}
+	return enqueueWebhookEvent(ctx, repo, invitation.CreatorUserID, models.WebhookEventRSVPUpdated, data)
+}
+
+// webhookRetryDelay n. başarısız denemeden sonra beklenecek süre: 1, 2, 4... dakika (en fazla 6 saat).
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := time.Minute
+	for i := 1; i < attempts && delay < maxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxWebhookRetryDelay {
+		return maxWebhookRetryDelay
+	}
+	return delay
+}
+
+// IWebhookService webhook uç noktalarını yönetir ve kuyruktaki teslimatları gönderir.
+type IWebhookService interface {
+	GetEndpoints(ctx context.Context, userID uint) ([]models.WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, userID uint, id uint) (*models.WebhookEndpoint, error)
+	CreateEndpoint(ctx context.Context, userID uint, input WebhookEndpointInput) (*models.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, userID uint, id uint, input WebhookEndpointInput) error
+	RotateSecret(ctx context.Context, userID uint, id uint) error
+	DeleteEndpoint(ctx context.Context, userID uint, id uint) error
+	GetDeliveries(ctx context.Context, userID uint, endpointID uint) ([]models.WebhookDelivery, error)
+	Resend(ctx context.Context, userID uint, deliveryID uint) (*models.WebhookDelivery, error)
+	ProcessDue(ctx context.Context) (delivered int, failed int, err error)
+}
+
+// WebhookService IWebhookService arayüzünü uygular.
+type WebhookService struct {
+	repo        repositories.IWebhookRepository
+	client      *http.Client
+	maxAttempts int
+}
+
+// NewWebhookService yeni bir WebhookService örneği oluşturur.
+func NewWebhookService() IWebhookService {
+	maxAttempts := configsenv.GetEnvAsInt("WEBHOOK_MAX_ATTEMPTS", defaultWebhookMaxAttempts)
+	if maxAttempts < 1 {
+		maxAttempts = defaultWebhookMaxAttempts
+	}
+	timeout := configsenv.GetEnvAsInt("WEBHOOK_TIMEOUT_SECONDS", defaultWebhookTimeoutSeconds)
+	if timeout < 1 {
+		timeout = defaultWebhookTimeoutSeconds
+	}
+	return &WebhookService{
+		repo:        repositories.NewWebhookRepository(),
+		client:      safehttp.NewClient(time.Duration(timeout)*time.Second, 0),
+		maxAttempts: maxAttempts,
+	}
+}
+
+// validateWebhookInput uç nokta bilgilerini doğrular; adres ve olay listesini normalleştirir.
+func validateWebhookInput(input *WebhookEndpointInput) (string, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return "", fmt.Errorf("%w: ad zorunludur", ErrWebhookInvalid)
+	}
+	if utf8.RuneCountInString(input.Name) > maxWebhookNameLength {
+		return "", fmt.Errorf("%w: ad en fazla %d karakter olabilir", ErrWebhookInvalid, maxWebhookNameLength)
+	}
+	address, err := webhook.NormalizeURL(input.URL)
+	if err != nil || len(address) > maxWebhookURLLength {
+		return "", fmt.Errorf("%w: adres http:// veya https:// ile başlayan geçerli bir URL olmalıdır", ErrWebhookInvalid)
+	}
+	input.URL = address
+
+	selected := make(map[models.WebhookEvent]bool)
+	for _, event := range input.Events {
+		selected[models.WebhookEvent(strings.TrimSpace(event))] = true
+	}
+	var events []string
+	for _, event := range models.WebhookEvents {
+		if selected[event] {
+			events = append(events, string(event))
+			delete(selected, event)
+		}
+	}
+	if len(selected) > 0 {
+		return "", fmt.Errorf("%w: bilinmeyen olay türü", ErrWebhookInvalid)
+	}
+	if len(events) == 0 {
+		return "", fmt.Errorf("%w: en az bir olay seçilmelidir", ErrWebhookInvalid)
+	}
+	return strings.Join(events, ","), nil
+}
+
+// ownedEndpoint uç noktayı bulur ve kullanıcıya ait olduğunu doğrular.
+func (s *WebhookService) ownedEndpoint(ctx context.Context, userID uint, id uint) (*models.WebhookEndpoint, error) {
+	endpoint, err := s.repo.FindEndpointByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	if endpoint.UserID != userID {
+		return nil, ErrWebhookForbidden
+	}
+	return endpoint, nil
+}
+
+// GetEndpoints kullanıcının uç noktalarını getirir.
+func (s *WebhookService) GetEndpoints(ctx context.Context, userID uint) ([]models.WebhookEndpoint, error) {
+	return s.repo.FindEndpointsByUserID(ctx, userID)
+}
+
+// GetEndpoint kullanıcının uç noktasını getirir.
+func (s *WebhookService) GetEndpoint(ctx context.Context, userID uint, id uint) (*models.WebhookEndpoint, error) {
+	return s.ownedEndpoint(ctx, userID, id)
+}
+
+// CreateEndpoint yeni bir uç nokta oluşturur ve imza anahtarını üretir.
+func (s *WebhookService) CreateEndpoint(ctx context.Context, userID uint, input WebhookEndpointInput) (*models.WebhookEndpoint, error) {
+	events, err := validateWebhookInput(&input)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.FindEndpointsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxWebhookEndpointsPerUser {
+		return nil, fmt.Errorf("%w: en fazla %d webhook tanımlanabilir", ErrWebhookInvalid, maxWebhookEndpointsPerUser)
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	endpoint := &models.WebhookEndpoint{
+		UserID:   userID,
+		Name:     input.Name,
+		URL:      input.URL,
+		Secret:   secret,
+		Events:   events,
+		IsActive: input.IsActive,
+	}
+	if err := s.repo.CreateEndpoint(contextWithUserID(ctx, userID), endpoint); err != nil {
+		return nil, err
+	}
+	if !input.IsActive {
+		// default:true olan sütunda false değeri Create ile yazılmaz.
+		if err := s.repo.UpdateEndpoint(contextWithUserID(ctx, userID), endpoint, map[string]interface{}{"is_active": false}); err != nil {
+			return nil, err
+		}
+	}
+	configslog.Log.Info("Webhook oluşturuldu", zap.Uint("endpointID", endpoint.ID), zap.Uint("userID", userID))
+	return endpoint, nil
+}
+
+// UpdateEndpoint uç noktanın adını, adresini, olaylarını ve etkinliğini günceller.
+func (s *WebhookService) UpdateEndpoint(ctx context.Context, userID uint, id uint, input WebhookEndpointInput) error {
+	endpoint, err := s.ownedEndpoint(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	events, err := validateWebhookInput(&input)
+	if err != nil {
+		return err
+	}
+	data := map[string]interface{}{
+		"name":      input.Name,
+		"url":       input.URL,
+		"events":    events,
+		"is_active": input.IsActive,
+	}
+	return s.repo.UpdateEndpoint(contextWithUserID(ctx, userID), endpoint, data)
+}
+
+// RotateSecret uç noktaya yeni bir imza anahtarı üretir. Kuyruktaki teslimatlar da yeni
+// anahtarla imzalanır.
+func (s *WebhookService) RotateSecret(ctx context.Context, userID uint, id uint) error {
+	endpoint, err := s.ownedEndpoint(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return err
+	}
+	return s.repo.UpdateEndpoint(contextWithUserID(ctx, userID), endpoint, map[string]interface{}{"secret": secret})
+}
+
+// DeleteEndpoint uç noktayı siler. Bekleyen teslimatları gönderim sırasında başarısız sayılır.
+func (s *WebhookService) DeleteEndpoint(ctx context.Context, userID uint, id uint) error {
+	endpoint, err := s.ownedEndpoint(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteEndpoint(contextWithUserID(ctx, userID), endpoint, userID)
+}
+
+// GetDeliveries uç noktanın son teslimat kayıtlarını yeniden eskiye getirir.
+func (s *WebhookService) GetDeliveries(ctx context.Context, userID uint, endpointID uint) ([]models.WebhookDelivery, error) {
+	if _, err := s.ownedEndpoint(ctx, userID, endpointID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindDeliveriesByEndpointID(ctx, endpointID, webhookDeliveryLogLimit)
+}
+
+// Resend teslimatın olayını aynı gövdeyle yeniden kuyruğa ekler. Kaynak kayıt olduğu gibi
+// kalır; yeni kayıt ResentFromID ile ona bağlanır ve ilk denemesi bir sonraki çalışmada yapılır.
+func (s *WebhookService) Resend(ctx context.Context, userID uint, deliveryID uint) (*models.WebhookDelivery, error) {
+	source, err := s.repo.FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	if source.Endpoint.UserID != userID {
+		return nil, ErrWebhookForbidden
+	}
+	if source.Endpoint.DeletedAt.Valid || !source.Endpoint.IsActive {
+		return nil, ErrWebhookEndpointInactive
+	}
+	sourceID := source.ID
+	delivery := &models.WebhookDelivery{
+		EndpointID:    source.EndpointID,
+		Event:         source.Event,
+		EventID:       source.EventID,
+		Payload:       source.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: time.Now().UTC(),
+		ResentFromID:  &sourceID,
+	}
+	if err := s.repo.CreateDelivery(contextWithUserID(ctx, userID), delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// ProcessDue zamanı gelen teslimatları gönderir. Başarısız gönderimler artan aralıklarla
+// yeniden denenir; deneme hakkı biten teslimat failed olarak işaretlenir.
+func (s *WebhookService) ProcessDue(ctx context.Context) (int, int, error) {
+	delivered, failed := 0, 0
+	for {
+		now := time.Now().UTC()
+		deliveries, err := s.repo.ClaimDue(ctx, now, webhookLease, webhookBatchSize)
+		if err != nil {
+			return delivered, failed, err
+		}
+		for i := range deliveries {
+			if s.deliver(ctx, &deliveries[i]) {
+				delivered++
+			} else {
+				failed++
+			}
+		}
+		if len(deliveries) < webhookBatchSize || ctx.Err() != nil {
+			return delivered, failed, nil
+		}
+	}
+}
+
+// deliver tek bir teslimatı gönderir ve sonucunu kaydeder.
+func (s *WebhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) bool {
+	endpoint := delivery.Endpoint
+	now := time.Now().UTC()
+	attempts := delivery.Attempts + 1
+	data := map[string]interface{}{"attempts": attempts}
+
+	var sendErr error
+	giveUp := attempts >= s.maxAttempts
+	if endpoint.ID == 0 || endpoint.DeletedAt.Valid || !endpoint.IsActive {
+		// Uç nokta kaldırıldıysa yeniden denemenin anlamı yok.
+		sendErr = ErrWebhookEndpointInactive
+		giveUp = true
+	} else {
+		resp, err := webhook.Send(ctx, s.client, webhook.Request{
+			URL:        endpoint.URL,
+			Secret:     endpoint.Secret,
+			Event:      string(delivery.Event),
+			DeliveryID: strconv.FormatUint(uint64(delivery.ID), 10),
+			Body:       []byte(delivery.Payload),
+		}, now)
+		sendErr = err
+		if resp != nil {
+			data["last_status_code"] = resp.StatusCode
+			data["last_response"] = resp.Body
+			data["duration_ms"] = int(resp.Duration / time.Millisecond)
+		} else {
+			data["last_status_code"] = 0
+			data["last_response"] = ""
+			data["duration_ms"] = 0
+		}
+	}
+
+	if sendErr == nil {
+		data["status"] = models.WebhookDeliveryDelivered
+		data["delivered_at"] = now
+		data["last_error"] = ""
+	} else {
+		message := sendErr.Error()
+		if len(message) > maxWebhookErrorLength {
+			message = strings.ToValidUTF8(message[:maxWebhookErrorLength], "")
+		}
+		data["last_error"] = message
+		if giveUp {
+			data["status"] = models.WebhookDeliveryFailed
+		} else {
+			data["next_attempt_at"] = now.Add(webhookRetryDelay(attempts))
+		}
+		configslog.Log.Warn("Webhook teslim edilemedi", zap.Uint("deliveryID", delivery.ID), zap.Int("attempt", attempts), zap.Error(sendErr))
+	}
+	if err := s.repo.UpdateDelivery(contextWithUserID(ctx, endpoint.UserID), delivery, data); err != nil {
+		configslog.Log.Error("Webhook teslimatı güncellenemedi", zap.Uint("deliveryID", delivery.ID), zap.Error(err))
+	}
+	return sendErr == nil
+}
+
+var _ IWebhookService = (*WebhookService)(nil)